@@ -1,16 +1,21 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/bradfitz/gomemcache/memcache"
+	"github.com/joinimpact/api/internal/analytics"
 	"github.com/joinimpact/api/internal/authentication"
+	"github.com/joinimpact/api/internal/authentication/oauth"
 	"github.com/joinimpact/api/internal/config"
 	"github.com/joinimpact/api/internal/conversations"
 	"github.com/joinimpact/api/internal/database/postgres"
 	"github.com/joinimpact/api/internal/email"
 	"github.com/joinimpact/api/internal/events"
+	"github.com/joinimpact/api/internal/exports"
 	"github.com/joinimpact/api/internal/hours"
 	"github.com/joinimpact/api/internal/models"
 	"github.com/joinimpact/api/internal/opportunities"
@@ -18,6 +23,7 @@ import (
 	"github.com/joinimpact/api/internal/pubsub"
 	"github.com/joinimpact/api/internal/search"
 	opportunitiesSearch "github.com/joinimpact/api/internal/search/stores/opportunities"
+	organizationsSearch "github.com/joinimpact/api/internal/search/stores/organizations"
 	"github.com/joinimpact/api/internal/snowflakes"
 	"github.com/joinimpact/api/internal/tags"
 	"github.com/joinimpact/api/internal/users"
@@ -85,11 +91,28 @@ func main() {
 		&models.ConversationMembership{},
 		&models.Message{},
 		&models.PasswordResetKey{},
+		&models.EmailVerificationKey{},
+		&models.Session{},
+		&models.TwoFactorCredential{},
+		&models.TwoFactorRecoveryCode{},
 		&models.UserTag{},
 		&models.Tag{},
 		&models.ThirdPartyIdentity{},
 		&models.VolunteeringHourLog{},
 		&models.VolunteeringHourLogRequest{},
+		&models.DataExport{},
+		&models.OrganizationAPIKey{},
+		&models.OrganizationRole{},
+		&models.OrganizationOwnershipTransfer{},
+		&models.OrganizationVerification{},
+		&models.OpportunityApplicationField{},
+		&models.OpportunityApplicationAnswer{},
+		&models.OpportunityWaitlistEntry{},
+		&models.EventShift{},
+		&models.EventShiftSignup{},
+		&models.EventOccurrenceOverride{},
+		&models.CalendarFeed{},
+		&models.EventCheckIn{},
 	)
 	if err != nil {
 		// Error migrating the database, panic.
@@ -106,6 +129,17 @@ func main() {
 	if err != nil {
 		log.Fatal().Err(err).Msg("Error initializing snowflake service")
 	}
+	jwtKeyring, err := authentication.NewKeyring(config)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Error loading JWT keys")
+	}
+	oidcProviders := oauth.OIDCProviders{}
+	if len(config.OIDCProvidersFile) > 0 {
+		oidcProviders, err = oauth.LoadOIDCProviders(config.OIDCProvidersFile, nil)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Error loading OpenID Connect providers")
+		}
+	}
 	emailService := email.NewService(config, email.NewSender(
 		"Impact",
 		"no-reply@joinimpact.org",
@@ -120,8 +154,12 @@ func main() {
 	// Repositories
 	userRepository := postgres.NewUserRepository(db, &log.Logger)
 	passwordResetRepository := postgres.NewPasswordResetRepository(db, &log.Logger)
+	emailVerificationKeyRepository := postgres.NewEmailVerificationKeyRepository(db, &log.Logger)
 	userProfileFieldRepository := postgres.NewUserProfileFieldRepository(db, &log.Logger)
 	thirdPartyIdentityRepository := postgres.NewThirdPartyIdentityRepository(db, &log.Logger)
+	sessionRepository := postgres.NewSessionRepository(db, &log.Logger)
+	twoFactorCredentialRepository := postgres.NewTwoFactorCredentialRepository(db, &log.Logger)
+	twoFactorRecoveryCodeRepository := postgres.NewTwoFactorRecoveryCodeRepository(db, &log.Logger)
 	userTagRepository := postgres.NewUserTagRepository(db, &log.Logger)
 	tagRepository := postgres.NewTagRepository(db, &log.Logger)
 	organizationRepository := postgres.NewOrganizationRepository(db, &log.Logger)
@@ -145,6 +183,19 @@ func main() {
 	eventResponseRepository := postgres.NewEventResponseRepository(db, &log.Logger)
 	volunteeringHourLogRepository := postgres.NewVolunteeringHourLogRepository(db, &log.Logger)
 	volunteeringHourLogRequestRepository := postgres.NewVolunteeringHourLogRequestRepository(db, &log.Logger)
+	dataExportRepository := postgres.NewDataExportRepository(db, &log.Logger)
+	organizationAPIKeyRepository := postgres.NewOrganizationAPIKeyRepository(db, &log.Logger)
+	organizationRoleRepository := postgres.NewOrganizationRoleRepository(db, &log.Logger)
+	organizationOwnershipTransferRepository := postgres.NewOrganizationOwnershipTransferRepository(db, &log.Logger)
+	organizationVerificationRepository := postgres.NewOrganizationVerificationRepository(db, &log.Logger)
+	opportunityApplicationFieldRepository := postgres.NewOpportunityApplicationFieldRepository(db, &log.Logger)
+	opportunityApplicationAnswerRepository := postgres.NewOpportunityApplicationAnswerRepository(db, &log.Logger)
+	opportunityWaitlistEntryRepository := postgres.NewOpportunityWaitlistEntryRepository(db, &log.Logger)
+	eventShiftRepository := postgres.NewEventShiftRepository(db, &log.Logger)
+	eventShiftSignupRepository := postgres.NewEventShiftSignupRepository(db, &log.Logger)
+	eventOccurrenceOverrideRepository := postgres.NewEventOccurrenceOverrideRepository(db, &log.Logger)
+	calendarFeedRepository := postgres.NewCalendarFeedRepository(db, &log.Logger)
+	eventCheckInRepository := postgres.NewEventCheckInRepository(db, &log.Logger)
 
 	// Elastic client
 	elasticClient, err := search.NewElasticsearch(config.ElasticHost, config.ElasticPort)
@@ -157,21 +208,45 @@ func main() {
 	if err != nil {
 		log.Fatal().Err(err).Msg("Error starting Opportunities search service")
 	}
+	organizationsSearchService := organizationsSearch.NewStore(elasticClient, organizationRepository, organizationTagRepository, tagRepository, &log.Logger, config)
+	err = organizationsSearchService.Start()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Error starting Organizations search service")
+	}
 
 	// Pub/sub service
 	broker := pubsub.NewBroker()
 
 	// Internal services
-	usersService := users.NewService(userRepository, userProfileFieldRepository, userTagRepository, tagRepository, config, &log.Logger, snowflakeService, locationService)
-	authenticationService := authentication.NewService(userRepository, passwordResetRepository, thirdPartyIdentityRepository, config, &log.Logger, snowflakeService, emailService)
-	organizationsService := organizations.NewService(organizationRepository, organizationMembershipRepository, organizationMembershipInviteRepository, organizationProfileFieldRepository, organizationTagRepository, userRepository, tagRepository, config, &log.Logger, snowflakeService, emailService, locationService)
-	opportunitiesService := opportunities.NewService(opportunityRepository, opportunityRequirementsRepository, opportunityLimitsRepository, opportunityTagRepository, opportunityMembershipRepository, opportunityMembershipRequestRepository, opportunityMembershipInviteRepository, tagRepository, userRepository, userTagRepository, organizationRepository, config, &log.Logger, snowflakeService, emailService, opportunitiesSearchService, locationService)
-	eventsService := events.NewService(eventRepository, eventResponseRepository, opportunityMembershipRepository, tagRepository, config, &log.Logger, snowflakeService, emailService, locationService)
-	conversationsService := conversations.NewService(conversationRepository, conversationMembershipRepository, conversationOpportunityMembershipRequestRepository, conversationOrganizationMembershipRepository, messageRepository, opportunityRepository, userRepository, userProfileFieldRepository, userTagRepository, tagRepository, volunteeringHourLogRequestRepository, config, &log.Logger, snowflakeService, emailService, broker, locationService)
+	usersService := users.NewService(userRepository, userProfileFieldRepository, userTagRepository, tagRepository, sessionRepository, organizationMembershipRepository, opportunityRepository, opportunityMembershipRepository, opportunityMembershipRequestRepository, config, &log.Logger, snowflakeService, locationService)
+	authenticationService := authentication.NewService(userRepository, passwordResetRepository, emailVerificationKeyRepository, thirdPartyIdentityRepository, sessionRepository, twoFactorCredentialRepository, twoFactorRecoveryCodeRepository, organizationAPIKeyRepository, config, jwtKeyring, &log.Logger, snowflakeService, emailService, oidcProviders, cache)
+	organizationsService := organizations.NewService(organizationRepository, organizationMembershipRepository, organizationMembershipInviteRepository, organizationProfileFieldRepository, organizationTagRepository, userRepository, tagRepository, twoFactorCredentialRepository, organizationAPIKeyRepository, organizationRoleRepository, organizationOwnershipTransferRepository, organizationVerificationRepository, config, &log.Logger, snowflakeService, emailService, organizationsSearchService, locationService)
+	opportunitiesService := opportunities.NewService(opportunityRepository, opportunityRequirementsRepository, opportunityLimitsRepository, opportunityTagRepository, opportunityMembershipRepository, opportunityMembershipRequestRepository, opportunityMembershipInviteRepository, opportunityApplicationFieldRepository, opportunityApplicationAnswerRepository, opportunityWaitlistEntryRepository, tagRepository, userRepository, userTagRepository, organizationRepository, config, &log.Logger, snowflakeService, emailService, opportunitiesSearchService, locationService)
+	eventsService := events.NewService(eventRepository, eventResponseRepository, eventOccurrenceOverrideRepository, eventShiftRepository, eventShiftSignupRepository, calendarFeedRepository, eventCheckInRepository, opportunityRepository, opportunityMembershipRepository, tagRepository, userTagRepository, config, &log.Logger, snowflakeService, emailService, locationService)
+	conversationsService := conversations.NewService(conversationRepository, conversationMembershipRepository, conversationOpportunityMembershipRequestRepository, conversationOrganizationMembershipRepository, messageRepository, opportunityRepository, userRepository, userProfileFieldRepository, userTagRepository, tagRepository, volunteeringHourLogRequestRepository, opportunityApplicationAnswerRepository, config, &log.Logger, snowflakeService, emailService, broker, locationService)
 	tagsService := tags.NewService(tagRepository, config, &log.Logger, snowflakeService)
 	hoursService := hours.NewService(volunteeringHourLogRepository, volunteeringHourLogRequestRepository, opportunityRepository, organizationRepository,
 		userRepository, eventRepository, config, &log.Logger, snowflakeService, emailService, broker, locationService)
 
+	exportsService := exports.NewService(dataExportRepository, userRepository, userProfileFieldRepository, userTagRepository, tagRepository, organizationMembershipRepository, opportunityMembershipRepository, eventResponseRepository, volunteeringHourLogRepository, volunteeringHourLogRequestRepository, messageRepository,
+		config, &log.Logger, snowflakeService, emailService)
+
+	analyticsService := analytics.NewService(opportunityRepository, opportunityMembershipRepository, opportunityMembershipRequestRepository, volunteeringHourLogRepository, eventRepository, eventResponseRepository, messageRepository, config, &log.Logger)
+
+	// Erase users whose deletion grace period has ended.
+	go users.RunErasureJob(context.Background(), usersService, time.Hour, &log.Logger)
+
+	// Delete data export archives whose download link expired.
+	go exports.RunCleanupJob(context.Background(), exportsService, time.Hour, &log.Logger)
+
+	// Open and close opportunities at their publish and close dates.
+	go opportunities.RunScheduleJob(context.Background(), opportunitiesService, time.Minute, &log.Logger)
+
+	// Expire waitlist offers and offer free spots to waitlisted volunteers.
+	go opportunities.RunWaitlistJob(context.Background(), opportunitiesService, 5*time.Minute, &log.Logger, func(ctx context.Context, offer models.OpportunityWaitlistEntry) {
+		conversationsService.SendWaitlistOfferMessage(ctx, offer.VolunteerID, offer.OpportunityID, *offer.OfferExpiresAt)
+	})
+
 	// WebSocket services
 	wsHub := hub.NewHub(hub.Options{})
 	hubManager := hubmanager.NewHubManager(wsHub)
@@ -180,7 +255,7 @@ func main() {
 	websocketService := socketserver.NewService(wsManager)
 
 	// Create a new app using the new config.
-	app := core.NewApp(config, &log.Logger, websocketService, authenticationService, usersService, organizationsService, tagsService, opportunitiesService, eventsService, conversationsService, hoursService)
+	app := core.NewApp(config, &log.Logger, websocketService, authenticationService, usersService, organizationsService, tagsService, opportunitiesService, eventsService, conversationsService, hoursService, exportsService, analyticsService)
 
 	// Print a message.
 	log.Info().Int("port", int(config.Port)).Str("version", APIVersion).Msg("Listening")
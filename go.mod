@@ -17,7 +17,7 @@ require (
 	github.com/hashicorp/go-version v1.2.1 // indirect
 	github.com/huandu/facebook/v2 v2.5.2
 	github.com/jinzhu/gorm v1.9.15
-	github.com/lib/pq v1.7.1 // indirect
+	github.com/lib/pq v1.7.1
 	github.com/liip/sheriff v0.0.0-20190308094614-91aa83a45a3d
 	github.com/mitchellh/mapstructure v1.3.3
 	github.com/oliamb/cutter v0.2.2
@@ -0,0 +1,52 @@
+package analytics
+
+import (
+	"time"
+
+	"github.com/joinimpact/api/internal/models"
+)
+
+// OrganizationAnalytics represents the aggregated activity of an organization over a time range.
+type OrganizationAnalytics struct {
+	From               time.Time          `json:"from"`
+	To                 time.Time          `json:"to"`
+	Interval           Interval           `json:"interval"`
+	Buckets            []Bucket           `json:"buckets"`                      // the time series of volunteers and requests
+	TotalHours         float32            `json:"totalHours"`                   // the total hours granted in the range
+	Hours              []OpportunityHours `json:"hours"`                        // the hours granted per opportunity
+	Attendance         Attendance         `json:"attendance"`                   // the attendance of events in the range
+	MedianResponseTime *float64           `json:"medianResponseTime,omitempty"` // the median time in seconds to reply to volunteers' messages
+}
+
+// Bucket represents the activity of an organization in a single interval.
+type Bucket struct {
+	Start         time.Time `json:"start"`
+	Volunteers    int       `json:"volunteers"`    // the total number of volunteers at the end of the interval
+	NewVolunteers int       `json:"newVolunteers"` // the number of volunteers who joined in the interval
+	Applications  int       `json:"applications"`  // the number of membership requests received in the interval
+	Acceptances   int       `json:"acceptances"`   // the number of membership requests accepted in the interval
+}
+
+// OpportunityHours represents the hours granted for a single opportunity.
+type OpportunityHours struct {
+	models.VolunteeringHourOpportunitySummary
+	Title string `json:"title,omitempty"`
+}
+
+// Attendance represents the responses of volunteers to events.
+type Attendance struct {
+	Attending    int               `json:"attending"`
+	NotAttending int               `json:"notAttending"`
+	Rate         float64           `json:"rate"` // the share of responding volunteers who can attend
+	Events       []EventAttendance `json:"events"`
+}
+
+// EventAttendance represents the responses of volunteers to a single event.
+type EventAttendance struct {
+	EventID      int64     `json:"eventId"`
+	Title        string    `json:"title"`
+	From         time.Time `json:"from"`
+	Attending    int       `json:"attending"`
+	NotAttending int       `json:"notAttending"`
+	Rate         float64   `json:"rate"`
+}
@@ -0,0 +1,87 @@
+package analytics
+
+import (
+	"sort"
+	"time"
+)
+
+// Interval is the size of the buckets of a time series.
+type Interval string
+
+// Intervals
+const (
+	IntervalDay   Interval = "day"
+	IntervalWeek  Interval = "week"
+	IntervalMonth Interval = "month"
+)
+
+// maxBuckets is the maximum number of buckets in a time series.
+const maxBuckets = 400
+
+// ParseInterval parses an interval name, defaulting to IntervalDay when the name is empty.
+func ParseInterval(name string) (Interval, error) {
+	switch Interval(name) {
+	case "":
+		return IntervalDay, nil
+	case IntervalDay, IntervalWeek, IntervalMonth:
+		return Interval(name), nil
+	}
+
+	return "", NewErrInvalidInterval()
+}
+
+// truncate returns the start of the bucket containing t, in UTC. Weeks start on Monday.
+func (i Interval) truncate(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch i {
+	case IntervalWeek:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case IntervalMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	return day
+}
+
+// next returns the start of the bucket after the bucket starting at t.
+func (i Interval) next(t time.Time) time.Time {
+	switch i {
+	case IntervalWeek:
+		return t.AddDate(0, 0, 7)
+	case IntervalMonth:
+		return t.AddDate(0, 1, 0)
+	}
+
+	return t.AddDate(0, 0, 1)
+}
+
+// bucketStarts returns the starts of all buckets of an interval between from and to.
+func bucketStarts(interval Interval, from, to time.Time) ([]time.Time, error) {
+	if !from.Before(to) {
+		return nil, NewErrInvalidRange()
+	}
+
+	starts := []time.Time{}
+	for start := interval.truncate(from); start.Before(to); start = interval.next(start) {
+		if len(starts) == maxBuckets {
+			return nil, NewErrInvalidRange()
+		}
+
+		starts = append(starts, start)
+	}
+
+	return starts, nil
+}
+
+// bucketIndex returns the index of the bucket containing t, or -1 if t is outside of all buckets.
+func bucketIndex(starts []time.Time, interval Interval, t time.Time) int {
+	if len(starts) == 0 || t.Before(starts[0]) || !t.Before(interval.next(starts[len(starts)-1])) {
+		return -1
+	}
+
+	return sort.Search(len(starts), func(i int) bool {
+		return starts[i].After(t)
+	}) - 1
+}
@@ -0,0 +1,87 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+)
+
+// TestBucketStarts tests that buckets are aligned to the start of their interval.
+func TestBucketStarts(t *testing.T) {
+	from := time.Date(2020, 7, 15, 13, 0, 0, 0, time.UTC) // a Wednesday
+	to := time.Date(2020, 9, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		interval Interval
+		first    time.Time
+		count    int
+	}{
+		{IntervalDay, time.Date(2020, 7, 15, 0, 0, 0, 0, time.UTC), 49},
+		{IntervalWeek, time.Date(2020, 7, 13, 0, 0, 0, 0, time.UTC), 8},
+		{IntervalMonth, time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC), 3},
+	}
+
+	for _, test := range tests {
+		starts, err := bucketStarts(test.interval, from, to)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.interval, err)
+		}
+
+		if len(starts) != test.count {
+			t.Errorf("%s: expected %d buckets, got %d", test.interval, test.count, len(starts))
+		}
+
+		if !starts[0].Equal(test.first) {
+			t.Errorf("%s: expected first bucket at %s, got %s", test.interval, test.first, starts[0])
+		}
+	}
+}
+
+// TestBucketStartsInvalidRange tests that empty and oversized ranges are rejected.
+func TestBucketStartsInvalidRange(t *testing.T) {
+	from := time.Date(2020, 7, 15, 0, 0, 0, 0, time.UTC)
+
+	if _, err := bucketStarts(IntervalDay, from, from); err == nil {
+		t.Error("expected an error for an empty range")
+	}
+
+	if _, err := bucketStarts(IntervalDay, from, from.AddDate(5, 0, 0)); err == nil {
+		t.Error("expected an error for a range with too many buckets")
+	}
+}
+
+// TestBucketIndex tests that times are placed in the bucket containing them.
+func TestBucketIndex(t *testing.T) {
+	from := time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC)
+	starts, err := bucketStarts(IntervalMonth, from, from.AddDate(0, 3, 0))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	tests := []struct {
+		t     time.Time
+		index int
+	}{
+		{time.Date(2020, 6, 30, 23, 0, 0, 0, time.UTC), -1},
+		{time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC), 0},
+		{time.Date(2020, 8, 31, 23, 0, 0, 0, time.UTC), 1},
+		{time.Date(2020, 9, 30, 23, 0, 0, 0, time.UTC), 2},
+		{time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC), -1},
+	}
+
+	for _, test := range tests {
+		if index := bucketIndex(starts, IntervalMonth, test.t); index != test.index {
+			t.Errorf("%s: expected bucket %d, got %d", test.t, test.index, index)
+		}
+	}
+}
+
+// TestMedian tests the median of odd and even sized samples.
+func TestMedian(t *testing.T) {
+	if m := median([]float64{5, 1, 3}); m != 3 {
+		t.Errorf("expected 3, got %v", m)
+	}
+
+	if m := median([]float64{4, 1, 3, 2}); m != 2.5 {
+		t.Errorf("expected 2.5, got %v", m)
+	}
+}
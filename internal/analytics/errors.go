@@ -0,0 +1,58 @@
+package analytics
+
+// ErrServerError is thrown when the server experiences an internal error.
+type ErrServerError struct {
+}
+
+// NewErrServerError creates and returns a ErrServerError.
+func NewErrServerError() error {
+	return &ErrServerError{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrServerError) Error() string {
+	return "internal error processing request, please try again"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrServerError) Ref() string {
+	return "generic.server_error"
+}
+
+// ErrInvalidInterval is thrown when an unknown time-series interval is requested.
+type ErrInvalidInterval struct {
+}
+
+// NewErrInvalidInterval creates and returns a ErrInvalidInterval.
+func NewErrInvalidInterval() error {
+	return &ErrInvalidInterval{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrInvalidInterval) Error() string {
+	return "invalid interval, must be one of day, week or month"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrInvalidInterval) Ref() string {
+	return "analytics.invalid_interval"
+}
+
+// ErrInvalidRange is thrown when the requested date range is empty or spans too many buckets.
+type ErrInvalidRange struct {
+}
+
+// NewErrInvalidRange creates and returns a ErrInvalidRange.
+func NewErrInvalidRange() error {
+	return &ErrInvalidRange{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrInvalidRange) Error() string {
+	return "invalid date range, the start must be before the end and the range must not contain too many intervals"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrInvalidRange) Ref() string {
+	return "analytics.invalid_range"
+}
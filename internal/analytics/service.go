@@ -0,0 +1,383 @@
+package analytics
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/joinimpact/api/internal/config"
+	"github.com/joinimpact/api/internal/models"
+	"github.com/joinimpact/api/pkg/dbctx"
+	"github.com/rs/zerolog"
+)
+
+// defaultRange is the range of the analytics when no start date is provided.
+const defaultRange = 30 * 24 * time.Hour
+
+// pageSize is the number of records loaded at a time from paginated repositories.
+const pageSize = 100
+
+// Service represents a service for aggregating the activity of organizations.
+type Service interface {
+	// GetOrganizationAnalytics aggregates the activity of an organization in the time range of the context,
+	// bucketing time series by the provided interval.
+	GetOrganizationAnalytics(ctx context.Context, organizationID int64, interval Interval) (*OrganizationAnalytics, error)
+}
+
+// service is the internal implementation of the analytics.Service interface.
+type service struct {
+	opportunityRepository                  models.OpportunityRepository
+	opportunityMembershipRepository        models.OpportunityMembershipRepository
+	opportunityMembershipRequestRepository models.OpportunityMembershipRequestRepository
+	volunteeringHourLogRepository          models.VolunteeringHourLogRepository
+	eventRepository                        models.EventRepository
+	eventResponseRepository                models.EventResponseRepository
+	messageRepository                      models.MessageRepository
+	config                                 *config.Config
+	logger                                 *zerolog.Logger
+}
+
+// NewService creates and returns a new analytics.Service.
+func NewService(opportunityRepository models.OpportunityRepository, opportunityMembershipRepository models.OpportunityMembershipRepository, opportunityMembershipRequestRepository models.OpportunityMembershipRequestRepository, volunteeringHourLogRepository models.VolunteeringHourLogRepository, eventRepository models.EventRepository, eventResponseRepository models.EventResponseRepository, messageRepository models.MessageRepository, config *config.Config, logger *zerolog.Logger) Service {
+	return &service{
+		opportunityRepository,
+		opportunityMembershipRepository,
+		opportunityMembershipRequestRepository,
+		volunteeringHourLogRepository,
+		eventRepository,
+		eventResponseRepository,
+		messageRepository,
+		config,
+		logger,
+	}
+}
+
+// GetOrganizationAnalytics aggregates the activity of an organization in the time range of the context,
+// bucketing time series by the provided interval.
+func (s *service) GetOrganizationAnalytics(ctx context.Context, organizationID int64, interval Interval) (*OrganizationAnalytics, error) {
+	to := time.Now().UTC()
+	if requestTo := dbctx.Get(ctx).To; requestTo != nil {
+		to = requestTo.UTC()
+	}
+
+	from := to.Add(-defaultRange)
+	if requestFrom := dbctx.Get(ctx).From; requestFrom != nil {
+		from = requestFrom.UTC()
+	}
+
+	starts, err := bucketStarts(interval, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// Query every repository with the resolved range, whatever the pagination of the request.
+	ctx = dbctx.Inject(ctx, dbctx.Request{
+		Limit: pageSize,
+		From:  &from,
+		To:    &to,
+	})
+
+	analytics := &OrganizationAnalytics{
+		From:     from,
+		To:       to,
+		Interval: interval,
+		Buckets:  make([]Bucket, len(starts)),
+	}
+	for i, start := range starts {
+		analytics.Buckets[i].Start = start
+	}
+
+	opportunities, err := s.findOpportunities(ctx, organizationID)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error finding organization opportunities")
+		return nil, NewErrServerError()
+	}
+
+	opportunityIDs := []int64{}
+	for _, opportunity := range opportunities {
+		opportunityIDs = append(opportunityIDs, opportunity.ID)
+	}
+
+	if err := s.countVolunteers(ctx, analytics, starts, opportunityIDs); err != nil {
+		s.logger.Error().Err(err).Msg("Error counting volunteers")
+		return nil, NewErrServerError()
+	}
+
+	if err := s.countRequests(ctx, analytics, starts, opportunityIDs); err != nil {
+		s.logger.Error().Err(err).Msg("Error counting membership requests")
+		return nil, NewErrServerError()
+	}
+
+	if err := s.sumHours(ctx, analytics, organizationID, opportunities); err != nil {
+		s.logger.Error().Err(err).Msg("Error summing up hours")
+		return nil, NewErrServerError()
+	}
+
+	if err := s.countAttendance(ctx, analytics, opportunityIDs); err != nil {
+		s.logger.Error().Err(err).Msg("Error counting event attendance")
+		return nil, NewErrServerError()
+	}
+
+	if err := s.measureResponseTime(ctx, analytics, organizationID); err != nil {
+		s.logger.Error().Err(err).Msg("Error measuring response time")
+		return nil, NewErrServerError()
+	}
+
+	return analytics, nil
+}
+
+// findOpportunities finds all of an organization's opportunities.
+func (s *service) findOpportunities(ctx context.Context, organizationID int64) ([]models.Opportunity, error) {
+	opportunities := []models.Opportunity{}
+	for page := 0; ; page++ {
+		ctx := dbctx.Inject(ctx, dbctx.Request{
+			Limit: pageSize,
+			Page:  page,
+		})
+
+		res, err := s.opportunityRepository.FindByOrganizationID(ctx, organizationID)
+		if err != nil {
+			return nil, err
+		}
+
+		opportunities = append(opportunities, res...)
+
+		if len(res) < pageSize {
+			return opportunities, nil
+		}
+	}
+}
+
+// countVolunteers counts the volunteers who joined in each bucket, and the total volunteers at the end of each.
+func (s *service) countVolunteers(ctx context.Context, analytics *OrganizationAnalytics, starts []time.Time, opportunityIDs []int64) error {
+	if len(opportunityIDs) < 1 {
+		return nil
+	}
+
+	memberships, err := s.opportunityMembershipRepository.FindByOpportunityIDs(ctx, opportunityIDs)
+	if err != nil {
+		return err
+	}
+
+	// The total of each bucket includes every volunteer who joined before it.
+	before := 0
+	for _, membership := range memberships {
+		if membership.PermissionsFlag != models.OpportunityPermissionsMember {
+			continue
+		}
+
+		if membership.JoinedAt.Before(starts[0]) {
+			before++
+			continue
+		}
+
+		if i := bucketIndex(starts, analytics.Interval, membership.JoinedAt); i >= 0 {
+			analytics.Buckets[i].NewVolunteers++
+		}
+	}
+
+	for i := range analytics.Buckets {
+		before += analytics.Buckets[i].NewVolunteers
+		analytics.Buckets[i].Volunteers = before
+	}
+
+	return nil
+}
+
+// countRequests counts the new membership requests and the accepted requests in each bucket.
+func (s *service) countRequests(ctx context.Context, analytics *OrganizationAnalytics, starts []time.Time, opportunityIDs []int64) error {
+	if len(opportunityIDs) < 1 {
+		return nil
+	}
+
+	requests, err := s.opportunityMembershipRequestRepository.FindHistoryByOpportunityIDs(ctx, opportunityIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, request := range requests {
+		if i := bucketIndex(starts, analytics.Interval, request.CreatedAt); i >= 0 && request.CreatedAt.Before(analytics.To) {
+			analytics.Buckets[i].Applications++
+		}
+
+		// Accepted requests are deleted at the time they are accepted.
+		if !request.Accepted || request.DeletedAt == nil || !request.DeletedAt.Before(analytics.To) {
+			continue
+		}
+
+		if i := bucketIndex(starts, analytics.Interval, *request.DeletedAt); i >= 0 {
+			analytics.Buckets[i].Acceptances++
+		}
+	}
+
+	return nil
+}
+
+// sumHours sums up the hours granted for each of the organization's opportunities.
+func (s *service) sumHours(ctx context.Context, analytics *OrganizationAnalytics, organizationID int64, opportunities []models.Opportunity) error {
+	summaries, err := s.volunteeringHourLogRepository.SummarizeByOpportunity(ctx, organizationID)
+	if err != nil {
+		return err
+	}
+
+	titles := map[int64]string{}
+	for _, opportunity := range opportunities {
+		titles[opportunity.ID] = opportunity.Title
+	}
+
+	analytics.Hours = []OpportunityHours{}
+	for _, summary := range summaries {
+		analytics.TotalHours += summary.Hours
+		analytics.Hours = append(analytics.Hours, OpportunityHours{
+			VolunteeringHourOpportunitySummary: summary,
+			Title:                              titles[summary.OpportunityID],
+		})
+	}
+
+	sort.Slice(analytics.Hours, func(i, j int) bool {
+		return analytics.Hours[i].Hours > analytics.Hours[j].Hours
+	})
+
+	return nil
+}
+
+// countAttendance counts the responses to the events of the organization's opportunities.
+func (s *service) countAttendance(ctx context.Context, analytics *OrganizationAnalytics, opportunityIDs []int64) error {
+	analytics.Attendance.Events = []EventAttendance{}
+	if len(opportunityIDs) < 1 {
+		return nil
+	}
+
+	events := []models.Event{}
+	for page := 0; ; page++ {
+		ctx := dbctx.Inject(ctx, dbctx.Request{
+			Limit: pageSize,
+			Page:  page,
+			From:  &analytics.From,
+			To:    &analytics.To,
+		})
+
+		res, err := s.eventRepository.FindByOpportunityIDs(ctx, opportunityIDs)
+		if err != nil {
+			return err
+		}
+
+		events = append(events, res...)
+
+		if len(res) < pageSize {
+			break
+		}
+	}
+
+	if len(events) < 1 {
+		return nil
+	}
+
+	eventIDs := []int64{}
+	for _, event := range events {
+		eventIDs = append(eventIDs, event.ID)
+	}
+
+	responses, err := s.eventResponseRepository.FindByEventIDs(ctx, eventIDs)
+	if err != nil {
+		return err
+	}
+
+	attendance := map[int64]*EventAttendance{}
+	for _, event := range events {
+		attendance[event.ID] = &EventAttendance{
+			EventID: event.ID,
+			Title:   event.Title,
+			From:    event.FromDate,
+		}
+	}
+
+	for _, response := range responses {
+		event, ok := attendance[response.EventID]
+		if !ok || response.Response == nil {
+			continue
+		}
+
+		// Responses to occurrences of recurring events outside of the range are not counted.
+		if occurrence := response.OccurrenceStart; occurrence != nil && (occurrence.Before(analytics.From) || !occurrence.Before(analytics.To)) {
+			continue
+		}
+
+		switch *response.Response {
+		case models.EventResponseCanAttend:
+			event.Attending++
+			analytics.Attendance.Attending++
+		case models.EventResponseCanNotAttend:
+			event.NotAttending++
+			analytics.Attendance.NotAttending++
+		}
+	}
+
+	for _, event := range events {
+		a := attendance[event.ID]
+		a.Rate = rate(a.Attending, a.NotAttending)
+		analytics.Attendance.Events = append(analytics.Attendance.Events, *a)
+	}
+	analytics.Attendance.Rate = rate(analytics.Attendance.Attending, analytics.Attendance.NotAttending)
+
+	return nil
+}
+
+// measureResponseTime measures the median time the organization took to reply to volunteers' messages.
+func (s *service) measureResponseTime(ctx context.Context, analytics *OrganizationAnalytics, organizationID int64) error {
+	messages, err := s.messageRepository.FindByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return err
+	}
+
+	// Messages are ordered by conversation, so a reply is the first organization message after a volunteer's.
+	responseTimes := []float64{}
+	var conversationID int64
+	var waitingSince *time.Time
+	for i, message := range messages {
+		if message.ConversationID != conversationID {
+			conversationID = message.ConversationID
+			waitingSince = nil
+		}
+
+		if message.SenderPerspective == nil || *message.SenderPerspective == models.MessageSenderPerspectiveVolunteer {
+			if waitingSince == nil {
+				waitingSince = &messages[i].Timestamp
+			}
+			continue
+		}
+
+		if waitingSince != nil {
+			responseTimes = append(responseTimes, message.Timestamp.Sub(*waitingSince).Seconds())
+			waitingSince = nil
+		}
+	}
+
+	if len(responseTimes) > 0 {
+		m := median(responseTimes)
+		analytics.MedianResponseTime = &m
+	}
+
+	return nil
+}
+
+// rate returns the share of attending volunteers among all volunteers who responded.
+func rate(attending, notAttending int) float64 {
+	if attending+notAttending == 0 {
+		return 0
+	}
+
+	return float64(attending) / float64(attending+notAttending)
+}
+
+// median returns the median of a non-empty sample, sorting it in place.
+func median(values []float64) float64 {
+	sort.Float64s(values)
+
+	middle := len(values) / 2
+	if len(values)%2 == 0 {
+		return (values[middle-1] + values[middle]) / 2
+	}
+
+	return values[middle]
+}
@@ -0,0 +1,177 @@
+package authentication
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/joinimpact/api/internal/email"
+	"github.com/joinimpact/api/internal/email/templates"
+	"github.com/joinimpact/api/internal/models"
+)
+
+// emailChangeKeyLifespan is how long an email change confirmation link stays valid.
+const emailChangeKeyLifespan = 24 * time.Hour
+
+// checkPassword checks a password against a user's password hash.
+func checkPassword(user *models.User, password string) error {
+	if len(user.Password) < 50 {
+		// The user has no password set.
+		return NewErrInvalidPassword()
+	}
+
+	ok, err := compareHashAndPassword(password, user.Password)
+	if err != nil || !ok {
+		return NewErrInvalidPassword()
+	}
+
+	return nil
+}
+
+// ChangePassword changes a user's password after checking their current
+// password, and revokes all of their sessions except the current one.
+func (s *service) ChangePassword(ctx context.Context, userID, currentSessionID int64, currentPassword, newPassword string) error {
+	user, err := s.userRepository.FindByID(userID)
+	if err != nil {
+		return NewErrUserNotFound()
+	}
+
+	if err := checkPassword(user, currentPassword); err != nil {
+		return err
+	}
+
+	if !validatePassword(newPassword) {
+		return NewErrWeakPassword()
+	}
+
+	hash, err := generateFromPassword(newPassword)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error hashing password")
+		return NewErrServerError()
+	}
+
+	if err := s.userRepository.Update(models.User{
+		Model: models.Model{
+			ID: user.ID,
+		},
+		Password: hash,
+	}); err != nil {
+		s.logger.Error().Err(err).Msg("Error updating user password")
+		return NewErrServerError()
+	}
+
+	if err := s.sessionRepository.RevokeByUserID(ctx, user.ID, currentSessionID); err != nil {
+		s.logger.Error().Err(err).Msg("Error revoking sessions after password change")
+		return NewErrServerError()
+	}
+
+	return nil
+}
+
+// RequestEmailChange emails a confirmation link to a user's new email, and a
+// notice to their current email. The email is only changed once the link is
+// opened. Users who have a password must provide it.
+func (s *service) RequestEmailChange(ctx context.Context, userID int64, newEmail, password string) error {
+	user, err := s.userRepository.FindByID(userID)
+	if err != nil {
+		return NewErrUserNotFound()
+	}
+
+	if len(user.Password) > 0 {
+		if err := checkPassword(user, password); err != nil {
+			return err
+		}
+	}
+
+	newEmail = strings.ToLower(strings.TrimSpace(newEmail))
+	if !validateEmail(newEmail) || newEmail == user.Email {
+		return NewErrInvalidEmail()
+	}
+
+	if _, err := s.userRepository.FindByEmail(newEmail); err == nil {
+		return NewErrEmailTaken()
+	}
+
+	key, err := generateEmailVerificationKey()
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error generating email change key")
+		return NewErrServerError()
+	}
+
+	changeKey := models.EmailVerificationKey{
+		UserID:    user.ID,
+		Email:     newEmail,
+		Key:       key,
+		ExpiresAt: time.Now().UTC().Add(emailChangeKeyLifespan),
+	}
+	changeKey.ID = s.snowflakeService.GenerateID()
+
+	if err := s.emailVerificationKeyRepository.Create(changeKey); err != nil {
+		s.logger.Error().Err(err).Msg("Error creating email change key")
+		return NewErrServerError()
+	}
+
+	confirmation := s.emailService.NewEmail(
+		email.NewRecipient(fmt.Sprintf("%s %s", user.FirstName, user.LastName), newEmail),
+		"Confirm your new email",
+		templates.EmailChangeTemplate(user.FirstName, newEmail, key),
+	)
+	if err := s.emailService.Send(confirmation); err != nil {
+		s.logger.Error().Err(err).Msg("Error sending email change confirmation")
+		return NewErrServerError()
+	}
+
+	notice := s.emailService.NewEmail(
+		email.NewRecipient(fmt.Sprintf("%s %s", user.FirstName, user.LastName), user.Email),
+		"Your email is being changed",
+		templates.EmailChangeNoticeTemplate(user.FirstName, newEmail),
+	)
+	if err := s.emailService.Send(notice); err != nil {
+		s.logger.Error().Err(err).Msg("Error sending email change notice")
+	}
+
+	return nil
+}
+
+// ConfirmEmailChange changes a user's email to the one an email change key
+// was sent to.
+func (s *service) ConfirmEmailChange(ctx context.Context, key string) error {
+	changeKey, err := s.emailVerificationKeyRepository.FindByKey(key)
+	if err != nil {
+		return NewErrInvalidKey()
+	}
+
+	user, err := s.userRepository.FindByID(changeKey.UserID)
+	if err != nil {
+		return NewErrUserNotFound()
+	}
+
+	if user.Email == changeKey.Email {
+		// The key is a regular verification key, or the change has already
+		// been applied.
+		return NewErrInvalidKey()
+	}
+
+	// The email may have been taken since the change was requested.
+	if _, err := s.userRepository.FindByEmail(changeKey.Email); err == nil {
+		return NewErrEmailTaken()
+	}
+
+	if err := s.userRepository.Update(models.User{
+		Model: models.Model{
+			ID: user.ID,
+		},
+		Email:         changeKey.Email,
+		EmailVerified: true,
+	}); err != nil {
+		s.logger.Error().Err(err).Msg("Error changing user email")
+		return NewErrServerError()
+	}
+
+	if err := s.emailVerificationKeyRepository.DeleteByUserID(user.ID); err != nil {
+		s.logger.Error().Err(err).Msg("Error deleting email verification keys")
+	}
+
+	return nil
+}
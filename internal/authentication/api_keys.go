@@ -0,0 +1,35 @@
+package authentication
+
+import (
+	"context"
+	"time"
+
+	"github.com/joinimpact/api/internal/models"
+	"github.com/joinimpact/api/pkg/apikeys"
+)
+
+// apiKeyLastUsedResolution is how often the last used time of an API key is updated.
+const apiKeyLastUsedResolution = time.Minute
+
+// AuthenticateAPIKey finds the organization API key matching a key, and records that it was used.
+func (s *service) AuthenticateAPIKey(ctx context.Context, key string) (*models.OrganizationAPIKey, error) {
+	if !apikeys.IsKey(key) {
+		return nil, NewErrInvalidToken()
+	}
+
+	apiKey, err := s.organizationAPIKeyRepository.FindByKeyHash(ctx, apikeys.Hash(key))
+	if err != nil {
+		return nil, NewErrInvalidToken()
+	}
+
+	// Avoid writing to the database on every request made with the key.
+	now := time.Now()
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) > apiKeyLastUsedResolution {
+		if err := s.organizationAPIKeyRepository.UpdateLastUsedAt(ctx, apiKey.ID, now); err != nil {
+			s.logger.Error().Err(err).Int64("apiKeyID", apiKey.ID).Msg("Error updating API key last used time")
+		}
+		apiKey.LastUsedAt = &now
+	}
+
+	return apiKey, nil
+}
@@ -0,0 +1,146 @@
+package authentication
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/joinimpact/api/internal/email"
+	"github.com/joinimpact/api/internal/email/templates"
+	"github.com/joinimpact/api/internal/models"
+	"github.com/joinimpact/api/pkg/attempts"
+)
+
+// loginEmailAttemptOptions limits failed logins to a single account.
+var loginEmailAttemptOptions = &attempts.Options{
+	Prefix:       "login_email",
+	FreeAttempts: 5,
+	BaseLockout:  time.Minute,
+	MaxLockout:   time.Hour,
+	Window:       24 * time.Hour,
+}
+
+// loginIPAttemptOptions limits failed logins from a single client, across
+// accounts. The limit is higher as many users may share an address.
+var loginIPAttemptOptions = &attempts.Options{
+	Prefix:       "login_ip",
+	FreeAttempts: 20,
+	BaseLockout:  time.Minute,
+	MaxLockout:   time.Hour,
+	Window:       time.Hour,
+}
+
+// passwordResetEmailAttemptOptions limits password reset requests for a
+// single account.
+var passwordResetEmailAttemptOptions = &attempts.Options{
+	Prefix:       "reset_email",
+	FreeAttempts: 3,
+	BaseLockout:  5 * time.Minute,
+	MaxLockout:   24 * time.Hour,
+	Window:       24 * time.Hour,
+}
+
+// passwordResetIPAttemptOptions limits password reset requests from a single
+// client, across accounts.
+var passwordResetIPAttemptOptions = &attempts.Options{
+	Prefix:       "reset_ip",
+	FreeAttempts: 10,
+	BaseLockout:  5 * time.Minute,
+	MaxLockout:   24 * time.Hour,
+	Window:       24 * time.Hour,
+}
+
+// magicLinkEmailAttemptOptions limits magic link requests for a single
+// account.
+var magicLinkEmailAttemptOptions = &attempts.Options{
+	Prefix:       "magic_link_email",
+	FreeAttempts: 5,
+	BaseLockout:  5 * time.Minute,
+	MaxLockout:   24 * time.Hour,
+	Window:       time.Hour,
+}
+
+// magicLinkIPAttemptOptions limits magic link requests from a single client,
+// across accounts.
+var magicLinkIPAttemptOptions = &attempts.Options{
+	Prefix:       "magic_link_ip",
+	FreeAttempts: 20,
+	BaseLockout:  5 * time.Minute,
+	MaxLockout:   24 * time.Hour,
+	Window:       time.Hour,
+}
+
+// attemptKey normalizes an email for use as an attempt key.
+func attemptKey(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// checkLocked returns an ErrTooManyAttempts if the email or IP address is
+// locked in the respective tracker.
+func checkLocked(emailTracker, ipTracker attempts.Tracker, email, ipAddress string) error {
+	if retryAfter, locked := emailTracker.Locked(attemptKey(email)); locked {
+		return NewErrTooManyAttempts(retryAfter)
+	}
+
+	if len(ipAddress) > 0 {
+		if retryAfter, locked := ipTracker.Locked(ipAddress); locked {
+			return NewErrTooManyAttempts(retryAfter)
+		}
+	}
+
+	return nil
+}
+
+// loginFailed records a failed login for an email and IP address, and
+// returns an ErrTooManyAttempts if either is now locked. The owner of the
+// account, if it exists, is notified the first time it is locked.
+func (s *service) loginFailed(email, ipAddress string, user *models.User) error {
+	result := s.loginEmailAttempts.Fail(attemptKey(email))
+	if result.Locked && result.Lockouts == 1 && user != nil {
+		s.logger.Warn().Int64("userId", user.ID).Str("ipAddress", ipAddress).Msg("Logins locked after too many failed attempts")
+		if err := s.sendAccountLockedEmail(user, result.RetryAfter); err != nil {
+			s.logger.Error().Err(err).Msg("Error sending account locked email")
+		}
+	}
+
+	if len(ipAddress) > 0 {
+		ipResult := s.loginIPAttempts.Fail(ipAddress)
+		if ipResult.Locked && ipResult.RetryAfter > result.RetryAfter {
+			result = ipResult
+		}
+	}
+
+	if result.Locked {
+		return NewErrTooManyAttempts(result.RetryAfter)
+	}
+
+	return nil
+}
+
+// sendAccountLockedEmail notifies a user that logins to their account have
+// been locked.
+func (s *service) sendAccountLockedEmail(user *models.User, lockout time.Duration) error {
+	email := s.emailService.NewEmail(
+		email.NewRecipient(fmt.Sprintf("%s %s", user.FirstName, user.LastName), user.Email),
+		"Your account has been locked",
+		templates.AccountLockedTemplate(user.FirstName, formatDuration(lockout)),
+	)
+
+	return s.emailService.Send(email)
+}
+
+// formatDuration formats a lockout duration for humans, such as "15 minutes".
+func formatDuration(d time.Duration) string {
+	unit := "minute"
+	n := int(d.Round(time.Minute) / time.Minute)
+	if d >= time.Hour {
+		unit = "hour"
+		n = int(d.Round(time.Hour) / time.Hour)
+	}
+
+	if n == 1 {
+		return fmt.Sprintf("1 %s", unit)
+	}
+
+	return fmt.Sprintf("%d %ss", n, unit)
+}
@@ -0,0 +1,110 @@
+package authentication
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/joinimpact/api/internal/email"
+	"github.com/joinimpact/api/internal/email/templates"
+	"github.com/joinimpact/api/internal/models"
+)
+
+// emailVerificationKeyLifespan is how long an email verification link stays valid.
+const emailVerificationKeyLifespan = 72 * time.Hour
+
+// emailVerificationResendInterval is the minimum time between two verification emails.
+const emailVerificationResendInterval = 2 * time.Minute
+
+// generateEmailVerificationKey generates and returns a random string to use as a key.
+func generateEmailVerificationKey() (string, error) {
+	return stringWithCharset(24, charset)
+}
+
+// sendVerificationEmail creates a new EmailVerificationKey for the user's
+// current email and emails the user a link to it.
+func (s *service) sendVerificationEmail(user *models.User) error {
+	key, err := generateEmailVerificationKey()
+	if err != nil {
+		return err
+	}
+
+	verificationKey := models.EmailVerificationKey{
+		UserID:    user.ID,
+		Email:     user.Email,
+		Key:       key,
+		ExpiresAt: time.Now().UTC().Add(emailVerificationKeyLifespan),
+	}
+	verificationKey.ID = s.snowflakeService.GenerateID()
+
+	if err := s.emailVerificationKeyRepository.Create(verificationKey); err != nil {
+		return err
+	}
+
+	email := s.emailService.NewEmail(
+		email.NewRecipient(fmt.Sprintf("%s %s", user.FirstName, user.LastName), user.Email),
+		"Verify your email",
+		templates.VerifyEmailTemplate(user.FirstName, key),
+	)
+
+	return s.emailService.Send(email)
+}
+
+// ResendVerificationEmail sends a new verification email to a user who has
+// not verified their email yet.
+func (s *service) ResendVerificationEmail(ctx context.Context, userID int64) error {
+	user, err := s.userRepository.FindByID(userID)
+	if err != nil {
+		return NewErrUserNotFound()
+	}
+
+	if user.EmailVerified {
+		return NewErrEmailAlreadyVerified()
+	}
+
+	latest, err := s.emailVerificationKeyRepository.FindLatestByUserID(user.ID)
+	if err == nil && time.Now().Sub(latest.CreatedAt) < emailVerificationResendInterval {
+		return NewErrVerificationEmailRecentlySent()
+	}
+
+	if err := s.sendVerificationEmail(user); err != nil {
+		s.logger.Error().Err(err).Msg("Error sending verification email")
+		return NewErrServerError()
+	}
+
+	return nil
+}
+
+// VerifyEmail marks a user's email as verified from an EmailVerificationKey's key.
+func (s *service) VerifyEmail(ctx context.Context, key string) error {
+	verificationKey, err := s.emailVerificationKeyRepository.FindByKey(key)
+	if err != nil {
+		return NewErrInvalidKey()
+	}
+
+	user, err := s.userRepository.FindByID(verificationKey.UserID)
+	if err != nil {
+		return NewErrUserNotFound()
+	}
+
+	if user.Email != verificationKey.Email {
+		// The user's email has changed since the key was sent.
+		return NewErrInvalidKey()
+	}
+
+	if err := s.userRepository.Update(models.User{
+		Model: models.Model{
+			ID: user.ID,
+		},
+		EmailVerified: true,
+	}); err != nil {
+		s.logger.Error().Err(err).Msg("Error verifying user email")
+		return NewErrServerError()
+	}
+
+	if err := s.emailVerificationKeyRepository.DeleteByUserID(user.ID); err != nil {
+		s.logger.Error().Err(err).Msg("Error deleting email verification keys")
+	}
+
+	return nil
+}
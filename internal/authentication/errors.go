@@ -0,0 +1,377 @@
+package authentication
+
+import "time"
+
+// ErrServerError is thrown when the server experiences an internal error.
+type ErrServerError struct {
+}
+
+// NewErrServerError creates and returns a ErrServerError.
+func NewErrServerError() error {
+	return &ErrServerError{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrServerError) Error() string {
+	return "internal error processing request, please try again"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrServerError) Ref() string {
+	return "generic.server_error"
+}
+
+// ErrInvalidToken is thrown when a token can not be parsed, has expired, or
+// belongs to a session which is no longer active.
+type ErrInvalidToken struct {
+}
+
+// NewErrInvalidToken creates and returns a ErrInvalidToken.
+func NewErrInvalidToken() error {
+	return &ErrInvalidToken{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrInvalidToken) Error() string {
+	return "invalid token"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrInvalidToken) Ref() string {
+	return "authentication.invalid_token"
+}
+
+// ErrRefreshTokenReused is thrown when a refresh token which has already been
+// rotated is used again.
+type ErrRefreshTokenReused struct {
+}
+
+// NewErrRefreshTokenReused creates and returns a ErrRefreshTokenReused.
+func NewErrRefreshTokenReused() error {
+	return &ErrRefreshTokenReused{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrRefreshTokenReused) Error() string {
+	return "refresh token has already been used, session revoked"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrRefreshTokenReused) Ref() string {
+	return "authentication.refresh_token_reused"
+}
+
+// ErrSessionNotFound is thrown when a session can not be found.
+type ErrSessionNotFound struct {
+}
+
+// NewErrSessionNotFound creates and returns a ErrSessionNotFound.
+func NewErrSessionNotFound() error {
+	return &ErrSessionNotFound{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrSessionNotFound) Error() string {
+	return "session not found"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrSessionNotFound) Ref() string {
+	return "authentication.session_not_found"
+}
+
+// ErrUserNotFound is thrown when the server is unable to find a User.
+type ErrUserNotFound struct {
+}
+
+// NewErrUserNotFound creates and returns a ErrUserNotFound.
+func NewErrUserNotFound() error {
+	return &ErrUserNotFound{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrUserNotFound) Error() string {
+	return "user not found"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrUserNotFound) Ref() string {
+	return "authentication.user_not_found"
+}
+
+// ErrInvalidKey is thrown when an emailed key is invalid or has expired.
+type ErrInvalidKey struct {
+}
+
+// NewErrInvalidKey creates and returns a ErrInvalidKey.
+func NewErrInvalidKey() error {
+	return &ErrInvalidKey{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrInvalidKey) Error() string {
+	return "invalid key"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrInvalidKey) Ref() string {
+	return "authentication.invalid_key"
+}
+
+// ErrEmailAlreadyVerified is thrown when a user who has already verified
+// their email requests another verification email.
+type ErrEmailAlreadyVerified struct {
+}
+
+// NewErrEmailAlreadyVerified creates and returns a ErrEmailAlreadyVerified.
+func NewErrEmailAlreadyVerified() error {
+	return &ErrEmailAlreadyVerified{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrEmailAlreadyVerified) Error() string {
+	return "email already verified"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrEmailAlreadyVerified) Ref() string {
+	return "authentication.email_already_verified"
+}
+
+// ErrVerificationEmailRecentlySent is thrown when a verification email is
+// requested too soon after the previous one.
+type ErrVerificationEmailRecentlySent struct {
+}
+
+// NewErrVerificationEmailRecentlySent creates and returns a ErrVerificationEmailRecentlySent.
+func NewErrVerificationEmailRecentlySent() error {
+	return &ErrVerificationEmailRecentlySent{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrVerificationEmailRecentlySent) Error() string {
+	return "a verification email was sent recently, please wait before requesting another"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrVerificationEmailRecentlySent) Ref() string {
+	return "authentication.verification_email_recently_sent"
+}
+
+// ErrInvalidTwoFactorCode is thrown when a two-factor or recovery code is
+// invalid or has already been used.
+type ErrInvalidTwoFactorCode struct {
+}
+
+// NewErrInvalidTwoFactorCode creates and returns a ErrInvalidTwoFactorCode.
+func NewErrInvalidTwoFactorCode() error {
+	return &ErrInvalidTwoFactorCode{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrInvalidTwoFactorCode) Error() string {
+	return "invalid two-factor code"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrInvalidTwoFactorCode) Ref() string {
+	return "authentication.invalid_two_factor_code"
+}
+
+// ErrTwoFactorAlreadyEnabled is thrown when a user who already has two-factor
+// authentication enabled tries to enroll again.
+type ErrTwoFactorAlreadyEnabled struct {
+}
+
+// NewErrTwoFactorAlreadyEnabled creates and returns a ErrTwoFactorAlreadyEnabled.
+func NewErrTwoFactorAlreadyEnabled() error {
+	return &ErrTwoFactorAlreadyEnabled{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrTwoFactorAlreadyEnabled) Error() string {
+	return "two-factor authentication already enabled"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrTwoFactorAlreadyEnabled) Ref() string {
+	return "authentication.two_factor_already_enabled"
+}
+
+// ErrTwoFactorNotEnabled is thrown when a two-factor code is provided for a
+// user who does not have two-factor authentication enabled.
+type ErrTwoFactorNotEnabled struct {
+}
+
+// NewErrTwoFactorNotEnabled creates and returns a ErrTwoFactorNotEnabled.
+func NewErrTwoFactorNotEnabled() error {
+	return &ErrTwoFactorNotEnabled{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrTwoFactorNotEnabled) Error() string {
+	return "two-factor authentication not enabled"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrTwoFactorNotEnabled) Ref() string {
+	return "authentication.two_factor_not_enabled"
+}
+
+// ErrTwoFactorLocked is thrown when two-factor codes are temporarily not
+// accepted after too many failed attempts.
+type ErrTwoFactorLocked struct {
+}
+
+// NewErrTwoFactorLocked creates and returns a ErrTwoFactorLocked.
+func NewErrTwoFactorLocked() error {
+	return &ErrTwoFactorLocked{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrTwoFactorLocked) Error() string {
+	return "too many failed two-factor attempts, please try again later"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrTwoFactorLocked) Ref() string {
+	return "authentication.two_factor_locked"
+}
+
+// ErrTooManyAttempts is thrown when an account or client is temporarily
+// locked out after too many failed attempts.
+type ErrTooManyAttempts struct {
+	RetryAfter time.Duration
+}
+
+// NewErrTooManyAttempts creates and returns a ErrTooManyAttempts.
+func NewErrTooManyAttempts(retryAfter time.Duration) error {
+	return &ErrTooManyAttempts{retryAfter}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrTooManyAttempts) Error() string {
+	return "too many attempts, please try again later"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrTooManyAttempts) Ref() string {
+	return "authentication.too_many_attempts"
+}
+
+// ErrOauthProviderNotFound is thrown when a login is attempted with an
+// OpenID Connect provider which is not configured.
+type ErrOauthProviderNotFound struct {
+}
+
+// NewErrOauthProviderNotFound creates and returns a ErrOauthProviderNotFound.
+func NewErrOauthProviderNotFound() error {
+	return &ErrOauthProviderNotFound{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrOauthProviderNotFound) Error() string {
+	return "oauth provider not found"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrOauthProviderNotFound) Ref() string {
+	return "authentication.oauth_provider_not_found"
+}
+
+// ErrOauthEmailNotVerified is thrown when an OpenID Connect login would be
+// linked to an existing account by an email the provider has not verified.
+type ErrOauthEmailNotVerified struct {
+}
+
+// NewErrOauthEmailNotVerified creates and returns a ErrOauthEmailNotVerified.
+func NewErrOauthEmailNotVerified() error {
+	return &ErrOauthEmailNotVerified{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrOauthEmailNotVerified) Error() string {
+	return "email not verified by oauth provider"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrOauthEmailNotVerified) Ref() string {
+	return "authentication.oauth_email_not_verified"
+}
+
+// ErrInvalidPassword is thrown when a user's current password is incorrect.
+type ErrInvalidPassword struct {
+}
+
+// NewErrInvalidPassword creates and returns a ErrInvalidPassword.
+func NewErrInvalidPassword() error {
+	return &ErrInvalidPassword{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrInvalidPassword) Error() string {
+	return "invalid password"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrInvalidPassword) Ref() string {
+	return "authentication.invalid_password"
+}
+
+// ErrWeakPassword is thrown when a new password does not meet the password
+// requirements.
+type ErrWeakPassword struct {
+}
+
+// NewErrWeakPassword creates and returns a ErrWeakPassword.
+func NewErrWeakPassword() error {
+	return &ErrWeakPassword{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrWeakPassword) Error() string {
+	return "password does not meet requirements"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrWeakPassword) Ref() string {
+	return "authentication.weak_password"
+}
+
+// ErrInvalidEmail is thrown when an email is not valid.
+type ErrInvalidEmail struct {
+}
+
+// NewErrInvalidEmail creates and returns a ErrInvalidEmail.
+func NewErrInvalidEmail() error {
+	return &ErrInvalidEmail{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrInvalidEmail) Error() string {
+	return "invalid email"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrInvalidEmail) Ref() string {
+	return "authentication.invalid_email"
+}
+
+// ErrEmailTaken is thrown when an email is already used by another account.
+type ErrEmailTaken struct {
+}
+
+// NewErrEmailTaken creates and returns a ErrEmailTaken.
+func NewErrEmailTaken() error {
+	return &ErrEmailTaken{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrEmailTaken) Error() string {
+	return "email taken"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrEmailTaken) Ref() string {
+	return "authentication.email_taken"
+}
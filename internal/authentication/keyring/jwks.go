@@ -0,0 +1,49 @@
+package keyring
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+)
+
+// JWKS represents a JSON Web Key Set as defined in RFC 7517.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWK represents a single public JSON Web Key.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	Curve     string `json:"crv,omitempty"` // OKP keys
+	X         string `json:"x,omitempty"`   // OKP keys
+	N         string `json:"n,omitempty"`   // RSA keys
+	E         string `json:"e,omitempty"`   // RSA keys
+}
+
+// rsaJWK creates a JWK from an RSA public key.
+func rsaJWK(key *Key, publicKey *rsa.PublicKey) JWK {
+	return JWK{
+		KeyType:   "RSA",
+		KeyID:     key.ID,
+		Use:       "sig",
+		Algorithm: key.Algorithm,
+		N:         base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()),
+		E:         base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes()),
+	}
+}
+
+// ed25519JWK creates a JWK from an Ed25519 public key.
+func ed25519JWK(key *Key, publicKey ed25519.PublicKey) JWK {
+	return JWK{
+		KeyType:   "OKP",
+		KeyID:     key.ID,
+		Use:       "sig",
+		Algorithm: key.Algorithm,
+		Curve:     "Ed25519",
+		X:         base64.RawURLEncoding.EncodeToString(publicKey),
+	}
+}
@@ -0,0 +1,298 @@
+// Package keyring manages the named keys used to sign and verify JWTs, so
+// that signing keys can be rotated without invalidating issued tokens.
+package keyring
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+// Supported signing algorithms.
+const (
+	// AlgorithmHS512 signs tokens with a shared secret using HMAC-SHA512.
+	AlgorithmHS512 = "HS512"
+	// AlgorithmRS256 signs tokens with an RSA private key.
+	AlgorithmRS256 = "RS256"
+	// AlgorithmEdDSA signs tokens with an Ed25519 private key.
+	AlgorithmEdDSA = "EdDSA"
+)
+
+// minimumSecretLength is the minimum length of HMAC secrets.
+const minimumSecretLength = 32
+
+// KeyConfig describes a single key as stored in a keys file.
+type KeyConfig struct {
+	ID         string `json:"id"`                   // the key's ID, sent in the kid header of tokens
+	Algorithm  string `json:"algorithm"`            // one of HS512, RS256 or EdDSA
+	Secret     string `json:"secret,omitempty"`     // the shared secret of HS512 keys
+	PrivateKey string `json:"privateKey,omitempty"` // PEM encoded private key of RS256 and EdDSA keys
+	PublicKey  string `json:"publicKey,omitempty"`  // PEM encoded public key, for keys which are only used to verify
+	Retired    bool   `json:"retired,omitempty"`    // retired keys are no longer accepted
+}
+
+// Key represents a single loaded key.
+type Key struct {
+	ID        string
+	Algorithm string
+	Retired   bool
+	signKey   interface{}
+	verifyKey interface{}
+}
+
+// CanSign returns true if the key can be used to sign tokens.
+func (k *Key) CanSign() bool {
+	return k.signKey != nil && !k.Retired
+}
+
+// method returns the jwt signing method of the key.
+func (k *Key) method() jwt.SigningMethod {
+	switch k.Algorithm {
+	case AlgorithmRS256:
+		return jwt.SigningMethodRS256
+	case AlgorithmEdDSA:
+		return SigningMethodEdDSA
+	}
+
+	return jwt.SigningMethodHS512
+}
+
+// Keyring holds a set of keys, one of which is used to sign new tokens.
+type Keyring struct {
+	keys       map[string]*Key
+	order      []string
+	signingKey *Key
+}
+
+// New creates a Keyring from a list of key configs. If signingKeyID is empty,
+// the first key which is able to sign is used to sign new tokens.
+func New(configs []KeyConfig, signingKeyID string) (*Keyring, error) {
+	keyring := &Keyring{
+		keys: map[string]*Key{},
+	}
+
+	for _, config := range configs {
+		key, err := parseKey(config)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := keyring.keys[key.ID]; ok {
+			return nil, fmt.Errorf("duplicate key id %q", key.ID)
+		}
+
+		keyring.keys[key.ID] = key
+		keyring.order = append(keyring.order, key.ID)
+	}
+
+	if len(signingKeyID) > 0 {
+		key, ok := keyring.keys[signingKeyID]
+		if !ok || !key.CanSign() {
+			return nil, fmt.Errorf("signing key %q not found or unable to sign", signingKeyID)
+		}
+
+		keyring.signingKey = key
+	} else {
+		for _, id := range keyring.order {
+			if key := keyring.keys[id]; key.CanSign() {
+				keyring.signingKey = key
+				break
+			}
+		}
+	}
+
+	if keyring.signingKey == nil {
+		return nil, errors.New("no signing key available")
+	}
+
+	return keyring, nil
+}
+
+// Load creates a Keyring from a JSON keys file in the format
+// {"keys": [KeyConfig, ...]}.
+func Load(path, signingKeyID string) (*Keyring, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file := struct {
+		Keys []KeyConfig `json:"keys"`
+	}{}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("error parsing keys file: %v", err)
+	}
+
+	return New(file.Keys, signingKeyID)
+}
+
+// parseKey loads a Key from a KeyConfig.
+func parseKey(config KeyConfig) (*Key, error) {
+	if len(config.ID) < 1 {
+		return nil, errors.New("key id missing")
+	}
+
+	key := &Key{
+		ID:        config.ID,
+		Algorithm: config.Algorithm,
+		Retired:   config.Retired,
+	}
+
+	switch config.Algorithm {
+	case AlgorithmHS512:
+		if len(config.Secret) <= minimumSecretLength {
+			return nil, fmt.Errorf("key %q: secret must be longer than %d characters", config.ID, minimumSecretLength)
+		}
+
+		key.signKey = []byte(config.Secret)
+		key.verifyKey = []byte(config.Secret)
+	case AlgorithmRS256:
+		if len(config.PrivateKey) > 0 {
+			privateKey, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(config.PrivateKey))
+			if err != nil {
+				return nil, fmt.Errorf("key %q: %v", config.ID, err)
+			}
+
+			key.signKey = privateKey
+			key.verifyKey = &privateKey.PublicKey
+		} else {
+			publicKey, err := jwt.ParseRSAPublicKeyFromPEM([]byte(config.PublicKey))
+			if err != nil {
+				return nil, fmt.Errorf("key %q: %v", config.ID, err)
+			}
+
+			key.verifyKey = publicKey
+		}
+	case AlgorithmEdDSA:
+		if len(config.PrivateKey) > 0 {
+			privateKey, err := parseEd25519PrivateKey(config.PrivateKey)
+			if err != nil {
+				return nil, fmt.Errorf("key %q: %v", config.ID, err)
+			}
+
+			key.signKey = privateKey
+			key.verifyKey = privateKey.Public()
+		} else {
+			publicKey, err := parseEd25519PublicKey(config.PublicKey)
+			if err != nil {
+				return nil, fmt.Errorf("key %q: %v", config.ID, err)
+			}
+
+			key.verifyKey = publicKey
+		}
+	default:
+		return nil, fmt.Errorf("key %q: unsupported algorithm %q", config.ID, config.Algorithm)
+	}
+
+	return key, nil
+}
+
+// parseEd25519PrivateKey parses a PEM encoded PKCS #8 Ed25519 private key.
+func parseEd25519PrivateKey(data string) (ed25519.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, errors.New("invalid PEM private key")
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, ok := parsed.(ed25519.PrivateKey)
+	if !ok {
+		return nil, errors.New("not an Ed25519 private key")
+	}
+
+	return privateKey, nil
+}
+
+// parseEd25519PublicKey parses a PEM encoded PKIX Ed25519 public key.
+func parseEd25519PublicKey(data string) (ed25519.PublicKey, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, errors.New("invalid PEM public key")
+	}
+
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, ok := parsed.(ed25519.PublicKey)
+	if !ok {
+		return nil, errors.New("not an Ed25519 public key")
+	}
+
+	return publicKey, nil
+}
+
+// SigningKey returns the key used to sign new tokens.
+func (k *Keyring) SigningKey() *Key {
+	return k.signingKey
+}
+
+// Sign signs a set of claims with the signing key, setting the kid header to
+// the key's ID.
+func (k *Keyring) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(k.signingKey.method(), claims)
+	token.Header["kid"] = k.signingKey.ID
+
+	return token.SignedString(k.signingKey.signKey)
+}
+
+// Keyfunc finds the key to verify a token with from its kid header. Unknown
+// and retired keys, and tokens signed with an algorithm other than the key's,
+// are rejected.
+func (k *Keyring) Keyfunc(token *jwt.Token) (interface{}, error) {
+	id, ok := token.Header["kid"].(string)
+	if !ok {
+		return nil, errors.New("token has no key id")
+	}
+
+	key, ok := k.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", id)
+	}
+
+	if key.Retired {
+		return nil, fmt.Errorf("key %q has been retired", id)
+	}
+
+	if token.Method.Alg() != key.method().Alg() {
+		return nil, fmt.Errorf("unexpected signing method %q for key %q", token.Method.Alg(), id)
+	}
+
+	return key.verifyKey, nil
+}
+
+// JWKS returns the public keys of all asymmetric keys which have not been
+// retired, as a JSON Web Key Set. Shared secrets are never included.
+func (k *Keyring) JWKS() *JWKS {
+	set := &JWKS{
+		Keys: []JWK{},
+	}
+
+	for _, id := range k.order {
+		key := k.keys[id]
+		if key.Retired {
+			continue
+		}
+
+		switch publicKey := key.verifyKey.(type) {
+		case *rsa.PublicKey:
+			set.Keys = append(set.Keys, rsaJWK(key, publicKey))
+		case ed25519.PublicKey:
+			set.Keys = append(set.Keys, ed25519JWK(key, publicKey))
+		}
+	}
+
+	return set
+}
@@ -0,0 +1,147 @@
+package keyring
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+const sampleSecret = "a-sample-secret-which-is-long-enough-to-sign"
+
+// rsaPrivateKeyPEM generates a PEM encoded RSA private key.
+func rsaPrivateKeyPEM(t *testing.T) string {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal("error generating rsa key:", err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(privateKey),
+	}))
+}
+
+// ed25519PrivateKeyPEM generates a PEM encoded Ed25519 private key.
+func ed25519PrivateKeyPEM(t *testing.T) string {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal("error generating ed25519 key:", err)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		t.Fatal("error marshalling ed25519 key:", err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{
+		Type:  "PRIVATE KEY",
+		Bytes: der,
+	}))
+}
+
+// TestSignAndVerify tests that tokens signed with each algorithm can be verified.
+func TestSignAndVerify(t *testing.T) {
+	configs := []KeyConfig{
+		{ID: "hmac", Algorithm: AlgorithmHS512, Secret: sampleSecret},
+		{ID: "rsa", Algorithm: AlgorithmRS256, PrivateKey: rsaPrivateKeyPEM(t)},
+		{ID: "ed25519", Algorithm: AlgorithmEdDSA, PrivateKey: ed25519PrivateKeyPEM(t)},
+	}
+
+	for _, config := range configs {
+		keyring, err := New(configs, config.ID)
+		if err != nil {
+			t.Fatal("error creating keyring:", err)
+		}
+
+		signed, err := keyring.Sign(jwt.StandardClaims{Subject: "1"})
+		if err != nil {
+			t.Fatalf("error signing with %s: %v", config.ID, err)
+		}
+
+		token, err := jwt.Parse(signed, keyring.Keyfunc)
+		if err != nil || !token.Valid {
+			t.Fatalf("error verifying token signed with %s: %v", config.ID, err)
+		}
+
+		if token.Header["kid"] != config.ID {
+			t.Fatalf("expected kid %s, got %v", config.ID, token.Header["kid"])
+		}
+	}
+}
+
+// TestRetiredKey tests that tokens signed with a retired key are rejected.
+func TestRetiredKey(t *testing.T) {
+	old, err := New([]KeyConfig{
+		{ID: "old", Algorithm: AlgorithmHS512, Secret: sampleSecret},
+	}, "")
+	if err != nil {
+		t.Fatal("error creating keyring:", err)
+	}
+
+	signed, err := old.Sign(jwt.StandardClaims{Subject: "1"})
+	if err != nil {
+		t.Fatal("error signing:", err)
+	}
+
+	keyring, err := New([]KeyConfig{
+		{ID: "new", Algorithm: AlgorithmEdDSA, PrivateKey: ed25519PrivateKeyPEM(t)},
+		{ID: "old", Algorithm: AlgorithmHS512, Secret: sampleSecret, Retired: true},
+	}, "")
+	if err != nil {
+		t.Fatal("error creating keyring:", err)
+	}
+
+	if _, err := jwt.Parse(signed, keyring.Keyfunc); err == nil {
+		t.Fatal("token signed with a retired key was accepted")
+	}
+}
+
+// TestAlgorithmMismatch tests that a token can't pick a different algorithm
+// than the one its key was configured with.
+func TestAlgorithmMismatch(t *testing.T) {
+	keyring, err := New([]KeyConfig{
+		{ID: "rsa", Algorithm: AlgorithmRS256, PrivateKey: rsaPrivateKeyPEM(t)},
+	}, "")
+	if err != nil {
+		t.Fatal("error creating keyring:", err)
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.StandardClaims{Subject: "1"})
+	token.Header["kid"] = "rsa"
+	signed, err := token.SignedString([]byte(sampleSecret))
+	if err != nil {
+		t.Fatal("error signing:", err)
+	}
+
+	if _, err := jwt.Parse(signed, keyring.Keyfunc); err == nil {
+		t.Fatal("token with mismatched algorithm was accepted")
+	}
+}
+
+// TestJWKS tests that only public keys of keys which have not been retired
+// are published.
+func TestJWKS(t *testing.T) {
+	keyring, err := New([]KeyConfig{
+		{ID: "hmac", Algorithm: AlgorithmHS512, Secret: sampleSecret},
+		{ID: "rsa", Algorithm: AlgorithmRS256, PrivateKey: rsaPrivateKeyPEM(t)},
+		{ID: "ed25519", Algorithm: AlgorithmEdDSA, PrivateKey: ed25519PrivateKeyPEM(t)},
+		{ID: "retired", Algorithm: AlgorithmEdDSA, PrivateKey: ed25519PrivateKeyPEM(t), Retired: true},
+	}, "")
+	if err != nil {
+		t.Fatal("error creating keyring:", err)
+	}
+
+	set := keyring.JWKS()
+	if len(set.Keys) != 2 {
+		t.Fatalf("expected 2 keys, got %d", len(set.Keys))
+	}
+
+	if set.Keys[0].KeyID != "rsa" || set.Keys[0].KeyType != "RSA" || set.Keys[1].KeyID != "ed25519" || set.Keys[1].KeyType != "OKP" {
+		t.Fatalf("unexpected keys %+v", set.Keys)
+	}
+}
@@ -0,0 +1,118 @@
+package authentication
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/joinimpact/api/internal/email"
+	"github.com/joinimpact/api/internal/email/templates"
+	"github.com/joinimpact/api/internal/models"
+)
+
+// magicLinkKeyLifespan is how long a magic link stays valid.
+const magicLinkKeyLifespan = 15 * time.Minute
+
+// RequestMagicLink emails a user a single-use link to log in without a
+// password. To avoid revealing which emails have accounts, no error is
+// returned if the user does not exist.
+func (s *service) RequestMagicLink(ctx context.Context, userEmail, ipAddress string) error {
+	if err := checkLocked(s.magicLinkEmailAttempts, s.magicLinkIPAttempts, userEmail, ipAddress); err != nil {
+		return err
+	}
+
+	// Every request counts towards the limits, whether or not the user exists.
+	s.magicLinkEmailAttempts.Fail(attemptKey(userEmail))
+	if len(ipAddress) > 0 {
+		s.magicLinkIPAttempts.Fail(ipAddress)
+	}
+
+	user, err := s.userRepository.FindByEmail(userEmail)
+	if err != nil {
+		return nil
+	}
+
+	key, err := generatePasswordResetKey()
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error generating magic link key")
+		return NewErrServerError()
+	}
+
+	magicLinkKey := models.PasswordResetKey{
+		UserID:    user.ID,
+		Key:       key,
+		Purpose:   models.PasswordResetKeyPurposeMagicLink,
+		ExpiresAt: time.Now().UTC().Add(magicLinkKeyLifespan),
+	}
+	magicLinkKey.ID = s.snowflakeService.GenerateID()
+
+	if err := s.passwordResetKeyRepository.Create(magicLinkKey); err != nil {
+		s.logger.Error().Err(err).Msg("Error creating magic link key")
+		return NewErrServerError()
+	}
+
+	email := s.emailService.NewEmail(
+		email.NewRecipient(fmt.Sprintf("%s %s", user.FirstName, user.LastName), user.Email),
+		"Your login link",
+		templates.MagicLinkTemplate(user.FirstName, key),
+	)
+
+	if err := s.emailService.Send(email); err != nil {
+		s.logger.Error().Err(err).Msg("Error sending magic link email")
+		return NewErrServerError()
+	}
+
+	return nil
+}
+
+// RedeemMagicLink logs in a user from a magic link's key, and returns a
+// TokenPair on success, or a TwoFactorChallenge if the user has two-factor
+// authentication enabled. The key can only be used once.
+func (s *service) RedeemMagicLink(ctx context.Context, key string, sessionInfo SessionInfo) (*TokenPair, *TwoFactorChallenge, error) {
+	magicLinkKey, err := s.passwordResetKeyRepository.FindByKey(key)
+	if err != nil || magicLinkKey.Purpose != models.PasswordResetKeyPurposeMagicLink {
+		return nil, nil, NewErrInvalidKey()
+	}
+
+	// Delete the key before logging in so it can't be used again. Only the
+	// request which deleted the key logs in.
+	consumed, err := s.passwordResetKeyRepository.Consume(magicLinkKey.ID)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error deleting magic link key")
+		return nil, nil, NewErrServerError()
+	}
+
+	if !consumed {
+		return nil, nil, NewErrInvalidKey()
+	}
+
+	user, err := s.userRepository.FindByID(magicLinkKey.UserID)
+	if err != nil {
+		return nil, nil, NewErrUserNotFound()
+	}
+
+	if !user.EmailVerified {
+		// Opening the link proves that the user owns their email.
+		if err := s.userRepository.Update(models.User{
+			Model: models.Model{
+				ID: user.ID,
+			},
+			EmailVerified: true,
+		}); err != nil {
+			s.logger.Error().Err(err).Msg("Error verifying user email")
+		}
+	}
+
+	s.magicLinkEmailAttempts.Reset(attemptKey(user.Email))
+
+	challenge, err := s.twoFactorChallenge(ctx, user.ID, LoginMethodMagicLink)
+	if err != nil {
+		return nil, nil, err
+	}
+	if challenge != nil {
+		return nil, challenge, nil
+	}
+
+	tokenPair, err := s.createSession(ctx, user.ID, LoginMethodMagicLink, sessionInfo)
+	return tokenPair, nil, err
+}
@@ -0,0 +1,514 @@
+package oauth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"golang.org/x/oauth2"
+)
+
+// oidcKeysRefreshInterval is the minimum time between two fetches of a
+// provider's JWKS, so that tokens with unknown key IDs can't be used to make
+// the server hammer the provider.
+const oidcKeysRefreshInterval = time.Minute
+
+// oidcClockSkew is the clock skew allowed when validating ID token times.
+const oidcClockSkew = time.Minute
+
+// oidcSigningMethods are the signing methods accepted for ID tokens.
+var oidcSigningMethods = map[string]bool{
+	"RS256": true,
+	"RS384": true,
+	"RS512": true,
+	"ES256": true,
+	"ES384": true,
+	"ES512": true,
+}
+
+// OIDCProviderConfig configures a single OpenID Connect provider.
+type OIDCProviderConfig struct {
+	Name           string   `json:"name"`                     // the provider's name, used in the login URL
+	Issuer         string   `json:"issuer"`                   // the issuer URL, used for discovery
+	ClientID       string   `json:"clientId"`                 // the client ID registered with the provider
+	ClientSecret   string   `json:"clientSecret"`             // the client secret registered with the provider
+	RedirectURL    string   `json:"redirectUrl"`              // the redirect URL used for authorization codes
+	Scopes         []string `json:"scopes,omitempty"`         // scopes to request, defaults to openid, email and profile
+	EmailClaim     string   `json:"emailClaim,omitempty"`     // the claim containing the email, defaults to email
+	FirstNameClaim string   `json:"firstNameClaim,omitempty"` // the claim containing the first name, defaults to given_name
+	LastNameClaim  string   `json:"lastNameClaim,omitempty"`  // the claim containing the last name, defaults to family_name
+}
+
+// OIDCClient is an interface for authenticating users with an OpenID Connect
+// provider.
+type OIDCClient interface {
+	// ExchangeCode exchanges an authorization code for a raw ID token.
+	ExchangeCode(ctx context.Context, code string) (string, error)
+	// VerifyIDToken verifies an ID token's signature and claims, and returns
+	// the profile it describes. If nonce is not empty, it must match the
+	// token's nonce claim.
+	VerifyIDToken(ctx context.Context, rawIDToken, nonce string) (*OIDCProfile, error)
+}
+
+// OIDCProfile represents a profile based on the claims of an ID token.
+type OIDCProfile struct {
+	Subject       string
+	FirstName     string
+	LastName      string
+	Email         string
+	EmailVerified bool // whether the provider stated that it verified the email
+}
+
+// oidcDiscovery contains the parts of a provider's discovery document which
+// are used.
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// oidcClient acts as the internal implementation of the OIDCClient interface.
+type oidcClient struct {
+	config     OIDCProviderConfig
+	httpClient *http.Client
+	now        func() time.Time
+
+	mu            sync.Mutex
+	discovery     *oidcDiscovery
+	keys          map[string]interface{}
+	keysFetchedAt time.Time
+}
+
+// NewOIDCClient creates and returns a new OIDCClient for a provider. The
+// provider's discovery document and keys are fetched on first use.
+func NewOIDCClient(config OIDCProviderConfig, httpClient *http.Client) OIDCClient {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	if len(config.Scopes) < 1 {
+		config.Scopes = []string{"openid", "email", "profile"}
+	}
+	if len(config.EmailClaim) < 1 {
+		config.EmailClaim = "email"
+	}
+	if len(config.FirstNameClaim) < 1 {
+		config.FirstNameClaim = "given_name"
+	}
+	if len(config.LastNameClaim) < 1 {
+		config.LastNameClaim = "family_name"
+	}
+
+	return &oidcClient{
+		config:     config,
+		httpClient: httpClient,
+		now:        time.Now,
+	}
+}
+
+// getJSON gets a URL and decodes the JSON response into v.
+func (c *oidcClient) getJSON(ctx context.Context, url string, v interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d from %s", res.StatusCode, url)
+	}
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(body, v)
+}
+
+// getDiscovery gets the provider's discovery document, fetching it if it
+// hasn't been fetched yet.
+func (c *oidcClient) getDiscovery(ctx context.Context) (*oidcDiscovery, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.discovery != nil {
+		return c.discovery, nil
+	}
+
+	var discovery oidcDiscovery
+	url := strings.TrimSuffix(c.config.Issuer, "/") + "/.well-known/openid-configuration"
+	if err := c.getJSON(ctx, url, &discovery); err != nil {
+		return nil, err
+	}
+
+	if discovery.Issuer != c.config.Issuer {
+		return nil, fmt.Errorf("discovered issuer %q does not match configured issuer %q", discovery.Issuer, c.config.Issuer)
+	}
+
+	if len(discovery.JWKSURI) < 1 {
+		return nil, errors.New("provider has no jwks_uri")
+	}
+
+	c.discovery = &discovery
+
+	return c.discovery, nil
+}
+
+// getKey gets a provider's verification key by ID, refetching the provider's
+// keys if the ID is unknown, as the provider may have rotated its keys.
+func (c *oidcClient) getKey(ctx context.Context, id string) (interface{}, error) {
+	discovery, err := c.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if key, ok := c.keys[id]; ok {
+		return key, nil
+	}
+
+	if c.keys != nil && c.now().Sub(c.keysFetchedAt) < oidcKeysRefreshInterval {
+		return nil, fmt.Errorf("unknown key id %q", id)
+	}
+
+	set := struct {
+		Keys []oidcJWK `json:"keys"`
+	}{}
+	if err := c.getJSON(ctx, discovery.JWKSURI, &set); err != nil {
+		return nil, err
+	}
+
+	keys := map[string]interface{}{}
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := jwk.publicKey()
+		if err != nil {
+			// Skip keys of unsupported types.
+			continue
+		}
+
+		keys[jwk.KeyID] = key
+	}
+
+	c.keys = keys
+	c.keysFetchedAt = c.now()
+
+	key, ok := c.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", id)
+	}
+
+	return key, nil
+}
+
+// ExchangeCode exchanges an authorization code for a raw ID token.
+func (c *oidcClient) ExchangeCode(ctx context.Context, code string) (string, error) {
+	discovery, err := c.getDiscovery(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	oauthConfig := &oauth2.Config{
+		RedirectURL:  c.config.RedirectURL,
+		ClientID:     c.config.ClientID,
+		ClientSecret: c.config.ClientSecret,
+		Scopes:       c.config.Scopes,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  discovery.AuthorizationEndpoint,
+			TokenURL: discovery.TokenEndpoint,
+		},
+	}
+
+	token, err := oauthConfig.Exchange(context.WithValue(ctx, oauth2.HTTPClient, c.httpClient), code)
+	if err != nil {
+		return "", err
+	}
+
+	idToken, ok := token.Extra("id_token").(string)
+	if !ok || len(idToken) < 1 {
+		return "", errors.New("token response has no id_token")
+	}
+
+	return idToken, nil
+}
+
+// VerifyIDToken verifies an ID token's signature and claims, and returns the
+// profile it describes.
+func (c *oidcClient) VerifyIDToken(ctx context.Context, rawIDToken, nonce string) (*OIDCProfile, error) {
+	claims := jwt.MapClaims{}
+	parser := &jwt.Parser{
+		// Time based claims are validated below, with allowance for skew.
+		SkipClaimsValidation: true,
+	}
+
+	_, err := parser.ParseWithClaims(rawIDToken, claims, func(t *jwt.Token) (interface{}, error) {
+		if !oidcSigningMethods[t.Method.Alg()] {
+			return nil, fmt.Errorf("unsupported signing method %q", t.Method.Alg())
+		}
+
+		id, _ := t.Header["kid"].(string)
+		key, err := c.getKey(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		switch key.(type) {
+		case *rsa.PublicKey:
+			if _, ok := t.Method.(*jwt.SigningMethodRSA); !ok {
+				return nil, errors.New("signing method does not match key type")
+			}
+		case *ecdsa.PublicKey:
+			if _, ok := t.Method.(*jwt.SigningMethodECDSA); !ok {
+				return nil, errors.New("signing method does not match key type")
+			}
+		}
+
+		return key, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.validateClaims(claims, nonce); err != nil {
+		return nil, err
+	}
+
+	return c.claimsToProfile(claims)
+}
+
+// validateClaims validates the issuer, audience, times and nonce of an ID
+// token.
+func (c *oidcClient) validateClaims(claims jwt.MapClaims, nonce string) error {
+	if issuer, _ := claims["iss"].(string); issuer != c.config.Issuer {
+		return errors.New("invalid issuer")
+	}
+
+	if !claimContains(claims["aud"], c.config.ClientID) {
+		return errors.New("invalid audience")
+	}
+
+	now := c.now()
+
+	exp, ok := claims["exp"].(float64)
+	if !ok || now.After(time.Unix(int64(exp), 0).Add(oidcClockSkew)) {
+		return errors.New("token expired")
+	}
+
+	if iat, ok := claims["iat"].(float64); ok && now.Add(oidcClockSkew).Before(time.Unix(int64(iat), 0)) {
+		return errors.New("token issued in the future")
+	}
+
+	if len(nonce) > 0 {
+		if tokenNonce, _ := claims["nonce"].(string); tokenNonce != nonce {
+			return errors.New("invalid nonce")
+		}
+	}
+
+	return nil
+}
+
+// claimsToProfile maps the claims of an ID token into an OIDCProfile.
+func (c *oidcClient) claimsToProfile(claims jwt.MapClaims) (*OIDCProfile, error) {
+	subject, _ := claims["sub"].(string)
+	if len(subject) < 1 {
+		return nil, errors.New("token has no subject")
+	}
+
+	email, _ := claims[c.config.EmailClaim].(string)
+	if len(email) < 1 {
+		return nil, errors.New("token has no email")
+	}
+
+	// Emails which the provider hasn't verified can't be trusted to link
+	// accounts. Tokens without the claim are accepted, but their emails are
+	// not treated as verified.
+	verified, ok := claims["email_verified"]
+	if ok && verified != true && verified != "true" {
+		return nil, errors.New("email not verified by provider")
+	}
+
+	profile := &OIDCProfile{
+		Subject:       subject,
+		Email:         email,
+		EmailVerified: ok,
+	}
+	profile.FirstName, _ = claims[c.config.FirstNameClaim].(string)
+	profile.LastName, _ = claims[c.config.LastNameClaim].(string)
+
+	if len(profile.FirstName) < 1 && len(profile.LastName) < 1 {
+		// Fall back to splitting the full name.
+		name, _ := claims["name"].(string)
+		parts := strings.SplitN(strings.TrimSpace(name), " ", 2)
+		profile.FirstName = parts[0]
+		if len(parts) > 1 {
+			profile.LastName = parts[1]
+		}
+	}
+
+	return profile, nil
+}
+
+// claimContains checks if a string or array claim contains a value.
+func claimContains(claim interface{}, value string) bool {
+	switch claim := claim.(type) {
+	case string:
+		return claim == value
+	case []interface{}:
+		for _, v := range claim {
+			if v == value {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// oidcJWK represents a single key of a provider's JWKS.
+type oidcJWK struct {
+	KeyType string `json:"kty"`
+	KeyID   string `json:"kid"`
+	Use     string `json:"use"`
+	N       string `json:"n"`
+	E       string `json:"e"`
+	Curve   string `json:"crv"`
+	X       string `json:"x"`
+	Y       string `json:"y"`
+}
+
+// publicKey returns the public key described by the JWK.
+func (k oidcJWK) publicKey() (interface{}, error) {
+	switch k.KeyType {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Curve {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Curve)
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported key type %q", k.KeyType)
+}
+
+// GetFirstName gets the first name of the profile.
+func (p *OIDCProfile) GetFirstName() string {
+	return p.FirstName
+}
+
+// GetLastName gets the last name of the profile.
+func (p *OIDCProfile) GetLastName() string {
+	return p.LastName
+}
+
+// GetEmail gets the email of the profile.
+func (p *OIDCProfile) GetEmail() string {
+	return p.Email
+}
+
+// OIDCProviders holds the configured OpenID Connect providers by name.
+type OIDCProviders map[string]OIDCClient
+
+// reservedProviderNames are the names of providers with dedicated clients.
+var reservedProviderNames = map[string]bool{
+	"google":   true,
+	"facebook": true,
+}
+
+// NewOIDCProviders creates OIDCProviders from a list of provider configs.
+func NewOIDCProviders(configs []OIDCProviderConfig, httpClient *http.Client) (OIDCProviders, error) {
+	providers := OIDCProviders{}
+	for _, config := range configs {
+		if len(config.Name) < 1 || len(config.Issuer) < 1 || len(config.ClientID) < 1 {
+			return nil, errors.New("oidc providers need a name, issuer and client id")
+		}
+
+		if reservedProviderNames[config.Name] {
+			return nil, fmt.Errorf("oidc provider name %q is reserved", config.Name)
+		}
+
+		if _, ok := providers[config.Name]; ok {
+			return nil, fmt.Errorf("duplicate oidc provider %q", config.Name)
+		}
+
+		providers[config.Name] = NewOIDCClient(config, httpClient)
+	}
+
+	return providers, nil
+}
+
+// LoadOIDCProviders loads OpenID Connect providers from a JSON file in the
+// format {"providers": [OIDCProviderConfig, ...]}.
+func LoadOIDCProviders(path string, httpClient *http.Client) (OIDCProviders, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file := struct {
+		Providers []OIDCProviderConfig `json:"providers"`
+	}{}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("error parsing oidc providers file: %v", err)
+	}
+
+	return NewOIDCProviders(file.Providers, httpClient)
+}
@@ -0,0 +1,249 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+const testClientID = "impact-test"
+
+// fakeIssuer is a local OpenID Connect provider for tests.
+type fakeIssuer struct {
+	server     *httptest.Server
+	keys       map[string]*rsa.PrivateKey
+	jwksHits   int
+	tokenCodes map[string]string
+}
+
+// newFakeIssuer starts a fake issuer with a single signing key.
+func newFakeIssuer(t *testing.T) *fakeIssuer {
+	issuer := &fakeIssuer{
+		keys:       map[string]*rsa.PrivateKey{},
+		tokenCodes: map[string]string{},
+	}
+	issuer.addKey(t, "key-1")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 issuer.server.URL,
+			"authorization_endpoint": issuer.server.URL + "/authorize",
+			"token_endpoint":         issuer.server.URL + "/token",
+			"jwks_uri":               issuer.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		issuer.jwksHits++
+
+		keys := []map[string]string{}
+		for id, key := range issuer.keys {
+			keys = append(keys, map[string]string{
+				"kty": "RSA",
+				"kid": id,
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			})
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{"keys": keys})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		idToken, ok := issuer.tokenCodes[r.Form.Get("code")]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"id_token":     idToken,
+		})
+	})
+
+	issuer.server = httptest.NewServer(mux)
+	t.Cleanup(issuer.server.Close)
+
+	return issuer
+}
+
+// addKey adds a new signing key to the issuer.
+func (i *fakeIssuer) addKey(t *testing.T, id string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal("error generating rsa key:", err)
+	}
+
+	i.keys[id] = key
+}
+
+// sign signs claims with one of the issuer's keys.
+func (i *fakeIssuer) sign(t *testing.T, keyID string, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = keyID
+
+	signed, err := token.SignedString(i.keys[keyID])
+	if err != nil {
+		t.Fatal("error signing id token:", err)
+	}
+
+	return signed
+}
+
+// claims returns a valid set of claims for the issuer.
+func (i *fakeIssuer) claims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":            i.server.URL,
+		"aud":            testClientID,
+		"sub":            "user-1",
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"iat":            time.Now().Unix(),
+		"email":          "yury@joinimpact.org",
+		"email_verified": true,
+		"given_name":     "Yury",
+		"family_name":    "Orlovskiy",
+	}
+}
+
+// client creates an OIDCClient for the issuer.
+func (i *fakeIssuer) client() OIDCClient {
+	return NewOIDCClient(OIDCProviderConfig{
+		Name:     "test",
+		Issuer:   i.server.URL,
+		ClientID: testClientID,
+	}, i.server.Client())
+}
+
+// TestVerifyIDToken tests that a valid ID token is mapped into a profile.
+func TestVerifyIDToken(t *testing.T) {
+	issuer := newFakeIssuer(t)
+
+	profile, err := issuer.client().VerifyIDToken(context.Background(), issuer.sign(t, "key-1", issuer.claims()), "")
+	if err != nil {
+		t.Fatal("error verifying id token:", err)
+	}
+
+	if profile.Subject != "user-1" || profile.GetEmail() != "yury@joinimpact.org" || profile.GetFirstName() != "Yury" || profile.GetLastName() != "Orlovskiy" || !profile.EmailVerified {
+		t.Fatalf("unexpected profile %+v", profile)
+	}
+}
+
+// TestVerifyIDTokenWithoutEmailVerified tests that emails are not treated as
+// verified when the token omits the email_verified claim.
+func TestVerifyIDTokenWithoutEmailVerified(t *testing.T) {
+	issuer := newFakeIssuer(t)
+	claims := issuer.claims()
+	delete(claims, "email_verified")
+
+	profile, err := issuer.client().VerifyIDToken(context.Background(), issuer.sign(t, "key-1", claims), "")
+	if err != nil {
+		t.Fatal("error verifying id token:", err)
+	}
+
+	if profile.EmailVerified {
+		t.Error("email treated as verified without the email_verified claim")
+	}
+}
+
+// TestVerifyIDTokenInvalid tests that invalid ID tokens are rejected.
+func TestVerifyIDTokenInvalid(t *testing.T) {
+	issuer := newFakeIssuer(t)
+	client := issuer.client()
+
+	tests := map[string]func(claims jwt.MapClaims){
+		"wrong issuer":       func(claims jwt.MapClaims) { claims["iss"] = "https://example.com" },
+		"wrong audience":     func(claims jwt.MapClaims) { claims["aud"] = []string{"someone-else"} },
+		"expired":            func(claims jwt.MapClaims) { claims["exp"] = time.Now().Add(-time.Hour).Unix() },
+		"unverified email":   func(claims jwt.MapClaims) { claims["email_verified"] = false },
+		"missing email":      func(claims jwt.MapClaims) { delete(claims, "email") },
+		"missing subject":    func(claims jwt.MapClaims) { delete(claims, "sub") },
+		"issued in future":   func(claims jwt.MapClaims) { claims["iat"] = time.Now().Add(time.Hour).Unix() },
+		"nonce not matching": func(claims jwt.MapClaims) { claims["nonce"] = "other" },
+	}
+
+	for name, modify := range tests {
+		claims := issuer.claims()
+		claims["nonce"] = "nonce"
+		modify(claims)
+
+		if _, err := client.VerifyIDToken(context.Background(), issuer.sign(t, "key-1", claims), "nonce"); err == nil {
+			t.Errorf("%s: token accepted", name)
+		}
+	}
+
+	// Tokens signed with an unknown key are rejected.
+	other := newFakeIssuer(t)
+	claims := issuer.claims()
+	if _, err := client.VerifyIDToken(context.Background(), other.sign(t, "key-1", claims), ""); err == nil {
+		t.Error("token signed with an unknown key accepted")
+	}
+
+	// Unsigned tokens are rejected.
+	unsigned, _ := jwt.NewWithClaims(jwt.SigningMethodNone, issuer.claims()).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if _, err := client.VerifyIDToken(context.Background(), unsigned, ""); err == nil {
+		t.Error("unsigned token accepted")
+	}
+}
+
+// TestKeyRotation tests that keys are refetched when a token uses an unknown
+// key ID, but not more often than the refresh interval.
+func TestKeyRotation(t *testing.T) {
+	issuer := newFakeIssuer(t)
+	client := issuer.client().(*oidcClient)
+
+	now := time.Now()
+	client.now = func() time.Time { return now }
+
+	if _, err := client.VerifyIDToken(context.Background(), issuer.sign(t, "key-1", issuer.claims()), ""); err != nil {
+		t.Fatal("error verifying id token:", err)
+	}
+
+	issuer.addKey(t, "key-2")
+	token := issuer.sign(t, "key-2", issuer.claims())
+
+	if _, err := client.VerifyIDToken(context.Background(), token, ""); err == nil {
+		t.Fatal("keys refetched before the refresh interval")
+	}
+
+	now = now.Add(oidcKeysRefreshInterval + time.Second)
+	if _, err := client.VerifyIDToken(context.Background(), token, ""); err != nil {
+		t.Fatal("error verifying id token after key rotation:", err)
+	}
+
+	if issuer.jwksHits != 2 {
+		t.Fatalf("expected 2 jwks fetches, got %d", issuer.jwksHits)
+	}
+}
+
+// TestExchangeCode tests exchanging an authorization code for an ID token.
+func TestExchangeCode(t *testing.T) {
+	issuer := newFakeIssuer(t)
+	idToken := issuer.sign(t, "key-1", issuer.claims())
+	issuer.tokenCodes["code-1"] = idToken
+
+	exchanged, err := issuer.client().ExchangeCode(context.Background(), "code-1")
+	if err != nil {
+		t.Fatal("error exchanging code:", err)
+	}
+
+	if exchanged != idToken {
+		t.Fatal("exchanged id token does not match")
+	}
+
+	if _, err := issuer.client().ExchangeCode(context.Background(), "code-2"); err == nil {
+		t.Fatal("invalid code accepted")
+	}
+}
@@ -1,8 +1,8 @@
 package authentication
 
 import (
-	"math/rand"
-	"time"
+	"crypto/rand"
+	"math/big"
 )
 
 // PasswordResetValidation contains fields about a password reset.
@@ -14,19 +14,22 @@ type PasswordResetValidation struct {
 const charset = "abcdefghijklmnopqrstuvwxyz" +
 	"ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
 
-	// TODO: move global variable to another solution.
-var seededRand *rand.Rand = rand.New(rand.NewSource(time.Now().UnixNano()))
-
-// stringWithCharset generates a random string using a charset.
-func stringWithCharset(length int, charset string) string {
+// stringWithCharset generates a random string using a charset. The characters are picked with
+// crypto/rand, as the strings are used as secret keys.
+func stringWithCharset(length int, charset string) (string, error) {
+	max := big.NewInt(int64(len(charset)))
 	b := make([]byte, length)
 	for i := range b {
-		b[i] = charset[seededRand.Intn(len(charset))]
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		b[i] = charset[n.Int64()]
 	}
-	return string(b)
+	return string(b), nil
 }
 
 // generatePasswordResetKey generates and returns a random string to use as a key.
-func generatePasswordResetKey() string {
+func generatePasswordResetKey() (string, error) {
 	return stringWithCharset(24, charset)
 }
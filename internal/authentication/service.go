@@ -7,91 +7,187 @@ import (
 	"strings"
 	"time"
 
+	"github.com/bradfitz/gomemcache/memcache"
+	"github.com/joinimpact/api/internal/authentication/keyring"
 	"github.com/joinimpact/api/internal/authentication/oauth"
 	"github.com/joinimpact/api/internal/config"
 	"github.com/joinimpact/api/internal/email"
 	"github.com/joinimpact/api/internal/email/templates"
 	"github.com/joinimpact/api/internal/models"
 	"github.com/joinimpact/api/internal/snowflakes"
+	"github.com/joinimpact/api/pkg/attempts"
 	"github.com/rs/zerolog"
 	"golang.org/x/oauth2"
 )
 
 // Service represents a provider of authentication services.
 type Service interface {
-	// Login attempts to login to a user account with a email and password, and returns a TokenPair on success.
-	Login(email, password string) (*TokenPair, error)
+	// Login attempts to login to a user account with a email and password, and returns a TokenPair on success,
+	// or a TwoFactorChallenge if the user has two-factor authentication enabled.
+	Login(ctx context.Context, email, password string, sessionInfo SessionInfo) (*TokenPair, *TwoFactorChallenge, error)
+	// VerifyTwoFactorChallenge completes a two-step login with a TOTP or recovery code, and returns a TokenPair on success.
+	VerifyTwoFactorChallenge(ctx context.Context, challengeToken, code string, sessionInfo SessionInfo) (*TokenPair, error)
 	// CheckEmail checks if an email is available for use. If the email is taken,
 	// an error will be returned.
 	CheckEmail(email string) error
 	// Register attempts to create a new user and returns a token pair on success.
-	Register(user models.User, password string) (*TokenPair, error)
+	Register(ctx context.Context, user models.User, password string, sessionInfo SessionInfo) (*TokenPair, error)
 	// RequestPasswordReset creates a new PasswordResetKey and emails the user a link to it.
-	RequestPasswordReset(userEmail string) error
+	RequestPasswordReset(userEmail, ipAddress string) error
 	// CheckPasswordReset gets a PasswordResetKey by its key for validation purposes.
 	CheckPasswordReset(key string) (*PasswordResetValidation, error)
 	// ResetPassword resets a user's password from a PasswordResetKey's key.
 	ResetPassword(key string, newPassword string) error
+	// RequestMagicLink emails a user a single-use link to log in without a password.
+	RequestMagicLink(ctx context.Context, userEmail, ipAddress string) error
+	// RedeemMagicLink logs in a user from a magic link's key, and returns a TokenPair on success,
+	// or a TwoFactorChallenge if the user has two-factor authentication enabled.
+	RedeemMagicLink(ctx context.Context, key string, sessionInfo SessionInfo) (*TokenPair, *TwoFactorChallenge, error)
+	// ChangePassword changes a user's password after checking their current password, and revokes all of their
+	// sessions except the current one.
+	ChangePassword(ctx context.Context, userID, currentSessionID int64, currentPassword, newPassword string) error
+	// RequestEmailChange emails a confirmation link to a user's new email, and a notice to their current email.
+	RequestEmailChange(ctx context.Context, userID int64, newEmail, password string) error
+	// ConfirmEmailChange changes a user's email to the one an email change key was sent to.
+	ConfirmEmailChange(ctx context.Context, key string) error
+	// ResendVerificationEmail sends a new verification email to a user who has not verified their email yet.
+	ResendVerificationEmail(ctx context.Context, userID int64) error
+	// VerifyEmail marks a user's email as verified from an EmailVerificationKey's key.
+	VerifyEmail(ctx context.Context, key string) error
 	// GetUserIDFromToken gets a user's ID from a JWT token.
 	GetUserIDFromToken(token string) (int64, error)
+	// GetSessionIDFromToken gets the ID of the session a JWT token was issued for.
+	GetSessionIDFromToken(token string) (int64, error)
+	// AuthenticateAPIKey finds the organization API key matching a key, and records that it was used.
+	AuthenticateAPIKey(ctx context.Context, key string) (*models.OrganizationAPIKey, error)
+	// JWKS returns the public keys which can be used to verify tokens.
+	JWKS() *keyring.JWKS
 	// OauthLogin authenticates using a third-party service instead of a traditional username and password.
-	OauthLogin(serviceName, accessToken string) (*OauthResponse, error)
-	// RefreshToken generates a new token pair from a refresh token.
+	OauthLogin(ctx context.Context, serviceName, accessToken string, sessionInfo SessionInfo) (*OauthResponse, error)
+	// OIDCLogin authenticates using a configured OpenID Connect provider, from either an ID token or an authorization code.
+	OIDCLogin(ctx context.Context, providerName, idToken, code, nonce string, sessionInfo SessionInfo) (*OauthResponse, error)
+	// RefreshToken generates a new token pair from a refresh token, rotating the session's refresh token.
 	RefreshToken(ctx context.Context, refreshToken string) (*TokenPair, error)
+	// Logout revokes the session that an auth or refresh token was issued for.
+	Logout(ctx context.Context, token string) error
+	// GetUserSessions gets all active sessions of a user.
+	GetUserSessions(ctx context.Context, userID, currentSessionID int64) ([]SessionView, error)
+	// RevokeSession revokes a single session of a user by ID.
+	RevokeSession(ctx context.Context, userID, sessionID int64) error
+	// GetTwoFactorStatus gets the status of a user's two-factor authentication.
+	GetTwoFactorStatus(ctx context.Context, userID int64) (*TwoFactorStatus, error)
+	// BeginTwoFactorEnrollment generates a new TOTP secret for a user.
+	BeginTwoFactorEnrollment(ctx context.Context, userID int64) (*TwoFactorEnrollment, error)
+	// ConfirmTwoFactorEnrollment enables two-factor authentication for a user and returns a set of recovery codes.
+	ConfirmTwoFactorEnrollment(ctx context.Context, userID int64, code string) ([]string, error)
+	// RegenerateRecoveryCodes replaces a user's recovery codes and returns the new codes.
+	RegenerateRecoveryCodes(ctx context.Context, userID int64, code string) ([]string, error)
+	// DisableTwoFactor disables two-factor authentication for a user.
+	DisableTwoFactor(ctx context.Context, userID int64, code string) error
 	// UpdateLastOnline updates a user's last online time.
 	UpdateLastOnline(ctx context.Context, userID int64) error
 }
 
 // service represents the default authentication service of this package.
 type service struct {
-	userRepository               models.UserRepository
-	passwordResetKeyRepository   models.PasswordResetKeyRepository
-	thirdPartyIdentityRepository models.ThirdPartyIdentityRepository
-	config                       *config.Config
-	logger                       *zerolog.Logger
-	snowflakeService             snowflakes.SnowflakeService
-	emailService                 email.Service
+	userRepository                  models.UserRepository
+	passwordResetKeyRepository      models.PasswordResetKeyRepository
+	emailVerificationKeyRepository  models.EmailVerificationKeyRepository
+	thirdPartyIdentityRepository    models.ThirdPartyIdentityRepository
+	sessionRepository               models.SessionRepository
+	twoFactorCredentialRepository   models.TwoFactorCredentialRepository
+	twoFactorRecoveryCodeRepository models.TwoFactorRecoveryCodeRepository
+	organizationAPIKeyRepository    models.OrganizationAPIKeyRepository
+	config                          *config.Config
+	keyring                         *keyring.Keyring
+	logger                          *zerolog.Logger
+	snowflakeService                snowflakes.SnowflakeService
+	emailService                    email.Service
+	oidcProviders                   oauth.OIDCProviders
+	loginEmailAttempts              attempts.Tracker
+	loginIPAttempts                 attempts.Tracker
+	passwordResetEmailAttempts      attempts.Tracker
+	passwordResetIPAttempts         attempts.Tracker
+	magicLinkEmailAttempts          attempts.Tracker
+	magicLinkIPAttempts             attempts.Tracker
 }
 
 // NewService creates and returns a new Service with the provided UserRepository, Config, Logger, and SnowflakeService.
-func NewService(userRepository models.UserRepository, passwordResetKeyRepository models.PasswordResetKeyRepository, thirdPartyIdentityRepository models.ThirdPartyIdentityRepository, config *config.Config, logger *zerolog.Logger,
-	snowflakeService snowflakes.SnowflakeService, emailService email.Service) Service {
+func NewService(userRepository models.UserRepository, passwordResetKeyRepository models.PasswordResetKeyRepository, emailVerificationKeyRepository models.EmailVerificationKeyRepository, thirdPartyIdentityRepository models.ThirdPartyIdentityRepository, sessionRepository models.SessionRepository, twoFactorCredentialRepository models.TwoFactorCredentialRepository, twoFactorRecoveryCodeRepository models.TwoFactorRecoveryCodeRepository, organizationAPIKeyRepository models.OrganizationAPIKeyRepository, config *config.Config, keyring *keyring.Keyring, logger *zerolog.Logger,
+	snowflakeService snowflakes.SnowflakeService, emailService email.Service, oidcProviders oauth.OIDCProviders, cache *memcache.Client) Service {
 	return &service{
 		userRepository,
 		passwordResetKeyRepository,
+		emailVerificationKeyRepository,
 		thirdPartyIdentityRepository,
+		sessionRepository,
+		twoFactorCredentialRepository,
+		twoFactorRecoveryCodeRepository,
+		organizationAPIKeyRepository,
 		config,
+		keyring,
 		logger,
 		snowflakeService,
 		emailService,
+		oidcProviders,
+		attempts.NewTracker(cache, loginEmailAttemptOptions),
+		attempts.NewTracker(cache, loginIPAttemptOptions),
+		attempts.NewTracker(cache, passwordResetEmailAttemptOptions),
+		attempts.NewTracker(cache, passwordResetIPAttemptOptions),
+		attempts.NewTracker(cache, magicLinkEmailAttemptOptions),
+		attempts.NewTracker(cache, magicLinkIPAttemptOptions),
 	}
 }
 
-// Login attempts to login to a user account with an email and password, and returns a TokenPair on success.
-func (s *service) Login(email, password string) (*TokenPair, error) {
+// Login attempts to login to a user account with an email and password, and returns a TokenPair on success,
+// or a TwoFactorChallenge if the user has two-factor authentication enabled.
+func (s *service) Login(ctx context.Context, email, password string, sessionInfo SessionInfo) (*TokenPair, *TwoFactorChallenge, error) {
+	if err := checkLocked(s.loginEmailAttempts, s.loginIPAttempts, email, sessionInfo.IPAddress); err != nil {
+		return nil, nil, err
+	}
+
 	// Find the user by email.
 	user, err := s.userRepository.FindByEmail(email)
 	if err != nil {
-		return nil, err
+		if lockErr := s.loginFailed(email, sessionInfo.IPAddress, nil); lockErr != nil {
+			return nil, nil, lockErr
+		}
+		return nil, nil, err
 	}
 
 	if len(user.Password) < 50 {
 		// Invalid/null password on user, block login.
-		return nil, errors.New("user password not set")
+		return nil, nil, errors.New("user password not set")
 	}
 
 	// Compare the provided password with the user password.
 	ok, err := compareHashAndPassword(password, user.Password)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if !ok {
+		if lockErr := s.loginFailed(email, sessionInfo.IPAddress, user); lockErr != nil {
+			return nil, nil, lockErr
+		}
 		// TODO: Custom type errors
-		return nil, errors.New("invalid password")
+		return nil, nil, errors.New("invalid password")
 	}
 
-	// Successful login, generate and return a token pair.
-	return s.generateTokenPair(user.ID)
+	s.loginEmailAttempts.Reset(attemptKey(email))
+
+	challenge, err := s.twoFactorChallenge(ctx, user.ID, LoginMethodPassword)
+	if err != nil {
+		return nil, nil, err
+	}
+	if challenge != nil {
+		// The password is correct, but a second factor is required before a
+		// session is created.
+		return nil, challenge, nil
+	}
+
+	// Successful login, create a session and return its token pair.
+	tokenPair, err := s.createSession(ctx, user.ID, LoginMethodPassword, sessionInfo)
+	return tokenPair, nil, err
 }
 
 // CheckEmail checks if an email is available for use. If the email is taken,
@@ -110,7 +206,7 @@ func (s *service) CheckEmail(email string) error {
 }
 
 // Register attempts to register a new user account with an email and password, and returns a TokenPair on success.
-func (s *service) Register(user models.User, password string) (*TokenPair, error) {
+func (s *service) Register(ctx context.Context, user models.User, password string, sessionInfo SessionInfo) (*TokenPair, error) {
 	if user.ID > 0 {
 		// Throw an error if the user already has an ID,
 		// possible indication that it already exists and this
@@ -160,12 +256,27 @@ func (s *service) Register(user models.User, password string) (*TokenPair, error
 		s.logger.Error().Err(err).Msg("error sending email to new user")
 	}
 
-	// Successful user creation, generate and return a token pair.
-	return s.generateTokenPair(user.ID)
+	err = s.sendVerificationEmail(&user)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("error sending verification email to new user")
+	}
+
+	// Successful user creation, create a session and return its token pair.
+	return s.createSession(ctx, user.ID, LoginMethodRegister, sessionInfo)
 }
 
 // RequestPasswordReset creates a new PasswordResetKey and emails the user a link to it.
-func (s *service) RequestPasswordReset(userEmail string) error {
+func (s *service) RequestPasswordReset(userEmail, ipAddress string) error {
+	if err := checkLocked(s.passwordResetEmailAttempts, s.passwordResetIPAttempts, userEmail, ipAddress); err != nil {
+		return err
+	}
+
+	// Every request counts towards the limits, whether or not the user exists.
+	s.passwordResetEmailAttempts.Fail(attemptKey(userEmail))
+	if len(ipAddress) > 0 {
+		s.passwordResetIPAttempts.Fail(ipAddress)
+	}
+
 	// Find the user by email.
 	user, err := s.userRepository.FindByEmail(userEmail)
 	if err != nil {
@@ -174,7 +285,10 @@ func (s *service) RequestPasswordReset(userEmail string) error {
 
 	// Generate an id and a key for the PasswordResetKey.
 	id := s.snowflakeService.GenerateID()
-	key := generatePasswordResetKey()
+	key, err := generatePasswordResetKey()
+	if err != nil {
+		return errors.New("could not create reset key")
+	}
 
 	// Create the PasswordResetKey.
 	err = s.passwordResetKeyRepository.Create(models.PasswordResetKey{
@@ -183,6 +297,7 @@ func (s *service) RequestPasswordReset(userEmail string) error {
 		},
 		UserID:    user.ID,
 		Key:       key,
+		Purpose:   models.PasswordResetKeyPurposeReset,
 		ExpiresAt: time.Now().UTC().Add(24 * time.Hour),
 	})
 	if err != nil {
@@ -207,7 +322,7 @@ func (s *service) RequestPasswordReset(userEmail string) error {
 // CheckPasswordReset gets a PasswordResetKey by its key for validation purposes.
 func (s *service) CheckPasswordReset(key string) (*PasswordResetValidation, error) {
 	resetKey, err := s.passwordResetKeyRepository.FindByKey(key)
-	if err != nil {
+	if err != nil || !resetKey.IsPasswordReset() {
 		return nil, errors.New("invalid key")
 	}
 
@@ -225,7 +340,7 @@ func (s *service) CheckPasswordReset(key string) (*PasswordResetValidation, erro
 // ResetPassword resets a user's password from a PasswordResetKey's key.
 func (s *service) ResetPassword(key string, newPassword string) error {
 	resetKey, err := s.passwordResetKeyRepository.FindByKey(key)
-	if err != nil {
+	if err != nil || !resetKey.IsPasswordReset() {
 		return errors.New("invalid key")
 	}
 
@@ -251,12 +366,13 @@ func (s *service) ResetPassword(key string, newPassword string) error {
 
 // OauthResponse has information relating to the autentication of users using Oauth.
 type OauthResponse struct {
-	UserCreated bool       `json:"userCreated"`
-	TokenPair   *TokenPair `json:"token"`
+	UserCreated        bool                `json:"userCreated"`
+	TokenPair          *TokenPair          `json:"token"`
+	TwoFactorChallenge *TwoFactorChallenge `json:"twoFactorChallenge,omitempty"`
 }
 
 // OauthLogin authenticates using a third-party service instead of a traditional username and password.
-func (s *service) OauthLogin(serviceName, accessToken string) (*OauthResponse, error) {
+func (s *service) OauthLogin(ctx context.Context, serviceName, accessToken string, sessionInfo SessionInfo) (*OauthResponse, error) {
 	var profile oauth.Profile
 	var token *oauth2.Token
 	var err error
@@ -282,34 +398,101 @@ func (s *service) OauthLogin(serviceName, accessToken string) (*OauthResponse, e
 		return nil, errors.New("invalid service name")
 	}
 
+	return s.completeOauthLogin(ctx, serviceName, profile, true, models.ThirdPartyIdentity{
+		ThirdPartyAccessToken:  token.AccessToken,
+		ThirdPartyRefreshToken: token.RefreshToken,
+	}, sessionInfo)
+}
+
+// OIDCLogin authenticates using a configured OpenID Connect provider, from
+// either an ID token or an authorization code. If nonce is not empty, it must
+// match the ID token's nonce.
+func (s *service) OIDCLogin(ctx context.Context, providerName, idToken, code, nonce string, sessionInfo SessionInfo) (*OauthResponse, error) {
+	client, ok := s.oidcProviders[providerName]
+	if !ok {
+		return nil, NewErrOauthProviderNotFound()
+	}
+
+	if len(idToken) < 1 {
+		exchanged, err := client.ExchangeCode(ctx, code)
+		if err != nil {
+			s.logger.Warn().Err(err).Str("provider", providerName).Msg("Error exchanging oidc authorization code")
+			return nil, NewErrInvalidToken()
+		}
+
+		idToken = exchanged
+	}
+
+	profile, err := client.VerifyIDToken(ctx, idToken, nonce)
+	if err != nil {
+		s.logger.Warn().Err(err).Str("provider", providerName).Msg("Invalid oidc id token")
+		return nil, NewErrInvalidToken()
+	}
+
+	return s.completeOauthLogin(ctx, providerName, profile, profile.EmailVerified, models.ThirdPartyIdentity{
+		ThirdPartyID: profile.Subject,
+	}, sessionInfo)
+}
+
+// completeOauthLogin logs in the user with a profile from a third-party
+// service, creating the user and their identity for the service if they
+// don't exist yet. The fields of identity are used for a new identity.
+// Identities with a third-party ID are found by that ID first, and logins are
+// only linked to an existing account by email if the service verified it.
+func (s *service) completeOauthLogin(ctx context.Context, serviceName string, profile oauth.Profile, emailVerified bool, identity models.ThirdPartyIdentity, sessionInfo SessionInfo) (*OauthResponse, error) {
 	if !validateEmail(profile.GetEmail()) {
 		return nil, errors.New("could not get user email")
 	}
 
-	user, created, err := s.createOauthUserIfNotExists(profile)
-	if err != nil {
-		return nil, err
+	var user *models.User
+	created := false
+	if len(identity.ThirdPartyID) > 0 {
+		if linked, err := s.thirdPartyIdentityRepository.FindByThirdPartyID(serviceName, identity.ThirdPartyID); err == nil {
+			user, err = s.userRepository.FindByID(linked.UserID)
+			if err != nil {
+				return nil, NewErrUserNotFound()
+			}
+		}
 	}
 
-	identity, err := s.thirdPartyIdentityRepository.FindUserIdentityByServiceName(user.ID, serviceName)
-	if err != nil {
-		identity = &models.ThirdPartyIdentity{
-			UserID:                 user.ID,
-			ThirdPartyServiceName:  serviceName,
-			ThirdPartyAccessToken:  token.AccessToken,
-			ThirdPartyRefreshToken: token.RefreshToken,
+	if user == nil {
+		var err error
+		user, created, err = s.createOauthUserIfNotExists(profile, emailVerified)
+		if err != nil {
+			return nil, err
 		}
+	}
 
+	existing, err := s.thirdPartyIdentityRepository.FindUserIdentityByServiceName(user.ID, serviceName)
+	if err == nil {
+		if len(existing.ThirdPartyID) > 0 && len(identity.ThirdPartyID) > 0 && existing.ThirdPartyID != identity.ThirdPartyID {
+			// A different account at the same service claims the user's email.
+			return nil, NewErrInvalidToken()
+		}
+	} else {
+		identity.UserID = user.ID
+		identity.ThirdPartyServiceName = serviceName
 		identity.ID = s.snowflakeService.GenerateID()
 
-		err = s.thirdPartyIdentityRepository.Create(*identity)
+		err = s.thirdPartyIdentityRepository.Create(identity)
 	}
 	if err != nil {
 		return nil, err
 	}
 
-	// Successful login, generate and return a token pair.
-	userToken, err := s.generateTokenPair(user.ID)
+	challenge, err := s.twoFactorChallenge(ctx, user.ID, LoginMethodOauthPrefix+serviceName)
+	if err != nil {
+		return nil, err
+	}
+	if challenge != nil {
+		return &OauthResponse{
+			UserCreated:        created,
+			TwoFactorChallenge: challenge,
+		}, nil
+	}
+
+	// Successful login, create a session and return its token pair.
+	userToken, err := s.createSession(ctx, user.ID, LoginMethodOauthPrefix+serviceName, sessionInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -323,17 +506,39 @@ func (s *service) OauthLogin(serviceName, accessToken string) (*OauthResponse, e
 // createOauthUserIfNotExists takes an oauth profile and checks to see if a user already exists.
 // If one exists, it will return it with the bool false,
 // and if not, it will return a newly created user with the bool true.
-func (s *service) createOauthUserIfNotExists(profile oauth.Profile) (*models.User, bool, error) {
+// Existing users are only returned if the provider verified the email.
+func (s *service) createOauthUserIfNotExists(profile oauth.Profile, emailVerified bool) (*models.User, bool, error) {
 	user, err := s.userRepository.FindByEmail(profile.GetEmail())
 	if err == nil {
+		if !emailVerified {
+			// Anyone could claim the email at the provider, so the login
+			// can't be trusted to belong to the owner of the account.
+			return nil, false, NewErrOauthEmailNotVerified()
+		}
+
+		if !user.EmailVerified {
+			// The provider has confirmed that the user owns the email.
+			user.EmailVerified = true
+			if err := s.userRepository.Update(models.User{
+				Model: models.Model{
+					ID: user.ID,
+				},
+				EmailVerified: true,
+			}); err != nil {
+				s.logger.Error().Err(err).Msg("error verifying email of oauth user")
+			}
+		}
+
 		return user, false, nil
 	}
 
-	// Create the new user around the oauth values.
+	// Create the new user around the oauth values. Emails from oauth
+	// providers have usually already been verified by the provider.
 	newUser := models.User{
-		Email:     strings.ToLower(profile.GetEmail()),
-		FirstName: profile.GetFirstName(),
-		LastName:  profile.GetLastName(),
+		Email:         strings.ToLower(profile.GetEmail()),
+		EmailVerified: emailVerified,
+		FirstName:     profile.GetFirstName(),
+		LastName:      profile.GetLastName(),
 	}
 
 	// Generate an ID for the new user.
@@ -358,22 +563,6 @@ func (s *service) createOauthUserIfNotExists(profile oauth.Profile) (*models.Use
 	return &newUser, true, nil
 }
 
-// RefreshToken generates a new token pair from a refresh token.
-func (s *service) RefreshToken(ctx context.Context, refreshToken string) (*TokenPair, error) {
-	claims, err := s.claimsFromToken(refreshToken)
-	if err != nil {
-		// Error validating/parsing token.
-		return nil, err
-	}
-
-	if claims.Type != RefreshTokenType {
-		return nil, errors.New("not a refresh token")
-	}
-
-	// No errors, generate a token pair.
-	return s.generateTokenPair(claims.UserID)
-}
-
 // UpdateLastOnline updates a user's last online time.
 func (s *service) UpdateLastOnline(ctx context.Context, userID int64) error {
 	user, err := s.userRepository.FindByID(userID)
@@ -0,0 +1,227 @@
+package authentication
+
+import (
+	"context"
+	"time"
+
+	"github.com/joinimpact/api/internal/models"
+)
+
+// refreshTokenGracePeriod is how long a refresh token which has just been
+// rotated out is still accepted, so that concurrent requests from the same
+// client racing to refresh are not treated as token reuse.
+const refreshTokenGracePeriod = 30 * time.Second
+
+const (
+	// LoginMethodPassword is the login method of sessions created with a password.
+	LoginMethodPassword = "password"
+	// LoginMethodRegister is the login method of sessions created on registration.
+	LoginMethodRegister = "register"
+	// LoginMethodMagicLink is the login method of sessions created from a magic link.
+	LoginMethodMagicLink = "magic_link"
+	// LoginMethodOauthPrefix prefixes the name of the third-party service in the
+	// login method of sessions created through Oauth, such as "oauth:google".
+	LoginMethodOauthPrefix = "oauth:"
+)
+
+// SessionInfo contains information about the client creating a session.
+type SessionInfo struct {
+	DeviceName string
+	UserAgent  string
+	IPAddress  string
+}
+
+// SessionView represents a session as shown to its owner.
+type SessionView struct {
+	ID          int64     `json:"id"`
+	LoginMethod string    `json:"loginMethod"`
+	DeviceName  string    `json:"deviceName"`
+	UserAgent   string    `json:"userAgent"`
+	IPAddress   string    `json:"ipAddress"`
+	CreatedAt   time.Time `json:"createdAt"`
+	LastUsedAt  time.Time `json:"lastUsedAt"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+	Current     bool      `json:"current"`
+}
+
+// createSession creates a new session for a user, recording the login method
+// used, and returns a TokenPair issued for it. Logging in cancels a scheduled
+// deletion of the user's account.
+func (s *service) createSession(ctx context.Context, userID int64, loginMethod string, info SessionInfo) (*TokenPair, error) {
+	if user, err := s.userRepository.FindByID(userID); err == nil && user.DeletionScheduledAt != nil {
+		if err := s.userRepository.SetDeletionScheduledAt(userID, nil); err != nil {
+			s.logger.Error().Err(err).Msg("Error cancelling user deletion")
+			return nil, NewErrServerError()
+		}
+		s.logger.Info().Int64("userId", userID).Msg("User deletion cancelled by login")
+	}
+
+	refreshTokenID, err := generateTokenID()
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error generating refresh token ID")
+		return nil, NewErrServerError()
+	}
+
+	now := time.Now().UTC()
+
+	session := models.Session{
+		Active:         true,
+		UserID:         userID,
+		RefreshTokenID: refreshTokenID,
+		LoginMethod:    loginMethod,
+		DeviceName:     info.DeviceName,
+		UserAgent:      info.UserAgent,
+		IPAddress:      info.IPAddress,
+		LastUsedAt:     now,
+		ExpiresAt:      now.Add(RefreshTokenLifespanDays * 24 * time.Hour),
+	}
+	session.ID = s.snowflakeService.GenerateID()
+
+	if err := s.sessionRepository.Create(ctx, session); err != nil {
+		s.logger.Error().Err(err).Msg("Error creating session")
+		return nil, NewErrServerError()
+	}
+
+	return s.generateTokenPair(userID, session.ID, refreshTokenID)
+}
+
+// activeSession finds the session a token was issued for and makes sure that
+// it is still active.
+func (s *service) activeSession(ctx context.Context, claims *jwtClaims) (*models.Session, error) {
+	if claims.SessionID == 0 {
+		// Tokens issued before sessions were persisted can't be revoked, so
+		// they are no longer accepted.
+		return nil, NewErrInvalidToken()
+	}
+
+	session, err := s.sessionRepository.FindByID(ctx, claims.SessionID)
+	if err != nil {
+		return nil, NewErrInvalidToken()
+	}
+
+	if !session.Active || session.UserID != claims.UserID || session.ExpiresAt.Before(time.Now().UTC()) {
+		return nil, NewErrInvalidToken()
+	}
+
+	return session, nil
+}
+
+// RefreshToken generates a new token pair from a refresh token. Every refresh
+// token can only be used once; presenting one which has already been rotated
+// out revokes the whole session.
+func (s *service) RefreshToken(ctx context.Context, refreshToken string) (*TokenPair, error) {
+	claims, err := s.claimsFromToken(refreshToken)
+	if err != nil {
+		// Error validating/parsing token.
+		return nil, NewErrInvalidToken()
+	}
+
+	if claims.Type != RefreshTokenType {
+		return nil, NewErrInvalidToken()
+	}
+
+	session, err := s.activeSession(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.Id != session.RefreshTokenID {
+		if claims.Id == session.PreviousRefreshTokenID && session.RotatedAt != nil && time.Now().UTC().Sub(*session.RotatedAt) < refreshTokenGracePeriod {
+			// The token was rotated moments ago by a concurrent request, issue
+			// a pair for the current refresh token instead.
+			return s.generateTokenPair(session.UserID, session.ID, session.RefreshTokenID)
+		}
+
+		// The refresh token has been used before, which means it may have
+		// been stolen. Revoke the session so that neither copy can be used.
+		s.logger.Warn().Int64("sessionId", session.ID).Int64("userId", session.UserID).Msg("Refresh token reused, revoking session")
+		if err := s.sessionRepository.RevokeByID(ctx, session.ID); err != nil {
+			s.logger.Error().Err(err).Msg("Error revoking session")
+		}
+
+		return nil, NewErrRefreshTokenReused()
+	}
+
+	newRefreshTokenID, err := generateTokenID()
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error generating refresh token ID")
+		return nil, NewErrServerError()
+	}
+
+	expiresAt := time.Now().UTC().Add(RefreshTokenLifespanDays * 24 * time.Hour)
+	if err := s.sessionRepository.RotateRefreshToken(ctx, session.ID, claims.Id, newRefreshTokenID, expiresAt); err != nil {
+		updated, findErr := s.sessionRepository.FindByID(ctx, session.ID)
+		if findErr != nil || updated.RefreshTokenID == claims.Id {
+			s.logger.Error().Err(err).Msg("Error rotating refresh token")
+			return nil, NewErrServerError()
+		}
+
+		// Lost the race against a concurrent refresh, retry against the
+		// updated session so the grace period applies.
+		return s.RefreshToken(ctx, refreshToken)
+	}
+
+	return s.generateTokenPair(session.UserID, session.ID, newRefreshTokenID)
+}
+
+// Logout revokes the session that an auth or refresh token was issued for.
+func (s *service) Logout(ctx context.Context, token string) error {
+	claims, err := s.claimsFromToken(token)
+	if err != nil {
+		return NewErrInvalidToken()
+	}
+
+	session, err := s.activeSession(ctx, claims)
+	if err != nil {
+		return err
+	}
+
+	if err := s.sessionRepository.RevokeByID(ctx, session.ID); err != nil {
+		s.logger.Error().Err(err).Msg("Error revoking session")
+		return NewErrServerError()
+	}
+
+	return nil
+}
+
+// GetUserSessions gets all active sessions of a user. The session with the ID
+// currentSessionID is marked as the current session.
+func (s *service) GetUserSessions(ctx context.Context, userID, currentSessionID int64) ([]SessionView, error) {
+	sessions, err := s.sessionRepository.FindByUserID(ctx, userID)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error getting user sessions")
+		return nil, NewErrServerError()
+	}
+
+	views := []SessionView{}
+	for _, session := range sessions {
+		views = append(views, SessionView{
+			ID:          session.ID,
+			LoginMethod: session.LoginMethod,
+			DeviceName:  session.DeviceName,
+			UserAgent:   session.UserAgent,
+			IPAddress:   session.IPAddress,
+			CreatedAt:   session.CreatedAt,
+			LastUsedAt:  session.LastUsedAt,
+			ExpiresAt:   session.ExpiresAt,
+			Current:     session.ID == currentSessionID,
+		})
+	}
+
+	return views, nil
+}
+
+// RevokeSession revokes a single session of a user by ID.
+func (s *service) RevokeSession(ctx context.Context, userID, sessionID int64) error {
+	session, err := s.sessionRepository.FindByID(ctx, sessionID)
+	if err != nil || session.UserID != userID || !session.Active {
+		return NewErrSessionNotFound()
+	}
+
+	if err := s.sessionRepository.RevokeByID(ctx, session.ID); err != nil {
+		s.logger.Error().Err(err).Msg("Error revoking session")
+		return NewErrServerError()
+	}
+
+	return nil
+}
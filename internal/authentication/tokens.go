@@ -1,11 +1,16 @@
 package authentication
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"time"
 
 	"github.com/dgrijalva/jwt-go"
+	"github.com/joinimpact/api/internal/authentication/keyring"
+	"github.com/joinimpact/api/internal/config"
 )
 
 // AuthTokenLifespanDays represents how long (in days) an auth token lasts before expiring.
@@ -14,6 +19,9 @@ const AuthTokenLifespanDays = 3
 // RefreshTokenLifespanDays represents how long (in days) a refresh token lasts before expiring.
 const RefreshTokenLifespanDays = 21
 
+// challengeTokenLifespan represents how long a two-factor challenge token lasts before expiring.
+const challengeTokenLifespan = 5 * time.Minute
+
 // TokenPair represents a pair of an auth token and refresh token.
 type TokenPair struct {
 	AuthToken     string `json:"authToken"`
@@ -28,53 +36,88 @@ const (
 	AuthTokenType = iota
 	// RefreshTokenType represents the type of a refresh token.
 	RefreshTokenType = iota
+	// ChallengeTokenType represents the type of a token proving that a user
+	// has passed the first step of a two-step login.
+	ChallengeTokenType = iota
 )
 
 // jwtClaims contains the claims that are used in generated JWT tokens.
 type jwtClaims struct {
-	UserID int64 `json:"userId"`
-	Type   int   `json:"type"`
+	UserID    int64 `json:"userId"`
+	SessionID int64 `json:"sid,omitempty"`
+	Type      int   `json:"type"`
+	// LoginMethod is the first factor a challenge token was issued after.
+	LoginMethod string `json:"loginMethod,omitempty"`
 	jwt.StandardClaims
 }
 
-// generateTokenPair generates a new TokenPair which includes an auth and refresh token using the user's ID.
-func (s *service) generateTokenPair(userID int64) (*TokenPair, error) {
+// generateTokenID generates a random ID for identifying a single refresh token.
+func generateTokenID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// legacyKeyID is the ID of the key created from config.JWTSecret when no keys
+// file is configured.
+const legacyKeyID = "default"
+
+// NewKeyring creates the keyring used to sign and verify JWTs. Keys are
+// loaded from config.JWTKeysFile if set, otherwise config.JWTSecret is used as
+// a single HS512 key.
+func NewKeyring(config *config.Config) (*keyring.Keyring, error) {
+	if len(config.JWTKeysFile) > 0 {
+		return keyring.Load(config.JWTKeysFile, config.JWTSigningKeyID)
+	}
+
 	// Ensure that there is a jwt secret present.
-	if len(s.config.JWTSecret) <= 32 {
-		s.logger.Error().Msg("no jwt secret present")
+	if len(config.JWTSecret) <= 32 {
 		return nil, errors.New("no jwt secret present")
 	}
 
-	now := time.Now()
+	return keyring.New([]keyring.KeyConfig{
+		{
+			ID:        legacyKeyID,
+			Algorithm: keyring.AlgorithmHS512,
+			Secret:    config.JWTSecret,
+		},
+	}, legacyKeyID)
+}
 
-	// Append the date to the secret for rolling secrets.
-	secret := fmt.Sprintf("%s::%d", s.config.JWTSecret, now.UTC().Unix())
+// generateTokenPair generates a new TokenPair which includes an auth and refresh token using the user's ID,
+// the session's ID and the ID of the session's current refresh token.
+func (s *service) generateTokenPair(userID, sessionID int64, refreshTokenID string) (*TokenPair, error) {
+	now := time.Now()
 
-	authTokenObject := jwt.NewWithClaims(jwt.SigningMethodHS512, jwtClaims{
-		UserID: userID,
-		Type:   AuthTokenType,
+	authToken, err := s.keyring.Sign(jwtClaims{
+		UserID:    userID,
+		SessionID: sessionID,
+		Type:      AuthTokenType,
 		StandardClaims: jwt.StandardClaims{
 			IssuedAt:  now.UTC().Unix(),
 			ExpiresAt: now.Add(AuthTokenLifespanDays * 24 * time.Hour).UTC().Unix(),
-			Issuer:    "impact-prod-01",
+			Issuer:    s.config.JWTIssuer,
 		},
 	})
-	authToken, err := authTokenObject.SignedString([]byte(secret))
 	if err != nil {
 		s.logger.Error().Err(err).Msg("error generating auth jwt")
 		return nil, errors.New("error generating auth jwt")
 	}
 
-	refreshTokenObject := jwt.NewWithClaims(jwt.SigningMethodHS512, jwtClaims{
-		UserID: userID,
-		Type:   RefreshTokenType,
+	refreshToken, err := s.keyring.Sign(jwtClaims{
+		UserID:    userID,
+		SessionID: sessionID,
+		Type:      RefreshTokenType,
 		StandardClaims: jwt.StandardClaims{
+			Id:        refreshTokenID,
 			IssuedAt:  now.UTC().Unix(),
 			ExpiresAt: now.Add(RefreshTokenLifespanDays * 24 * time.Hour).UTC().Unix(),
-			Issuer:    "impact-prod-01",
+			Issuer:    s.config.JWTIssuer,
 		},
 	})
-	refreshToken, err := refreshTokenObject.SignedString([]byte(secret))
 	if err != nil {
 		s.logger.Error().Err(err).Msg("error generating refresh jwt")
 		return nil, errors.New("error generating refresh jwt")
@@ -89,16 +132,51 @@ func (s *service) generateTokenPair(userID int64) (*TokenPair, error) {
 	}, nil
 }
 
+// generateChallengeToken generates a short-lived challenge token for a user
+// who still has to provide a second factor after logging in with loginMethod,
+// and returns it with its expiry.
+func (s *service) generateChallengeToken(userID int64, loginMethod string) (string, int64, error) {
+	now := time.Now()
+	expiry := now.Add(challengeTokenLifespan).UTC().Unix()
+
+	token, err := s.keyring.Sign(jwtClaims{
+		UserID:      userID,
+		Type:        ChallengeTokenType,
+		LoginMethod: loginMethod,
+		StandardClaims: jwt.StandardClaims{
+			IssuedAt:  now.UTC().Unix(),
+			ExpiresAt: expiry,
+			Issuer:    s.config.JWTIssuer,
+		},
+	})
+	if err != nil {
+		s.logger.Error().Err(err).Msg("error generating challenge jwt")
+		return "", 0, errors.New("error generating challenge jwt")
+	}
+
+	return token, expiry, nil
+}
+
 // claimsFromToken validates and parses a token. If the token is valid, it
 // will return a jwtClaims.
 func (s *service) claimsFromToken(token string) (*jwtClaims, error) {
 	jwt, err := jwt.ParseWithClaims(token, &jwtClaims{}, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Header["kid"]; ok {
+			return s.keyring.Keyfunc(t)
+		}
+
+		// Tokens issued before key IDs were introduced were signed with the
+		// date appended to config.JWTSecret, keep accepting them until they
+		// expire.
 		claims, ok := t.Claims.(*jwtClaims)
 		if !ok {
 			return nil, errors.New("error processing jwt claims")
 		}
 
-		// Append the date to the secret for rolling secrets.
+		if len(s.config.JWTSecret) <= 32 || t.Method != jwt.SigningMethodHS512 {
+			return nil, errors.New("token has no key id")
+		}
+
 		secret := fmt.Sprintf("%s::%d", s.config.JWTSecret, claims.IssuedAt)
 
 		return []byte(secret), nil
@@ -109,18 +187,46 @@ func (s *service) claimsFromToken(token string) (*jwtClaims, error) {
 	}
 
 	if claims, ok := jwt.Claims.(*jwtClaims); ok && jwt.Valid {
+		if claims.Issuer != s.config.JWTIssuer {
+			return nil, errors.New("invalid token issuer")
+		}
+
 		return claims, nil
 	}
 
 	return nil, err
 }
 
-// GetUserIDFromToken gets a user's ID from a JWT token.
+// JWKS returns the public keys which can be used to verify tokens.
+func (s *service) JWKS() *keyring.JWKS {
+	return s.keyring.JWKS()
+}
+
+// GetUserIDFromToken gets a user's ID from a JWT token. Tokens belonging to
+// a revoked or expired session are rejected.
 func (s *service) GetUserIDFromToken(token string) (int64, error) {
 	claims, err := s.claimsFromToken(token)
 	if err != nil {
 		return 0, err
 	}
 
+	if claims.Type != AuthTokenType {
+		return 0, NewErrInvalidToken()
+	}
+
+	if _, err := s.activeSession(context.Background(), claims); err != nil {
+		return 0, err
+	}
+
 	return claims.UserID, nil
 }
+
+// GetSessionIDFromToken gets the ID of the session a JWT token was issued for.
+func (s *service) GetSessionIDFromToken(token string) (int64, error) {
+	claims, err := s.claimsFromToken(token)
+	if err != nil {
+		return 0, err
+	}
+
+	return claims.SessionID, nil
+}
@@ -0,0 +1,341 @@
+package authentication
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/joinimpact/api/internal/models"
+	"github.com/joinimpact/api/pkg/totp"
+)
+
+// twoFactorIssuer is the issuer shown in authenticator apps.
+const twoFactorIssuer = "Impact"
+
+// twoFactorSkew is the number of time steps of clock drift allowed between
+// the server and a user's authenticator.
+const twoFactorSkew = 1
+
+// twoFactorMaxFailedAttempts is the number of consecutive failed codes after
+// which a user's codes are temporarily not accepted.
+const twoFactorMaxFailedAttempts = 5
+
+// twoFactorLockout is how long codes are not accepted after too many failures.
+const twoFactorLockout = 15 * time.Minute
+
+// recoveryCodeCount is the number of recovery codes generated at once.
+const recoveryCodeCount = 10
+
+// recoveryCodeCharset is the charset of recovery codes, without characters
+// which are easily confused with each other.
+const recoveryCodeCharset = "abcdefghjkmnpqrstuvwxyz23456789"
+
+// TwoFactorChallenge is returned instead of a TokenPair when a user has to
+// provide a second factor to finish logging in.
+type TwoFactorChallenge struct {
+	TwoFactorRequired bool   `json:"twoFactorRequired"`
+	ChallengeToken    string `json:"challengeToken"`
+	ChallengeExpiry   int64  `json:"challengeExpiry"`
+}
+
+// TwoFactorEnrollment contains the information needed to add a new TOTP
+// secret to an authenticator app.
+type TwoFactorEnrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+// TwoFactorStatus describes a user's two-factor authentication setup.
+type TwoFactorStatus struct {
+	Enabled                bool `json:"enabled"`
+	RecoveryCodesRemaining int  `json:"recoveryCodesRemaining"`
+}
+
+// hashRecoveryCode hashes a recovery code for storage.
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.Replace(strings.TrimSpace(code), "-", "", -1))
+
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// generateRecoveryCode generates a random recovery code in the format xxxxx-xxxxx.
+func generateRecoveryCode() (string, error) {
+	b := make([]byte, 10)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	for i := range b {
+		b[i] = recoveryCodeCharset[int(b[i])%len(recoveryCodeCharset)]
+	}
+
+	return string(b[:5]) + "-" + string(b[5:]), nil
+}
+
+// twoFactorChallenge checks if a user has two-factor authentication enabled,
+// and if so, returns a challenge that must be completed before logging in.
+// The login method is kept in the challenge for the session created after it.
+func (s *service) twoFactorChallenge(ctx context.Context, userID int64, loginMethod string) (*TwoFactorChallenge, error) {
+	credential, err := s.twoFactorCredentialRepository.FindByUserID(ctx, userID)
+	if err != nil || !credential.Confirmed {
+		return nil, nil
+	}
+
+	token, expiry, err := s.generateChallengeToken(userID, loginMethod)
+	if err != nil {
+		return nil, NewErrServerError()
+	}
+
+	return &TwoFactorChallenge{
+		TwoFactorRequired: true,
+		ChallengeToken:    token,
+		ChallengeExpiry:   expiry,
+	}, nil
+}
+
+// VerifyTwoFactorChallenge completes a two-step login using a challenge token
+// and a TOTP or recovery code, and returns a TokenPair on success.
+func (s *service) VerifyTwoFactorChallenge(ctx context.Context, challengeToken, code string, sessionInfo SessionInfo) (*TokenPair, error) {
+	claims, err := s.claimsFromToken(challengeToken)
+	if err != nil || claims.Type != ChallengeTokenType {
+		return nil, NewErrInvalidToken()
+	}
+
+	if err := s.checkTwoFactorCode(ctx, claims.UserID, code, true); err != nil {
+		return nil, err
+	}
+
+	return s.createSession(ctx, claims.UserID, claims.LoginMethod, sessionInfo)
+}
+
+// checkTwoFactorCode checks a TOTP code, or a recovery code if allowed, for
+// a user with confirmed two-factor authentication.
+func (s *service) checkTwoFactorCode(ctx context.Context, userID int64, code string, allowRecoveryCode bool) error {
+	credential, err := s.twoFactorCredentialRepository.FindByUserID(ctx, userID)
+	if err != nil || !credential.Confirmed {
+		return NewErrTwoFactorNotEnabled()
+	}
+
+	now := time.Now().UTC()
+	if credential.LockedUntil != nil && credential.LockedUntil.After(now) {
+		return NewErrTwoFactorLocked()
+	}
+
+	counter, ok := totp.Validate(credential.Secret, code, now, twoFactorSkew)
+	if ok && counter > credential.LastUsedCounter {
+		credential.LastUsedCounter = counter
+		credential.FailedAttempts = 0
+		credential.LockedUntil = nil
+		if err := s.twoFactorCredentialRepository.Save(ctx, *credential); err != nil {
+			s.logger.Error().Err(err).Msg("Error saving two-factor credential")
+			return NewErrServerError()
+		}
+
+		return nil
+	}
+
+	if allowRecoveryCode && s.useRecoveryCode(ctx, userID, code) {
+		return nil
+	}
+
+	credential.FailedAttempts++
+	if credential.FailedAttempts >= twoFactorMaxFailedAttempts {
+		lockedUntil := now.Add(twoFactorLockout)
+		credential.LockedUntil = &lockedUntil
+		credential.FailedAttempts = 0
+	}
+	if err := s.twoFactorCredentialRepository.Save(ctx, *credential); err != nil {
+		s.logger.Error().Err(err).Msg("Error saving two-factor credential")
+	}
+
+	return NewErrInvalidTwoFactorCode()
+}
+
+// useRecoveryCode marks a matching unused recovery code of a user as used,
+// returning false if no such code exists.
+func (s *service) useRecoveryCode(ctx context.Context, userID int64, code string) bool {
+	codes, err := s.twoFactorRecoveryCodeRepository.FindUnusedByUserID(ctx, userID)
+	if err != nil {
+		return false
+	}
+
+	hash := hashRecoveryCode(code)
+	for _, recoveryCode := range codes {
+		if subtle.ConstantTimeCompare([]byte(recoveryCode.CodeHash), []byte(hash)) != 1 {
+			continue
+		}
+
+		now := time.Now().UTC()
+		recoveryCode.UsedAt = &now
+		if err := s.twoFactorRecoveryCodeRepository.Update(ctx, recoveryCode); err != nil {
+			s.logger.Error().Err(err).Msg("Error using recovery code")
+			return false
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// generateRecoveryCodes replaces all of a user's recovery codes with new
+// ones, and returns the new codes. The codes are only stored hashed, so this
+// is the only time they can be shown.
+func (s *service) generateRecoveryCodes(ctx context.Context, userID int64) ([]string, error) {
+	if err := s.twoFactorRecoveryCodeRepository.DeleteByUserID(ctx, userID); err != nil {
+		s.logger.Error().Err(err).Msg("Error deleting recovery codes")
+		return nil, NewErrServerError()
+	}
+
+	codes := []string{}
+	for i := 0; i < recoveryCodeCount; i++ {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			s.logger.Error().Err(err).Msg("Error generating recovery code")
+			return nil, NewErrServerError()
+		}
+
+		recoveryCode := models.TwoFactorRecoveryCode{
+			UserID:   userID,
+			CodeHash: hashRecoveryCode(code),
+		}
+		recoveryCode.ID = s.snowflakeService.GenerateID()
+
+		if err := s.twoFactorRecoveryCodeRepository.Create(ctx, recoveryCode); err != nil {
+			s.logger.Error().Err(err).Msg("Error creating recovery code")
+			return nil, NewErrServerError()
+		}
+
+		codes = append(codes, code)
+	}
+
+	return codes, nil
+}
+
+// GetTwoFactorStatus gets the status of a user's two-factor authentication.
+func (s *service) GetTwoFactorStatus(ctx context.Context, userID int64) (*TwoFactorStatus, error) {
+	status := &TwoFactorStatus{}
+
+	credential, err := s.twoFactorCredentialRepository.FindByUserID(ctx, userID)
+	if err != nil || !credential.Confirmed {
+		return status, nil
+	}
+
+	status.Enabled = true
+
+	codes, err := s.twoFactorRecoveryCodeRepository.FindUnusedByUserID(ctx, userID)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error getting recovery codes")
+		return nil, NewErrServerError()
+	}
+
+	status.RecoveryCodesRemaining = len(codes)
+
+	return status, nil
+}
+
+// BeginTwoFactorEnrollment generates a new TOTP secret for a user which has
+// to be confirmed with a first code before it is used for logins.
+func (s *service) BeginTwoFactorEnrollment(ctx context.Context, userID int64) (*TwoFactorEnrollment, error) {
+	user, err := s.userRepository.FindByID(userID)
+	if err != nil {
+		return nil, NewErrUserNotFound()
+	}
+
+	credential, err := s.twoFactorCredentialRepository.FindByUserID(ctx, userID)
+	if err == nil && credential.Confirmed {
+		return nil, NewErrTwoFactorAlreadyEnabled()
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error generating TOTP secret")
+		return nil, NewErrServerError()
+	}
+
+	if credential != nil && credential.ID != 0 {
+		// Replace the secret of the previous, unconfirmed enrollment.
+		credential.Secret = secret
+		err = s.twoFactorCredentialRepository.Save(ctx, *credential)
+	} else {
+		newCredential := models.TwoFactorCredential{
+			UserID: userID,
+			Secret: secret,
+		}
+		newCredential.ID = s.snowflakeService.GenerateID()
+		err = s.twoFactorCredentialRepository.Create(ctx, newCredential)
+	}
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error saving two-factor credential")
+		return nil, NewErrServerError()
+	}
+
+	return &TwoFactorEnrollment{
+		Secret: secret,
+		URI:    totp.URI(secret, twoFactorIssuer, user.Email),
+	}, nil
+}
+
+// ConfirmTwoFactorEnrollment enables two-factor authentication for a user
+// with the first code from their authenticator, and returns a set of
+// recovery codes.
+func (s *service) ConfirmTwoFactorEnrollment(ctx context.Context, userID int64, code string) ([]string, error) {
+	credential, err := s.twoFactorCredentialRepository.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, NewErrTwoFactorNotEnabled()
+	}
+
+	if credential.Confirmed {
+		return nil, NewErrTwoFactorAlreadyEnabled()
+	}
+
+	counter, ok := totp.Validate(credential.Secret, code, time.Now().UTC(), twoFactorSkew)
+	if !ok {
+		return nil, NewErrInvalidTwoFactorCode()
+	}
+
+	now := time.Now().UTC()
+	credential.Confirmed = true
+	credential.ConfirmedAt = &now
+	credential.LastUsedCounter = counter
+	if err := s.twoFactorCredentialRepository.Save(ctx, *credential); err != nil {
+		s.logger.Error().Err(err).Msg("Error confirming two-factor credential")
+		return nil, NewErrServerError()
+	}
+
+	return s.generateRecoveryCodes(ctx, userID)
+}
+
+// RegenerateRecoveryCodes replaces a user's recovery codes after checking a
+// TOTP code, and returns the new codes.
+func (s *service) RegenerateRecoveryCodes(ctx context.Context, userID int64, code string) ([]string, error) {
+	if err := s.checkTwoFactorCode(ctx, userID, code, false); err != nil {
+		return nil, err
+	}
+
+	return s.generateRecoveryCodes(ctx, userID)
+}
+
+// DisableTwoFactor disables two-factor authentication for a user after
+// checking a TOTP or recovery code.
+func (s *service) DisableTwoFactor(ctx context.Context, userID int64, code string) error {
+	if err := s.checkTwoFactorCode(ctx, userID, code, true); err != nil {
+		return err
+	}
+
+	if err := s.twoFactorCredentialRepository.DeleteByUserID(ctx, userID); err != nil {
+		s.logger.Error().Err(err).Msg("Error deleting two-factor credential")
+		return NewErrServerError()
+	}
+
+	if err := s.twoFactorRecoveryCodeRepository.DeleteByUserID(ctx, userID); err != nil {
+		s.logger.Error().Err(err).Msg("Error deleting recovery codes")
+	}
+
+	return nil
+}
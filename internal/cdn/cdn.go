@@ -3,10 +3,12 @@ package cdn
 import (
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"github.com/joinimpact/api/internal/config"
 )
@@ -56,3 +58,93 @@ func (c *Client) UploadImage(imageName string, reader io.Reader) (string, error)
 
 	return fmt.Sprintf("https://cdn.joinimpact.org/%s", imageName), nil
 }
+
+// UploadPrivateFile uploads a file to the Spaces CDN which can't be accessed publicly. It can be
+// downloaded through a link created with PresignedURL.
+func (c *Client) UploadPrivateFile(name, contentType string, reader io.Reader) error {
+	uploader := s3manager.NewUploader(c.session)
+
+	_, err := uploader.Upload(&s3manager.UploadInput{
+		Bucket:      aws.String(c.config.CDNBucket),
+		Key:         aws.String(name),
+		Body:        reader,
+		ContentType: aws.String(contentType),
+		ACL:         aws.String("private"),
+	})
+
+	return err
+}
+
+// PresignedURL creates a link to download a private file which expires after a duration.
+func (c *Client) PresignedURL(name string, expiry time.Duration) (string, error) {
+	req, _ := s3.New(c.session).GetObjectRequest(&s3.GetObjectInput{
+		Bucket: aws.String(c.config.CDNBucket),
+		Key:    aws.String(name),
+	})
+
+	return req.Presign(expiry)
+}
+
+// DeleteFile deletes a file from the Spaces CDN.
+func (c *Client) DeleteFile(name string) error {
+	_, err := s3.New(c.session).DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(c.config.CDNBucket),
+		Key:    aws.String(name),
+	})
+
+	return err
+}
+
+// DeleteFiles deletes all files whose names start with a prefix from the Spaces CDN.
+func (c *Client) DeleteFiles(prefix string) error {
+	client := s3.New(c.session)
+
+	var deleteErr error
+	err := client.ListObjectsV2Pages(&s3.ListObjectsV2Input{
+		Bucket: aws.String(c.config.CDNBucket),
+		Prefix: aws.String(prefix),
+	}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		if len(page.Contents) < 1 {
+			return true
+		}
+
+		objects := []*s3.ObjectIdentifier{}
+		for _, object := range page.Contents {
+			objects = append(objects, &s3.ObjectIdentifier{Key: object.Key})
+		}
+
+		_, deleteErr = client.DeleteObjects(&s3.DeleteObjectsInput{
+			Bucket: aws.String(c.config.CDNBucket),
+			Delete: &s3.Delete{
+				Objects: objects,
+				Quiet:   aws.Bool(true),
+			},
+		})
+
+		return deleteErr == nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return deleteErr
+}
+
+// ListFolders lists the names of the folders directly below a prefix, which ends with a slash, in
+// the Spaces CDN. Each name includes the prefix and ends with a slash.
+func (c *Client) ListFolders(prefix string) ([]string, error) {
+	folders := []string{}
+	err := s3.New(c.session).ListObjectsV2Pages(&s3.ListObjectsV2Input{
+		Bucket:    aws.String(c.config.CDNBucket),
+		Prefix:    aws.String(prefix),
+		Delimiter: aws.String("/"),
+	}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, commonPrefix := range page.CommonPrefixes {
+			folders = append(folders, aws.StringValue(commonPrefix.Prefix))
+		}
+
+		return true
+	})
+
+	return folders, err
+}
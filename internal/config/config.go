@@ -1,8 +1,10 @@
 package config
 
 import (
+	"net"
 	"os"
 	"strconv"
+	"strings"
 )
 
 // defaultJWTSecret is blank for security reasons so that an error will occur if no valid JWT secret is found.
@@ -15,6 +17,9 @@ const defaultPort = 3000
 type Config struct {
 	DevMode             bool   // enables/disables certain debugging features for development
 	JWTSecret           string // secret for signing JWTs for authorization
+	JWTKeysFile         string // path to a JSON file of named JWT keys, used instead of JWTSecret when set
+	JWTSigningKeyID     string // the ID of the key in JWTKeysFile used to sign new JWTs
+	JWTIssuer           string // the issuer of JWTs
 	Port                int    // the HTTP port to serve the application on
 	DatabaseHost        string // the host of the postgres database
 	DatabasePort        int    // the port of the postgres database
@@ -32,11 +37,26 @@ type Config struct {
 	FacebookAppID       string // the app id for Facebook Oauth
 	FacebookAppSecret   string // the app secret for Facebook Oauth
 	FacebookCallbackURL string // the callback URL for Facebook Oauth
+	OIDCProvidersFile   string // path to a JSON file of OpenID Connect providers
 	GoogleMapsAPIKey    string // the api key for accessing Google Places and Geocoding APIs
+	CalendarFeedURL     string // the base URL of calendar feeds, which feed tokens are appended to
+	CheckInSecret       string // secret for signing event check-in codes, JWTSecret if blank
 	ElasticHost         string
 	ElasticPort         string
 	MemcachedHost       string
 	MemcachedPort       string
+	// RequireEmailVerification prevents users who have not verified their
+	// email from messaging organizations or requesting opportunity membership.
+	RequireEmailVerification bool
+	// RequireVerifiedOrganizationForMinors prevents organizations which are
+	// not verified from inviting users under 18 to opportunities.
+	RequireVerifiedOrganizationForMinors bool
+	// StaffUserIDs are the IDs of the users who can access the platform
+	// staff API, such as for reviewing organization verification requests.
+	StaffUserIDs []int64
+	// TrustedProxies are the networks of the reverse proxies in front of the
+	// API, whose X-Forwarded-For and X-Real-IP headers are honoured.
+	TrustedProxies []*net.IPNet
 }
 
 // NewConfig generates a new config from environment variables and returns a Config struct.
@@ -44,6 +64,9 @@ func NewConfig() *Config {
 	return &Config{
 		DevMode:             envBool("IMPACT_DEV_MODE", true),
 		JWTSecret:           envString("IMPACT_JWT_SECRET", defaultJWTSecret),
+		JWTKeysFile:         envString("IMPACT_JWT_KEYS_FILE", ""),
+		JWTSigningKeyID:     envString("IMPACT_JWT_SIGNING_KEY_ID", ""),
+		JWTIssuer:           envString("IMPACT_JWT_ISSUER", "impact-prod-01"),
 		Port:                envInt("PORT", defaultPort),
 		DatabaseHost:        envString("IMPACT_DATABASE_HOST", "localhost"),
 		DatabasePort:        envInt("IMPACT_DATABASE_PORT", 5432),
@@ -61,11 +84,19 @@ func NewConfig() *Config {
 		FacebookAppID:       envString("IMPACT_FACEBOOK_APP_ID", ""),
 		FacebookAppSecret:   envString("IMPACT_FACEBOOK_APP_SECRET", ""),
 		FacebookCallbackURL: envString("IMPACT_FACEBOOK_CALLBACK_URL", "https://dev.joinimpact.org/auth/login"),
+		OIDCProvidersFile:   envString("IMPACT_OIDC_PROVIDERS_FILE", ""),
 		GoogleMapsAPIKey:    envString("IMPACT_GOOGLE_MAPS_API_KEY", ""),
+		CalendarFeedURL:     envString("IMPACT_CALENDAR_FEED_URL", "https://api.joinimpact.org/calendars"),
+		CheckInSecret:       envString("IMPACT_CHECK_IN_SECRET", ""),
 		ElasticHost:         envString("IMPACT_ELASTIC_HOST", "localhost"),
 		ElasticPort:         envString("IMPACT_ELASTIC_PORT", "9200"),
 		MemcachedHost:       envString("IMPACT_MEMCACHED_HOST", "localhost"),
 		MemcachedPort:       envString("IMPACT_MEMCACHED_PORT", "11211"),
+
+		RequireEmailVerification:             envBool("IMPACT_REQUIRE_EMAIL_VERIFICATION", false),
+		RequireVerifiedOrganizationForMinors: envBool("IMPACT_REQUIRE_VERIFIED_ORGANIZATION_FOR_MINORS", false),
+		StaffUserIDs:                         envInt64List("IMPACT_STAFF_USER_IDS"),
+		TrustedProxies:                       envNetworkList("IMPACT_TRUSTED_PROXIES"),
 	}
 }
 
@@ -108,3 +139,62 @@ func envBool(name string, fallback bool) bool {
 	// Fallback
 	return fallback
 }
+
+// envInt64List gets an environment variable by the name provided as a comma-separated list of int64s, skipping any invalid values.
+func envInt64List(name string) []int64 {
+	list := []int64{}
+	for _, value := range strings.Split(os.Getenv(name), ",") {
+		i, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			continue
+		}
+		list = append(list, i)
+	}
+	return list
+}
+
+// envNetworkList gets an environment variable by the name provided as a comma-separated list of IP addresses and CIDR networks, skipping any invalid values.
+func envNetworkList(name string) []*net.IPNet {
+	list := []*net.IPNet{}
+	for _, value := range strings.Split(os.Getenv(name), ",") {
+		value = strings.TrimSpace(value)
+		if !strings.Contains(value, "/") {
+			// A single address is a network of one.
+			if ip := net.ParseIP(value); ip != nil {
+				bits := 8 * net.IPv6len
+				if ip.To4() != nil {
+					ip, bits = ip.To4(), 8*net.IPv4len
+				}
+				list = append(list, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			}
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			continue
+		}
+		list = append(list, network)
+	}
+	return list
+}
+
+// IsStaff checks whether a user is a member of the platform staff.
+func (c *Config) IsStaff(userID int64) bool {
+	for _, id := range c.StaffUserIDs {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTrustedProxy checks whether an IP address belongs to one of the trusted proxies.
+func (c *Config) IsTrustedProxy(ip net.IP) bool {
+	for _, network := range c.TrustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -60,3 +60,23 @@ func (e *ErrUserNotInConversation) Error() string {
 func (e *ErrUserNotInConversation) Ref() string {
 	return "conversations.user_not_in_conversation"
 }
+
+// ErrEmailNotVerified is thrown when a user who has not verified their email
+// attempts an action which requires a verified email.
+type ErrEmailNotVerified struct {
+}
+
+// NewErrEmailNotVerified creates and returns a ErrEmailNotVerified.
+func NewErrEmailNotVerified() error {
+	return &ErrEmailNotVerified{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrEmailNotVerified) Error() string {
+	return "email not verified"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrEmailNotVerified) Ref() string {
+	return "conversations.email_not_verified"
+}
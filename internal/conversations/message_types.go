@@ -1,5 +1,7 @@
 package conversations
 
+import "time"
+
 // MessageStandard represents a standard message.
 type MessageStandard struct {
 	Text string `json:"text"`
@@ -7,8 +9,9 @@ type MessageStandard struct {
 
 // MessageVolunteerRequestProfile represents the message sent containing a user's profile when they request to join an opportunity.
 type MessageVolunteerRequestProfile struct {
-	Message string `json:"message"`
-	UserID  int64  `json:"userId"`
+	Message   string `json:"message"`
+	UserID    int64  `json:"userId"`
+	RequestID int64  `json:"requestId,omitempty"` // the ID of the membership request, used to find the answers to the application form
 }
 
 // MessageTypeVolunteerRequestAcceptance represents the message sent when a user is accepted to an opportunity.
@@ -32,3 +35,10 @@ type MessageTypeHoursAccepted struct {
 type MessageTypeHoursDeclined struct {
 	VolunteeringHourLogRequestID int64 `json:"requestId"`
 }
+
+// MessageTypeWaitlistSpotOffered represents the message sent when a spot in a full opportunity is offered to a waitlisted volunteer.
+type MessageTypeWaitlistSpotOffered struct {
+	UserID        int64     `json:"userId"`
+	OpportunityID int64     `json:"opportunityId"`
+	ExpiresAt     time.Time `json:"expiresAt"`
+}
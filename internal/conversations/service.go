@@ -15,11 +15,15 @@ import (
 	"github.com/joinimpact/api/internal/snowflakes"
 	"github.com/joinimpact/api/pkg/dbctx"
 	"github.com/joinimpact/api/pkg/location"
+	"github.com/joinimpact/api/pkg/scopes"
 	"github.com/rs/zerolog"
 )
 
 var stream = pubsub.Stream("impact.users")
 
+// applicationFileLinkLifespan is how long links to files attached to applications stay valid.
+const applicationFileLinkLifespan = 15 * time.Minute
+
 // Events
 const (
 	EventMessageSent                   = "messages.MESSAGE_SENT"
@@ -56,6 +60,10 @@ type Service interface {
 	SendHoursRequestDeclinedMessage(ctx context.Context, userID, requestID int64) (int64, error)
 	// SendVolunteerRequestAcceptanceMessage sends a VolunteerRequestAcceptance message based on request ID and opportunity ID.
 	SendVolunteerRequestAcceptanceMessage(ctx context.Context, userID, accepterID, opportunityID int64) (int64, error)
+	// SendWaitlistOfferMessage sends a WaitlistSpotOffered message to a waitlisted volunteer's organization conversation.
+	SendWaitlistOfferMessage(ctx context.Context, userID, opportunityID int64, expiresAt time.Time) (int64, error)
+	// RemoveOrganizationMember deactivates the conversation memberships a user holds as a member of an organization.
+	RemoveOrganizationMember(ctx context.Context, organizationID, userID int64) error
 }
 
 // service represents the internal implementation of the conversations Service.
@@ -71,6 +79,7 @@ type service struct {
 	userTagRepository                                  models.UserTagRepository
 	tagRepository                                      models.TagRepository
 	volunteeringHourLogRequestRepository               models.VolunteeringHourLogRequestRepository
+	opportunityApplicationAnswerRepository             models.OpportunityApplicationAnswerRepository
 	config                                             *config.Config
 	logger                                             *zerolog.Logger
 	snowflakeService                                   snowflakes.SnowflakeService
@@ -81,7 +90,7 @@ type service struct {
 }
 
 // NewService creates and returns a new conversations.Service.
-func NewService(conversationRepository models.ConversationRepository, conversationMembershipRepository models.ConversationMembershipRepository, conversationOpportunityMembershipRequestRepository models.ConversationOpportunityMembershipRequestRepository, conversationOrganizationMembershipRepository models.ConversationOrganizationMembershipRepository, messageRepository models.MessageRepository, opportunityRepository models.OpportunityRepository, userRepository models.UserRepository, userProfileFieldRepository models.UserProfileFieldRepository, userTagRepository models.UserTagRepository, tagRepository models.TagRepository, volunteeringHourLogRequestRepository models.VolunteeringHourLogRequestRepository, config *config.Config, logger *zerolog.Logger, snowflakeService snowflakes.SnowflakeService, emailService email.Service, broker pubsub.Broker, locationService location.Service) Service {
+func NewService(conversationRepository models.ConversationRepository, conversationMembershipRepository models.ConversationMembershipRepository, conversationOpportunityMembershipRequestRepository models.ConversationOpportunityMembershipRequestRepository, conversationOrganizationMembershipRepository models.ConversationOrganizationMembershipRepository, messageRepository models.MessageRepository, opportunityRepository models.OpportunityRepository, userRepository models.UserRepository, userProfileFieldRepository models.UserProfileFieldRepository, userTagRepository models.UserTagRepository, tagRepository models.TagRepository, volunteeringHourLogRequestRepository models.VolunteeringHourLogRequestRepository, opportunityApplicationAnswerRepository models.OpportunityApplicationAnswerRepository, config *config.Config, logger *zerolog.Logger, snowflakeService snowflakes.SnowflakeService, emailService email.Service, broker pubsub.Broker, locationService location.Service) Service {
 	return &service{
 		conversationRepository,
 		conversationMembershipRepository,
@@ -94,6 +103,7 @@ func NewService(conversationRepository models.ConversationRepository, conversati
 		userTagRepository,
 		tagRepository,
 		volunteeringHourLogRequestRepository,
+		opportunityApplicationAnswerRepository,
 		config,
 		logger,
 		snowflakeService,
@@ -130,8 +140,9 @@ func (s *service) CreateOpportunityMembershipRequestConversation(ctx context.Con
 	message.Timestamp = time.Now()
 	message.Edited = false
 	messageBody := MessageVolunteerRequestProfile{
-		Message: messageStr,
-		UserID:  volunteerID,
+		Message:   messageStr,
+		UserID:    volunteerID,
+		RequestID: opportunityMembershipRequestID,
 	}
 
 	jsonBytes, err := json.Marshal(messageBody)
@@ -174,7 +185,7 @@ func (s *service) createOrganizationVolunteerConversation(ctx context.Context, v
 	conversationMembership.Active = true
 	conversationMembership.ConversationID = conversation.ID
 	conversationMembership.UserID = volunteerID
-	conversationMembership.Role = 0 // TODO: make this a constant in the models package
+	conversationMembership.Role = models.ConversationMembershipRoleVolunteer
 
 	if err := s.conversationMembershipRepository.Create(conversationMembership); err != nil {
 		return 0, NewErrServerError()
@@ -363,6 +374,17 @@ func (s *service) GetOrganizationConversation(ctx context.Context, conversationI
 
 // SendStandardMessage sends a standard message to a conversation, returning the ID on success.
 func (s *service) SendStandardMessage(ctx context.Context, conversationID, senderID int64, messageText string, asOrganization bool) (int64, error) {
+	if !asOrganization && s.config.RequireEmailVerification {
+		sender, err := s.userRepository.FindByID(senderID)
+		if err != nil {
+			return 0, NewErrUserNotFound()
+		}
+
+		if !sender.EmailVerified {
+			return 0, NewErrEmailNotVerified()
+		}
+	}
+
 	message := models.Message{}
 	message.ID = s.snowflakeService.GenerateID()
 	message.Timestamp = time.Now()
@@ -439,6 +461,45 @@ func (s *service) brokerPublishEventConversationMembershipCreated(conversationMe
 	return nil
 }
 
+// brokerPublishEventConversationMembershipDeleted publishes a message as an EventConversationMembershipDeleted event.
+// Should be called asynchronously/spawned as a goroutine.
+func (s *service) brokerPublishEventConversationMembershipDeleted(conversationMembership models.ConversationMembership) error {
+	if err := s.broker.Publish(stream, pubsub.Event{
+		EventName: EventConversationMembershipDeleted,
+		Payload:   conversationMembership,
+	}); err != nil {
+		s.logger.Error().Err(err).Msg("Error publishing message to pub/sub")
+		return err
+	}
+
+	return nil
+}
+
+// RemoveOrganizationMember deactivates the conversation memberships a user holds as a member of an organization,
+// leaving the conversations they take part in as a volunteer untouched.
+func (s *service) RemoveOrganizationMember(ctx context.Context, organizationID, userID int64) error {
+	memberships, err := s.conversationMembershipRepository.FindByUserIDAndOrganizationID(ctx, userID, organizationID)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error getting organization conversation memberships")
+		return NewErrServerError()
+	}
+
+	for _, membership := range memberships {
+		if membership.Role == models.ConversationMembershipRoleVolunteer {
+			continue
+		}
+
+		if err := s.conversationMembershipRepository.Deactivate(membership.ID); err != nil {
+			s.logger.Error().Err(err).Msg("Error deactivating conversation membership")
+			return NewErrServerError()
+		}
+
+		go s.brokerPublishEventConversationMembershipDeleted(membership)
+	}
+
+	return nil
+}
+
 // ConversationMessagesResponse represents a response containing messages and paging information.
 type ConversationMessagesResponse struct {
 	Messages     []MessageView `json:"messages"`
@@ -520,7 +581,7 @@ func (s *service) parseMessage(ctx context.Context, messageType string, rawMessa
 			return nil, err
 		}
 
-		view, err := s.getMessageVolunteerRequestProfileView(ctx, body.UserID, body.Message)
+		view, err := s.getMessageVolunteerRequestProfileView(ctx, body.UserID, body.RequestID, body.Message)
 		if err != nil {
 			return nil, err
 		}
@@ -577,6 +638,19 @@ func (s *service) parseMessage(ctx context.Context, messageType string, rawMessa
 			return nil, err
 		}
 
+		return view, nil
+	case models.MessageTypeWaitlistSpotOffered:
+		body := MessageTypeWaitlistSpotOffered{}
+		err := json.Unmarshal(rawMessage, &body)
+		if err != nil {
+			return nil, err
+		}
+
+		view, err := s.getMessageWaitlistSpotOffered(ctx, body.UserID, body.OpportunityID, body.ExpiresAt)
+		if err != nil {
+			return nil, err
+		}
+
 		return view, nil
 	}
 
@@ -590,8 +664,9 @@ func (s *service) parseMessage(ctx context.Context, messageType string, rawMessa
 	return body, nil
 }
 
-// getMessageVolunteerRequestProfileView gets a MessageVolunteerRequestProfileView for a user by ID.
-func (s *service) getMessageVolunteerRequestProfileView(ctx context.Context, userID int64, message string) (*MessageVolunteerRequestProfileView, error) {
+// getMessageVolunteerRequestProfileView gets a MessageVolunteerRequestProfileView for a user by ID, along with
+// the answers to the application form of the membership request.
+func (s *service) getMessageVolunteerRequestProfileView(ctx context.Context, userID, requestID int64, message string) (*MessageVolunteerRequestProfileView, error) {
 	profile := &MessageVolunteerRequestProfileView{}
 	// Find the user to verify that it is active.
 	user, err := s.userRepository.FindByID(userID)
@@ -647,12 +722,42 @@ func (s *service) getMessageVolunteerRequestProfileView(ctx context.Context, use
 		return nil, NewErrServerError()
 	}
 
-	profile.ProfileFields = fields
+	// The view is sent to the managers of the organization the user applied to.
+	profile.ProfileFields = []models.UserProfileField{}
+	for _, field := range fields {
+		if field.VisibleTo(scopes.ScopeManager) {
+			profile.ProfileFields = append(profile.ProfileFields, field)
+		}
+	}
 
 	profile.PreviousExperience = &PreviousExperience{
 		Count: 0,
 	}
 
+	// Messages sent before application forms existed have no request ID.
+	profile.Answers = []models.OpportunityApplicationAnswer{}
+	if requestID != 0 {
+		answers, err := s.opportunityApplicationAnswerRepository.FindByRequestID(ctx, requestID)
+		if err != nil {
+			return nil, NewErrServerError()
+		}
+
+		for i, answer := range answers {
+			if answer.Type != models.ApplicationFieldTypeFile || answer.Value == "" {
+				continue
+			}
+
+			link, err := s.cdnClient.PresignedURL(answer.Value, applicationFileLinkLifespan)
+			if err != nil {
+				return nil, NewErrServerError()
+			}
+
+			answers[i].URL = link
+		}
+
+		profile.Answers = answers
+	}
+
 	return profile, nil
 }
 
@@ -691,6 +796,32 @@ func (s *service) getMessageVolunteerRequestAcceptance(ctx context.Context, user
 	return view, nil
 }
 
+// getMessageWaitlistSpotOffered gets a MessageTypeWaitlistSpotOfferedView by user ID and opportunity ID.
+func (s *service) getMessageWaitlistSpotOffered(ctx context.Context, userID, opportunityID int64, expiresAt time.Time) (*MessageTypeWaitlistSpotOfferedView, error) {
+	view := &MessageTypeWaitlistSpotOfferedView{}
+
+	opportunity, err := s.opportunityRepository.FindByID(ctx, opportunityID)
+	if err != nil {
+		return nil, err
+	}
+
+	volunteer, err := s.userRepository.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	view.Volunteer = &MessageUserWithName{
+		ID:        userID,
+		FirstName: volunteer.FirstName,
+		LastName:  volunteer.LastName,
+	}
+	view.OpportunityID = opportunityID
+	view.OpportunityTitle = opportunity.Title
+	view.ExpiresAt = expiresAt
+
+	return view, nil
+}
+
 // getMessageTypeHoursRequestedView gets a MessageTypeHoursRequestedView by request ID.
 func (s *service) getMessageTypeHoursRequestedView(ctx context.Context, requestID int64) (*MessageTypeHoursRequestedView, error) {
 	view := &MessageTypeHoursRequestedView{}
@@ -877,3 +1008,47 @@ func (s *service) SendVolunteerRequestAcceptanceMessage(ctx context.Context, use
 
 	return message.ID, nil
 }
+
+// SendWaitlistOfferMessage sends a WaitlistSpotOffered message to a waitlisted volunteer's organization conversation.
+func (s *service) SendWaitlistOfferMessage(ctx context.Context, userID, opportunityID int64, expiresAt time.Time) (int64, error) {
+	opportunity, err := s.opportunityRepository.FindByID(ctx, opportunityID)
+	if err != nil {
+		return 0, NewErrServerError()
+	}
+
+	conversation, err := s.conversationRepository.FindUserOrganizationConversation(ctx, userID, opportunity.OrganizationID)
+	if err != nil {
+		return 0, NewErrConversationNotFound()
+	}
+
+	message := models.Message{}
+	message.ID = s.snowflakeService.GenerateID()
+	message.Timestamp = time.Now()
+	message.ConversationID = conversation.ID
+	// Offers are made automatically, so they are sent on behalf of the opportunity's creator.
+	message.SenderID = opportunity.CreatorID
+	message.Type = models.MessageTypeWaitlistSpotOffered
+	perspective := models.MessageSenderPerspectiveOrganization
+
+	message.SenderPerspective = &perspective
+
+	messageBody := MessageTypeWaitlistSpotOffered{
+		UserID:        userID,
+		OpportunityID: opportunityID,
+		ExpiresAt:     expiresAt,
+	}
+
+	jsonBytes, err := marshalMessageBody(messageBody)
+	if err != nil {
+		return 0, NewErrServerError()
+	}
+
+	message.Body = *jsonBytes
+	message.Edited = false
+	if err := s.sendMessage(ctx, message); err != nil {
+		s.logger.Error().Err(err).Msg("Error creating message")
+		return 0, NewErrServerError()
+	}
+
+	return message.ID, nil
+}
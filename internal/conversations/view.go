@@ -37,16 +37,17 @@ type MessageSenderView struct {
 
 // MessageVolunteerRequestProfileView represents a view of a message containing a user's profile.
 type MessageVolunteerRequestProfileView struct {
-	UserID             int64                     `json:"userId"`
-	ProfilePicture     string                    `json:"profilePicture,omitempty"`            // a URL for the user's profile picture
-	FirstName          string                    `json:"firstName"`                           // the user's first name
-	LastName           string                    `json:"lastName"`                            // the user's last name
-	DateOfBirth        time.Time                 `json:"dateOfBirth,omitempty" scope:"owner"` // the user's date of birth, used for calculating age
-	PreviousExperience *PreviousExperience       `json:"previousExperience"`
-	Tags               []models.Tag              `json:"tags"`                             // the user's tags
-	Location           *location.Location        `json:"location,omitempty" scope:"owner"` // a formatted location
-	ProfileFields      []models.UserProfileField `json:"profile"`                          // the user's profile fields
-	Message            string                    `json:"message"`
+	UserID             int64                                 `json:"userId"`
+	ProfilePicture     string                                `json:"profilePicture,omitempty"`            // a URL for the user's profile picture
+	FirstName          string                                `json:"firstName"`                           // the user's first name
+	LastName           string                                `json:"lastName"`                            // the user's last name
+	DateOfBirth        time.Time                             `json:"dateOfBirth,omitempty" scope:"owner"` // the user's date of birth, used for calculating age
+	PreviousExperience *PreviousExperience                   `json:"previousExperience"`
+	Tags               []models.Tag                          `json:"tags"`                             // the user's tags
+	Location           *location.Location                    `json:"location,omitempty" scope:"owner"` // a formatted location
+	ProfileFields      []models.UserProfileField             `json:"profile"`                          // the user's profile fields
+	Message            string                                `json:"message"`
+	Answers            []models.OpportunityApplicationAnswer `json:"answers"` // the user's answers to the opportunity's application form
 }
 
 // PreviousExperience represents a user's previous experience.
@@ -83,3 +84,11 @@ type MessageTypeHoursAcceptedView struct {
 type MessageTypeHoursDeclinedView struct {
 	models.VolunteeringHourLogRequest
 }
+
+// MessageTypeWaitlistSpotOfferedView represents a view of a message containing a spot offered to a waitlisted volunteer.
+type MessageTypeWaitlistSpotOfferedView struct {
+	Volunteer        *MessageUserWithName `json:"volunteer"`
+	OpportunityID    int64                `json:"opportunityId"`
+	OpportunityTitle string               `json:"opportunityTitle"`
+	ExpiresAt        time.Time            `json:"expiresAt"` // the time until which the spot is held for the volunteer
+}
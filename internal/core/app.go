@@ -8,11 +8,14 @@ import (
 
 	"github.com/go-chi/chi"
 	"github.com/go-chi/chi/middleware"
+	"github.com/joinimpact/api/internal/analytics"
 	"github.com/joinimpact/api/internal/authentication"
 	"github.com/joinimpact/api/internal/config"
 	"github.com/joinimpact/api/internal/conversations"
+	"github.com/joinimpact/api/internal/core/handlers/auth"
 	authm "github.com/joinimpact/api/internal/core/middleware/auth"
 	"github.com/joinimpact/api/internal/events"
+	"github.com/joinimpact/api/internal/exports"
 	"github.com/joinimpact/api/internal/hours"
 	"github.com/joinimpact/api/internal/opportunities"
 	"github.com/joinimpact/api/internal/organizations"
@@ -38,10 +41,12 @@ type App struct {
 	eventsService         events.Service
 	conversationsService  conversations.Service
 	hoursService          hours.Service
+	exportsService        exports.Service
+	analyticsService      analytics.Service
 }
 
 // NewApp creates and returns a new *App with the provided Config.
-func NewApp(config *config.Config, logger *zerolog.Logger, websocketService socketserver.Service, authenticationService authentication.Service, usersService users.Service, organizationsService organizations.Service, tagsService tags.Service, opportunitiesService opportunities.Service, eventsService events.Service, conversationsService conversations.Service, hoursService hours.Service) *App {
+func NewApp(config *config.Config, logger *zerolog.Logger, websocketService socketserver.Service, authenticationService authentication.Service, usersService users.Service, organizationsService organizations.Service, tagsService tags.Service, opportunitiesService opportunities.Service, eventsService events.Service, conversationsService conversations.Service, hoursService hours.Service, exportsService exports.Service, analyticsService analytics.Service) *App {
 	return &App{
 		config,
 		logger,
@@ -54,6 +59,8 @@ func NewApp(config *config.Config, logger *zerolog.Logger, websocketService sock
 		eventsService,
 		conversationsService,
 		hoursService,
+		exportsService,
+		analyticsService,
 	}
 }
 
@@ -78,6 +85,9 @@ func (app *App) Serve() error {
 		// Add the healthcheck.
 		router.Get("/healthcheck", healthcheckHandler)
 
+		// Publish the public keys for verifying tokens.
+		router.Get("/.well-known/jwks.json", auth.JWKS(app.authenticationService))
+
 		// Mount the API router at /api/v1.
 		router.Mount(fmt.Sprintf("/api/v%d", APIRevision), app.Router())
 
@@ -0,0 +1,108 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/joinimpact/api/internal/core/middleware/auth"
+	"github.com/joinimpact/api/internal/models"
+	"github.com/joinimpact/api/internal/opportunities"
+	"github.com/joinimpact/api/internal/organizations"
+	"github.com/joinimpact/api/pkg/idctx"
+	"github.com/joinimpact/api/pkg/parse"
+	"github.com/joinimpact/api/pkg/resp"
+)
+
+// verificationError writes the response for an error returned by a verification method of the organizations service.
+func verificationError(w http.ResponseWriter, r *http.Request, err error) {
+	switch err.(type) {
+	case *organizations.ErrOrganizationNotFound, *organizations.ErrVerificationNotFound:
+		resp.NotFound(w, r, resp.APIError(err, nil))
+	case *organizations.ErrVerificationNotPending:
+		resp.BadRequest(w, r, resp.APIError(err, nil))
+	case *organizations.ErrServerError:
+		resp.ServerError(w, r, resp.APIError(err, nil))
+	default:
+		resp.ServerError(w, r, resp.UnknownError)
+	}
+}
+
+// VerificationsGet gets the organization verification requests awaiting review.
+func VerificationsGet(organizationsService organizations.Service) http.HandlerFunc {
+	type response struct {
+		Verifications []models.OrganizationVerification `json:"verifications"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		verifications, err := organizationsService.GetPendingVerifications(ctx)
+		if err != nil {
+			verificationError(w, r, err)
+			return
+		}
+
+		resp.OK(w, r, response{verifications})
+	}
+}
+
+// VerificationGet gets a single organization verification request along with links to its documents.
+func VerificationGet(organizationsService organizations.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		verificationID, err := idctx.Get(r, "verificationID")
+		if err != nil {
+			return
+		}
+
+		review, err := organizationsService.GetVerificationReview(ctx, verificationID)
+		if err != nil {
+			verificationError(w, r, err)
+			return
+		}
+
+		resp.OK(w, r, review)
+	}
+}
+
+// VerificationReviewPost approves or rejects an organization verification request, and updates
+// the organization's opportunities in search.
+func VerificationReviewPost(organizationsService organizations.Service, opportunitiesService opportunities.Service) http.HandlerFunc {
+	type request struct {
+		Approve bool   `json:"approve"`
+		Note    string `json:"note" validate:"max=1024"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userID, ok := ctx.Value(auth.KeyUserID).(int64)
+		if !ok {
+			resp.ServerError(w, r, resp.UnknownError)
+			return
+		}
+
+		verificationID, err := idctx.Get(r, "verificationID")
+		if err != nil {
+			return
+		}
+
+		req := request{}
+		err = parse.POST(w, r, &req)
+		if err != nil {
+			return
+		}
+
+		verification, err := organizationsService.ReviewVerification(ctx, verificationID, userID, req.Approve, req.Note)
+		if err != nil {
+			verificationError(w, r, err)
+			return
+		}
+
+		err = opportunitiesService.ReindexOrganizationOpportunities(ctx, verification.OrganizationID)
+		if err != nil {
+			resp.ServerError(w, r, resp.APIError(err, nil))
+			return
+		}
+
+		resp.OK(w, r, verification)
+	}
+}
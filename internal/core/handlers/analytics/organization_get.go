@@ -0,0 +1,42 @@
+package analytics
+
+import (
+	"net/http"
+
+	"github.com/joinimpact/api/internal/analytics"
+	"github.com/joinimpact/api/pkg/idctx"
+	"github.com/joinimpact/api/pkg/resp"
+)
+
+// OrganizationGet gets the analytics of an organization, bucketed by the interval query parameter.
+func OrganizationGet(analyticsService analytics.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		organizationID, err := idctx.Get(r, "organizationID")
+		if err != nil {
+			return
+		}
+
+		interval, err := analytics.ParseInterval(r.URL.Query().Get("interval"))
+		if err != nil {
+			resp.BadRequest(w, r, resp.APIError(err, nil))
+			return
+		}
+
+		res, err := analyticsService.GetOrganizationAnalytics(ctx, organizationID, interval)
+		if err != nil {
+			switch err.(type) {
+			case *analytics.ErrInvalidRange:
+				resp.BadRequest(w, r, resp.APIError(err, nil))
+			case *analytics.ErrServerError:
+				resp.ServerError(w, r, resp.APIError(err, nil))
+			default:
+				resp.ServerError(w, r, resp.UnknownError)
+			}
+			return
+		}
+
+		resp.OK(w, r, res)
+	}
+}
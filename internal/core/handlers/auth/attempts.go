@@ -0,0 +1,21 @@
+package auth
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/joinimpact/api/internal/authentication"
+	"github.com/joinimpact/api/pkg/resp"
+)
+
+// tooManyAttempts responds to a request which was rejected because of a
+// lockout, with the number of seconds until the client may retry.
+func tooManyAttempts(w http.ResponseWriter, r *http.Request, err *authentication.ErrTooManyAttempts) {
+	retryAfter := int64((err.RetryAfter + time.Second - 1) / time.Second)
+
+	w.Header().Set("Retry-After", strconv.FormatInt(retryAfter, 10))
+	resp.TooManyRequests(w, r, resp.APIError(err, map[string]int64{
+		"retryAfter": retryAfter,
+	}))
+}
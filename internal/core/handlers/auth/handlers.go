@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
 	"github.com/joinimpact/api/internal/authentication"
 	authm "github.com/joinimpact/api/internal/core/middleware/auth"
 	"github.com/joinimpact/api/internal/models"
@@ -18,17 +19,29 @@ import (
 func Login(service authentication.Service) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		req := struct {
-			Email    string `json:"email" validate:"email"`
-			Password string `json:"password" validate:"min=8,max=512"`
+			Email      string `json:"email" validate:"email"`
+			Password   string `json:"password" validate:"min=8,max=512"`
+			DeviceName string `json:"deviceName" validate:"max=64"`
 		}{}
 		err := parse.POST(w, r, &req)
 		if err != nil {
 			return
 		}
 
-		tokenPair, err := service.Login(req.Email, req.Password)
+		tokenPair, challenge, err := service.Login(r.Context(), req.Email, req.Password, sessionInfo(r, req.DeviceName))
 		if err != nil {
-			resp.ServerError(w, r, resp.Error(500, err.Error()))
+			switch err := err.(type) {
+			case *authentication.ErrTooManyAttempts:
+				tooManyAttempts(w, r, err)
+			default:
+				resp.ServerError(w, r, resp.Error(500, err.Error()))
+			}
+			return
+		}
+
+		if challenge != nil {
+			// A second factor is required, no session has been created yet.
+			resp.OK(w, r, challenge)
 			return
 		}
 
@@ -70,6 +83,7 @@ func Register(service authentication.Service, usersService users.Service) http.H
 			Password    string                `json:"password" validate:"min=8,max=512"`
 			DateOfBirth time.Time             `json:"dateOfBirth" validate:"omitempty,minAge=13,maxAge=100"`
 			Location    *location.Coordinates `json:"location"`
+			DeviceName  string                `json:"deviceName" validate:"max=64"`
 		}{}
 		err := parse.POST(w, r, &req)
 		if err != nil {
@@ -79,12 +93,12 @@ func Register(service authentication.Service, usersService users.Service) http.H
 		// Round the date of birth to the nearest day.
 		req.DateOfBirth = req.DateOfBirth.Truncate(24 * time.Hour)
 
-		tokenPair, err := service.Register(models.User{
+		tokenPair, err := service.Register(r.Context(), models.User{
 			FirstName:   req.FirstName,
 			LastName:    req.LastName,
 			Email:       req.Email,
 			DateOfBirth: req.DateOfBirth,
-		}, req.Password)
+		}, req.Password, sessionInfo(r, req.DeviceName))
 		if err != nil {
 			resp.ServerError(w, r, resp.Error(500, err.Error()))
 			return
@@ -112,9 +126,14 @@ func RequestPasswordReset(service authentication.Service) http.HandlerFunc {
 			return
 		}
 
-		err = service.RequestPasswordReset(req.Email)
+		err = service.RequestPasswordReset(req.Email, clientIP(r))
 		if err != nil {
-			resp.NotFound(w, r, resp.Error(404, err.Error()))
+			switch err := err.(type) {
+			case *authentication.ErrTooManyAttempts:
+				tooManyAttempts(w, r, err)
+			default:
+				resp.NotFound(w, r, resp.Error(404, err.Error()))
+			}
 			return
 		}
 
@@ -169,25 +188,95 @@ func ResetPassword(service authentication.Service) http.HandlerFunc {
 	}
 }
 
+// RequestMagicLink requests a single-use login link to be emailed to a user.
+func RequestMagicLink(service authentication.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := struct {
+			Email string `json:"email" validate:"email"`
+		}{}
+		err := parse.POST(w, r, &req)
+		if err != nil {
+			return
+		}
+
+		err = service.RequestMagicLink(r.Context(), req.Email, clientIP(r))
+		if err != nil {
+			switch err := err.(type) {
+			case *authentication.ErrTooManyAttempts:
+				tooManyAttempts(w, r, err)
+			case *authentication.ErrServerError:
+				resp.ServerError(w, r, resp.APIError(err, nil))
+			default:
+				resp.ServerError(w, r, resp.UnknownError)
+			}
+			return
+		}
+
+		resp.OK(w, r, map[string]bool{
+			"success": true,
+		})
+	}
+}
+
+// RedeemMagicLink logs in a user using a magic link's key.
+func RedeemMagicLink(service authentication.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := struct {
+			DeviceName string `json:"deviceName" validate:"max=64"`
+		}{}
+		err := parse.POST(w, r, &req)
+		if err != nil {
+			return
+		}
+
+		tokenPair, challenge, err := service.RedeemMagicLink(r.Context(), chi.URLParam(r, "magicLinkKey"), sessionInfo(r, req.DeviceName))
+		if err != nil {
+			switch err.(type) {
+			case *authentication.ErrInvalidKey, *authentication.ErrUserNotFound:
+				resp.NotFound(w, r, resp.APIError(err, nil))
+			case *authentication.ErrServerError:
+				resp.ServerError(w, r, resp.APIError(err, nil))
+			default:
+				resp.ServerError(w, r, resp.UnknownError)
+			}
+			return
+		}
+
+		if challenge != nil {
+			// A second factor is required, no session has been created yet.
+			resp.OK(w, r, challenge)
+			return
+		}
+
+		// Update cookies.
+		authm.SetAuthCookies(w, r, tokenPair)
+
+		resp.OK(w, r, tokenPair)
+	}
+}
+
 // GoogleOauth attempts to use Google to login through Oauth.
 func GoogleOauth(service authentication.Service) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		req := struct {
-			Token string `json:"token" validate:"min=8,max=1024"`
+			Token      string `json:"token" validate:"min=8,max=1024"`
+			DeviceName string `json:"deviceName" validate:"max=64"`
 		}{}
 		err := parse.POST(w, r, &req)
 		if err != nil {
 			return
 		}
 
-		res, err := service.OauthLogin("google", req.Token)
+		res, err := service.OauthLogin(r.Context(), "google", req.Token, sessionInfo(r, req.DeviceName))
 		if err != nil {
 			resp.BadRequest(w, r, resp.Error(400, err.Error()))
 			return
 		}
 
-		// Update cookies.
-		authm.SetAuthCookies(w, r, res.TokenPair)
+		if res.TokenPair != nil {
+			// Update cookies.
+			authm.SetAuthCookies(w, r, res.TokenPair)
+		}
 
 		resp.OK(w, r, res)
 	}
@@ -197,29 +286,111 @@ func GoogleOauth(service authentication.Service) http.HandlerFunc {
 func FacebookOauth(service authentication.Service) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		req := struct {
-			Token string `json:"token" validate:"min=8,max=1024"`
+			Token      string `json:"token" validate:"min=8,max=1024"`
+			DeviceName string `json:"deviceName" validate:"max=64"`
 		}{}
 		err := parse.POST(w, r, &req)
 		if err != nil {
 			return
 		}
 
-		res, err := service.OauthLogin("facebook", req.Token)
+		res, err := service.OauthLogin(r.Context(), "facebook", req.Token, sessionInfo(r, req.DeviceName))
 		if err != nil {
 			resp.BadRequest(w, r, resp.Error(400, err.Error()))
 			return
 		}
 
-		// Update cookies.
-		authm.SetAuthCookies(w, r, res.TokenPair)
+		if res.TokenPair != nil {
+			// Update cookies.
+			authm.SetAuthCookies(w, r, res.TokenPair)
+		}
 
 		resp.OK(w, r, res)
 	}
 }
 
-// Logout logs a user out by clearing auth and refresh token cookies.
+// OIDCOauth attempts to login through a configured OpenID Connect provider,
+// with either an ID token or an authorization code.
+func OIDCOauth(service authentication.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := struct {
+			IDToken    string `json:"idToken" validate:"required_without=Code,max=8192"`
+			Code       string `json:"code" validate:"required_without=IDToken,max=2048"`
+			Nonce      string `json:"nonce" validate:"max=256"`
+			DeviceName string `json:"deviceName" validate:"max=64"`
+		}{}
+		err := parse.POST(w, r, &req)
+		if err != nil {
+			return
+		}
+
+		res, err := service.OIDCLogin(r.Context(), chi.URLParam(r, "provider"), req.IDToken, req.Code, req.Nonce, sessionInfo(r, req.DeviceName))
+		if err != nil {
+			switch err.(type) {
+			case *authentication.ErrOauthProviderNotFound:
+				resp.NotFound(w, r, resp.APIError(err, nil))
+			case *authentication.ErrInvalidToken:
+				resp.Unauthorized(w, r, resp.APIError(err, nil))
+			case *authentication.ErrOauthEmailNotVerified:
+				resp.Forbidden(w, r, resp.APIError(err, nil))
+			default:
+				resp.BadRequest(w, r, resp.Error(400, err.Error()))
+			}
+			return
+		}
+
+		if res.TokenPair != nil {
+			// Update cookies.
+			authm.SetAuthCookies(w, r, res.TokenPair)
+		}
+
+		resp.OK(w, r, res)
+	}
+}
+
+// TwoFactorVerify completes a two-step login with a TOTP or recovery code.
+func TwoFactorVerify(service authentication.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := struct {
+			ChallengeToken string `json:"challengeToken" validate:"min=1"`
+			Code           string `json:"code" validate:"min=6,max=16"`
+			DeviceName     string `json:"deviceName" validate:"max=64"`
+		}{}
+		err := parse.POST(w, r, &req)
+		if err != nil {
+			return
+		}
+
+		tokenPair, err := service.VerifyTwoFactorChallenge(r.Context(), req.ChallengeToken, req.Code, sessionInfo(r, req.DeviceName))
+		if err != nil {
+			switch err.(type) {
+			case *authentication.ErrInvalidToken, *authentication.ErrInvalidTwoFactorCode, *authentication.ErrTwoFactorNotEnabled:
+				resp.Unauthorized(w, r, resp.APIError(err, nil))
+			case *authentication.ErrTwoFactorLocked:
+				resp.Forbidden(w, r, resp.APIError(err, nil))
+			case *authentication.ErrServerError:
+				resp.ServerError(w, r, resp.APIError(err, nil))
+			default:
+				resp.ServerError(w, r, resp.UnknownError)
+			}
+			return
+		}
+
+		// Update cookies.
+		authm.SetAuthCookies(w, r, tokenPair)
+
+		resp.OK(w, r, tokenPair)
+	}
+}
+
+// Logout logs a user out by revoking their session and clearing auth and refresh token cookies.
 func Logout(service authentication.Service) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		if token := requestToken(r); len(token) > 0 {
+			// An invalid or already revoked session is as good as logged out.
+			_ = service.Logout(r.Context(), token)
+		}
+
 		authm.ClearAuthCookies(w, r)
 
 		resp.OK(w, r, map[string]bool{
@@ -227,3 +398,94 @@ func Logout(service authentication.Service) http.HandlerFunc {
 		})
 	}
 }
+
+// VerifyEmail verifies a user's email using a verification key.
+func VerifyEmail(service authentication.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := chi.URLParam(r, "emailVerificationKey")
+
+		err := service.VerifyEmail(r.Context(), key)
+		if err != nil {
+			switch err.(type) {
+			case *authentication.ErrInvalidKey, *authentication.ErrUserNotFound:
+				resp.NotFound(w, r, resp.APIError(err, nil))
+			case *authentication.ErrServerError:
+				resp.ServerError(w, r, resp.APIError(err, nil))
+			default:
+				resp.ServerError(w, r, resp.UnknownError)
+			}
+			return
+		}
+
+		resp.OK(w, r, map[string]bool{
+			"success": true,
+		})
+	}
+}
+
+// ConfirmEmailChange changes a user's email using an email change key.
+func ConfirmEmailChange(service authentication.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := chi.URLParam(r, "emailChangeKey")
+
+		err := service.ConfirmEmailChange(r.Context(), key)
+		if err != nil {
+			switch err.(type) {
+			case *authentication.ErrInvalidKey, *authentication.ErrUserNotFound:
+				resp.NotFound(w, r, resp.APIError(err, nil))
+			case *authentication.ErrEmailTaken:
+				resp.BadRequest(w, r, resp.APIError(err, nil))
+			case *authentication.ErrServerError:
+				resp.ServerError(w, r, resp.APIError(err, nil))
+			default:
+				resp.ServerError(w, r, resp.UnknownError)
+			}
+			return
+		}
+
+		resp.OK(w, r, map[string]bool{
+			"success": true,
+		})
+	}
+}
+
+// ResendVerificationEmail sends the authenticated user a new verification email.
+func ResendVerificationEmail(service authentication.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userID, ok := ctx.Value(authm.KeyUserID).(int64)
+		if !ok {
+			resp.ServerError(w, r, resp.UnknownError)
+			return
+		}
+
+		err := service.ResendVerificationEmail(ctx, userID)
+		if err != nil {
+			switch err.(type) {
+			case *authentication.ErrUserNotFound:
+				resp.NotFound(w, r, resp.APIError(err, nil))
+			case *authentication.ErrEmailAlreadyVerified, *authentication.ErrVerificationEmailRecentlySent:
+				resp.BadRequest(w, r, resp.APIError(err, nil))
+			case *authentication.ErrServerError:
+				resp.ServerError(w, r, resp.APIError(err, nil))
+			default:
+				resp.ServerError(w, r, resp.UnknownError)
+			}
+			return
+		}
+
+		resp.OK(w, r, map[string]bool{
+			"success": true,
+		})
+	}
+}
+
+// JWKS serves the public keys which can be used to verify tokens as a JSON
+// Web Key Set. The set is served as-is, so that standard JWKS clients can
+// consume it.
+func JWKS(service authentication.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		render.JSON(w, r, service.JWKS())
+	}
+}
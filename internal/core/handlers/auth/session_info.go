@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/joinimpact/api/internal/authentication"
+	authm "github.com/joinimpact/api/internal/core/middleware/auth"
+)
+
+// sessionInfo builds an authentication.SessionInfo describing the client
+// which made the request.
+func sessionInfo(r *http.Request, deviceName string) authentication.SessionInfo {
+	return authentication.SessionInfo{
+		DeviceName: deviceName,
+		UserAgent:  r.UserAgent(),
+		IPAddress:  clientIP(r),
+	}
+}
+
+// clientIP gets the IP address of the client, as resolved by the client IP
+// middleware.
+func clientIP(r *http.Request) string {
+	if ip, ok := r.Context().Value(authm.KeyClientIP).(string); ok {
+		return ip
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
+// requestToken gets the token identifying the session of a request, from the
+// Authorization header or from the token cookies.
+func requestToken(r *http.Request) string {
+	header := strings.SplitN(r.Header.Get("Authorization"), " ", 2)
+	if len(header) == 2 && header[0] == "Bearer" {
+		return header[1]
+	}
+
+	// Prefer the refresh token, as it outlives the auth token.
+	if cookie, err := r.Cookie("refresh_token"); err == nil {
+		return cookie.Value
+	}
+
+	if cookie, err := r.Cookie("auth_token"); err == nil {
+		return cookie.Value
+	}
+
+	return ""
+}
@@ -0,0 +1,48 @@
+package browse
+
+import (
+	"net/http"
+
+	"github.com/joinimpact/api/internal/organizations"
+	organizationsSearch "github.com/joinimpact/api/internal/search/stores/organizations"
+	"github.com/joinimpact/api/pkg/parse"
+	"github.com/joinimpact/api/pkg/resp"
+)
+
+// OrganizationsQueryPost queries organizations.
+func OrganizationsQueryPost(organizationsService organizations.Service) http.HandlerFunc {
+	type request struct {
+		organizationsSearch.Query
+	}
+	type response struct {
+		TotalResults  uint                                `json:"totalResults"`
+		Pages         uint                                `json:"pages"`
+		Organizations []organizations.OrganizationProfile `json:"organizations"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		req := request{}
+		err := parse.POST(w, r, &req)
+		if err != nil {
+			return
+		}
+
+		res, err := organizationsService.Search(ctx, req.Query)
+		if err != nil {
+			switch err.(type) {
+			case *organizations.ErrServerError:
+				resp.ServerError(w, r, resp.APIError(err, nil))
+			default:
+				resp.ServerError(w, r, resp.UnknownError)
+			}
+			return
+		}
+
+		resp.OK(w, r, response{
+			TotalResults:  res.TotalResults,
+			Pages:         res.Pages,
+			Organizations: res.Organizations,
+		})
+	}
+}
@@ -47,6 +47,8 @@ func MessagesPost(conversationsService conversations.Service, asOrganization boo
 			switch err.(type) {
 			case *conversations.ErrConversationNotFound, *conversations.ErrUserNotFound:
 				resp.NotFound(w, r, resp.APIError(err, nil))
+			case *conversations.ErrEmailNotVerified:
+				resp.Forbidden(w, r, resp.APIError(err, nil))
 			case *conversations.ErrServerError:
 				resp.ServerError(w, r, resp.APIError(err, nil))
 			default:
@@ -0,0 +1,223 @@
+package events
+
+import (
+	"net/http"
+
+	"github.com/joinimpact/api/internal/core/middleware/auth"
+	"github.com/joinimpact/api/internal/events"
+	"github.com/joinimpact/api/internal/models"
+	"github.com/joinimpact/api/internal/users"
+	"github.com/joinimpact/api/pkg/idctx"
+	"github.com/joinimpact/api/pkg/parse"
+	"github.com/joinimpact/api/pkg/resp"
+)
+
+// attendanceError writes the response for an error returned by a check-in method of the events service.
+func attendanceError(w http.ResponseWriter, r *http.Request, err error) {
+	switch err.(type) {
+	case *events.ErrEventNotFound, *events.ErrOccurrenceNotFound, *events.ErrNotCheckedIn:
+		resp.NotFound(w, r, resp.APIError(err, nil))
+	case *events.ErrInvalidCheckInCode, *events.ErrCheckInClosed, *events.ErrAlreadyCheckedIn, *events.ErrOccurrenceRequired:
+		resp.BadRequest(w, r, resp.APIError(err, nil))
+	case *events.ErrNotVolunteer:
+		resp.Forbidden(w, r, resp.APIError(err, nil))
+	case *events.ErrServerError:
+		resp.ServerError(w, r, resp.APIError(err, nil))
+	default:
+		resp.ServerError(w, r, resp.UnknownError)
+	}
+}
+
+// CheckInCodeGet gets the current check-in code of an event, for coordinators to show as a QR code.
+func CheckInCodeGet(eventsService events.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		eventID, err := idctx.Get(r, "eventID")
+		if err != nil {
+			return
+		}
+
+		code, err := eventsService.GetCheckInCode(ctx, eventID)
+		if err != nil {
+			attendanceError(w, r, err)
+			return
+		}
+
+		resp.OK(w, r, code)
+	}
+}
+
+// CheckInPost checks the current user in to an event with the check-in code they scanned.
+func CheckInPost(eventsService events.Service) http.HandlerFunc {
+	type request struct {
+		Code string `json:"code" validate:"min=1,max=128"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userID, ok := ctx.Value(auth.KeyUserID).(int64)
+		if !ok {
+			resp.ServerError(w, r, resp.UnknownError)
+			return
+		}
+
+		eventID, err := idctx.Get(r, "eventID")
+		if err != nil {
+			return
+		}
+
+		req := request{}
+		err = parse.POST(w, r, &req)
+		if err != nil {
+			return
+		}
+
+		checkIn, err := eventsService.CheckIn(ctx, eventID, userID, req.Code)
+		if err != nil {
+			attendanceError(w, r, err)
+			return
+		}
+
+		resp.OK(w, r, checkIn)
+	}
+}
+
+// CheckOutPost checks the current user out of an event and logs their hours.
+func CheckOutPost(eventsService events.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userID, ok := ctx.Value(auth.KeyUserID).(int64)
+		if !ok {
+			resp.ServerError(w, r, resp.UnknownError)
+			return
+		}
+
+		eventID, err := idctx.Get(r, "eventID")
+		if err != nil {
+			return
+		}
+
+		checkIn, err := eventsService.CheckOut(ctx, eventID, userID, 0)
+		if err != nil {
+			attendanceError(w, r, err)
+			return
+		}
+
+		resp.OK(w, r, checkIn)
+	}
+}
+
+// VolunteerCheckInPost checks a volunteer in to an event on behalf of the current user, a manager. Recurring
+// events take the occurrence in the query.
+func VolunteerCheckInPost(eventsService events.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		managerID, ok := ctx.Value(auth.KeyUserID).(int64)
+		if !ok {
+			resp.ServerError(w, r, resp.UnknownError)
+			return
+		}
+
+		eventID, err := idctx.Get(r, "eventID")
+		if err != nil {
+			return
+		}
+
+		userID, err := idctx.Get(r, "userID")
+		if err != nil {
+			return
+		}
+
+		occurrence, ok := occurrenceParam(w, r)
+		if !ok {
+			return
+		}
+
+		checkIn, err := eventsService.CheckInVolunteer(ctx, eventID, userID, managerID, occurrence)
+		if err != nil {
+			attendanceError(w, r, err)
+			return
+		}
+
+		resp.OK(w, r, checkIn)
+	}
+}
+
+// VolunteerCheckOutPost checks a volunteer out of an event on behalf of the current user, a manager, and
+// logs the volunteer's hours.
+func VolunteerCheckOutPost(eventsService events.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		managerID, ok := ctx.Value(auth.KeyUserID).(int64)
+		if !ok {
+			resp.ServerError(w, r, resp.UnknownError)
+			return
+		}
+
+		eventID, err := idctx.Get(r, "eventID")
+		if err != nil {
+			return
+		}
+
+		userID, err := idctx.Get(r, "userID")
+		if err != nil {
+			return
+		}
+
+		checkIn, err := eventsService.CheckOut(ctx, eventID, userID, managerID)
+		if err != nil {
+			attendanceError(w, r, err)
+			return
+		}
+
+		resp.OK(w, r, checkIn)
+	}
+}
+
+// AttendanceGet gets the attendance report of an event, or of the occurrence of a recurring event in the query.
+func AttendanceGet(eventsService events.Service, usersService users.Service) http.HandlerFunc {
+	type attendee struct {
+		models.EventCheckIn
+		users.UserProfile
+	}
+	type response struct {
+		*events.AttendanceReport
+		Attendees []attendee `json:"attendees"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		eventID, err := idctx.Get(r, "eventID")
+		if err != nil {
+			return
+		}
+
+		occurrence, ok := occurrenceParam(w, r)
+		if !ok {
+			return
+		}
+
+		report, err := eventsService.GetAttendanceReport(ctx, eventID, occurrence)
+		if err != nil {
+			attendanceError(w, r, err)
+			return
+		}
+
+		attendees := []attendee{}
+		for _, checkIn := range report.Attendees {
+			profile, err := usersService.GetMinimalUserProfile(checkIn.UserID)
+			if err != nil {
+				// The volunteer's account was deactivated, but their attendance still counts.
+				profile = &users.UserProfile{}
+			}
+
+			attendees = append(attendees, attendee{checkIn, *profile})
+		}
+
+		resp.OK(w, r, response{report, attendees})
+	}
+}
@@ -0,0 +1,138 @@
+package events
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi"
+	"github.com/joinimpact/api/internal/core/middleware/auth"
+	"github.com/joinimpact/api/internal/events"
+	"github.com/joinimpact/api/internal/models"
+	"github.com/joinimpact/api/internal/opportunities"
+	"github.com/joinimpact/api/internal/organizations"
+	"github.com/joinimpact/api/internal/users"
+	"github.com/joinimpact/api/pkg/idctx"
+	"github.com/joinimpact/api/pkg/resp"
+)
+
+// userFeedName is the name calendar apps show for volunteers' calendar feeds.
+const userFeedName = "Impact"
+
+// calendarFeedResponse is the response to creating or regenerating a calendar feed.
+type calendarFeedResponse struct {
+	URL string `json:"url"`
+}
+
+// UserCalendarFeedPost creates a calendar feed of a volunteer's events, or regenerates its URL to revoke the
+// old one.
+func UserCalendarFeedPost(eventsService events.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userID, err := idctx.Get(r, "userID")
+		if err != nil {
+			return
+		}
+
+		url, err := eventsService.RegenerateUserCalendarFeed(ctx, userID)
+		if err != nil {
+			resp.ServerError(w, r, resp.APIError(err, nil))
+			return
+		}
+
+		resp.OK(w, r, calendarFeedResponse{url})
+	}
+}
+
+// OpportunityCalendarFeedPost creates a manager's calendar feed of an opportunity's events, or regenerates its
+// URL to revoke the old one.
+func OpportunityCalendarFeedPost(eventsService events.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userID, ok := ctx.Value(auth.KeyUserID).(int64)
+		if !ok {
+			resp.ServerError(w, r, resp.UnknownError)
+			return
+		}
+
+		opportunityID, err := idctx.Get(r, "opportunityID")
+		if err != nil {
+			return
+		}
+
+		url, err := eventsService.RegenerateOpportunityCalendarFeed(ctx, opportunityID, userID)
+		if err != nil {
+			resp.ServerError(w, r, resp.APIError(err, nil))
+			return
+		}
+
+		resp.OK(w, r, calendarFeedResponse{url})
+	}
+}
+
+// CalendarFeedGet serves a calendar feed by its secret token, in iCalendar format. Feeds stop working when
+// their user's account is deactivated, and managers' feeds stop working when they leave the organization or
+// no longer hold the permission to edit its events.
+func CalendarFeedGet(eventsService events.Service, usersService users.Service, opportunitiesService opportunities.Service, organizationsService organizations.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		token := strings.TrimSuffix(chi.URLParam(r, "token"), ".ics")
+
+		feed, err := eventsService.GetCalendarFeed(ctx, token)
+		if err != nil {
+			resp.NotFound(w, r, resp.APIError(err, nil))
+			return
+		}
+
+		if _, err := usersService.GetMinimalUserProfile(feed.UserID); err != nil {
+			resp.NotFound(w, r, resp.APIError(events.NewErrCalendarFeedNotFound(), nil))
+			return
+		}
+
+		name := userFeedName
+		if feed.OpportunityID != nil {
+			opportunity, err := opportunitiesService.GetMinimalOpportunity(ctx, *feed.OpportunityID)
+			if err != nil {
+				resp.NotFound(w, r, resp.APIError(events.NewErrCalendarFeedNotFound(), nil))
+				return
+			}
+
+			permissions, err := organizationsService.GetOrganizationPermissions(ctx, opportunity.OrganizationID, feed.UserID)
+			if err != nil || !hasPermission(permissions, models.PermissionEventsEdit) {
+				resp.NotFound(w, r, resp.APIError(events.NewErrCalendarFeedNotFound(), nil))
+				return
+			}
+
+			name = opportunity.Title
+		}
+
+		calendar, err := eventsService.RenderCalendarFeed(ctx, *feed, name)
+		if err != nil {
+			switch err.(type) {
+			case *events.ErrServerError:
+				resp.ServerError(w, r, resp.APIError(err, nil))
+			default:
+				resp.ServerError(w, r, resp.UnknownError)
+			}
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+		w.Header().Set("Cache-Control", "private, max-age=300")
+		w.WriteHeader(http.StatusOK)
+		w.Write(calendar)
+	}
+}
+
+// hasPermission checks whether a list of permission names includes a permission.
+func hasPermission(permissions []string, permission string) bool {
+	for _, name := range permissions {
+		if name == permission {
+			return true
+		}
+	}
+
+	return false
+}
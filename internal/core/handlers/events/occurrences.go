@@ -0,0 +1,142 @@
+package events
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/joinimpact/api/internal/events"
+	"github.com/joinimpact/api/internal/models"
+	"github.com/joinimpact/api/pkg/idctx"
+	"github.com/joinimpact/api/pkg/parse"
+	"github.com/joinimpact/api/pkg/resp"
+)
+
+// occurrenceParam parses the optional "occurrence" query parameter, which selects an occurrence of a recurring
+// event by its original start time. It writes an error response and returns false if the parameter is invalid.
+func occurrenceParam(w http.ResponseWriter, r *http.Request) (*time.Time, bool) {
+	value := r.URL.Query().Get("occurrence")
+	if value == "" {
+		return nil, true
+	}
+
+	occurrence, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		resp.BadRequest(w, r, resp.Error(400, "invalid occurrence"))
+		return nil, false
+	}
+
+	return &occurrence, true
+}
+
+// occurrenceError writes the response for an error returned by an occurrence method of the events service.
+func occurrenceError(w http.ResponseWriter, r *http.Request, err error) {
+	switch err.(type) {
+	case *events.ErrEventNotFound, *events.ErrOccurrenceNotFound, *events.ErrOverrideNotFound:
+		resp.NotFound(w, r, resp.APIError(err, nil))
+	case *events.ErrInvalidOverride:
+		resp.BadRequest(w, r, resp.APIError(err, nil))
+	case *events.ErrServerError:
+		resp.ServerError(w, r, resp.APIError(err, nil))
+	default:
+		resp.ServerError(w, r, resp.UnknownError)
+	}
+}
+
+// OverridesGet gets the overrides of the occurrences of a recurring event.
+func OverridesGet(eventsService events.Service) http.HandlerFunc {
+	type response struct {
+		Overrides []models.EventOccurrenceOverride `json:"overrides"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		eventID, err := idctx.Get(r, "eventID")
+		if err != nil {
+			return
+		}
+
+		overrides, err := eventsService.GetOccurrenceOverrides(ctx, eventID)
+		if err != nil {
+			occurrenceError(w, r, err)
+			return
+		}
+
+		resp.OK(w, r, response{overrides})
+	}
+}
+
+// OverridePut changes or cancels a single occurrence of a recurring event.
+func OverridePut(eventsService events.Service) http.HandlerFunc {
+	type request struct {
+		Occurrence  time.Time  `json:"occurrence" validate:"required"`
+		Cancelled   bool       `json:"cancelled"`
+		Title       *string    `json:"title"`
+		Description *string    `json:"description"`
+		FromDate    *time.Time `json:"from"`
+		ToDate      *time.Time `json:"to"`
+	}
+	type response struct {
+		Success bool `json:"success"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		eventID, err := idctx.Get(r, "eventID")
+		if err != nil {
+			return
+		}
+
+		req := request{}
+		err = parse.POST(w, r, &req)
+		if err != nil {
+			return
+		}
+
+		err = eventsService.OverrideOccurrence(ctx, eventID, models.EventOccurrenceOverride{
+			OccurrenceStart: req.Occurrence,
+			Cancelled:       req.Cancelled,
+			Title:           req.Title,
+			Description:     req.Description,
+			FromDate:        req.FromDate,
+			ToDate:          req.ToDate,
+		})
+		if err != nil {
+			occurrenceError(w, r, err)
+			return
+		}
+
+		resp.OK(w, r, response{true})
+	}
+}
+
+// OverrideDelete restores the occurrence of a recurring event in the query to the event's schedule.
+func OverrideDelete(eventsService events.Service) http.HandlerFunc {
+	type response struct {
+		Success bool `json:"success"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		eventID, err := idctx.Get(r, "eventID")
+		if err != nil {
+			return
+		}
+
+		occurrence, ok := occurrenceParam(w, r)
+		if !ok {
+			return
+		}
+
+		if occurrence == nil {
+			resp.BadRequest(w, r, resp.APIError(events.NewErrOccurrenceRequired(), nil))
+			return
+		}
+
+		if err := eventsService.RestoreOccurrence(ctx, eventID, *occurrence); err != nil {
+			occurrenceError(w, r, err)
+			return
+		}
+
+		resp.OK(w, r, response{true})
+	}
+}
@@ -38,6 +38,8 @@ func Patch(eventsService events.Service) http.HandlerFunc {
 			switch err.(type) {
 			case *events.ErrEventNotFound:
 				resp.NotFound(w, r, resp.Error(404, err.Error()))
+			case *events.ErrInvalidRecurrence:
+				resp.BadRequest(w, r, resp.APIError(err, nil))
 			case *events.ErrServerError:
 				resp.ServerError(w, r, resp.Error(500, err.Error()))
 			default:
@@ -44,6 +44,8 @@ func Post(eventsService events.Service) http.HandlerFunc {
 			switch err.(type) {
 			case *events.ErrEventNotFound:
 				resp.NotFound(w, r, resp.Error(404, err.Error()))
+			case *events.ErrInvalidRecurrence:
+				resp.BadRequest(w, r, resp.APIError(err, nil))
 			case *events.ErrServerError:
 				resp.ServerError(w, r, resp.Error(500, err.Error()))
 			default:
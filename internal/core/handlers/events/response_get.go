@@ -9,7 +9,7 @@ import (
 	"github.com/joinimpact/api/pkg/resp"
 )
 
-// ResponseGet gets a user's response to an event.
+// ResponseGet gets a user's response to an event, or to the occurrence of a recurring event in the query.
 func ResponseGet(eventsService events.Service) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
@@ -25,11 +25,18 @@ func ResponseGet(eventsService events.Service) http.HandlerFunc {
 			return
 		}
 
-		response, err := eventsService.GetUserEventResponse(ctx, userID, eventID)
+		occurrence, ok := occurrenceParam(w, r)
+		if !ok {
+			return
+		}
+
+		response, err := eventsService.GetUserEventResponse(ctx, userID, eventID, occurrence)
 		if err != nil {
 			switch err.(type) {
-			case *events.ErrEventNotFound, *events.ErrResponseNotFound:
+			case *events.ErrEventNotFound, *events.ErrResponseNotFound, *events.ErrOccurrenceNotFound:
 				resp.NotFound(w, r, resp.APIError(err, nil))
+			case *events.ErrOccurrenceRequired:
+				resp.BadRequest(w, r, resp.APIError(err, nil))
 			case *events.ErrServerError:
 				resp.ServerError(w, r, resp.APIError(err, nil))
 			default:
@@ -11,7 +11,7 @@ import (
 	"github.com/joinimpact/api/pkg/resp"
 )
 
-// ResponsePut adds a user's response to an event.
+// ResponsePut adds a user's response to an event, or to the occurrence of a recurring event in the query.
 func ResponsePut(eventsService events.Service) http.HandlerFunc {
 	type request struct {
 		Response *int `json:"response" validate:"required,max=2"`
@@ -33,6 +33,11 @@ func ResponsePut(eventsService events.Service) http.HandlerFunc {
 			return
 		}
 
+		occurrence, ok := occurrenceParam(w, r)
+		if !ok {
+			return
+		}
+
 		req := request{}
 		err = parse.POST(w, r, &req)
 		if err != nil {
@@ -42,17 +47,19 @@ func ResponsePut(eventsService events.Service) http.HandlerFunc {
 		// Check which value the response matches.
 		switch *req.Response {
 		case models.EventResponseCanAttend:
-			err = eventsService.SetEventResponseCanAttend(ctx, eventID, userID)
+			err = eventsService.SetEventResponseCanAttend(ctx, eventID, userID, occurrence)
 		case models.EventResponseCanNotAttend:
-			err = eventsService.SetEventResponseCanNotAttend(ctx, eventID, userID)
+			err = eventsService.SetEventResponseCanNotAttend(ctx, eventID, userID, occurrence)
 		default:
 			return
 		}
 
 		if err != nil {
 			switch err.(type) {
-			case *events.ErrEventNotFound, *events.ErrResponseNotFound:
+			case *events.ErrEventNotFound, *events.ErrResponseNotFound, *events.ErrOccurrenceNotFound:
 				resp.NotFound(w, r, resp.APIError(err, nil))
+			case *events.ErrOccurrenceRequired:
+				resp.BadRequest(w, r, resp.APIError(err, nil))
 			case *events.ErrServerError:
 				resp.ServerError(w, r, resp.APIError(err, nil))
 			default:
@@ -10,7 +10,7 @@ import (
 	"github.com/joinimpact/api/pkg/resp"
 )
 
-// ResponsesGet gets all responses for a single event.
+// ResponsesGet gets all responses for a single event, or for the occurrence of a recurring event in the query.
 func ResponsesGet(eventsService events.Service, usersService users.Service) http.HandlerFunc {
 	type responseObject struct {
 		models.EventResponse
@@ -27,11 +27,18 @@ func ResponsesGet(eventsService events.Service, usersService users.Service) http
 			return
 		}
 
-		responses, err := eventsService.GetEventResponses(ctx, eventID)
+		occurrence, ok := occurrenceParam(w, r)
+		if !ok {
+			return
+		}
+
+		responses, err := eventsService.GetEventResponses(ctx, eventID, occurrence)
 		if err != nil {
 			switch err.(type) {
-			case *events.ErrEventNotFound, *events.ErrResponseNotFound:
+			case *events.ErrEventNotFound, *events.ErrResponseNotFound, *events.ErrOccurrenceNotFound:
 				resp.NotFound(w, r, resp.APIError(err, nil))
+			case *events.ErrOccurrenceRequired:
+				resp.BadRequest(w, r, resp.APIError(err, nil))
 			case *events.ErrServerError:
 				resp.ServerError(w, r, resp.APIError(err, nil))
 			default:
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/joinimpact/api/internal/core/middleware/auth"
+	"github.com/joinimpact/api/internal/core/middleware/permissions"
 	"github.com/joinimpact/api/internal/events"
 	"github.com/joinimpact/api/internal/models"
 	"github.com/joinimpact/api/internal/opportunities"
@@ -67,3 +68,35 @@ func ScopeProviderEvents(eventsService events.Service, organizationsService orga
 		return scopes.NoChange
 	}
 }
+
+// PermissionProviderEvents provides the named permissions of a user id in the organization of an event.
+func PermissionProviderEvents(eventsService events.Service, organizationsService organizations.Service, opportunitiesService opportunities.Service) permissions.Function {
+	return func(ctx context.Context) []string {
+		userID, ok := ctx.Value(auth.KeyUserID).(int64)
+		if !ok {
+			return nil
+		}
+
+		eventID, err := idctx.GetFromContext(ctx, "eventID")
+		if err != nil {
+			return nil
+		}
+
+		event, err := eventsService.GetMinimalEvent(ctx, eventID)
+		if err != nil {
+			return nil
+		}
+
+		opportunity, err := opportunitiesService.GetMinimalOpportunity(ctx, event.OpportunityID)
+		if err != nil {
+			return nil
+		}
+
+		names, err := organizationsService.GetOrganizationPermissions(ctx, opportunity.OrganizationID, userID)
+		if err != nil {
+			return nil
+		}
+
+		return names
+	}
+}
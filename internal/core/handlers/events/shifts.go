@@ -0,0 +1,322 @@
+package events
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/joinimpact/api/internal/core/middleware/auth"
+	"github.com/joinimpact/api/internal/events"
+	"github.com/joinimpact/api/internal/models"
+	"github.com/joinimpact/api/internal/users"
+	"github.com/joinimpact/api/pkg/idctx"
+	"github.com/joinimpact/api/pkg/parse"
+	"github.com/joinimpact/api/pkg/resp"
+)
+
+// shiftRequest represents the input to create or update a shift.
+type shiftRequest struct {
+	Title         string    `json:"title" validate:"min=1,max=128"`
+	FromDate      time.Time `json:"from" validate:"required"`
+	ToDate        time.Time `json:"to" validate:"required"`
+	Capacity      int       `json:"capacity" validate:"min=1,max=1000"`
+	RequiredTagID *int64    `json:"requiredTagId"`
+}
+
+// toShift converts a shiftRequest to a models.EventShift.
+func (req shiftRequest) toShift() models.EventShift {
+	return models.EventShift{
+		Title:         req.Title,
+		FromDate:      req.FromDate,
+		ToDate:        req.ToDate,
+		Capacity:      req.Capacity,
+		RequiredTagID: req.RequiredTagID,
+	}
+}
+
+// shiftError writes the response for an error returned by a shift method of the events service.
+func shiftError(w http.ResponseWriter, r *http.Request, err error) {
+	switch err.(type) {
+	case *events.ErrEventNotFound, *events.ErrShiftNotFound, *events.ErrSignupNotFound:
+		resp.NotFound(w, r, resp.APIError(err, nil))
+	case *events.ErrInvalidShift, *events.ErrShiftCapacityTooLow, *events.ErrShiftFull, *events.ErrAlreadySignedUp:
+		resp.BadRequest(w, r, resp.APIError(err, nil))
+	case *events.ErrNotVolunteer, *events.ErrMissingRequiredTag:
+		resp.Forbidden(w, r, resp.APIError(err, nil))
+	case *events.ErrServerError:
+		resp.ServerError(w, r, resp.APIError(err, nil))
+	default:
+		resp.ServerError(w, r, resp.UnknownError)
+	}
+}
+
+// ShiftsGet gets all shifts of an event.
+func ShiftsGet(eventsService events.Service) http.HandlerFunc {
+	type response struct {
+		Shifts []events.ShiftView `json:"shifts"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userID, ok := ctx.Value(auth.KeyUserID).(int64)
+		if !ok {
+			resp.ServerError(w, r, resp.UnknownError)
+			return
+		}
+
+		eventID, err := idctx.Get(r, "eventID")
+		if err != nil {
+			return
+		}
+
+		shifts, err := eventsService.GetEventShifts(ctx, eventID, userID)
+		if err != nil {
+			shiftError(w, r, err)
+			return
+		}
+
+		resp.OK(w, r, response{shifts})
+	}
+}
+
+// ShiftsPost adds a shift to an event.
+func ShiftsPost(eventsService events.Service) http.HandlerFunc {
+	type response struct {
+		ShiftID int64 `json:"shiftId"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		eventID, err := idctx.Get(r, "eventID")
+		if err != nil {
+			return
+		}
+
+		req := shiftRequest{}
+		err = parse.POST(w, r, &req)
+		if err != nil {
+			return
+		}
+
+		id, err := eventsService.CreateEventShift(ctx, eventID, req.toShift())
+		if err != nil {
+			shiftError(w, r, err)
+			return
+		}
+
+		resp.OK(w, r, response{id})
+	}
+}
+
+// ShiftPut updates a shift of an event.
+func ShiftPut(eventsService events.Service) http.HandlerFunc {
+	type response struct {
+		Success bool `json:"success"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		eventID, err := idctx.Get(r, "eventID")
+		if err != nil {
+			return
+		}
+
+		shiftID, err := idctx.Get(r, "shiftID")
+		if err != nil {
+			return
+		}
+
+		req := shiftRequest{}
+		err = parse.POST(w, r, &req)
+		if err != nil {
+			return
+		}
+
+		shift := req.toShift()
+		shift.ID = shiftID
+
+		if err := eventsService.UpdateEventShift(ctx, eventID, shift); err != nil {
+			shiftError(w, r, err)
+			return
+		}
+
+		resp.OK(w, r, response{true})
+	}
+}
+
+// ShiftDelete deletes a shift of an event.
+func ShiftDelete(eventsService events.Service) http.HandlerFunc {
+	type response struct {
+		Success bool `json:"success"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		eventID, err := idctx.Get(r, "eventID")
+		if err != nil {
+			return
+		}
+
+		shiftID, err := idctx.Get(r, "shiftID")
+		if err != nil {
+			return
+		}
+
+		if err := eventsService.DeleteEventShift(ctx, eventID, shiftID); err != nil {
+			shiftError(w, r, err)
+			return
+		}
+
+		resp.OK(w, r, response{true})
+	}
+}
+
+// ShiftSignupPost signs the current user up for a shift.
+func ShiftSignupPost(eventsService events.Service) http.HandlerFunc {
+	type response struct {
+		Success bool `json:"success"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userID, ok := ctx.Value(auth.KeyUserID).(int64)
+		if !ok {
+			resp.ServerError(w, r, resp.UnknownError)
+			return
+		}
+
+		eventID, err := idctx.Get(r, "eventID")
+		if err != nil {
+			return
+		}
+
+		shiftID, err := idctx.Get(r, "shiftID")
+		if err != nil {
+			return
+		}
+
+		if err := eventsService.SignUpForShift(ctx, eventID, shiftID, userID); err != nil {
+			shiftError(w, r, err)
+			return
+		}
+
+		resp.OK(w, r, response{true})
+	}
+}
+
+// ShiftSignupDelete removes the current user from a shift.
+func ShiftSignupDelete(eventsService events.Service) http.HandlerFunc {
+	type response struct {
+		Success bool `json:"success"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userID, ok := ctx.Value(auth.KeyUserID).(int64)
+		if !ok {
+			resp.ServerError(w, r, resp.UnknownError)
+			return
+		}
+
+		eventID, err := idctx.Get(r, "eventID")
+		if err != nil {
+			return
+		}
+
+		shiftID, err := idctx.Get(r, "shiftID")
+		if err != nil {
+			return
+		}
+
+		if err := eventsService.DropShift(ctx, eventID, shiftID, userID); err != nil {
+			shiftError(w, r, err)
+			return
+		}
+
+		resp.OK(w, r, response{true})
+	}
+}
+
+// ShiftSwapPost moves the current user from a shift to another shift of the same event.
+func ShiftSwapPost(eventsService events.Service) http.HandlerFunc {
+	type request struct {
+		ShiftID int64 `json:"shiftId" validate:"required"`
+	}
+	type response struct {
+		Success bool `json:"success"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userID, ok := ctx.Value(auth.KeyUserID).(int64)
+		if !ok {
+			resp.ServerError(w, r, resp.UnknownError)
+			return
+		}
+
+		eventID, err := idctx.Get(r, "eventID")
+		if err != nil {
+			return
+		}
+
+		shiftID, err := idctx.Get(r, "shiftID")
+		if err != nil {
+			return
+		}
+
+		req := request{}
+		err = parse.POST(w, r, &req)
+		if err != nil {
+			return
+		}
+
+		if err := eventsService.SwapShift(ctx, eventID, shiftID, req.ShiftID, userID); err != nil {
+			shiftError(w, r, err)
+			return
+		}
+
+		resp.OK(w, r, response{true})
+	}
+}
+
+// ShiftRosterGet gets the volunteers signed up for a shift.
+func ShiftRosterGet(eventsService events.Service, usersService users.Service) http.HandlerFunc {
+	type rosterEntry struct {
+		models.EventShiftSignup
+		users.UserProfile
+	}
+	type response struct {
+		Roster []rosterEntry `json:"roster"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		eventID, err := idctx.Get(r, "eventID")
+		if err != nil {
+			return
+		}
+
+		shiftID, err := idctx.Get(r, "shiftID")
+		if err != nil {
+			return
+		}
+
+		signups, err := eventsService.GetShiftRoster(ctx, eventID, shiftID)
+		if err != nil {
+			shiftError(w, r, err)
+			return
+		}
+
+		roster := []rosterEntry{}
+		for _, signup := range signups {
+			profile, err := usersService.GetMinimalUserProfile(signup.UserID)
+			if err != nil {
+				// The volunteer's account was deactivated.
+				continue
+			}
+
+			roster = append(roster, rosterEntry{signup, *profile})
+		}
+
+		resp.OK(w, r, response{roster})
+	}
+}
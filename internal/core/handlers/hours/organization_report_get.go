@@ -0,0 +1,48 @@
+package hours
+
+import (
+	"net/http"
+
+	"github.com/joinimpact/api/internal/hours"
+	"github.com/joinimpact/api/internal/organizations"
+	"github.com/joinimpact/api/pkg/idctx"
+	"github.com/joinimpact/api/pkg/resp"
+)
+
+// OrganizationReportGet sums up the hours of an organization, including the hours of its chapters.
+func OrganizationReportGet(hoursService hours.Service, organizationsService organizations.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		organizationID, err := idctx.Get(r, "organizationID")
+		if err != nil {
+			return
+		}
+
+		organizationIDs, err := organizationsService.GetOrganizationTreeIDs(ctx, organizationID)
+		if err != nil {
+			switch err.(type) {
+			case *organizations.ErrOrganizationNotFound:
+				resp.NotFound(w, r, resp.APIError(err, nil))
+			case *organizations.ErrServerError:
+				resp.ServerError(w, r, resp.APIError(err, nil))
+			default:
+				resp.ServerError(w, r, resp.UnknownError)
+			}
+			return
+		}
+
+		report, err := hoursService.GetOrganizationsReport(ctx, organizationIDs)
+		if err != nil {
+			switch err.(type) {
+			case *hours.ErrServerError:
+				resp.ServerError(w, r, resp.APIError(err, nil))
+			default:
+				resp.ServerError(w, r, resp.UnknownError)
+			}
+			return
+		}
+
+		resp.OK(w, r, report)
+	}
+}
@@ -21,6 +21,7 @@ func OrganizationRequestAcceptPost(hoursService hours.Service, conversationsServ
 
 		userID, ok := ctx.Value(auth.KeyUserID).(int64)
 		if !ok {
+			resp.ServerError(w, r, resp.UnknownError)
 			return
 		}
 
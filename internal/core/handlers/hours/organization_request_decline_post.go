@@ -21,6 +21,7 @@ func OrganizationRequestDeclinePost(hoursService hours.Service, conversationsSer
 
 		userID, ok := ctx.Value(auth.KeyUserID).(int64)
 		if !ok {
+			resp.ServerError(w, r, resp.UnknownError)
 			return
 		}
 
@@ -0,0 +1,122 @@
+package opportunities
+
+import (
+	"net/http"
+
+	"github.com/joinimpact/api/internal/core/middleware/auth"
+	"github.com/joinimpact/api/internal/models"
+	"github.com/joinimpact/api/internal/opportunities"
+	"github.com/joinimpact/api/pkg/idctx"
+	"github.com/joinimpact/api/pkg/parse"
+	"github.com/joinimpact/api/pkg/resp"
+)
+
+// applicationFormError writes the response for an error returned by an application form method of the
+// opportunities service.
+func applicationFormError(w http.ResponseWriter, r *http.Request, err error) {
+	switch err.(type) {
+	case *opportunities.ErrOpportunityNotFound:
+		resp.NotFound(w, r, resp.APIError(err, nil))
+	case *opportunities.ErrInvalidApplicationForm, *opportunities.ErrInvalidApplicationFile:
+		resp.BadRequest(w, r, resp.APIError(err, nil))
+	case *opportunities.ErrServerError:
+		resp.ServerError(w, r, resp.APIError(err, nil))
+	default:
+		resp.ServerError(w, r, resp.UnknownError)
+	}
+}
+
+// ApplicationFormGet gets the fields of an opportunity's application form.
+func ApplicationFormGet(opportunitiesService opportunities.Service) http.HandlerFunc {
+	type response struct {
+		Fields []models.OpportunityApplicationField `json:"fields"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		opportunityID, err := idctx.Get(r, "opportunityID")
+		if err != nil {
+			return
+		}
+
+		fields, err := opportunitiesService.GetApplicationForm(ctx, opportunityID)
+		if err != nil {
+			applicationFormError(w, r, err)
+			return
+		}
+
+		resp.OK(w, r, response{fields})
+	}
+}
+
+// ApplicationFormPut replaces the fields of an opportunity's application form.
+func ApplicationFormPut(opportunitiesService opportunities.Service) http.HandlerFunc {
+	type request struct {
+		Fields []models.OpportunityApplicationField `json:"fields"`
+	}
+	type response struct {
+		Fields []models.OpportunityApplicationField `json:"fields"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		opportunityID, err := idctx.Get(r, "opportunityID")
+		if err != nil {
+			return
+		}
+
+		req := request{}
+		err = parse.POST(w, r, &req)
+		if err != nil {
+			return
+		}
+
+		fields, err := opportunitiesService.UpdateApplicationForm(ctx, opportunityID, req.Fields)
+		if err != nil {
+			applicationFormError(w, r, err)
+			return
+		}
+
+		resp.OK(w, r, response{fields})
+	}
+}
+
+// ApplicationFilePost uploads a file to attach to an application, and returns the key to submit as the
+// answer to a file field.
+func ApplicationFilePost(opportunitiesService opportunities.Service) http.HandlerFunc {
+	type response struct {
+		Key string `json:"key"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userID, ok := ctx.Value(auth.KeyUserID).(int64)
+		if !ok {
+			resp.ServerError(w, r, resp.UnknownError)
+			return
+		}
+
+		opportunityID, err := idctx.Get(r, "opportunityID")
+		if err != nil {
+			return
+		}
+
+		// Parse our multipart form, 10 << 20 specifies a maximum
+		// upload of 10 MB files.
+		r.ParseMultipartForm(10 << 20)
+		file, handler, err := r.FormFile("file")
+		if err != nil {
+			resp.BadRequest(w, r, resp.Error(400, "invalid file"))
+			return
+		}
+		defer file.Close()
+
+		key, err := opportunitiesService.UploadApplicationFile(ctx, opportunityID, userID, handler.Header.Get("Content-Type"), file)
+		if err != nil {
+			applicationFormError(w, r, err)
+			return
+		}
+
+		resp.OK(w, r, response{key})
+	}
+}
@@ -14,6 +14,7 @@ const (
 	VolunteerStatusCanApply       = 0
 	VolunteerStatusAlreadyApplied = 1
 	VolunteerStatusAlreadyMember  = 2
+	VolunteerStatusClosed         = 3
 )
 
 // StatusGet gets a user's status per opportunity.
@@ -44,7 +45,14 @@ func StatusGet(opportunitiesService opportunities.Service) http.HandlerFunc {
 		// Check if the user can apply.
 		err = opportunitiesService.CanRequestOpportunityMembership(ctx, opportunityID, userID)
 		if err != nil {
-			resp.OK(w, r, response{VolunteerStatusAlreadyApplied})
+			switch err.(type) {
+			case *opportunities.ErrOpportunityNotOpen:
+				resp.OK(w, r, response{VolunteerStatusClosed})
+			case *opportunities.ErrOpportunityNotFound:
+				resp.NotFound(w, r, resp.APIError(err, nil))
+			default:
+				resp.OK(w, r, response{VolunteerStatusAlreadyApplied})
+			}
 			return
 		}
 
@@ -46,8 +46,12 @@ func InviteAcceptPost(opportunitiesService opportunities.Service) http.HandlerFu
 		err = opportunitiesService.AcceptInvite(ctx, opportunityID, userID, inviteID, req.Key)
 		if err != nil {
 			switch err.(type) {
-			case *opportunities.ErrInviteInvalid:
+			case *opportunities.ErrInviteInvalid, *opportunities.ErrOpportunityNotFound:
 				resp.NotFound(w, r, resp.APIError(err, nil))
+			case *opportunities.ErrOrganizationNotVerified:
+				resp.Forbidden(w, r, resp.APIError(err, nil))
+			case *opportunities.ErrOpportunityFull:
+				resp.BadRequest(w, r, resp.APIError(err, nil))
 			case *opportunities.ErrServerError:
 				resp.ServerError(w, r, resp.APIError(err, nil))
 			default:
@@ -0,0 +1,56 @@
+package opportunities
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/joinimpact/api/internal/opportunities"
+	"github.com/joinimpact/api/pkg/idctx"
+	"github.com/joinimpact/api/pkg/parse"
+	"github.com/joinimpact/api/pkg/resp"
+)
+
+// LifecyclePut moves an opportunity to a new lifecycle status.
+func LifecyclePut(opportunitiesService opportunities.Service) http.HandlerFunc {
+	type request struct {
+		Status    string     `json:"status" validate:"oneof=draft scheduled open closed completed archived"`
+		PublishAt *time.Time `json:"publishAt"`
+		CloseAt   *time.Time `json:"closeAt"`
+	}
+	type response struct {
+		Success bool `json:"success"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		opportunityID, err := idctx.Get(r, "opportunityID")
+		if err != nil {
+			return
+		}
+
+		req := request{}
+		err = parse.POST(w, r, &req)
+		if err != nil {
+			return
+		}
+
+		err = opportunitiesService.SetOpportunityStatus(ctx, opportunityID, req.Status, req.PublishAt, req.CloseAt)
+		if err != nil {
+			switch err.(type) {
+			case *opportunities.ErrOpportunityNotPublishable:
+				resp.BadRequest(w, r, resp.ErrorInvalidFields(98, "missing or invalid fields", err.(*opportunities.ErrOpportunityNotPublishable).InvalidFields))
+			case *opportunities.ErrInvalidStatusTransition, *opportunities.ErrInvalidSchedule:
+				resp.BadRequest(w, r, resp.APIError(err, nil))
+			case *opportunities.ErrOpportunityNotFound:
+				resp.NotFound(w, r, resp.APIError(err, nil))
+			case *opportunities.ErrServerError:
+				resp.ServerError(w, r, resp.APIError(err, nil))
+			default:
+				resp.ServerError(w, r, resp.UnknownError)
+			}
+			return
+		}
+
+		resp.OK(w, r, response{true})
+	}
+}
@@ -14,7 +14,8 @@ import (
 // RequestPost creates an opportunity request for a user on the specified opportunity.
 func RequestPost(opportunitiesService opportunities.Service, conversationsService conversations.Service) http.HandlerFunc {
 	type request struct {
-		Message string `json:"message" validate:"omitempty,min=12,max=512"`
+		Message string                            `json:"message" validate:"omitempty,min=12,max=512"`
+		Answers []opportunities.ApplicationAnswer `json:"answers"`
 	}
 	type response struct {
 		Success        bool  `json:"success"`
@@ -54,13 +55,21 @@ func RequestPost(opportunitiesService opportunities.Service, conversationsServic
 		}
 
 		// Create the opportunity membership request.
-		requestID, err := opportunitiesService.RequestOpportunityMembership(ctx, opportunityID, userID)
+		requestID, err := opportunitiesService.RequestOpportunityMembership(ctx, opportunityID, userID, req.Answers)
 		if err != nil {
 			switch err.(type) {
 			case *opportunities.ErrOpportunityNotFound, *opportunities.ErrTagNotFound:
 				resp.NotFound(w, r, resp.Error(404, err.Error()))
 			case *opportunities.ErrMembershipAlreadyRequested:
 				resp.BadRequest(w, r, resp.Error(400, err.Error()))
+			case *opportunities.ErrOpportunityNotOpen:
+				resp.BadRequest(w, r, resp.APIError(err, nil))
+			case *opportunities.ErrInvalidApplicationAnswers:
+				resp.BadRequest(w, r, resp.APIError(err, map[string][]int64{
+					"fieldIds": err.(*opportunities.ErrInvalidApplicationAnswers).FieldIDs,
+				}))
+			case *opportunities.ErrEmailNotVerified:
+				resp.Forbidden(w, r, resp.APIError(err, nil))
 			case *opportunities.ErrServerError:
 				resp.ServerError(w, r, resp.Error(500, err.Error()))
 			default:
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/joinimpact/api/internal/core/middleware/auth"
+	"github.com/joinimpact/api/internal/core/middleware/permissions"
 	"github.com/joinimpact/api/internal/models"
 	"github.com/joinimpact/api/internal/opportunities"
 	"github.com/joinimpact/api/internal/organizations"
@@ -58,3 +59,30 @@ func ScopeProviderOpportunities(organizationsService organizations.Service, oppo
 		return scopes.NoChange
 	}
 }
+
+// PermissionProviderOpportunities provides the named permissions of a user id in the organization of an opportunity.
+func PermissionProviderOpportunities(organizationsService organizations.Service, opportunitiesService opportunities.Service) permissions.Function {
+	return func(ctx context.Context) []string {
+		userID, ok := ctx.Value(auth.KeyUserID).(int64)
+		if !ok {
+			return nil
+		}
+
+		opportunityID, err := idctx.GetFromContext(ctx, "opportunityID")
+		if err != nil {
+			return nil
+		}
+
+		opportunity, err := opportunitiesService.GetMinimalOpportunity(ctx, opportunityID)
+		if err != nil {
+			return nil
+		}
+
+		names, err := organizationsService.GetOrganizationPermissions(ctx, opportunity.OrganizationID, userID)
+		if err != nil {
+			return nil
+		}
+
+		return names
+	}
+}
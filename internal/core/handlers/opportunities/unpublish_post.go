@@ -28,6 +28,8 @@ func UnpublishPost(opportunitiesService opportunities.Service) http.HandlerFunc
 				resp.BadRequest(w, r, resp.ErrorInvalidFields(98, "missing or invalid fields", err.(*opportunities.ErrOpportunityNotPublishable).InvalidFields))
 			case *opportunities.ErrOpportunityNotFound, *opportunities.ErrTagNotFound:
 				resp.NotFound(w, r, resp.Error(404, err.Error()))
+			case *opportunities.ErrInvalidStatusTransition:
+				resp.BadRequest(w, r, resp.APIError(err, nil))
 			case *opportunities.ErrServerError:
 				resp.ServerError(w, r, resp.Error(500, err.Error()))
 			default:
@@ -40,6 +40,8 @@ func VolunteersAcceptPost(opportunitiesService opportunities.Service, conversati
 			switch err.(type) {
 			case *opportunities.ErrRequestNotFound:
 				resp.NotFound(w, r, resp.APIError(err, nil))
+			case *opportunities.ErrOpportunityFull:
+				resp.BadRequest(w, r, resp.APIError(err, nil))
 			case *opportunities.ErrServerError:
 				resp.ServerError(w, r, resp.APIError(err, nil))
 			default:
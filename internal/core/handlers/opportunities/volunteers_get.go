@@ -20,6 +20,7 @@ type OpportunityVolunteer struct {
 type OpportunityPendingVolunteer struct {
 	models.OpportunityMembershipRequest
 	users.UserProfile
+	Answers []models.OpportunityApplicationAnswer `json:"answers"` // the volunteer's answers to the application form
 }
 
 // OpportunityInvitedVolunteer represents a pending volunteer in an opportunity.
@@ -103,7 +104,18 @@ func VolunteersGet(opportunitiesService opportunities.Service, usersService user
 				return
 			}
 
-			pendingVolunteers = append(pendingVolunteers, OpportunityPendingVolunteer{membership, *profile})
+			answers, err := opportunitiesService.GetApplicationAnswers(ctx, membership.ID)
+			if err != nil {
+				switch err.(type) {
+				case *opportunities.ErrServerError:
+					resp.ServerError(w, r, resp.APIError(err, nil))
+				default:
+					resp.ServerError(w, r, resp.UnknownError)
+				}
+				return
+			}
+
+			pendingVolunteers = append(pendingVolunteers, OpportunityPendingVolunteer{membership, *profile, answers})
 		}
 
 		invites, err := opportunitiesService.GetOpportunityInvitedVolunteers(ctx, opportunityID)
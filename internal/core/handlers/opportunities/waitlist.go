@@ -0,0 +1,206 @@
+package opportunities
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/joinimpact/api/internal/conversations"
+	"github.com/joinimpact/api/internal/core/middleware/auth"
+	"github.com/joinimpact/api/internal/models"
+	"github.com/joinimpact/api/internal/opportunities"
+	"github.com/joinimpact/api/pkg/idctx"
+	"github.com/joinimpact/api/pkg/resp"
+)
+
+// waitlistError writes the response for an error returned by a waitlist method of the opportunities service.
+func waitlistError(w http.ResponseWriter, r *http.Request, err error) {
+	switch err.(type) {
+	case *opportunities.ErrMembershipNotFound, *opportunities.ErrWaitlistEntryNotFound:
+		resp.NotFound(w, r, resp.APIError(err, nil))
+	case *opportunities.ErrServerError:
+		resp.ServerError(w, r, resp.APIError(err, nil))
+	default:
+		resp.ServerError(w, r, resp.UnknownError)
+	}
+}
+
+// notifyWaitlistOffers sends a conversation message to each waitlisted volunteer who was offered a spot.
+// Volunteers without a conversation with the organization were already notified by email.
+func notifyWaitlistOffers(ctx context.Context, conversationsService conversations.Service, offers []models.OpportunityWaitlistEntry) {
+	for _, offer := range offers {
+		if offer.OfferExpiresAt == nil {
+			continue
+		}
+
+		conversationsService.SendWaitlistOfferMessage(ctx, offer.VolunteerID, offer.OpportunityID, *offer.OfferExpiresAt)
+	}
+}
+
+// LeavePost removes the current user from an opportunity they volunteer in.
+func LeavePost(opportunitiesService opportunities.Service, conversationsService conversations.Service) http.HandlerFunc {
+	type response struct {
+		Success bool `json:"success"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userID, ok := ctx.Value(auth.KeyUserID).(int64)
+		if !ok {
+			resp.ServerError(w, r, resp.UnknownError)
+			return
+		}
+
+		opportunityID, err := idctx.Get(r, "opportunityID")
+		if err != nil {
+			return
+		}
+
+		offers, err := opportunitiesService.RemoveVolunteer(ctx, opportunityID, userID)
+		if err != nil {
+			waitlistError(w, r, err)
+			return
+		}
+
+		notifyWaitlistOffers(ctx, conversationsService, offers)
+
+		resp.OK(w, r, response{true})
+	}
+}
+
+// VolunteerDelete removes a volunteer from an opportunity.
+func VolunteerDelete(opportunitiesService opportunities.Service, conversationsService conversations.Service) http.HandlerFunc {
+	type response struct {
+		Success bool `json:"success"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		opportunityID, err := idctx.Get(r, "opportunityID")
+		if err != nil {
+			return
+		}
+
+		volunteerID, err := idctx.Get(r, "userID")
+		if err != nil {
+			return
+		}
+
+		offers, err := opportunitiesService.RemoveVolunteer(ctx, opportunityID, volunteerID)
+		if err != nil {
+			waitlistError(w, r, err)
+			return
+		}
+
+		notifyWaitlistOffers(ctx, conversationsService, offers)
+
+		resp.OK(w, r, response{true})
+	}
+}
+
+// WaitlistGet gets the waiting and offered volunteers of an opportunity's waitlist, in order.
+func WaitlistGet(opportunitiesService opportunities.Service) http.HandlerFunc {
+	type response struct {
+		Waitlist []models.OpportunityWaitlistEntry `json:"waitlist"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		opportunityID, err := idctx.Get(r, "opportunityID")
+		if err != nil {
+			return
+		}
+
+		entries, err := opportunitiesService.GetWaitlist(ctx, opportunityID)
+		if err != nil {
+			waitlistError(w, r, err)
+			return
+		}
+
+		resp.OK(w, r, response{entries})
+	}
+}
+
+// WaitlistMeGet gets the current user's place on an opportunity's waitlist.
+func WaitlistMeGet(opportunitiesService opportunities.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userID, ok := ctx.Value(auth.KeyUserID).(int64)
+		if !ok {
+			resp.ServerError(w, r, resp.UnknownError)
+			return
+		}
+
+		opportunityID, err := idctx.Get(r, "opportunityID")
+		if err != nil {
+			return
+		}
+
+		position, err := opportunitiesService.GetWaitlistPosition(ctx, opportunityID, userID)
+		if err != nil {
+			waitlistError(w, r, err)
+			return
+		}
+
+		resp.OK(w, r, position)
+	}
+}
+
+// WaitlistAcceptPost claims the spot offered to the current user, which is held until a manager reviews their request.
+func WaitlistAcceptPost(opportunitiesService opportunities.Service) http.HandlerFunc {
+	type response struct {
+		Success bool `json:"success"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userID, ok := ctx.Value(auth.KeyUserID).(int64)
+		if !ok {
+			resp.ServerError(w, r, resp.UnknownError)
+			return
+		}
+
+		opportunityID, err := idctx.Get(r, "opportunityID")
+		if err != nil {
+			return
+		}
+
+		if err := opportunitiesService.AcceptWaitlistOffer(ctx, opportunityID, userID); err != nil {
+			waitlistError(w, r, err)
+			return
+		}
+
+		resp.OK(w, r, response{true})
+	}
+}
+
+// WaitlistDeclinePost turns down the spot offered to the current user, or takes them off the waitlist.
+func WaitlistDeclinePost(opportunitiesService opportunities.Service, conversationsService conversations.Service) http.HandlerFunc {
+	type response struct {
+		Success bool `json:"success"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userID, ok := ctx.Value(auth.KeyUserID).(int64)
+		if !ok {
+			resp.ServerError(w, r, resp.UnknownError)
+			return
+		}
+
+		opportunityID, err := idctx.Get(r, "opportunityID")
+		if err != nil {
+			return
+		}
+
+		offers, err := opportunitiesService.DeclineWaitlistOffer(ctx, opportunityID, userID)
+		if err != nil {
+			waitlistError(w, r, err)
+			return
+		}
+
+		notifyWaitlistOffers(ctx, conversationsService, offers)
+
+		resp.OK(w, r, response{true})
+	}
+}
@@ -0,0 +1,125 @@
+package organizations
+
+import (
+	"net/http"
+
+	"github.com/joinimpact/api/internal/core/middleware/auth"
+	"github.com/joinimpact/api/internal/organizations"
+	"github.com/joinimpact/api/pkg/idctx"
+	"github.com/joinimpact/api/pkg/parse"
+	"github.com/joinimpact/api/pkg/resp"
+	"github.com/joinimpact/api/pkg/scopes"
+)
+
+// APIKeysGet gets all API keys of an organization.
+func APIKeysGet(organizationsService organizations.Service) http.HandlerFunc {
+	type response struct {
+		APIKeys []organizations.APIKey `json:"apiKeys"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		organizationID, err := idctx.Get(r, "organizationID")
+		if err != nil {
+			return
+		}
+
+		apiKeys, err := organizationsService.GetAPIKeys(ctx, organizationID)
+		if err != nil {
+			switch err.(type) {
+			case *organizations.ErrServerError:
+				resp.ServerError(w, r, resp.APIError(err, nil))
+			default:
+				resp.ServerError(w, r, resp.UnknownError)
+			}
+			return
+		}
+
+		resp.OK(w, r, response{apiKeys})
+	}
+}
+
+// APIKeysPost creates a new API key for an organization. The key is only returned in this response.
+func APIKeysPost(organizationsService organizations.Service) http.HandlerFunc {
+	type request struct {
+		Name  string `json:"name" validate:"min=1,max=64"`
+		Scope string `json:"scope" validate:"oneof=manager admin"`
+	}
+	type response struct {
+		APIKey *organizations.APIKey `json:"apiKey"`
+		Key    string                `json:"key"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userID, ok := ctx.Value(auth.KeyUserID).(int64)
+		if !ok {
+			resp.ServerError(w, r, resp.UnknownError)
+			return
+		}
+
+		organizationID, err := idctx.Get(r, "organizationID")
+		if err != nil {
+			return
+		}
+
+		req := request{}
+		err = parse.POST(w, r, &req)
+		if err != nil {
+			return
+		}
+
+		scope, _ := scopes.ParseScope(req.Scope)
+		apiKey, key, err := organizationsService.CreateAPIKey(ctx, organizationID, userID, req.Name, scope)
+		if err != nil {
+			switch err.(type) {
+			case *organizations.ErrOrganizationNotFound:
+				resp.NotFound(w, r, resp.APIError(err, nil))
+			case *organizations.ErrInvalidAPIKeyName, *organizations.ErrInvalidAPIKeyScope:
+				resp.BadRequest(w, r, resp.APIError(err, nil))
+			case *organizations.ErrServerError:
+				resp.ServerError(w, r, resp.APIError(err, nil))
+			default:
+				resp.ServerError(w, r, resp.UnknownError)
+			}
+			return
+		}
+
+		resp.OK(w, r, response{apiKey, key})
+	}
+}
+
+// APIKeyDelete revokes an organization's API key by ID.
+func APIKeyDelete(organizationsService organizations.Service) http.HandlerFunc {
+	type response struct {
+		Success bool `json:"success"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		organizationID, err := idctx.Get(r, "organizationID")
+		if err != nil {
+			return
+		}
+
+		apiKeyID, err := idctx.Get(r, "apiKeyID")
+		if err != nil {
+			return
+		}
+
+		err = organizationsService.RevokeAPIKey(ctx, organizationID, apiKeyID)
+		if err != nil {
+			switch err.(type) {
+			case *organizations.ErrAPIKeyNotFound:
+				resp.NotFound(w, r, resp.APIError(err, nil))
+			case *organizations.ErrServerError:
+				resp.ServerError(w, r, resp.APIError(err, nil))
+			default:
+				resp.ServerError(w, r, resp.UnknownError)
+			}
+			return
+		}
+
+		resp.OK(w, r, response{true})
+	}
+}
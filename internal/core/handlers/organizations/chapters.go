@@ -0,0 +1,110 @@
+package organizations
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/joinimpact/api/internal/core/middleware/auth"
+	"github.com/joinimpact/api/internal/organizations"
+	"github.com/joinimpact/api/pkg/idctx"
+	"github.com/joinimpact/api/pkg/location"
+	"github.com/joinimpact/api/pkg/parse"
+	"github.com/joinimpact/api/pkg/resp"
+)
+
+// chapterError writes the response for an error returned by a chapter method of the organizations service.
+func chapterError(w http.ResponseWriter, r *http.Request, err error) {
+	switch err.(type) {
+	case *organizations.ErrOrganizationNotFound:
+		resp.NotFound(w, r, resp.APIError(err, nil))
+	case *organizations.ErrInvalidParentOrganization:
+		resp.BadRequest(w, r, resp.APIError(err, nil))
+	case *organizations.ErrServerError:
+		resp.ServerError(w, r, resp.APIError(err, nil))
+	default:
+		resp.ServerError(w, r, resp.UnknownError)
+	}
+}
+
+// ParentPut makes an organization a chapter of a parent organization. A parent ID of 0 removes the
+// organization from its parent.
+func ParentPut(organizationsService organizations.Service) http.HandlerFunc {
+	type request struct {
+		ParentID int64 `json:"parentId"`
+	}
+	type response struct {
+		Success bool `json:"success"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userID, ok := ctx.Value(auth.KeyUserID).(int64)
+		if !ok {
+			resp.ServerError(w, r, resp.UnknownError)
+			return
+		}
+
+		organizationID, err := idctx.Get(r, "organizationID")
+		if err != nil {
+			return
+		}
+
+		req := request{}
+		err = parse.POST(w, r, &req)
+		if err != nil {
+			return
+		}
+
+		err = organizationsService.SetParentOrganization(ctx, organizationID, req.ParentID, userID)
+		if err != nil {
+			chapterError(w, r, err)
+			return
+		}
+
+		resp.OK(w, r, response{true})
+	}
+}
+
+// ChaptersGet gets the chapters of an organization, sorted by distance from the lat and long query
+// parameters, or from the organization itself if they are not provided.
+func ChaptersGet(organizationsService organizations.Service) http.HandlerFunc {
+	type response struct {
+		Chapters []organizations.Chapter `json:"chapters"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		organizationID, err := idctx.Get(r, "organizationID")
+		if err != nil {
+			return
+		}
+
+		var from *location.Coordinates
+		if r.URL.Query().Get("lat") != "" || r.URL.Query().Get("long") != "" {
+			latitude, err := strconv.ParseFloat(r.URL.Query().Get("lat"), 64)
+			if err != nil {
+				resp.BadRequest(w, r, resp.Error(400, "invalid latitude"))
+				return
+			}
+
+			longitude, err := strconv.ParseFloat(r.URL.Query().Get("long"), 64)
+			if err != nil {
+				resp.BadRequest(w, r, resp.Error(400, "invalid longitude"))
+				return
+			}
+
+			from = &location.Coordinates{
+				Latitude:  latitude,
+				Longitude: longitude,
+			}
+		}
+
+		chapters, err := organizationsService.GetChapters(ctx, organizationID, from)
+		if err != nil {
+			chapterError(w, r, err)
+			return
+		}
+
+		resp.OK(w, r, response{chapters})
+	}
+}
@@ -235,6 +235,51 @@ func CreateOrganization(organizationsService organizations.Service) http.Handler
 	}
 }
 
+// UpdateOrganizationSecurity updates an organization's security settings.
+func UpdateOrganizationSecurity(organizationsService organizations.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userID, ok := ctx.Value(auth.KeyUserID).(int64)
+		if !ok {
+			resp.ServerError(w, r, resp.UnknownError)
+			return
+		}
+
+		organizationID, err := idctx.Get(r, "organizationID")
+		if err != nil {
+			return
+		}
+
+		req := struct {
+			RequireTwoFactor bool `json:"requireTwoFactor"`
+		}{}
+		err = parse.POST(w, r, &req)
+		if err != nil {
+			return
+		}
+
+		err = organizationsService.SetTwoFactorRequirement(ctx, organizationID, userID, req.RequireTwoFactor)
+		if err != nil {
+			switch err.(type) {
+			case *organizations.ErrOrganizationNotFound:
+				resp.NotFound(w, r, resp.APIError(err, nil))
+			case *organizations.ErrTwoFactorNotEnabled:
+				resp.Forbidden(w, r, resp.APIError(err, nil))
+			case *organizations.ErrServerError:
+				resp.ServerError(w, r, resp.APIError(err, nil))
+			default:
+				resp.ServerError(w, r, resp.UnknownError)
+			}
+			return
+		}
+
+		resp.OK(w, r, map[string]bool{
+			"success": true,
+		})
+	}
+}
+
 // DeleteOrganization deletes a single organization by ID.
 func DeleteOrganization(organizationsService organizations.Service) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -713,7 +758,7 @@ func InviteDeclinePost(organizationsService organizations.Service) http.HandlerF
 }
 
 // OrganizationVolunteersGet gets all volunteers in all opportunities inside an organization.
-func OrganizationVolunteersGet(opportunitiesService opportunities.Service, usersService users.Service) http.HandlerFunc {
+func OrganizationVolunteersGet(organizationsService organizations.Service, opportunitiesService opportunities.Service, usersService users.Service) http.HandlerFunc {
 	type OpportunitySummary struct {
 		ID    int64  `json:"id"`
 		Title string `json:"title"`
@@ -751,7 +796,21 @@ func OrganizationVolunteersGet(opportunitiesService opportunities.Service, users
 			return
 		}
 
-		memberships, err := opportunitiesService.GetOrganizationOpportunityVolunteers(ctx, organizationID)
+		// Volunteers of chapters are included in the volunteers of their parent organization.
+		organizationIDs, err := organizationsService.GetOrganizationTreeIDs(ctx, organizationID)
+		if err != nil {
+			switch err.(type) {
+			case *organizations.ErrOrganizationNotFound:
+				resp.NotFound(w, r, resp.APIError(err, nil))
+			case *organizations.ErrServerError:
+				resp.ServerError(w, r, resp.APIError(err, nil))
+			default:
+				resp.ServerError(w, r, resp.UnknownError)
+			}
+			return
+		}
+
+		memberships, err := opportunitiesService.GetOrganizationOpportunityVolunteers(ctx, organizationIDs)
 		if err != nil {
 			switch err.(type) {
 			case *opportunities.ErrRequestNotFound:
@@ -783,7 +842,7 @@ func OrganizationVolunteersGet(opportunitiesService opportunities.Service, users
 			volunteers = append(volunteers, OpportunityVolunteer{OpportunitySummary{membership.Opportunity.ID, membership.Opportunity.Title}, membership, *profile})
 		}
 
-		invited, err := opportunitiesService.GetOrganizationOpportunityInvitedVolunteers(ctx, organizationID)
+		invited, err := opportunitiesService.GetOrganizationOpportunityInvitedVolunteers(ctx, organizationIDs)
 		if err != nil {
 			switch err.(type) {
 			case *opportunities.ErrRequestNotFound:
@@ -819,7 +878,7 @@ func OrganizationVolunteersGet(opportunitiesService opportunities.Service, users
 			invitedVolunteers = append(invitedVolunteers, OpportunityInvitedVolunteer{OpportunitySummary{membership.Opportunity.ID, membership.Opportunity.Title}, true, membership, *profile})
 		}
 
-		pending, err := opportunitiesService.GetOrganizationOpportunityRequestedVolunteers(ctx, organizationID)
+		pending, err := opportunitiesService.GetOrganizationOpportunityRequestedVolunteers(ctx, organizationIDs)
 		if err != nil {
 			switch err.(type) {
 			case *opportunities.ErrRequestNotFound:
@@ -0,0 +1,242 @@
+package organizations
+
+import (
+	"net/http"
+
+	"github.com/joinimpact/api/internal/conversations"
+	"github.com/joinimpact/api/internal/core/middleware/auth"
+	"github.com/joinimpact/api/internal/organizations"
+	"github.com/joinimpact/api/pkg/idctx"
+	"github.com/joinimpact/api/pkg/parse"
+	"github.com/joinimpact/api/pkg/resp"
+)
+
+// memberError writes the response for an error returned by a member management method of the organizations service.
+func memberError(w http.ResponseWriter, r *http.Request, err error) {
+	switch err.(type) {
+	case *organizations.ErrOrganizationNotFound, *organizations.ErrMemberNotFound, *organizations.ErrTransferNotFound, *organizations.ErrUserNotFound:
+		resp.NotFound(w, r, resp.APIError(err, nil))
+	case *organizations.ErrInvalidPermissionsFlag, *organizations.ErrInvalidTransferRecipient, *organizations.ErrCreatorCannotLeave:
+		resp.BadRequest(w, r, resp.APIError(err, nil))
+	case *organizations.ErrCannotManageMember:
+		resp.Forbidden(w, r, resp.APIError(err, nil))
+	case *organizations.ErrServerError:
+		resp.ServerError(w, r, resp.APIError(err, nil))
+	default:
+		resp.ServerError(w, r, resp.UnknownError)
+	}
+}
+
+// MemberPatch changes the permissions flag of a member of an organization.
+func MemberPatch(organizationsService organizations.Service) http.HandlerFunc {
+	type request struct {
+		PermissionsFlag int `json:"permissionsFlag"`
+	}
+	type response struct {
+		Success bool `json:"success"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		actorID, ok := ctx.Value(auth.KeyUserID).(int64)
+		if !ok {
+			resp.ServerError(w, r, resp.UnknownError)
+			return
+		}
+
+		organizationID, err := idctx.Get(r, "organizationID")
+		if err != nil {
+			return
+		}
+
+		userID, err := idctx.Get(r, "userID")
+		if err != nil {
+			return
+		}
+
+		req := request{}
+		err = parse.POST(w, r, &req)
+		if err != nil {
+			return
+		}
+
+		err = organizationsService.UpdateMemberPermissions(ctx, organizationID, actorID, userID, req.PermissionsFlag)
+		if err != nil {
+			memberError(w, r, err)
+			return
+		}
+
+		resp.OK(w, r, response{true})
+	}
+}
+
+// MemberDelete removes a member from an organization and deactivates their conversation memberships.
+func MemberDelete(organizationsService organizations.Service, conversationsService conversations.Service) http.HandlerFunc {
+	type response struct {
+		Success bool `json:"success"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		actorID, ok := ctx.Value(auth.KeyUserID).(int64)
+		if !ok {
+			resp.ServerError(w, r, resp.UnknownError)
+			return
+		}
+
+		organizationID, err := idctx.Get(r, "organizationID")
+		if err != nil {
+			return
+		}
+
+		userID, err := idctx.Get(r, "userID")
+		if err != nil {
+			return
+		}
+
+		err = organizationsService.RemoveMember(ctx, organizationID, actorID, userID)
+		if err != nil {
+			memberError(w, r, err)
+			return
+		}
+
+		err = conversationsService.RemoveOrganizationMember(ctx, organizationID, userID)
+		if err != nil {
+			resp.ServerError(w, r, resp.APIError(err, nil))
+			return
+		}
+
+		resp.OK(w, r, response{true})
+	}
+}
+
+// LeavePost removes the authenticated user from an organization.
+func LeavePost(organizationsService organizations.Service, conversationsService conversations.Service) http.HandlerFunc {
+	type response struct {
+		Success bool `json:"success"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userID, ok := ctx.Value(auth.KeyUserID).(int64)
+		if !ok {
+			resp.ServerError(w, r, resp.UnknownError)
+			return
+		}
+
+		organizationID, err := idctx.Get(r, "organizationID")
+		if err != nil {
+			return
+		}
+
+		err = organizationsService.LeaveOrganization(ctx, organizationID, userID)
+		if err != nil {
+			memberError(w, r, err)
+			return
+		}
+
+		err = conversationsService.RemoveOrganizationMember(ctx, organizationID, userID)
+		if err != nil {
+			resp.ServerError(w, r, resp.APIError(err, nil))
+			return
+		}
+
+		resp.OK(w, r, response{true})
+	}
+}
+
+// TransferPost asks a member of an organization to take over ownership from its creator.
+func TransferPost(organizationsService organizations.Service) http.HandlerFunc {
+	type request struct {
+		UserID int64 `json:"userId" validate:"required"`
+	}
+	type response struct {
+		Success bool `json:"success"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userID, ok := ctx.Value(auth.KeyUserID).(int64)
+		if !ok {
+			resp.ServerError(w, r, resp.UnknownError)
+			return
+		}
+
+		organizationID, err := idctx.Get(r, "organizationID")
+		if err != nil {
+			return
+		}
+
+		req := request{}
+		err = parse.POST(w, r, &req)
+		if err != nil {
+			return
+		}
+
+		err = organizationsService.RequestOwnershipTransfer(ctx, organizationID, userID, req.UserID)
+		if err != nil {
+			memberError(w, r, err)
+			return
+		}
+
+		resp.OK(w, r, response{true})
+	}
+}
+
+// TransferAcceptPost accepts a pending ownership transfer as its recipient.
+func TransferAcceptPost(organizationsService organizations.Service) http.HandlerFunc {
+	type response struct {
+		Success bool `json:"success"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userID, ok := ctx.Value(auth.KeyUserID).(int64)
+		if !ok {
+			resp.ServerError(w, r, resp.UnknownError)
+			return
+		}
+
+		organizationID, err := idctx.Get(r, "organizationID")
+		if err != nil {
+			return
+		}
+
+		err = organizationsService.AcceptOwnershipTransfer(ctx, organizationID, userID)
+		if err != nil {
+			memberError(w, r, err)
+			return
+		}
+
+		resp.OK(w, r, response{true})
+	}
+}
+
+// TransferDeclinePost declines a pending ownership transfer as its recipient, or cancels it as its sender.
+func TransferDeclinePost(organizationsService organizations.Service) http.HandlerFunc {
+	type response struct {
+		Success bool `json:"success"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userID, ok := ctx.Value(auth.KeyUserID).(int64)
+		if !ok {
+			resp.ServerError(w, r, resp.UnknownError)
+			return
+		}
+
+		organizationID, err := idctx.Get(r, "organizationID")
+		if err != nil {
+			return
+		}
+
+		err = organizationsService.DeclineOwnershipTransfer(ctx, organizationID, userID)
+		if err != nil {
+			memberError(w, r, err)
+			return
+		}
+
+		resp.OK(w, r, response{true})
+	}
+}
@@ -0,0 +1,179 @@
+package organizations
+
+import (
+	"net/http"
+
+	"github.com/joinimpact/api/internal/models"
+	"github.com/joinimpact/api/internal/organizations"
+	"github.com/joinimpact/api/pkg/idctx"
+	"github.com/joinimpact/api/pkg/parse"
+	"github.com/joinimpact/api/pkg/resp"
+)
+
+// roleError writes the response for an error returned by a role method of the organizations service.
+func roleError(w http.ResponseWriter, r *http.Request, err error) {
+	switch err.(type) {
+	case *organizations.ErrOrganizationNotFound, *organizations.ErrRoleNotFound, *organizations.ErrMemberNotFound:
+		resp.NotFound(w, r, resp.APIError(err, nil))
+	case *organizations.ErrInvalidRoleName, *organizations.ErrInvalidPermission:
+		resp.BadRequest(w, r, resp.APIError(err, nil))
+	case *organizations.ErrServerError:
+		resp.ServerError(w, r, resp.APIError(err, nil))
+	default:
+		resp.ServerError(w, r, resp.UnknownError)
+	}
+}
+
+// RolesGet gets all custom roles of an organization, along with every permission a role can grant.
+func RolesGet(organizationsService organizations.Service) http.HandlerFunc {
+	type response struct {
+		Roles       []models.OrganizationRole `json:"roles"`
+		Permissions []string                  `json:"permissions"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		organizationID, err := idctx.Get(r, "organizationID")
+		if err != nil {
+			return
+		}
+
+		roles, err := organizationsService.GetRoles(ctx, organizationID)
+		if err != nil {
+			roleError(w, r, err)
+			return
+		}
+
+		resp.OK(w, r, response{roles, models.OrganizationPermissions})
+	}
+}
+
+// RolesPost creates a new role in an organization.
+func RolesPost(organizationsService organizations.Service) http.HandlerFunc {
+	type request struct {
+		Name        string   `json:"name" validate:"min=1,max=48"`
+		Permissions []string `json:"permissions"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		organizationID, err := idctx.Get(r, "organizationID")
+		if err != nil {
+			return
+		}
+
+		req := request{}
+		err = parse.POST(w, r, &req)
+		if err != nil {
+			return
+		}
+
+		role, err := organizationsService.CreateRole(ctx, organizationID, req.Name, req.Permissions)
+		if err != nil {
+			roleError(w, r, err)
+			return
+		}
+
+		resp.OK(w, r, role)
+	}
+}
+
+// RolePut replaces the name and permissions of an organization's role.
+func RolePut(organizationsService organizations.Service) http.HandlerFunc {
+	type request struct {
+		Name        string   `json:"name" validate:"min=1,max=48"`
+		Permissions []string `json:"permissions"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		organizationID, err := idctx.Get(r, "organizationID")
+		if err != nil {
+			return
+		}
+
+		roleID, err := idctx.Get(r, "roleID")
+		if err != nil {
+			return
+		}
+
+		req := request{}
+		err = parse.POST(w, r, &req)
+		if err != nil {
+			return
+		}
+
+		role, err := organizationsService.UpdateRole(ctx, organizationID, roleID, req.Name, req.Permissions)
+		if err != nil {
+			roleError(w, r, err)
+			return
+		}
+
+		resp.OK(w, r, role)
+	}
+}
+
+// RoleDelete deletes an organization's role.
+func RoleDelete(organizationsService organizations.Service) http.HandlerFunc {
+	type response struct {
+		Success bool `json:"success"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		organizationID, err := idctx.Get(r, "organizationID")
+		if err != nil {
+			return
+		}
+
+		roleID, err := idctx.Get(r, "roleID")
+		if err != nil {
+			return
+		}
+
+		err = organizationsService.DeleteRole(ctx, organizationID, roleID)
+		if err != nil {
+			roleError(w, r, err)
+			return
+		}
+
+		resp.OK(w, r, response{true})
+	}
+}
+
+// MemberRolePut sets the role of a member of an organization. A role ID of 0 removes the member's role.
+func MemberRolePut(organizationsService organizations.Service) http.HandlerFunc {
+	type request struct {
+		RoleID int64 `json:"roleId"`
+	}
+	type response struct {
+		Success bool `json:"success"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		organizationID, err := idctx.Get(r, "organizationID")
+		if err != nil {
+			return
+		}
+
+		userID, err := idctx.Get(r, "userID")
+		if err != nil {
+			return
+		}
+
+		req := request{}
+		err = parse.POST(w, r, &req)
+		if err != nil {
+			return
+		}
+
+		err = organizationsService.SetMemberRole(ctx, organizationID, userID, req.RoleID)
+		if err != nil {
+			roleError(w, r, err)
+			return
+		}
+
+		resp.OK(w, r, response{true})
+	}
+}
@@ -4,22 +4,32 @@ import (
 	"context"
 
 	"github.com/joinimpact/api/internal/core/middleware/auth"
+	"github.com/joinimpact/api/internal/core/middleware/permissions"
 	"github.com/joinimpact/api/internal/models"
 	"github.com/joinimpact/api/internal/organizations"
 	"github.com/joinimpact/api/pkg/idctx"
 	"github.com/joinimpact/api/pkg/scopes"
 )
 
-// ScopeProviderOrganizations provides a scope based on a user id and organization.
+// ScopeProviderOrganizations provides a scope based on a user id or API key and organization.
 func ScopeProviderOrganizations(organizationsService organizations.Service) scopes.ScopeFunction {
 	return func(ctx context.Context) scopes.Scope {
-		userID, ok := ctx.Value(auth.KeyUserID).(int64)
-		if !ok {
+		organizationID, err := idctx.GetFromContext(ctx, "organizationID")
+		if err != nil {
 			return scopes.NoChange
 		}
 
-		organizationID, err := idctx.GetFromContext(ctx, "organizationID")
-		if err != nil {
+		// API keys only grant access to their own organization.
+		if apiKey, ok := ctx.Value(auth.KeyOrganizationAPIKey).(*models.OrganizationAPIKey); ok {
+			if apiKey.OrganizationID != organizationID {
+				return scopes.NoChange
+			}
+
+			return apiKey.Scope
+		}
+
+		userID, ok := ctx.Value(auth.KeyUserID).(int64)
+		if !ok {
 			return scopes.NoChange
 		}
 
@@ -40,3 +50,33 @@ func ScopeProviderOrganizations(organizationsService organizations.Service) scop
 		return scopes.NoChange
 	}
 }
+
+// PermissionProviderOrganizations provides the named permissions of a user id or API key in an organization.
+func PermissionProviderOrganizations(organizationsService organizations.Service) permissions.Function {
+	return func(ctx context.Context) []string {
+		organizationID, err := idctx.GetFromContext(ctx, "organizationID")
+		if err != nil {
+			return nil
+		}
+
+		if apiKey, ok := ctx.Value(auth.KeyOrganizationAPIKey).(*models.OrganizationAPIKey); ok {
+			if apiKey.OrganizationID != organizationID {
+				return nil
+			}
+
+			return models.PermissionsForScope(apiKey.Scope)
+		}
+
+		userID, ok := ctx.Value(auth.KeyUserID).(int64)
+		if !ok {
+			return nil
+		}
+
+		names, err := organizationsService.GetOrganizationPermissions(ctx, organizationID, userID)
+		if err != nil {
+			return nil
+		}
+
+		return names
+	}
+}
@@ -0,0 +1,93 @@
+package organizations
+
+import (
+	"net/http"
+
+	"github.com/joinimpact/api/internal/core/middleware/auth"
+	"github.com/joinimpact/api/internal/organizations"
+	"github.com/joinimpact/api/pkg/idctx"
+	"github.com/joinimpact/api/pkg/resp"
+)
+
+// verificationError writes the response for an error returned by a verification method of the organizations service.
+func verificationError(w http.ResponseWriter, r *http.Request, err error) {
+	switch err.(type) {
+	case *organizations.ErrOrganizationNotFound, *organizations.ErrVerificationNotFound:
+		resp.NotFound(w, r, resp.APIError(err, nil))
+	case *organizations.ErrVerificationAlreadySubmitted, *organizations.ErrInvalidRegistrationNumber, *organizations.ErrInvalidVerificationDocuments, *organizations.ErrVerificationNotPending:
+		resp.BadRequest(w, r, resp.APIError(err, nil))
+	case *organizations.ErrServerError:
+		resp.ServerError(w, r, resp.APIError(err, nil))
+	default:
+		resp.ServerError(w, r, resp.UnknownError)
+	}
+}
+
+// VerificationGet gets the latest verification request of an organization.
+func VerificationGet(organizationsService organizations.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		organizationID, err := idctx.Get(r, "organizationID")
+		if err != nil {
+			return
+		}
+
+		verification, err := organizationsService.GetVerification(ctx, organizationID)
+		if err != nil {
+			verificationError(w, r, err)
+			return
+		}
+
+		resp.OK(w, r, verification)
+	}
+}
+
+// VerificationPost submits a verification request for an organization. The request is a multipart
+// form with a registrationNumber field and one or more documents files.
+func VerificationPost(organizationsService organizations.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userID, ok := ctx.Value(auth.KeyUserID).(int64)
+		if !ok {
+			resp.ServerError(w, r, resp.UnknownError)
+			return
+		}
+
+		organizationID, err := idctx.Get(r, "organizationID")
+		if err != nil {
+			return
+		}
+
+		// Parse our multipart form, 25 << 20 specifies a maximum
+		// upload of 25 MB of documents.
+		if err := r.ParseMultipartForm(25 << 20); err != nil {
+			resp.BadRequest(w, r, resp.Error(400, "invalid form"))
+			return
+		}
+
+		documents := []organizations.VerificationDocument{}
+		for _, header := range r.MultipartForm.File["documents"] {
+			file, err := header.Open()
+			if err != nil {
+				resp.BadRequest(w, r, resp.Error(400, "invalid file"))
+				return
+			}
+			defer file.Close()
+
+			documents = append(documents, organizations.VerificationDocument{
+				ContentType: header.Header.Get("Content-Type"),
+				Reader:      file,
+			})
+		}
+
+		verification, err := organizationsService.SubmitVerification(ctx, organizationID, userID, r.FormValue("registrationNumber"), documents)
+		if err != nil {
+			verificationError(w, r, err)
+			return
+		}
+
+		resp.OK(w, r, verification)
+	}
+}
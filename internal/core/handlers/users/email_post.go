@@ -0,0 +1,55 @@
+package users
+
+import (
+	"net/http"
+
+	"github.com/joinimpact/api/internal/authentication"
+	"github.com/joinimpact/api/pkg/idctx"
+	"github.com/joinimpact/api/pkg/parse"
+	"github.com/joinimpact/api/pkg/resp"
+)
+
+// EmailPost requests a change of a user's email. The change is applied once
+// it is confirmed from the new email.
+func EmailPost(authService authentication.Service) http.HandlerFunc {
+	type request struct {
+		Email    string `json:"email" validate:"email"`
+		Password string `json:"password" validate:"max=512"`
+	}
+	type response struct {
+		Success bool `json:"success"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userID, err := idctx.Get(r, "userID")
+		if err != nil {
+			return
+		}
+
+		req := request{}
+		err = parse.POST(w, r, &req)
+		if err != nil {
+			return
+		}
+
+		err = authService.RequestEmailChange(ctx, userID, req.Email, req.Password)
+		if err != nil {
+			switch err.(type) {
+			case *authentication.ErrUserNotFound:
+				resp.NotFound(w, r, resp.APIError(err, nil))
+			case *authentication.ErrInvalidPassword:
+				resp.Forbidden(w, r, resp.APIError(err, nil))
+			case *authentication.ErrInvalidEmail, *authentication.ErrEmailTaken:
+				resp.BadRequest(w, r, resp.APIError(err, nil))
+			case *authentication.ErrServerError:
+				resp.ServerError(w, r, resp.APIError(err, nil))
+			default:
+				resp.ServerError(w, r, resp.UnknownError)
+			}
+			return
+		}
+
+		resp.OK(w, r, response{true})
+	}
+}
@@ -0,0 +1,43 @@
+package users
+
+import (
+	"net/http"
+
+	"github.com/joinimpact/api/internal/exports"
+	"github.com/joinimpact/api/internal/models"
+	"github.com/joinimpact/api/pkg/idctx"
+	"github.com/joinimpact/api/pkg/resp"
+)
+
+// ExportPost starts building an archive of a user's personal data. The user
+// is emailed a download link once it is ready.
+func ExportPost(exportsService exports.Service) http.HandlerFunc {
+	type response struct {
+		Export *models.DataExport `json:"export"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userID, err := idctx.Get(r, "userID")
+		if err != nil {
+			return
+		}
+
+		dataExport, err := exportsService.RequestExport(ctx, userID)
+		if err != nil {
+			switch err.(type) {
+			case *exports.ErrUserNotFound:
+				resp.NotFound(w, r, resp.APIError(err, nil))
+			case *exports.ErrExportRecentlyRequested:
+				resp.TooManyRequests(w, r, resp.APIError(err, nil))
+			case *exports.ErrServerError:
+				resp.ServerError(w, r, resp.APIError(err, nil))
+			default:
+				resp.ServerError(w, r, resp.UnknownError)
+			}
+			return
+		}
+
+		resp.OK(w, r, response{dataExport})
+	}
+}
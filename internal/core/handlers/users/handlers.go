@@ -8,7 +8,8 @@ import (
 	"github.com/joinimpact/api/pkg/resp"
 )
 
-// DeletePost deletes a user by ID.
+// DeletePost schedules a user for deletion by ID. The user can cancel the
+// deletion by logging in again during the grace period.
 func DeletePost(usersService users.Service) http.HandlerFunc {
 	type response struct {
 		Success bool `json:"success"`
@@ -26,6 +27,8 @@ func DeletePost(usersService users.Service) http.HandlerFunc {
 			switch err.(type) {
 			case *users.ErrUserNotFound:
 				resp.NotFound(w, r, resp.Error(404, err.Error()))
+			case *users.ErrUserOwnsOrganization:
+				resp.BadRequest(w, r, resp.Error(400, err.Error()))
 			case *users.ErrServerError:
 				resp.ServerError(w, r, resp.Error(500, err.Error()))
 			default:
@@ -17,8 +17,9 @@ import (
 // userRequestContext contains contextual information about a user needed
 // for the /users routes.
 type userRequestContext struct {
-	userID int64
-	isSelf bool
+	userID   int64
+	viewerID int64
+	isSelf   bool
 }
 
 type key int
@@ -69,8 +70,9 @@ func Middleware(authService authentication.Service) func(next http.Handler) http
 			}
 
 			ctx = context.WithValue(ctx, keyUserRequestContext, &userRequestContext{
-				userID: queryUserID,
-				isSelf: queryUserID == userID,
+				userID:   queryUserID,
+				viewerID: userID,
+				isSelf:   queryUserID == userID,
 			})
 
 			// Inject the user ID into the idctx.
@@ -0,0 +1,58 @@
+package users
+
+import (
+	"net/http"
+
+	"github.com/joinimpact/api/internal/authentication"
+	"github.com/joinimpact/api/internal/core/middleware/auth"
+	"github.com/joinimpact/api/pkg/idctx"
+	"github.com/joinimpact/api/pkg/parse"
+	"github.com/joinimpact/api/pkg/resp"
+)
+
+// PasswordPut changes a user's password after checking their current
+// password, and logs out all of their other sessions.
+func PasswordPut(authService authentication.Service) http.HandlerFunc {
+	type request struct {
+		CurrentPassword string `json:"currentPassword" validate:"min=1,max=512"`
+		NewPassword     string `json:"newPassword" validate:"min=8,max=512"`
+	}
+	type response struct {
+		Success bool `json:"success"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userID, err := idctx.Get(r, "userID")
+		if err != nil {
+			return
+		}
+
+		req := request{}
+		err = parse.POST(w, r, &req)
+		if err != nil {
+			return
+		}
+
+		sessionID, _ := ctx.Value(auth.KeySessionID).(int64)
+
+		err = authService.ChangePassword(ctx, userID, sessionID, req.CurrentPassword, req.NewPassword)
+		if err != nil {
+			switch err.(type) {
+			case *authentication.ErrUserNotFound:
+				resp.NotFound(w, r, resp.APIError(err, nil))
+			case *authentication.ErrInvalidPassword:
+				resp.Forbidden(w, r, resp.APIError(err, nil))
+			case *authentication.ErrWeakPassword:
+				resp.BadRequest(w, r, resp.APIError(err, nil))
+			case *authentication.ErrServerError:
+				resp.ServerError(w, r, resp.APIError(err, nil))
+			default:
+				resp.ServerError(w, r, resp.UnknownError)
+			}
+			return
+		}
+
+		resp.OK(w, r, response{true})
+	}
+}
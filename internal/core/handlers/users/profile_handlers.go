@@ -16,6 +16,7 @@ import (
 	"github.com/joinimpact/api/pkg/location"
 	"github.com/joinimpact/api/pkg/parse"
 	"github.com/joinimpact/api/pkg/resp"
+	"github.com/joinimpact/api/pkg/scopes"
 	"github.com/oliamb/cutter"
 )
 
@@ -29,7 +30,7 @@ func GetUserProfile(usersService users.Service) http.HandlerFunc {
 			return
 		}
 
-		profile, err := usersService.GetUserProfile(reqCtx.userID, reqCtx.isSelf)
+		profile, err := usersService.GetUserProfile(reqCtx.userID, scopes.ScopeFromContext(ctx))
 		if err != nil {
 			switch err.(type) {
 			case *users.ErrUserNotFound:
@@ -113,6 +114,8 @@ func UpdateUserProfile(usersService users.Service) http.HandlerFunc {
 				switch err.(type) {
 				case *users.ErrUserNotFound:
 					resp.NotFound(w, r, resp.Error(404, err.Error()))
+				case *users.ErrInvalidPrivacy:
+					resp.BadRequest(w, r, resp.Error(400, err.Error()))
 				case *users.ErrServerError:
 					resp.ServerError(w, r, resp.Error(500, err.Error()))
 				default:
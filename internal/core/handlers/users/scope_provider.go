@@ -3,11 +3,12 @@ package users
 import (
 	"context"
 
+	"github.com/joinimpact/api/internal/users"
 	"github.com/joinimpact/api/pkg/scopes"
 )
 
 // ScopeProviderUsers provides a scope provider function for the users API.
-func ScopeProviderUsers() scopes.ScopeFunction {
+func ScopeProviderUsers(usersService users.Service) scopes.ScopeFunction {
 	return func(ctx context.Context) scopes.Scope {
 		reqCtx, ok := ctx.Value(keyUserRequestContext).(*userRequestContext)
 		if !ok {
@@ -18,6 +19,11 @@ func ScopeProviderUsers() scopes.ScopeFunction {
 			return scopes.ScopeOwner
 		}
 
-		return scopes.NoChange
+		scope, err := usersService.GetViewerScope(ctx, reqCtx.userID, reqCtx.viewerID)
+		if err != nil {
+			return scopes.NoChange
+		}
+
+		return scope
 	}
 }
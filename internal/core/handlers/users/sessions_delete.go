@@ -0,0 +1,44 @@
+package users
+
+import (
+	"net/http"
+
+	"github.com/joinimpact/api/internal/authentication"
+	"github.com/joinimpact/api/pkg/idctx"
+	"github.com/joinimpact/api/pkg/resp"
+)
+
+// SessionsDelete revokes one of a user's sessions by ID.
+func SessionsDelete(authService authentication.Service) http.HandlerFunc {
+	type response struct {
+		Success bool `json:"success"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userID, err := idctx.Get(r, "userID")
+		if err != nil {
+			return
+		}
+
+		sessionID, err := idctx.Get(r, "sessionID")
+		if err != nil {
+			return
+		}
+
+		err = authService.RevokeSession(ctx, userID, sessionID)
+		if err != nil {
+			switch err.(type) {
+			case *authentication.ErrSessionNotFound:
+				resp.NotFound(w, r, resp.APIError(err, nil))
+			case *authentication.ErrServerError:
+				resp.ServerError(w, r, resp.APIError(err, nil))
+			default:
+				resp.ServerError(w, r, resp.UnknownError)
+			}
+			return
+		}
+
+		resp.OK(w, r, response{true})
+	}
+}
@@ -0,0 +1,40 @@
+package users
+
+import (
+	"net/http"
+
+	"github.com/joinimpact/api/internal/authentication"
+	"github.com/joinimpact/api/internal/core/middleware/auth"
+	"github.com/joinimpact/api/pkg/idctx"
+	"github.com/joinimpact/api/pkg/resp"
+)
+
+// SessionsGet gets all of a user's active sessions.
+func SessionsGet(authService authentication.Service) http.HandlerFunc {
+	type response struct {
+		Sessions []authentication.SessionView `json:"sessions"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userID, err := idctx.Get(r, "userID")
+		if err != nil {
+			return
+		}
+
+		sessionID, _ := ctx.Value(auth.KeySessionID).(int64)
+
+		sessions, err := authService.GetUserSessions(ctx, userID, sessionID)
+		if err != nil {
+			switch err.(type) {
+			case *authentication.ErrServerError:
+				resp.ServerError(w, r, resp.APIError(err, nil))
+			default:
+				resp.ServerError(w, r, resp.UnknownError)
+			}
+			return
+		}
+
+		resp.OK(w, r, response{sessions})
+	}
+}
@@ -0,0 +1,54 @@
+package users
+
+import (
+	"net/http"
+
+	"github.com/joinimpact/api/internal/authentication"
+	"github.com/joinimpact/api/pkg/idctx"
+	"github.com/joinimpact/api/pkg/parse"
+	"github.com/joinimpact/api/pkg/resp"
+)
+
+// TwoFactorDelete disables two-factor authentication for a user, after
+// checking a TOTP or recovery code.
+func TwoFactorDelete(authService authentication.Service) http.HandlerFunc {
+	type request struct {
+		Code string `json:"code" validate:"min=6,max=16"`
+	}
+	type response struct {
+		Success bool `json:"success"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userID, err := idctx.Get(r, "userID")
+		if err != nil {
+			return
+		}
+
+		req := request{}
+		err = parse.POST(w, r, &req)
+		if err != nil {
+			return
+		}
+
+		err = authService.DisableTwoFactor(ctx, userID, req.Code)
+		if err != nil {
+			switch err.(type) {
+			case *authentication.ErrTwoFactorNotEnabled:
+				resp.NotFound(w, r, resp.APIError(err, nil))
+			case *authentication.ErrInvalidTwoFactorCode:
+				resp.BadRequest(w, r, resp.APIError(err, nil))
+			case *authentication.ErrTwoFactorLocked:
+				resp.Forbidden(w, r, resp.APIError(err, nil))
+			case *authentication.ErrServerError:
+				resp.ServerError(w, r, resp.APIError(err, nil))
+			default:
+				resp.ServerError(w, r, resp.UnknownError)
+			}
+			return
+		}
+
+		resp.OK(w, r, response{true})
+	}
+}
@@ -0,0 +1,39 @@
+package users
+
+import (
+	"net/http"
+
+	"github.com/joinimpact/api/internal/authentication"
+	"github.com/joinimpact/api/pkg/idctx"
+	"github.com/joinimpact/api/pkg/resp"
+)
+
+// TwoFactorPost begins two-factor authentication enrollment for a user,
+// returning a new TOTP secret which still has to be confirmed.
+func TwoFactorPost(authService authentication.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userID, err := idctx.Get(r, "userID")
+		if err != nil {
+			return
+		}
+
+		enrollment, err := authService.BeginTwoFactorEnrollment(ctx, userID)
+		if err != nil {
+			switch err.(type) {
+			case *authentication.ErrUserNotFound:
+				resp.NotFound(w, r, resp.APIError(err, nil))
+			case *authentication.ErrTwoFactorAlreadyEnabled:
+				resp.BadRequest(w, r, resp.APIError(err, nil))
+			case *authentication.ErrServerError:
+				resp.ServerError(w, r, resp.APIError(err, nil))
+			default:
+				resp.ServerError(w, r, resp.UnknownError)
+			}
+			return
+		}
+
+		resp.OK(w, r, enrollment)
+	}
+}
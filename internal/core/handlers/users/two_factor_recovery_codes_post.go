@@ -0,0 +1,53 @@
+package users
+
+import (
+	"net/http"
+
+	"github.com/joinimpact/api/internal/authentication"
+	"github.com/joinimpact/api/pkg/idctx"
+	"github.com/joinimpact/api/pkg/parse"
+	"github.com/joinimpact/api/pkg/resp"
+)
+
+// TwoFactorRecoveryCodesPost replaces a user's recovery codes.
+func TwoFactorRecoveryCodesPost(authService authentication.Service) http.HandlerFunc {
+	type request struct {
+		Code string `json:"code" validate:"len=6"`
+	}
+	type response struct {
+		RecoveryCodes []string `json:"recoveryCodes"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userID, err := idctx.Get(r, "userID")
+		if err != nil {
+			return
+		}
+
+		req := request{}
+		err = parse.POST(w, r, &req)
+		if err != nil {
+			return
+		}
+
+		codes, err := authService.RegenerateRecoveryCodes(ctx, userID, req.Code)
+		if err != nil {
+			switch err.(type) {
+			case *authentication.ErrTwoFactorNotEnabled:
+				resp.NotFound(w, r, resp.APIError(err, nil))
+			case *authentication.ErrInvalidTwoFactorCode:
+				resp.BadRequest(w, r, resp.APIError(err, nil))
+			case *authentication.ErrTwoFactorLocked:
+				resp.Forbidden(w, r, resp.APIError(err, nil))
+			case *authentication.ErrServerError:
+				resp.ServerError(w, r, resp.APIError(err, nil))
+			default:
+				resp.ServerError(w, r, resp.UnknownError)
+			}
+			return
+		}
+
+		resp.OK(w, r, response{codes})
+	}
+}
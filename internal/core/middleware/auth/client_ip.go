@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/joinimpact/api/internal/config"
+)
+
+// ClientIPMiddleware adds the IP address of the client to the context. Proxy headers are only
+// honoured when the request comes from one of the trusted proxies in the config, as any client
+// can set them.
+func ClientIPMiddleware(config *config.Config) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), KeyClientIP, clientIP(r, config))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// clientIP gets the IP address of the client which made a request.
+func clientIP(r *http.Request, config *config.Config) string {
+	remoteAddr, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		remoteAddr = r.RemoteAddr
+	}
+
+	if ip := net.ParseIP(remoteAddr); ip == nil || !config.IsTrustedProxy(ip) {
+		return remoteAddr
+	}
+
+	if forwardedFor := r.Header.Get("X-Forwarded-For"); len(forwardedFor) > 0 {
+		// Each proxy appends the address it received the request from, so the client is the
+		// right-most address which is not a trusted proxy. Addresses left of it can be forged.
+		hops := strings.Split(forwardedFor, ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop := strings.TrimSpace(hops[i])
+			ip := net.ParseIP(hop)
+			if ip == nil {
+				break
+			}
+
+			if !config.IsTrustedProxy(ip) || i == 0 {
+				return hop
+			}
+		}
+
+		return remoteAddr
+	}
+
+	if realIP := strings.TrimSpace(r.Header.Get("X-Real-IP")); net.ParseIP(realIP) != nil {
+		return realIP
+	}
+
+	return remoteAddr
+}
@@ -8,6 +8,8 @@ import (
 	"strings"
 
 	"github.com/joinimpact/api/internal/authentication"
+	"github.com/joinimpact/api/internal/config"
+	"github.com/joinimpact/api/pkg/apikeys"
 	"github.com/joinimpact/api/pkg/resp"
 )
 
@@ -17,6 +19,9 @@ type Key int
 // Keys
 const (
 	KeyUserID Key = iota
+	KeySessionID
+	KeyOrganizationAPIKey
+	KeyClientIP
 )
 
 // getToken attempts to get the token from the Authorization HTTP header.
@@ -50,7 +55,14 @@ func AuthMiddleware(authService authentication.Service) func(next http.Handler)
 				return
 			}
 
+			sessionID, err := authService.GetSessionIDFromToken(token)
+			if err != nil {
+				resp.Unauthorized(w, r, resp.Error(401, "invalid auth token"))
+				return
+			}
+
 			ctx = context.WithValue(ctx, KeyUserID, userID)
+			ctx = context.WithValue(ctx, KeySessionID, sessionID)
 
 			next.ServeHTTP(w, r.WithContext(ctx))
 
@@ -60,6 +72,64 @@ func AuthMiddleware(authService authentication.Service) func(next http.Handler)
 	}
 }
 
+// APIKeyMiddleware authenticates requests made with an organization API key and adds the
+// *models.OrganizationAPIKey to the context. Requests with any other token are passed to
+// AuthMiddleware. Requests made with an API key have no user ID in their context.
+func APIKeyMiddleware(authService authentication.Service) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		authMiddleware := AuthMiddleware(authService)(next)
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, err := getToken(r)
+			if err != nil || !apikeys.IsKey(token) {
+				authMiddleware.ServeHTTP(w, r)
+				return
+			}
+
+			apiKey, err := authService.AuthenticateAPIKey(r.Context(), token)
+			if err != nil {
+				resp.Unauthorized(w, r, resp.Error(401, "invalid api key"))
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), KeyOrganizationAPIKey, apiKey)
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RequireUser rejects requests which were not made by a logged in user, such as
+// requests made with an API key.
+func RequireUser() func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, ok := r.Context().Value(KeyUserID).(int64); !ok {
+				resp.Forbidden(w, r, resp.Error(403, "this resource can only be accessed by a logged in user"))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireStaff rejects requests which were not made by a member of the platform staff, as
+// configured by StaffUserIDs.
+func RequireStaff(config *config.Config) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userID, ok := r.Context().Value(KeyUserID).(int64)
+			if !ok || !config.IsStaff(userID) {
+				resp.Forbidden(w, r, resp.Error(403, "this resource can only be accessed by platform staff"))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 // CookieMiddleware takes authentication information from the cookies and injects it into the Authorization header for later consumption.
 func CookieMiddleware(authService authentication.Service) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
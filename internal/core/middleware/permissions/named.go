@@ -0,0 +1,68 @@
+package permissions
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/joinimpact/api/pkg/resp"
+)
+
+type key int
+
+const (
+	keyPermissions key = iota
+)
+
+// Function is a type that represents a function that takes a context and returns
+// the names of the permissions held by the requester, or nil to leave the
+// current permissions unchanged.
+type Function func(ctx context.Context) []string
+
+// Middleware provides a middleware that injects named permissions into the context.
+func Middleware(function Function) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			permissions := function(ctx)
+			if permissions == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			set := map[string]bool{}
+			for _, permission := range permissions {
+				set[permission] = true
+			}
+
+			ctx = context.WithValue(ctx, keyPermissions, set)
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// Has returns true if the permissions in a context include a named permission.
+func Has(ctx context.Context, permission string) bool {
+	set, ok := ctx.Value(keyPermissions).(map[string]bool)
+	if !ok {
+		return false
+	}
+
+	return set[permission]
+}
+
+// RequireNamed requires a named permission, such as "hours.approve", for a user to
+// access an endpoint.
+func RequireNamed(permission string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !Has(r.Context(), permission) {
+				resp.Forbidden(w, r, resp.Error(403, "you do not have sufficient permissions to this resource"))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
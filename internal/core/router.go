@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/go-chi/chi"
+	"github.com/joinimpact/api/internal/core/handlers/admin"
+	"github.com/joinimpact/api/internal/core/handlers/analytics"
 	"github.com/joinimpact/api/internal/core/handlers/auth"
 	"github.com/joinimpact/api/internal/core/handlers/browse"
 	"github.com/joinimpact/api/internal/core/handlers/conversations"
@@ -16,6 +18,7 @@ import (
 	authm "github.com/joinimpact/api/internal/core/middleware/auth"
 	"github.com/joinimpact/api/internal/core/middleware/db"
 	"github.com/joinimpact/api/internal/core/middleware/permissions"
+	"github.com/joinimpact/api/internal/models"
 	"github.com/joinimpact/api/pkg/idctx"
 	"github.com/joinimpact/api/pkg/scopes"
 )
@@ -23,7 +26,10 @@ import (
 // Router assembles and returns a *chi.Mux router with all the API routes.
 func (app *App) Router() *chi.Mux {
 	router := chi.NewRouter()
+	router.Use(authm.ClientIPMiddleware(app.config))
 	router.Get("/healthcheck", healthcheckHandler)
+	// Calendar apps subscribe to feeds by a secret URL without logging in.
+	router.Get("/calendars/{token}", events.CalendarFeedGet(app.eventsService, app.usersService, app.opportunitiesService, app.organizationsService))
 	router.Route("/auth", func(r chi.Router) {
 		r.Post("/login", auth.Login(app.authenticationService))
 		r.Post("/validate-email", auth.ValidateEmail(app.authenticationService))
@@ -38,9 +44,26 @@ func (app *App) Router() *chi.Mux {
 			})
 		})
 
+		r.Route("/magic-link", func(r chi.Router) {
+			r.Post("/", auth.RequestMagicLink(app.authenticationService))
+			r.Post("/{magicLinkKey}", auth.RedeemMagicLink(app.authenticationService))
+		})
+
+		r.Post("/two-factor/verify", auth.TwoFactorVerify(app.authenticationService))
+
+		r.Route("/verify-email", func(r chi.Router) {
+			r.
+				With(authm.CookieMiddleware(app.authenticationService), authm.AuthMiddleware(app.authenticationService)).
+				Post("/resend", auth.ResendVerificationEmail(app.authenticationService))
+			r.Post("/{emailVerificationKey}", auth.VerifyEmail(app.authenticationService))
+		})
+
+		r.Post("/email-change/{emailChangeKey}", auth.ConfirmEmailChange(app.authenticationService))
+
 		r.Route("/oauth", func(r chi.Router) {
 			r.Post("/google", auth.GoogleOauth(app.authenticationService))
 			r.Post("/facebook", auth.FacebookOauth(app.authenticationService))
+			r.Post("/oidc/{provider}", auth.OIDCOauth(app.authenticationService))
 		})
 	})
 
@@ -53,16 +76,32 @@ func (app *App) Router() *chi.Mux {
 		// Gets limit and other database query parameters from the URL.
 		router.Use(db.ContextMiddleware())
 
+		router.Route("/admin", func(r chi.Router) {
+			r.Use(authm.RequireStaff(app.config))
+
+			r.Route("/verifications", func(r chi.Router) {
+				r.Get("/", admin.VerificationsGet(app.organizationsService))
+
+				r.Route("/{verificationID}", func(r chi.Router) {
+					r.Use(idctx.Prepare("verificationID"))
+
+					r.Get("/", admin.VerificationGet(app.organizationsService))
+					r.Post("/review", admin.VerificationReviewPost(app.organizationsService, app.opportunitiesService))
+				})
+			})
+		})
+
 		router.Route("/browse", func(r chi.Router) {
 			r.Get("/", browse.Get(app.opportunitiesService))
 			r.Post("/query", browse.QueryPost(app.opportunitiesService))
+			r.Post("/organizations/query", browse.OrganizationsQueryPost(app.organizationsService))
 		})
 
 		router.Route("/users", func(r chi.Router) {
 			r.Route("/{userID}", func(r chi.Router) {
 				// For processing the userID param.
 				r.Use(users.Middleware(app.authenticationService))
-				r.Use(scopes.Middleware(users.ScopeProviderUsers()))
+				r.Use(scopes.Middleware(users.ScopeProviderUsers(app.usersService)))
 
 				r.Get("/", users.GetUserProfile(app.usersService))
 				r.With(permissions.Require(scopes.ScopeOwner)).Delete("/", users.DeletePost(app.usersService))
@@ -73,10 +112,29 @@ func (app *App) Router() *chi.Mux {
 				r.With(permissions.Require(scopes.ScopeOwner)).Delete("/tags/{tagID}", users.DeleteUserTag(app.usersService))
 
 				r.With(permissions.Require(scopes.ScopeOwner)).Post("/profile-picture", users.UploadProfilePicture(app.usersService))
+				r.With(permissions.Require(scopes.ScopeOwner)).Put("/password", users.PasswordPut(app.authenticationService))
+				r.With(permissions.Require(scopes.ScopeOwner)).Post("/email", users.EmailPost(app.authenticationService))
+				r.With(permissions.Require(scopes.ScopeOwner)).Post("/export", users.ExportPost(app.exportsService))
+
+				r.Route("/sessions", func(r chi.Router) {
+					r.Use(permissions.Require(scopes.ScopeOwner))
+					r.Get("/", users.SessionsGet(app.authenticationService))
+					r.With(idctx.Prepare("sessionID")).Delete("/{sessionID}", users.SessionsDelete(app.authenticationService))
+				})
+
+				r.Route("/two-factor", func(r chi.Router) {
+					r.Use(permissions.Require(scopes.ScopeOwner))
+					r.Get("/", users.TwoFactorGet(app.authenticationService))
+					r.Post("/", users.TwoFactorPost(app.authenticationService))
+					r.Delete("/", users.TwoFactorDelete(app.authenticationService))
+					r.Post("/confirm", users.TwoFactorConfirmPost(app.authenticationService))
+					r.Post("/recovery-codes", users.TwoFactorRecoveryCodesPost(app.authenticationService))
+				})
 
 				r.With(permissions.Require(scopes.ScopeOwner)).Get("/organizations", organizations.GetUserOrganizations(app.organizationsService))
 				r.Get("/opportunities", opportunities.GetByVolunteer(app.opportunitiesService))
 				r.With(permissions.Require(scopes.ScopeOwner)).Get("/events", events.GetByVolunteer(app.eventsService))
+				r.With(permissions.Require(scopes.ScopeOwner)).Post("/calendar-feed", events.UserCalendarFeedPost(app.eventsService))
 
 				r.Route("/conversations", func(r chi.Router) {
 					r.Use(permissions.Require(scopes.ScopeOwner))
@@ -102,104 +160,65 @@ func (app *App) Router() *chi.Mux {
 			})
 		})
 
-		router.Route("/organizations", func(r chi.Router) {
-			r.Post("/", organizations.CreateOrganization(app.organizationsService))
-
-			r.Route("/{organizationID}", func(r chi.Router) {
-				r.Use(idctx.Prepare("organizationID"))
-				r.Use(scopes.Middleware(organizations.ScopeProviderOrganizations(app.organizationsService)))
-
-				r.Get("/", organizations.GetOrganizationProfile(app.organizationsService))
-				r.With(permissions.Require(scopes.ScopeAdmin)).Patch("/", organizations.UpdateOrganizationProfile(app.organizationsService))
-				r.With(permissions.Require(scopes.ScopeAdmin)).Delete("/", organizations.DeleteOrganization(app.organizationsService))
-
-				r.Get("/tags", organizations.GetOrganizationTags(app.organizationsService))
-				r.With(permissions.Require(scopes.ScopeAdmin)).Post("/tags", organizations.PostOrganizationTags(app.organizationsService))
-				r.With(permissions.Require(scopes.ScopeAdmin)).Delete("/tags/{tagID}", organizations.DeleteOrganizationTag(app.organizationsService))
-
-				r.With(permissions.Require(scopes.ScopeAdmin)).Post("/profile-picture", organizations.UploadProfilePicture(app.organizationsService))
-				r.With(permissions.Require(scopes.ScopeAdmin)).Post("/invite", organizations.PostInvite(app.organizationsService))
-
-				r.Route("/invites", func(r chi.Router) {
-					r.With(permissions.Require(scopes.ScopeAdmin)).Post("/", organizations.PostInvite(app.organizationsService))
-
-					r.Route("/{inviteID}", func(r chi.Router) {
-						r.Use(permissions.Require(scopes.ScopeAuthenticated))
-						r.Use(idctx.Prepare("inviteID"))
-
-						r.Post("/validate", organizations.InviteValidatePost(app.organizationsService))
-						r.Post("/accept", organizations.InviteAcceptPost(app.organizationsService))
-						r.Post("/decline", organizations.InviteDeclinePost(app.organizationsService))
-					})
-				})
-
-				r.With(permissions.Require(scopes.ScopeManager)).Get("/members", organizations.MembersGet(app.organizationsService, app.usersService))
-				r.With(permissions.Require(scopes.ScopeManager)).Get("/volunteers", organizations.OrganizationVolunteersGet(app.opportunitiesService, app.usersService))
-
-				r.Route("/opportunities", func(r chi.Router) {
-					r.With(permissions.Require(scopes.ScopeManager)).Post("/", opportunities.Post(app.opportunitiesService))
-					r.With(permissions.Require(scopes.ScopeAuthenticated)).Get("/", opportunities.Get(app.opportunitiesService))
-				})
-
-				r.Route("/conversations", func(r chi.Router) {
-					r.Use(permissions.Require(scopes.ScopeManager))
-
-					r.Get("/", conversations.GetByOrganization(app.conversationsService))
-					r.Route("/{conversationID}", func(r chi.Router) {
-						r.Use(idctx.Prepare("conversationID"))
-						r.Get("/", conversations.Get(app.conversationsService, true))
-
-						r.Route("/messages", func(r chi.Router) {
-							r.Get("/", conversations.MessagesGet(app.conversationsService))
-							r.Post("/", conversations.MessagesPost(app.conversationsService, true))
-						})
-					})
-				})
-
-				r.Route("/hours", func(r chi.Router) {
-					r.Route("/requests", func(r chi.Router) {
-						r.Post("/", hours.OrganizationRequestsPost(app.hoursService, app.conversationsService))
-
-						r.Route("/{requestID}", func(r chi.Router) {
-							r.Use(idctx.Prepare("requestID"))
-							r.With(permissions.Require(scopes.ScopeManager)).Post("/accept", hours.OrganizationRequestAcceptPost(app.hoursService, app.conversationsService))
-							r.With(permissions.Require(scopes.ScopeManager)).Post("/decline", hours.OrganizationRequestDeclinePost(app.hoursService, app.conversationsService))
-						})
-					})
-				})
-			})
-
-		})
-
 		router.Route("/opportunities", func(r chi.Router) {
 			r.Route("/{opportunityID}", func(r chi.Router) {
 				r.Use(idctx.Prepare("opportunityID"))
 				r.Use(scopes.Middleware(opportunities.ScopeProviderOpportunities(app.organizationsService, app.opportunitiesService)))
+				r.Use(permissions.Middleware(opportunities.PermissionProviderOpportunities(app.organizationsService, app.opportunitiesService)))
 
 				r.Get("/", opportunities.GetOne(app.opportunitiesService))
 				r.
-					With(permissions.Require(scopes.ScopeManager)).
+					With(permissions.RequireNamed(models.PermissionOpportunitiesEdit)).
 					Patch("/", opportunities.Patch(app.opportunitiesService))
 				r.
-					With(permissions.Require(scopes.ScopeManager)).
+					With(permissions.RequireNamed(models.PermissionOpportunitiesEdit)).
 					Delete("/", opportunities.Delete(app.opportunitiesService))
 
 				r.
-					With(permissions.Require(scopes.ScopeManager)).
+					With(permissions.RequireNamed(models.PermissionOpportunitiesEdit)).
 					Post("/profile-picture", opportunities.ProfilePicturePost(app.opportunitiesService))
 
 				r.
 					Post("/request", opportunities.RequestPost(app.opportunitiesService, app.conversationsService))
+				r.
+					With(authm.RequireUser(), permissions.Require(scopes.ScopeAuthenticated)).
+					Post("/leave", opportunities.LeavePost(app.opportunitiesService, app.conversationsService))
+
+				r.Route("/application-form", func(r chi.Router) {
+					r.Get("/", opportunities.ApplicationFormGet(app.opportunitiesService))
+					r.
+						With(permissions.RequireNamed(models.PermissionOpportunitiesEdit)).
+						Put("/", opportunities.ApplicationFormPut(app.opportunitiesService))
+					r.
+						With(authm.RequireUser(), permissions.Require(scopes.ScopeAuthenticated)).
+						Post("/files", opportunities.ApplicationFilePost(app.opportunitiesService))
+				})
 
 				r.Route("/volunteers", func(r chi.Router) {
-					r.Use(permissions.Require(scopes.ScopeManager))
+					r.Use(permissions.RequireNamed(models.PermissionVolunteersView))
 					r.Get("/", opportunities.VolunteersGet(app.opportunitiesService, app.usersService))
 
 					r.Route("/{userID}", func(r chi.Router) {
+						r.Use(permissions.RequireNamed(models.PermissionVolunteersManage))
 						r.Use(idctx.Prepare("userID"))
 
 						r.Post("/accept", opportunities.VolunteersAcceptPost(app.opportunitiesService, app.conversationsService))
 						r.Post("/decline", opportunities.VolunteersDeclinePost(app.opportunitiesService))
+						r.Delete("/", opportunities.VolunteerDelete(app.opportunitiesService, app.conversationsService))
+					})
+				})
+
+				r.Route("/waitlist", func(r chi.Router) {
+					r.
+						With(permissions.RequireNamed(models.PermissionVolunteersView)).
+						Get("/", opportunities.WaitlistGet(app.opportunitiesService))
+
+					r.Group(func(r chi.Router) {
+						r.Use(authm.RequireUser(), permissions.Require(scopes.ScopeAuthenticated))
+
+						r.Get("/me", opportunities.WaitlistMeGet(app.opportunitiesService))
+						r.Post("/accept", opportunities.WaitlistAcceptPost(app.opportunitiesService))
+						r.Post("/decline", opportunities.WaitlistDeclinePost(app.opportunitiesService, app.conversationsService))
 					})
 				})
 
@@ -218,29 +237,33 @@ func (app *App) Router() *chi.Mux {
 				})
 
 				r.
-					With(permissions.Require(scopes.ScopeManager)).
+					With(permissions.RequireNamed(models.PermissionOpportunitiesEdit)).
 					Post("/publish", opportunities.PublishPost(app.opportunitiesService))
 				r.
-					With(permissions.Require(scopes.ScopeManager)).
+					With(permissions.RequireNamed(models.PermissionOpportunitiesEdit)).
 					Post("/unpublish", opportunities.UnpublishPost(app.opportunitiesService))
+				r.
+					With(permissions.RequireNamed(models.PermissionOpportunitiesEdit)).
+					Put("/lifecycle", opportunities.LifecyclePut(app.opportunitiesService))
 
 				r.Route("/tags", func(r chi.Router) {
 					r.Get("/", opportunities.TagsGet(app.opportunitiesService))
 					r.
-						With(permissions.Require(scopes.ScopeManager)).
+						With(permissions.RequireNamed(models.PermissionOpportunitiesEdit)).
 						Post("/", opportunities.TagsPost(app.opportunitiesService))
 
 					r.Route("/{tagID}", func(r chi.Router) {
 						r.Use(idctx.Prepare("tagID"))
 
 						r.
-							With(permissions.Require(scopes.ScopeManager)).
+							With(permissions.RequireNamed(models.PermissionOpportunitiesEdit)).
 							Delete("/", opportunities.TagsDelete(app.opportunitiesService))
 					})
 				})
 
-				r.With(permissions.Require(scopes.ScopeManager)).Post("/events", events.Post(app.eventsService))
+				r.With(permissions.RequireNamed(models.PermissionEventsEdit)).Post("/events", events.Post(app.eventsService))
 				r.With(permissions.Require(scopes.ScopeCollaborator)).Get("/events", events.GetByOpportunity(app.eventsService))
+				r.With(permissions.RequireNamed(models.PermissionEventsEdit)).Post("/calendar-feed", events.OpportunityCalendarFeedPost(app.eventsService))
 			})
 		})
 
@@ -248,11 +271,12 @@ func (app *App) Router() *chi.Mux {
 			r.Route("/{eventID}", func(r chi.Router) {
 				r.Use(idctx.Prepare("eventID"))
 				r.Use(scopes.Middleware(events.ScopeProviderEvents(app.eventsService, app.organizationsService, app.opportunitiesService)))
+				r.Use(permissions.Middleware(events.PermissionProviderEvents(app.eventsService, app.organizationsService, app.opportunitiesService)))
 				r.Use(permissions.Require(scopes.ScopeCollaborator))
 
 				r.Get("/", events.GetOne(app.eventsService))
-				r.With(permissions.Require(scopes.ScopeManager)).Patch("/", events.Patch(app.eventsService))
-				r.With(permissions.Require(scopes.ScopeManager)).Delete("/", events.Delete(app.eventsService))
+				r.With(permissions.RequireNamed(models.PermissionEventsEdit)).Patch("/", events.Patch(app.eventsService))
+				r.With(permissions.RequireNamed(models.PermissionEventsEdit)).Delete("/", events.Delete(app.eventsService))
 
 				r.Route("/response", func(r chi.Router) {
 					r.Get("/", events.ResponseGet(app.eventsService))
@@ -260,6 +284,196 @@ func (app *App) Router() *chi.Mux {
 				})
 
 				r.Get("/responses", events.ResponsesGet(app.eventsService, app.usersService))
+
+				r.Route("/overrides", func(r chi.Router) {
+					r.Use(permissions.RequireNamed(models.PermissionEventsEdit))
+
+					r.Get("/", events.OverridesGet(app.eventsService))
+					r.Put("/", events.OverridePut(app.eventsService))
+					r.Delete("/", events.OverrideDelete(app.eventsService))
+				})
+
+				r.Route("/shifts", func(r chi.Router) {
+					r.Get("/", events.ShiftsGet(app.eventsService))
+					r.With(permissions.RequireNamed(models.PermissionEventsEdit)).Post("/", events.ShiftsPost(app.eventsService))
+
+					r.Route("/{shiftID}", func(r chi.Router) {
+						r.Use(idctx.Prepare("shiftID"))
+
+						r.With(permissions.RequireNamed(models.PermissionEventsEdit)).Put("/", events.ShiftPut(app.eventsService))
+						r.With(permissions.RequireNamed(models.PermissionEventsEdit)).Delete("/", events.ShiftDelete(app.eventsService))
+						r.With(permissions.RequireNamed(models.PermissionVolunteersView)).Get("/roster", events.ShiftRosterGet(app.eventsService, app.usersService))
+
+						r.Post("/signup", events.ShiftSignupPost(app.eventsService))
+						r.Delete("/signup", events.ShiftSignupDelete(app.eventsService))
+						r.Post("/swap", events.ShiftSwapPost(app.eventsService))
+					})
+				})
+
+				r.Route("/check-in", func(r chi.Router) {
+					r.With(permissions.RequireNamed(models.PermissionEventsEdit)).Get("/code", events.CheckInCodeGet(app.eventsService))
+					r.Post("/", events.CheckInPost(app.eventsService))
+				})
+				r.Post("/check-out", events.CheckOutPost(app.eventsService))
+
+				r.Route("/attendance", func(r chi.Router) {
+					r.With(permissions.RequireNamed(models.PermissionVolunteersView)).Get("/", events.AttendanceGet(app.eventsService, app.usersService))
+
+					r.Route("/{userID}", func(r chi.Router) {
+						r.Use(idctx.Prepare("userID"))
+						r.Use(permissions.RequireNamed(models.PermissionHoursApprove))
+
+						r.Post("/check-in", events.VolunteerCheckInPost(app.eventsService))
+						r.Post("/check-out", events.VolunteerCheckOutPost(app.eventsService))
+					})
+				})
+			})
+		})
+	})
+
+	// Organization routes can also be called by servers with an organization API key.
+	router.Group(func(router chi.Router) {
+		router.Use(authm.CookieMiddleware(app.authenticationService))
+		router.Use(authm.APIKeyMiddleware(app.authenticationService))
+		router.Use(scopes.Middleware(func(ctx context.Context) scopes.Scope {
+			if _, ok := ctx.Value(authm.KeyUserID).(int64); !ok {
+				// API keys only receive a scope from their organization.
+				return scopes.ScopeUnauthenticated
+			}
+
+			return scopes.ScopeAuthenticated
+		}))
+		// Gets limit and other database query parameters from the URL.
+		router.Use(db.ContextMiddleware())
+
+		router.Route("/organizations", func(r chi.Router) {
+			r.With(authm.RequireUser()).Post("/", organizations.CreateOrganization(app.organizationsService))
+
+			r.Route("/{organizationID}", func(r chi.Router) {
+				r.Use(idctx.Prepare("organizationID"))
+				r.Use(scopes.Middleware(organizations.ScopeProviderOrganizations(app.organizationsService)))
+				r.Use(permissions.Middleware(organizations.PermissionProviderOrganizations(app.organizationsService)))
+
+				r.Get("/", organizations.GetOrganizationProfile(app.organizationsService))
+				r.With(permissions.RequireNamed(models.PermissionOrganizationEdit)).Patch("/", organizations.UpdateOrganizationProfile(app.organizationsService))
+				r.With(authm.RequireUser(), permissions.Require(scopes.ScopeAdmin)).Delete("/", organizations.DeleteOrganization(app.organizationsService))
+				r.With(authm.RequireUser(), permissions.Require(scopes.ScopeAdmin)).Put("/security", organizations.UpdateOrganizationSecurity(app.organizationsService))
+
+				r.With(permissions.RequireNamed(models.PermissionAnalyticsView)).Get("/analytics", analytics.OrganizationGet(app.analyticsService))
+
+				r.Get("/chapters", organizations.ChaptersGet(app.organizationsService))
+				r.With(authm.RequireUser(), permissions.Require(scopes.ScopeAdmin)).Put("/parent", organizations.ParentPut(app.organizationsService))
+
+				r.Route("/verification", func(r chi.Router) {
+					r.Use(authm.RequireUser(), permissions.Require(scopes.ScopeAdmin))
+
+					r.Get("/", organizations.VerificationGet(app.organizationsService))
+					r.Post("/", organizations.VerificationPost(app.organizationsService))
+				})
+
+				r.Get("/tags", organizations.GetOrganizationTags(app.organizationsService))
+				r.With(permissions.RequireNamed(models.PermissionOrganizationEdit)).Post("/tags", organizations.PostOrganizationTags(app.organizationsService))
+				r.With(permissions.RequireNamed(models.PermissionOrganizationEdit)).Delete("/tags/{tagID}", organizations.DeleteOrganizationTag(app.organizationsService))
+
+				r.With(permissions.RequireNamed(models.PermissionOrganizationEdit)).Post("/profile-picture", organizations.UploadProfilePicture(app.organizationsService))
+				r.With(authm.RequireUser(), permissions.RequireNamed(models.PermissionMembersInvite)).Post("/invite", organizations.PostInvite(app.organizationsService))
+
+				r.Route("/invites", func(r chi.Router) {
+					r.With(authm.RequireUser(), permissions.RequireNamed(models.PermissionMembersInvite)).Post("/", organizations.PostInvite(app.organizationsService))
+
+					r.Route("/{inviteID}", func(r chi.Router) {
+						r.Use(authm.RequireUser())
+						r.Use(permissions.Require(scopes.ScopeAuthenticated))
+						r.Use(idctx.Prepare("inviteID"))
+
+						r.Post("/validate", organizations.InviteValidatePost(app.organizationsService))
+						r.Post("/accept", organizations.InviteAcceptPost(app.organizationsService))
+						r.Post("/decline", organizations.InviteDeclinePost(app.organizationsService))
+					})
+				})
+
+				r.Route("/api-keys", func(r chi.Router) {
+					r.Use(authm.RequireUser())
+					r.Use(permissions.Require(scopes.ScopeAdmin))
+
+					r.Get("/", organizations.APIKeysGet(app.organizationsService))
+					r.Post("/", organizations.APIKeysPost(app.organizationsService))
+					r.With(idctx.Prepare("apiKeyID")).Delete("/{apiKeyID}", organizations.APIKeyDelete(app.organizationsService))
+				})
+
+				r.Route("/roles", func(r chi.Router) {
+					// Only owners can manage roles, so that members can not grant themselves permissions.
+					r.Use(authm.RequireUser())
+					r.Use(permissions.Require(scopes.ScopeAdmin))
+
+					r.Get("/", organizations.RolesGet(app.organizationsService))
+					r.Post("/", organizations.RolesPost(app.organizationsService))
+
+					r.Route("/{roleID}", func(r chi.Router) {
+						r.Use(idctx.Prepare("roleID"))
+
+						r.Put("/", organizations.RolePut(app.organizationsService))
+						r.Delete("/", organizations.RoleDelete(app.organizationsService))
+					})
+				})
+
+				r.Route("/members", func(r chi.Router) {
+					r.With(permissions.RequireNamed(models.PermissionMembersView)).Get("/", organizations.MembersGet(app.organizationsService, app.usersService))
+					r.
+						With(authm.RequireUser(), permissions.Require(scopes.ScopeAdmin), idctx.Prepare("userID")).
+						Put("/{userID}/role", organizations.MemberRolePut(app.organizationsService))
+
+					r.Group(func(r chi.Router) {
+						r.Use(authm.RequireUser(), permissions.Require(scopes.ScopeAdmin), idctx.Prepare("userID"))
+
+						r.Patch("/{userID}", organizations.MemberPatch(app.organizationsService))
+						r.Delete("/{userID}", organizations.MemberDelete(app.organizationsService, app.conversationsService))
+					})
+				})
+				r.With(authm.RequireUser()).Post("/leave", organizations.LeavePost(app.organizationsService, app.conversationsService))
+
+				r.Route("/transfer", func(r chi.Router) {
+					r.Use(authm.RequireUser())
+
+					r.With(permissions.Require(scopes.ScopeOwner)).Post("/", organizations.TransferPost(app.organizationsService))
+					r.Post("/accept", organizations.TransferAcceptPost(app.organizationsService))
+					r.Post("/decline", organizations.TransferDeclinePost(app.organizationsService))
+				})
+				r.With(permissions.RequireNamed(models.PermissionVolunteersView)).Get("/volunteers", organizations.OrganizationVolunteersGet(app.organizationsService, app.opportunitiesService, app.usersService))
+
+				r.Route("/opportunities", func(r chi.Router) {
+					r.With(authm.RequireUser(), permissions.RequireNamed(models.PermissionOpportunitiesEdit)).Post("/", opportunities.Post(app.opportunitiesService))
+					r.With(permissions.Require(scopes.ScopeAuthenticated)).Get("/", opportunities.Get(app.opportunitiesService))
+				})
+
+				r.Route("/conversations", func(r chi.Router) {
+					r.Use(permissions.RequireNamed(models.PermissionConversationsRead))
+
+					r.Get("/", conversations.GetByOrganization(app.conversationsService))
+					r.Route("/{conversationID}", func(r chi.Router) {
+						r.Use(idctx.Prepare("conversationID"))
+						r.Get("/", conversations.Get(app.conversationsService, true))
+
+						r.Route("/messages", func(r chi.Router) {
+							r.Get("/", conversations.MessagesGet(app.conversationsService))
+							r.With(authm.RequireUser(), permissions.RequireNamed(models.PermissionConversationsReply)).Post("/", conversations.MessagesPost(app.conversationsService, true))
+						})
+					})
+				})
+
+				r.Route("/hours", func(r chi.Router) {
+					r.With(permissions.RequireNamed(models.PermissionVolunteersView)).Get("/report", hours.OrganizationReportGet(app.hoursService, app.organizationsService))
+
+					r.Route("/requests", func(r chi.Router) {
+						r.With(authm.RequireUser()).Post("/", hours.OrganizationRequestsPost(app.hoursService, app.conversationsService))
+
+						r.Route("/{requestID}", func(r chi.Router) {
+							r.Use(idctx.Prepare("requestID"))
+							r.With(authm.RequireUser(), permissions.RequireNamed(models.PermissionHoursApprove)).Post("/accept", hours.OrganizationRequestAcceptPost(app.hoursService, app.conversationsService))
+							r.With(authm.RequireUser(), permissions.RequireNamed(models.PermissionHoursApprove)).Post("/decline", hours.OrganizationRequestDeclinePost(app.hoursService, app.conversationsService))
+						})
+					})
+				})
 			})
 		})
 	})
@@ -0,0 +1,56 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jinzhu/gorm"
+	"github.com/joinimpact/api/internal/models"
+	"github.com/rs/zerolog"
+)
+
+// calendarFeedRepository stores and controls CalendarFeeds in the database.
+type calendarFeedRepository struct {
+	db     *gorm.DB
+	logger *zerolog.Logger
+}
+
+// NewCalendarFeedRepository creates and returns a new CalendarFeedRepository.
+func NewCalendarFeedRepository(db *gorm.DB, logger *zerolog.Logger) models.CalendarFeedRepository {
+	return &calendarFeedRepository{db, logger}
+}
+
+// FindByTokenHash finds a single entity by the hash of its token.
+func (r *calendarFeedRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*models.CalendarFeed, error) {
+	var feed models.CalendarFeed
+	if err := r.db.Where("token_hash = ?", tokenHash).First(&feed).Error; err != nil {
+		return &feed, err
+	}
+	return &feed, nil
+}
+
+// FindByUserID finds a user's entity for an opportunity, or the user's own entity when opportunityID is nil.
+func (r *calendarFeedRepository) FindByUserID(ctx context.Context, userID int64, opportunityID *int64) (*models.CalendarFeed, error) {
+	var feed models.CalendarFeed
+
+	db := r.db.Where("user_id = ?", userID)
+	if opportunityID == nil {
+		db = db.Where("opportunity_id IS NULL")
+	} else {
+		db = db.Where("opportunity_id = ?", *opportunityID)
+	}
+
+	if err := db.First(&feed).Error; err != nil {
+		return &feed, err
+	}
+	return &feed, nil
+}
+
+// Create creates a new entity.
+func (r *calendarFeedRepository) Create(ctx context.Context, feed models.CalendarFeed) error {
+	return r.db.Create(&feed).Error
+}
+
+// UpdateTokenHash replaces the hash of an entity's token.
+func (r *calendarFeedRepository) UpdateTokenHash(ctx context.Context, id int64, tokenHash string) error {
+	return r.db.Model(&models.CalendarFeed{}).Where("id = ?", id).Update("token_hash", tokenHash).Error
+}
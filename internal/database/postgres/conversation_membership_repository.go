@@ -56,6 +56,15 @@ func (r *conversationMembershipRepository) FindByUserIDAndConversationID(ctx con
 
 }
 
+// FindByUserIDAndOrganizationID finds a user's active memberships in an organization's conversations.
+func (r *conversationMembershipRepository) FindByUserIDAndOrganizationID(ctx context.Context, userID, organizationID int64) ([]models.ConversationMembership, error) {
+	var conversationMemberships []models.ConversationMembership
+	if err := r.db.Joins("JOIN conversations on conversations.id = conversation_memberships.conversation_id").Where("conversation_memberships.user_id = ? AND conversations.organization_id = ? AND conversation_memberships.active = True", userID, organizationID).Find(&conversationMemberships).Error; err != nil {
+		return conversationMemberships, err
+	}
+	return conversationMemberships, nil
+}
+
 // Create creates a new User.
 func (r *conversationMembershipRepository) Create(conversationMembership models.ConversationMembership) error {
 	return r.db.Create(&conversationMembership).Error
@@ -66,6 +75,11 @@ func (r *conversationMembershipRepository) Update(conversationMembership models.
 	return r.db.Model(&models.ConversationMembership{}).Updates(conversationMembership).Error
 }
 
+// Deactivate marks a membership as inactive.
+func (r *conversationMembershipRepository) Deactivate(id int64) error {
+	return r.db.Model(&models.ConversationMembership{}).Where("id = ?", id).Update("active", false).Error
+}
+
 // DeleteByID deletes a User by ID.
 func (r *conversationMembershipRepository) DeleteByID(id int64) error {
 	return r.db.Delete(&models.ConversationMembership{
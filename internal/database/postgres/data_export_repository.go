@@ -0,0 +1,58 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/joinimpact/api/internal/models"
+	"github.com/rs/zerolog"
+)
+
+// dataExportRepository stores and controls DataExports in the database.
+type dataExportRepository struct {
+	db     *gorm.DB
+	logger *zerolog.Logger
+}
+
+// NewDataExportRepository creates and returns a new DataExportRepository.
+func NewDataExportRepository(db *gorm.DB, logger *zerolog.Logger) models.DataExportRepository {
+	return &dataExportRepository{db, logger}
+}
+
+// FindByID finds a single entity by ID.
+func (r *dataExportRepository) FindByID(ctx context.Context, id int64) (*models.DataExport, error) {
+	var dataExport models.DataExport
+	if err := r.db.First(&dataExport, id).Error; err != nil {
+		return &dataExport, err
+	}
+	return &dataExport, nil
+}
+
+// FindLatestByUserID finds the most recently created entity of a user.
+func (r *dataExportRepository) FindLatestByUserID(ctx context.Context, userID int64) (*models.DataExport, error) {
+	var dataExport models.DataExport
+	if err := r.db.Where("user_id = ?", userID).Order("created_at DESC").First(&dataExport).Error; err != nil {
+		return &dataExport, err
+	}
+	return &dataExport, nil
+}
+
+// FindExpired finds all ready entities whose download link expired before a time.
+func (r *dataExportRepository) FindExpired(ctx context.Context, before time.Time) ([]models.DataExport, error) {
+	dataExports := []models.DataExport{}
+	if err := r.db.Where("status = ? AND expires_at < ?", models.DataExportStatusReady, before).Find(&dataExports).Error; err != nil {
+		return dataExports, err
+	}
+	return dataExports, nil
+}
+
+// Create creates a new entity.
+func (r *dataExportRepository) Create(ctx context.Context, dataExport models.DataExport) error {
+	return r.db.Create(&dataExport).Error
+}
+
+// Save saves all fields in the provided entity.
+func (r *dataExportRepository) Save(ctx context.Context, dataExport models.DataExport) error {
+	return r.db.Save(&dataExport).Error
+}
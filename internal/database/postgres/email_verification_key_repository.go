@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"errors"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/joinimpact/api/internal/models"
+	"github.com/rs/zerolog"
+)
+
+// emailVerificationKeyRepository stores and controls EmailVerificationKeys in the database.
+type emailVerificationKeyRepository struct {
+	db     *gorm.DB
+	logger *zerolog.Logger
+}
+
+// NewEmailVerificationKeyRepository creates and returns a new EmailVerificationKeyRepository.
+func NewEmailVerificationKeyRepository(db *gorm.DB, logger *zerolog.Logger) models.EmailVerificationKeyRepository {
+	return &emailVerificationKeyRepository{db, logger}
+}
+
+// FindByID finds a single EmailVerificationKey by ID.
+func (r *emailVerificationKeyRepository) FindByID(id int64) (*models.EmailVerificationKey, error) {
+	var evk models.EmailVerificationKey
+	if err := r.db.First(&evk, id).Error; err != nil {
+		return &evk, err
+	}
+	return &evk, nil
+}
+
+// FindByKey finds a single EmailVerificationKey by Key.
+func (r *emailVerificationKeyRepository) FindByKey(key string) (*models.EmailVerificationKey, error) {
+	var evk models.EmailVerificationKey
+	if err := r.db.Where("key = ?", key).First(&evk).Error; err != nil {
+		return &evk, err
+	}
+
+	// Compare expired time.
+	if evk.ExpiresAt.Sub(time.Now().UTC()) <= 0 {
+		return nil, errors.New("expired")
+	}
+
+	return &evk, nil
+}
+
+// FindLatestByUserID finds the most recently created EmailVerificationKey of a user.
+func (r *emailVerificationKeyRepository) FindLatestByUserID(userID int64) (*models.EmailVerificationKey, error) {
+	var evk models.EmailVerificationKey
+	if err := r.db.Where("user_id = ?", userID).Order("created_at DESC").First(&evk).Error; err != nil {
+		return &evk, err
+	}
+	return &evk, nil
+}
+
+// Create creates a new EmailVerificationKey.
+func (r *emailVerificationKeyRepository) Create(evk models.EmailVerificationKey) error {
+	return r.db.Create(&evk).Error
+}
+
+// Update updates an EmailVerificationKey with the ID in the provided EmailVerificationKey.
+func (r *emailVerificationKeyRepository) Update(evk models.EmailVerificationKey) error {
+	return r.db.Model(&models.EmailVerificationKey{}).Updates(evk).Error
+}
+
+// DeleteByID deletes an EmailVerificationKey by ID.
+func (r *emailVerificationKeyRepository) DeleteByID(id int64) error {
+	return r.db.Delete(&models.EmailVerificationKey{
+		Model: models.Model{
+			ID: id,
+		},
+	}).Error
+}
+
+// DeleteByUserID deletes all EmailVerificationKeys of a user.
+func (r *emailVerificationKeyRepository) DeleteByUserID(userID int64) error {
+	return r.db.Where("user_id = ?", userID).Delete(&models.EmailVerificationKey{}).Error
+}
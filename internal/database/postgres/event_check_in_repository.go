@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/joinimpact/api/internal/models"
+	"github.com/rs/zerolog"
+)
+
+// eventCheckInRepository stores and controls EventCheckIns in the database.
+type eventCheckInRepository struct {
+	db     *gorm.DB
+	logger *zerolog.Logger
+}
+
+// NewEventCheckInRepository creates and returns a new EventCheckInRepository.
+func NewEventCheckInRepository(db *gorm.DB, logger *zerolog.Logger) models.EventCheckInRepository {
+	return &eventCheckInRepository{db, logger}
+}
+
+// FindByEventID finds all entities of an event in check-in order, or only the entities of a single
+// occurrence if one is given.
+func (r *eventCheckInRepository) FindByEventID(ctx context.Context, eventID int64, occurrence *time.Time) ([]models.EventCheckIn, error) {
+	checkIns := []models.EventCheckIn{}
+	db := r.db.Where("event_id = ?", eventID)
+	if occurrence != nil {
+		db = db.Where("occurrence_start = ?", *occurrence)
+	}
+
+	if err := db.Order("checked_in_at ASC").Find(&checkIns).Error; err != nil {
+		return checkIns, err
+	}
+	return checkIns, nil
+}
+
+// FindOpenByUserID finds a user's latest entity in an event which is not checked out.
+func (r *eventCheckInRepository) FindOpenByUserID(ctx context.Context, eventID, userID int64) (*models.EventCheckIn, error) {
+	var checkIn models.EventCheckIn
+	if err := r.db.
+		Where("event_id = ? AND user_id = ? AND checked_out_at IS NULL", eventID, userID).
+		Order("checked_in_at DESC").
+		First(&checkIn).Error; err != nil {
+		return &checkIn, err
+	}
+	return &checkIn, nil
+}
+
+// Create creates a new entity. It returns false without creating the entity when the user already
+// checked in to the event or occurrence, which the unique index checks so that concurrent check-ins
+// can not both be created.
+func (r *eventCheckInRepository) Create(ctx context.Context, checkIn models.EventCheckIn) (bool, error) {
+	err := r.db.Set("gorm:insert_option", "ON CONFLICT DO NOTHING").Create(&checkIn).Error
+	if err == sql.ErrNoRows {
+		// No row was inserted, so no ID was returned.
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// CheckOut sets the check-out time, manager and hours of an entity and creates its hour log. It returns
+// false without creating the hour log when the entity was already checked out, so that concurrent
+// check-outs can not log the hours twice.
+func (r *eventCheckInRepository) CheckOut(ctx context.Context, checkIn models.EventCheckIn, hourLog models.VolunteeringHourLog) (bool, error) {
+	checkedOut := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		db := tx.
+			Model(&models.EventCheckIn{}).
+			Where("id = ? AND checked_out_at IS NULL", checkIn.ID).
+			UpdateColumns(map[string]interface{}{
+				"checked_out_at":    checkIn.CheckedOutAt,
+				"checked_out_by_id": checkIn.CheckedOutByID,
+				"hours":             checkIn.Hours,
+				"hour_log_id":       hourLog.ID,
+				"updated_at":        time.Now(),
+			})
+		if db.Error != nil {
+			return db.Error
+		}
+
+		if db.RowsAffected < 1 {
+			return nil
+		}
+
+		checkedOut = true
+		return tx.Create(&hourLog).Error
+	})
+
+	return checkedOut && err == nil, err
+}
@@ -0,0 +1,49 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/joinimpact/api/internal/models"
+	"github.com/rs/zerolog"
+)
+
+// eventOccurrenceOverrideRepository stores and controls EventOccurrenceOverrides in the database.
+type eventOccurrenceOverrideRepository struct {
+	db     *gorm.DB
+	logger *zerolog.Logger
+}
+
+// NewEventOccurrenceOverrideRepository creates and returns a new EventOccurrenceOverrideRepository.
+func NewEventOccurrenceOverrideRepository(db *gorm.DB, logger *zerolog.Logger) models.EventOccurrenceOverrideRepository {
+	return &eventOccurrenceOverrideRepository{db, logger}
+}
+
+// FindByEventID finds all entities of an event, ordered by occurrence.
+func (r *eventOccurrenceOverrideRepository) FindByEventID(ctx context.Context, eventID int64) ([]models.EventOccurrenceOverride, error) {
+	overrides := []models.EventOccurrenceOverride{}
+	if err := r.db.Where("event_id = ?", eventID).Order("occurrence_start ASC").Find(&overrides).Error; err != nil {
+		return overrides, err
+	}
+	return overrides, nil
+}
+
+// FindByOccurrence finds the entity of a single occurrence of an event.
+func (r *eventOccurrenceOverrideRepository) FindByOccurrence(ctx context.Context, eventID int64, occurrence time.Time) (*models.EventOccurrenceOverride, error) {
+	var override models.EventOccurrenceOverride
+	if err := r.db.Where("event_id = ? AND occurrence_start = ?", eventID, occurrence).First(&override).Error; err != nil {
+		return &override, err
+	}
+	return &override, nil
+}
+
+// Save creates an entity, or updates all fields of an existing entity.
+func (r *eventOccurrenceOverrideRepository) Save(ctx context.Context, override models.EventOccurrenceOverride) error {
+	return r.db.Save(&override).Error
+}
+
+// DeleteByID permanently deletes an entity by ID, so that the occurrence can be overridden again.
+func (r *eventOccurrenceOverrideRepository) DeleteByID(ctx context.Context, id int64) error {
+	return r.db.Unscoped().Delete(&models.EventOccurrenceOverride{}, "id = ?", id).Error
+}
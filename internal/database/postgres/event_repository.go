@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/jinzhu/gorm"
 	"github.com/joinimpact/api/internal/models"
@@ -42,12 +43,13 @@ func (r *eventRepository) FindByOpportunityID(ctx context.Context, opportunityID
 		Where("opportunity_id = ? AND active = True AND title LIKE ?", opportunityID, fmt.Sprintf("%%%s%%", dbctx.Query)).
 		Order("from_date ASC", true)
 
+	// Recurring events are included while they may have occurrences in the range.
 	if dbctx.From != nil {
-		db = db.Where("from_date >= ?", *dbctx.From)
+		db = db.Where("from_date >= ? OR recurrence <> ''", *dbctx.From)
 	}
 
 	if dbctx.To != nil {
-		db = db.Where("to_date < ?", *dbctx.To)
+		db = db.Where("to_date < ? OR (recurrence <> '' AND from_date < ?)", *dbctx.To, *dbctx.To)
 	}
 
 	if err := db.
@@ -70,8 +72,9 @@ func (r *eventRepository) FindByOpportunityIDs(ctx context.Context, opportunityI
 		Where("opportunity_id IN (?) AND active = True AND title LIKE ?", opportunityIDs, fmt.Sprintf("%%%s%%", dbctx.Query)).
 		Order("from_date ASC", true)
 
+	// Recurring events are included while they may have occurrences in the range.
 	if dbctx.From != nil {
-		db = db.Where("from_date >= ? OR to_date >= ?", *dbctx.From, *dbctx.From)
+		db = db.Where("from_date >= ? OR to_date >= ? OR recurrence <> ''", *dbctx.From, *dbctx.From)
 	}
 
 	if dbctx.To != nil {
@@ -86,6 +89,19 @@ func (r *eventRepository) FindByOpportunityIDs(ctx context.Context, opportunityI
 	return events, nil
 }
 
+// FindDeletedByOpportunityIDs finds entities of multiple opportunities which were deleted after a time.
+func (r *eventRepository) FindDeletedByOpportunityIDs(ctx context.Context, opportunityIDs []int64, since time.Time) ([]models.Event, error) {
+	var events []models.Event
+	if err := r.db.
+		Unscoped().
+		Where("opportunity_id IN (?) AND deleted_at > ?", opportunityIDs, since).
+		Find(&events).
+		Error; err != nil {
+		return events, err
+	}
+	return events, nil
+}
+
 // FindByCreatorID finds multiple entities by the creator ID.
 func (r *eventRepository) FindByCreatorID(ctx context.Context, creatorID int64) ([]models.Event, error) {
 	var events []models.Event
@@ -100,9 +116,45 @@ func (r *eventRepository) Create(ctx context.Context, event models.Event) error
 	return r.db.Create(&event).Error
 }
 
-// Update updates a Event with the ID in the provided Event.
+// Update updates a Event with the ID in the provided Event, and increases its sequence.
 func (r *eventRepository) Update(ctx context.Context, event models.Event) error {
-	return r.db.Model(&models.Event{}).Updates(event).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Event{}).Updates(event).Error; err != nil {
+			return err
+		}
+
+		return tx.
+			Model(&models.Event{}).
+			Where("id = ?", event.ID).
+			UpdateColumn("sequence", gorm.Expr("sequence + 1")).
+			Error
+	})
+}
+
+// UpdateRecurrence updates the recurrence rule and time zone of an entity, which may be empty, and
+// increases its sequence.
+func (r *eventRepository) UpdateRecurrence(ctx context.Context, id int64, recurrence, timeZone string) error {
+	return r.db.
+		Model(&models.Event{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"recurrence": recurrence,
+			"time_zone":  timeZone,
+			"sequence":   gorm.Expr("sequence + 1"),
+		}).
+		Error
+}
+
+// Touch increases the sequence of an entity and sets its update time, for changes to its occurrences.
+func (r *eventRepository) Touch(ctx context.Context, id int64) error {
+	return r.db.
+		Model(&models.Event{}).
+		Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{
+			"sequence":   gorm.Expr("sequence + 1"),
+			"updated_at": time.Now(),
+		}).
+		Error
 }
 
 // Save saves all fields in the provided entity.
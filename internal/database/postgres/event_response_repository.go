@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"time"
 
 	"github.com/jinzhu/gorm"
 	"github.com/joinimpact/api/internal/models"
@@ -52,6 +53,15 @@ func (r *eventResponseRepository) FindByEventID(ctx context.Context, eventID int
 	return eventResponses, nil
 }
 
+// FindByEventIDs finds multiple entities by multiple event IDs.
+func (r *eventResponseRepository) FindByEventIDs(ctx context.Context, eventIDs []int64) ([]models.EventResponse, error) {
+	var eventResponses []models.EventResponse
+	if err := r.db.Where("event_id IN (?)", eventIDs).Find(&eventResponses).Error; err != nil {
+		return eventResponses, err
+	}
+	return eventResponses, nil
+}
+
 // FindByUserID finds multiple entities by the user ID.
 func (r *eventResponseRepository) FindByUserID(ctx context.Context, userID int64) ([]models.EventResponse, error) {
 	var eventResponses []models.EventResponse
@@ -61,10 +71,20 @@ func (r *eventResponseRepository) FindByUserID(ctx context.Context, userID int64
 	return eventResponses, nil
 }
 
-// FindInEventByUserID finds an entity by the event and user ID.
+// FindInEventByUserID finds an entity by the event and user ID. Responses to single occurrences of a
+// recurring event are not included.
 func (r *eventResponseRepository) FindInEventByUserID(ctx context.Context, eventID, userID int64) (*models.EventResponse, error) {
 	var eventResponse models.EventResponse
-	if err := r.db.Where("event_id = ? AND user_id = ?", eventID, userID).First(&eventResponse).Error; err != nil {
+	if err := r.db.Where("event_id = ? AND user_id = ? AND occurrence_start IS NULL", eventID, userID).First(&eventResponse).Error; err != nil {
+		return &eventResponse, err
+	}
+	return &eventResponse, nil
+}
+
+// FindInOccurrenceByUserID finds an entity by the user ID in a single occurrence of a recurring event.
+func (r *eventResponseRepository) FindInOccurrenceByUserID(ctx context.Context, eventID, userID int64, occurrence time.Time) (*models.EventResponse, error) {
+	var eventResponse models.EventResponse
+	if err := r.db.Where("event_id = ? AND user_id = ? AND occurrence_start = ?", eventID, userID, occurrence).First(&eventResponse).Error; err != nil {
 		return &eventResponse, err
 	}
 	return &eventResponse, nil
@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jinzhu/gorm"
+	"github.com/joinimpact/api/internal/models"
+	"github.com/rs/zerolog"
+)
+
+// eventShiftRepository stores and controls EventShifts in the database.
+type eventShiftRepository struct {
+	db     *gorm.DB
+	logger *zerolog.Logger
+}
+
+// NewEventShiftRepository creates and returns a new EventShiftRepository.
+func NewEventShiftRepository(db *gorm.DB, logger *zerolog.Logger) models.EventShiftRepository {
+	return &eventShiftRepository{db, logger}
+}
+
+// FindByID finds a single entity by ID.
+func (r *eventShiftRepository) FindByID(ctx context.Context, id int64) (*models.EventShift, error) {
+	var shift models.EventShift
+	if err := r.db.First(&shift, id).Error; err != nil {
+		return &shift, err
+	}
+	return &shift, nil
+}
+
+// FindByEventID finds all entities of an event, ordered by start time.
+func (r *eventShiftRepository) FindByEventID(ctx context.Context, eventID int64) ([]models.EventShift, error) {
+	shifts := []models.EventShift{}
+	if err := r.db.Where("event_id = ?", eventID).Order("from_date ASC").Find(&shifts).Error; err != nil {
+		return shifts, err
+	}
+	return shifts, nil
+}
+
+// Create creates a new entity.
+func (r *eventShiftRepository) Create(ctx context.Context, shift models.EventShift) error {
+	return r.db.Create(&shift).Error
+}
+
+// Update updates the title, times, capacity and required tag of an entity. It returns false without
+// updating the entity when the capacity is below the number of volunteers signed up.
+func (r *eventShiftRepository) Update(ctx context.Context, shift models.EventShift) (bool, error) {
+	// The capacity is checked in the same statement, so that volunteers signing up at the same time are counted.
+	db := r.db.
+		Model(&models.EventShift{}).
+		Where("id = ? AND filled <= ?", shift.ID, shift.Capacity).
+		Updates(map[string]interface{}{
+			"title":           shift.Title,
+			"from_date":       shift.FromDate,
+			"to_date":         shift.ToDate,
+			"capacity":        shift.Capacity,
+			"required_tag_id": shift.RequiredTagID,
+		})
+	if db.Error != nil {
+		return false, db.Error
+	}
+
+	return db.RowsAffected > 0, nil
+}
+
+// DeleteByID deletes an entity and its sign-ups by ID.
+func (r *eventShiftRepository) DeleteByID(ctx context.Context, id int64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Delete(&models.EventShiftSignup{}, "shift_id = ?", id).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(&models.EventShift{
+			Model: models.Model{
+				ID: id,
+			},
+		}).Error
+	})
+}
@@ -0,0 +1,140 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jinzhu/gorm"
+	"github.com/joinimpact/api/internal/models"
+	"github.com/rs/zerolog"
+)
+
+// errShiftFull rolls back a sign-up transaction when the shift has no free places.
+var errShiftFull = errors.New("shift is full")
+
+// eventShiftSignupRepository stores and controls EventShiftSignups in the database.
+type eventShiftSignupRepository struct {
+	db     *gorm.DB
+	logger *zerolog.Logger
+}
+
+// NewEventShiftSignupRepository creates and returns a new EventShiftSignupRepository.
+func NewEventShiftSignupRepository(db *gorm.DB, logger *zerolog.Logger) models.EventShiftSignupRepository {
+	return &eventShiftSignupRepository{db, logger}
+}
+
+// FindByShiftID finds all entities of a shift, in sign-up order.
+func (r *eventShiftSignupRepository) FindByShiftID(ctx context.Context, shiftID int64) ([]models.EventShiftSignup, error) {
+	signups := []models.EventShiftSignup{}
+	if err := r.db.Where("shift_id = ?", shiftID).Order("created_at ASC").Find(&signups).Error; err != nil {
+		return signups, err
+	}
+	return signups, nil
+}
+
+// FindInEventByUserID finds all of a user's entities in an event.
+func (r *eventShiftSignupRepository) FindInEventByUserID(ctx context.Context, eventID, userID int64) ([]models.EventShiftSignup, error) {
+	signups := []models.EventShiftSignup{}
+	if err := r.db.Where("event_id = ? AND user_id = ?", eventID, userID).Find(&signups).Error; err != nil {
+		return signups, err
+	}
+	return signups, nil
+}
+
+// FindInShiftByUserID finds a user's entity in a shift.
+func (r *eventShiftSignupRepository) FindInShiftByUserID(ctx context.Context, shiftID, userID int64) (*models.EventShiftSignup, error) {
+	var signup models.EventShiftSignup
+	if err := r.db.Where("shift_id = ? AND user_id = ?", shiftID, userID).First(&signup).Error; err != nil {
+		return &signup, err
+	}
+	return &signup, nil
+}
+
+// Create creates a new entity. It returns false without creating the entity when the shift is full.
+func (r *eventShiftSignupRepository) Create(ctx context.Context, signup models.EventShiftSignup) (bool, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := takePlace(tx, signup.ShiftID); err != nil {
+			return err
+		}
+
+		return tx.Create(&signup).Error
+	})
+	if err == errShiftFull {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// Swap replaces an entity with a new entity in another shift. It returns false without changing either
+// shift when the other shift is full, and gorm.ErrRecordNotFound when the entity was already deleted.
+func (r *eventShiftSignupRepository) Swap(ctx context.Context, signup models.EventShiftSignup, replacement models.EventShiftSignup) (bool, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := takePlace(tx, replacement.ShiftID); err != nil {
+			return err
+		}
+
+		deleted, err := deleteSignup(tx, signup)
+		if err != nil {
+			return err
+		}
+
+		if !deleted {
+			// The sign-up was deleted by another request, so the volunteer has no place to swap.
+			return gorm.ErrRecordNotFound
+		}
+
+		return tx.Create(&replacement).Error
+	})
+	if err == errShiftFull {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// Delete deletes an entity.
+func (r *eventShiftSignupRepository) Delete(ctx context.Context, signup models.EventShiftSignup) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		_, err := deleteSignup(tx, signup)
+		return err
+	})
+}
+
+// takePlace counts a new volunteer in a shift, or returns errShiftFull. The capacity is checked in the
+// same statement as the count is updated, so that concurrent sign-ups can not overbook the shift.
+func takePlace(tx *gorm.DB, shiftID int64) error {
+	db := tx.
+		Model(&models.EventShift{}).
+		Where("id = ? AND filled < capacity", shiftID).
+		UpdateColumn("filled", gorm.Expr("filled + 1"))
+	if db.Error != nil {
+		return db.Error
+	}
+
+	if db.RowsAffected < 1 {
+		return errShiftFull
+	}
+
+	return nil
+}
+
+// deleteSignup permanently deletes a sign-up and frees its place in the shift. Sign-ups are not soft
+// deleted, so that the volunteer can sign up for the shift again. It returns false when the sign-up was
+// already deleted by another request, which already freed its place.
+func deleteSignup(tx *gorm.DB, signup models.EventShiftSignup) (bool, error) {
+	db := tx.Unscoped().Delete(&models.EventShiftSignup{}, "id = ?", signup.ID)
+	if db.Error != nil {
+		return false, db.Error
+	}
+
+	if db.RowsAffected < 1 {
+		return false, nil
+	}
+
+	return true, tx.
+		Model(&models.EventShift{}).
+		Where("id = ?", signup.ShiftID).
+		UpdateColumn("filled", gorm.Expr("filled - 1")).
+		Error
+}
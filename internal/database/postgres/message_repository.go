@@ -57,6 +57,34 @@ func (r *messageRepository) FindByConversationID(ctx context.Context, conversati
 	return messages, nil
 }
 
+// FindByOrganizationID finds all entities in an organization's conversations in the time range of the
+// context, ordered by conversation and timestamp.
+func (r *messageRepository) FindByOrganizationID(ctx context.Context, organizationID int64) ([]models.Message, error) {
+	var messages []models.Message
+
+	dbctx := dbctx.Get(ctx)
+
+	db := r.db.
+		Joins("JOIN conversations ON conversations.id = messages.conversation_id").
+		Where("conversations.organization_id = ?", organizationID).
+		Order("messages.conversation_id ASC").
+		Order("messages.timestamp ASC")
+
+	if dbctx.From != nil {
+		db = db.Where("messages.timestamp >= ?", *dbctx.From)
+	}
+
+	if dbctx.To != nil {
+		db = db.Where("messages.timestamp < ?", *dbctx.To)
+	}
+
+	if err := db.Find(&messages).Error; err != nil {
+		return messages, err
+	}
+
+	return messages, nil
+}
+
 // FindBySenderID finds multiple entities by the sender ID.
 func (r *messageRepository) FindBySenderID(ctx context.Context, senderID int64) (*models.MessagesResponse, error) {
 	messages := &models.MessagesResponse{}
@@ -0,0 +1,42 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jinzhu/gorm"
+	"github.com/joinimpact/api/internal/models"
+	"github.com/rs/zerolog"
+)
+
+// opportunityApplicationAnswerRepository stores and controls OpportunityApplicationAnswers in the database.
+type opportunityApplicationAnswerRepository struct {
+	db     *gorm.DB
+	logger *zerolog.Logger
+}
+
+// NewOpportunityApplicationAnswerRepository creates and returns a new OpportunityApplicationAnswerRepository.
+func NewOpportunityApplicationAnswerRepository(db *gorm.DB, logger *zerolog.Logger) models.OpportunityApplicationAnswerRepository {
+	return &opportunityApplicationAnswerRepository{db, logger}
+}
+
+// FindByRequestID finds the answers of a membership request.
+func (r *opportunityApplicationAnswerRepository) FindByRequestID(ctx context.Context, requestID int64) ([]models.OpportunityApplicationAnswer, error) {
+	answers := []models.OpportunityApplicationAnswer{}
+	if err := r.db.Where("opportunity_membership_request_id = ?", requestID).Order("id ASC").Find(&answers).Error; err != nil {
+		return answers, err
+	}
+	return answers, nil
+}
+
+// Create creates multiple new entities.
+func (r *opportunityApplicationAnswerRepository) Create(ctx context.Context, answers []models.OpportunityApplicationAnswer) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, answer := range answers {
+			if err := tx.Create(&answer).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,46 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jinzhu/gorm"
+	"github.com/joinimpact/api/internal/models"
+	"github.com/rs/zerolog"
+)
+
+// opportunityApplicationFieldRepository stores and controls OpportunityApplicationFields in the database.
+type opportunityApplicationFieldRepository struct {
+	db     *gorm.DB
+	logger *zerolog.Logger
+}
+
+// NewOpportunityApplicationFieldRepository creates and returns a new OpportunityApplicationFieldRepository.
+func NewOpportunityApplicationFieldRepository(db *gorm.DB, logger *zerolog.Logger) models.OpportunityApplicationFieldRepository {
+	return &opportunityApplicationFieldRepository{db, logger}
+}
+
+// FindByOpportunityID finds the fields of an opportunity's form, ordered by position.
+func (r *opportunityApplicationFieldRepository) FindByOpportunityID(ctx context.Context, opportunityID int64) ([]models.OpportunityApplicationField, error) {
+	fields := []models.OpportunityApplicationField{}
+	if err := r.db.Where("opportunity_id = ?", opportunityID).Order("position ASC").Find(&fields).Error; err != nil {
+		return fields, err
+	}
+	return fields, nil
+}
+
+// ReplaceByOpportunityID replaces all fields of an opportunity's form.
+func (r *opportunityApplicationFieldRepository) ReplaceByOpportunityID(ctx context.Context, opportunityID int64, fields []models.OpportunityApplicationField) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.OpportunityApplicationField{}, "opportunity_id = ?", opportunityID).Error; err != nil {
+			return err
+		}
+
+		for _, field := range fields {
+			if err := tx.Create(&field).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
@@ -2,7 +2,9 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jinzhu/gorm"
 	"github.com/joinimpact/api/internal/models"
@@ -10,6 +12,9 @@ import (
 	"github.com/rs/zerolog"
 )
 
+// errOpportunityFull rolls back a join transaction when the opportunity has no free spots.
+var errOpportunityFull = errors.New("opportunity is full")
+
 // opportunityMembershipRepository stores and controls OpportunityMemberships in the database.
 type opportunityMembershipRepository struct {
 	db     *gorm.DB
@@ -61,6 +66,19 @@ func (r *opportunityMembershipRepository) FindByOpportunityIDs(ctx context.Conte
 	return opportunityMemberships, nil
 }
 
+// CountVolunteers counts the active volunteer memberships of an opportunity.
+func (r *opportunityMembershipRepository) CountVolunteers(ctx context.Context, opportunityID int64) (int, error) {
+	count := 0
+	if err := r.db.
+		Model(&models.OpportunityMembership{}).
+		Where("opportunity_id = ? AND active = True AND permissions_flag = ?", opportunityID, models.OpportunityPermissionsMember).
+		Count(&count).
+		Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
 // FindUserInOpportunity finds a user's membership in a specific opportunity.
 func (r *opportunityMembershipRepository) FindUserInOpportunity(ctx context.Context, opportunityID, userID int64) (*models.OpportunityMembership, error) {
 	var opportunityMembership models.OpportunityMembership
@@ -75,6 +93,57 @@ func (r *opportunityMembershipRepository) Create(ctx context.Context, opportunit
 	return r.db.Create(&opportunityMembership).Error
 }
 
+// CreateVolunteer creates a new volunteer entity. It returns false without creating the entity when the
+// opportunity's volunteers cap is reached by its volunteers and the spots held for other waitlisted
+// volunteers at a time. The opportunity's limits are locked until the entity is created, so that
+// concurrent joins can not exceed the cap.
+func (r *opportunityMembershipRepository) CreateVolunteer(ctx context.Context, opportunityMembership models.OpportunityMembership, at time.Time) (bool, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var limits models.OpportunityLimits
+		err := tx.
+			Set("gorm:query_option", "FOR UPDATE").
+			Where("opportunity_id = ?", opportunityMembership.OpportunityID).
+			First(&limits).
+			Error
+		if err != nil && !gorm.IsRecordNotFoundError(err) {
+			return err
+		}
+
+		if err == nil && limits.VolunteersCapActive {
+			volunteers := 0
+			if err := tx.
+				Model(&models.OpportunityMembership{}).
+				Where("opportunity_id = ? AND active = True AND permissions_flag = ?", opportunityMembership.OpportunityID, models.OpportunityPermissionsMember).
+				Count(&volunteers).
+				Error; err != nil {
+				return err
+			}
+
+			// A spot held for the volunteer themselves is taken by joining.
+			held := 0
+			if err := tx.
+				Model(&models.OpportunityWaitlistEntry{}).
+				Where("opportunity_id = ? AND volunteer_id <> ? AND (status = ? OR (status = ? AND offer_expires_at >= ?))",
+					opportunityMembership.OpportunityID, opportunityMembership.UserID, models.WaitlistStatusClaimed, models.WaitlistStatusOffered, at).
+				Count(&held).
+				Error; err != nil {
+				return err
+			}
+
+			if volunteers+held >= limits.VolunteersCap {
+				return errOpportunityFull
+			}
+		}
+
+		return tx.Create(&opportunityMembership).Error
+	})
+	if err == errOpportunityFull {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
 // Update updates a User with the ID in the provided User.
 func (r *opportunityMembershipRepository) Update(ctx context.Context, opportunityMembership models.OpportunityMembership) error {
 	return r.db.Model(&models.OpportunityMembership{}).Updates(opportunityMembership).Error
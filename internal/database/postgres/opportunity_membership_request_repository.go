@@ -1,8 +1,11 @@
 package postgres
 
 import (
+	"context"
+
 	"github.com/jinzhu/gorm"
 	"github.com/joinimpact/api/internal/models"
+	"github.com/joinimpact/api/pkg/dbctx"
 	"github.com/rs/zerolog"
 )
 
@@ -56,6 +59,32 @@ func (r *opportunityMembershipRequestRepository) FindByOpportunityIDs(ids []int6
 	return opportunityMembershipRequests, nil
 }
 
+// FindHistoryByOpportunityIDs finds all entities of multiple opportunity IDs, including accepted and declined
+// requests, which were open at any point in the time range of the context.
+func (r *opportunityMembershipRequestRepository) FindHistoryByOpportunityIDs(ctx context.Context, ids []int64) ([]models.OpportunityMembershipRequest, error) {
+	var opportunityMembershipRequests []models.OpportunityMembershipRequest
+
+	dbctx := dbctx.Get(ctx)
+
+	// Handled requests are soft deleted, so they are only found unscoped.
+	db := r.db.
+		Unscoped().
+		Where("opportunity_id in (?)", ids)
+
+	if dbctx.From != nil {
+		db = db.Where("deleted_at IS NULL OR deleted_at >= ?", *dbctx.From)
+	}
+
+	if dbctx.To != nil {
+		db = db.Where("created_at < ?", *dbctx.To)
+	}
+
+	if err := db.Find(&opportunityMembershipRequests).Error; err != nil {
+		return opportunityMembershipRequests, err
+	}
+	return opportunityMembershipRequests, nil
+}
+
 // FindInOpportunityByVolunteerID finds a single entity by opportunity and volunteer ID.
 func (r *opportunityMembershipRequestRepository) FindInOpportunityByVolunteerID(opportunityID, volunteerID int64) (*models.OpportunityMembershipRequest, error) {
 	var opportunityMembership models.OpportunityMembershipRequest
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/jinzhu/gorm"
 	"github.com/joinimpact/api/internal/models"
@@ -66,6 +67,30 @@ func (r *opportunityRepository) FindByCreatorID(ctx context.Context, creatorID i
 	return opportunities, nil
 }
 
+// FindScheduledToPublish finds all scheduled entities whose publish time is before a time.
+func (r *opportunityRepository) FindScheduledToPublish(ctx context.Context, before time.Time) ([]models.Opportunity, error) {
+	var opportunities []models.Opportunity
+	if err := r.db.
+		Where("status = ? AND publish_at <= ? AND active = True", models.OpportunityStatusScheduled, before).
+		Find(&opportunities).
+		Error; err != nil {
+		return opportunities, err
+	}
+	return opportunities, nil
+}
+
+// FindOpenToClose finds all open entities whose close time is before a time.
+func (r *opportunityRepository) FindOpenToClose(ctx context.Context, before time.Time) ([]models.Opportunity, error) {
+	var opportunities []models.Opportunity
+	if err := r.db.
+		Where("status = ? AND close_at <= ? AND active = True", models.OpportunityStatusOpen, before).
+		Find(&opportunities).
+		Error; err != nil {
+		return opportunities, err
+	}
+	return opportunities, nil
+}
+
 // Create creates a new User.
 func (r *opportunityRepository) Create(ctx context.Context, opportunity models.Opportunity) error {
 	return r.db.Create(&opportunity).Error
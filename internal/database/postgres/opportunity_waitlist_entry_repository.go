@@ -0,0 +1,119 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/joinimpact/api/internal/models"
+	"github.com/rs/zerolog"
+)
+
+// openWaitlistStatuses are the statuses of entries which are still on the waitlist.
+var openWaitlistStatuses = []string{models.WaitlistStatusWaiting, models.WaitlistStatusOffered, models.WaitlistStatusClaimed}
+
+// opportunityWaitlistEntryRepository stores and controls OpportunityWaitlistEntries in the database.
+type opportunityWaitlistEntryRepository struct {
+	db     *gorm.DB
+	logger *zerolog.Logger
+}
+
+// NewOpportunityWaitlistEntryRepository creates and returns a new OpportunityWaitlistEntryRepository.
+func NewOpportunityWaitlistEntryRepository(db *gorm.DB, logger *zerolog.Logger) models.OpportunityWaitlistEntryRepository {
+	return &opportunityWaitlistEntryRepository{db, logger}
+}
+
+// FindByID finds a single entity by ID.
+func (r *opportunityWaitlistEntryRepository) FindByID(ctx context.Context, id int64) (*models.OpportunityWaitlistEntry, error) {
+	var entry models.OpportunityWaitlistEntry
+	if err := r.db.First(&entry, id).Error; err != nil {
+		return &entry, err
+	}
+	return &entry, nil
+}
+
+// FindOpenByOpportunityID finds the waiting, offered and claimed entities of an opportunity, in waitlist order.
+func (r *opportunityWaitlistEntryRepository) FindOpenByOpportunityID(ctx context.Context, opportunityID int64) ([]models.OpportunityWaitlistEntry, error) {
+	entries := []models.OpportunityWaitlistEntry{}
+	if err := r.db.
+		Where("opportunity_id = ? AND status IN (?)", opportunityID, openWaitlistStatuses).
+		Order("created_at ASC").
+		Order("id ASC").
+		Find(&entries).
+		Error; err != nil {
+		return entries, err
+	}
+	return entries, nil
+}
+
+// FindOpenInOpportunityByVolunteerID finds a volunteer's waiting, offered or claimed entity in an opportunity.
+func (r *opportunityWaitlistEntryRepository) FindOpenInOpportunityByVolunteerID(ctx context.Context, opportunityID, volunteerID int64) (*models.OpportunityWaitlistEntry, error) {
+	var entry models.OpportunityWaitlistEntry
+	if err := r.db.
+		Where("opportunity_id = ? AND volunteer_id = ? AND status IN (?)", opportunityID, volunteerID, openWaitlistStatuses).
+		First(&entry).
+		Error; err != nil {
+		return &entry, err
+	}
+	return &entry, nil
+}
+
+// FindNextWaiting finds the first waiting entity of an opportunity.
+func (r *opportunityWaitlistEntryRepository) FindNextWaiting(ctx context.Context, opportunityID int64) (*models.OpportunityWaitlistEntry, error) {
+	var entry models.OpportunityWaitlistEntry
+	if err := r.db.
+		Where("opportunity_id = ? AND status = ?", opportunityID, models.WaitlistStatusWaiting).
+		Order("created_at ASC").
+		Order("id ASC").
+		First(&entry).
+		Error; err != nil {
+		return &entry, err
+	}
+	return &entry, nil
+}
+
+// FindWaitingOpportunityIDs finds the IDs of all opportunities with waiting entities.
+func (r *opportunityWaitlistEntryRepository) FindWaitingOpportunityIDs(ctx context.Context) ([]int64, error) {
+	ids := []int64{}
+	if err := r.db.
+		Model(&models.OpportunityWaitlistEntry{}).
+		Where("status = ?", models.WaitlistStatusWaiting).
+		Pluck("DISTINCT opportunity_id", &ids).
+		Error; err != nil {
+		return ids, err
+	}
+	return ids, nil
+}
+
+// FindExpiredOffers finds all offered entities whose offer expired before a time.
+func (r *opportunityWaitlistEntryRepository) FindExpiredOffers(ctx context.Context, before time.Time) ([]models.OpportunityWaitlistEntry, error) {
+	entries := []models.OpportunityWaitlistEntry{}
+	if err := r.db.Where("status = ? AND offer_expires_at < ?", models.WaitlistStatusOffered, before).Find(&entries).Error; err != nil {
+		return entries, err
+	}
+	return entries, nil
+}
+
+// CountOpenOffers counts the entities of an opportunity which hold a spot at a time: claimed entities, and
+// offered entities whose offer has not expired.
+func (r *opportunityWaitlistEntryRepository) CountOpenOffers(ctx context.Context, opportunityID int64, at time.Time) (int, error) {
+	count := 0
+	if err := r.db.
+		Model(&models.OpportunityWaitlistEntry{}).
+		Where("opportunity_id = ? AND (status = ? OR (status = ? AND offer_expires_at >= ?))", opportunityID, models.WaitlistStatusClaimed, models.WaitlistStatusOffered, at).
+		Count(&count).
+		Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// Create creates a new entity.
+func (r *opportunityWaitlistEntryRepository) Create(ctx context.Context, entry models.OpportunityWaitlistEntry) error {
+	return r.db.Create(&entry).Error
+}
+
+// Save saves all fields in the provided entity.
+func (r *opportunityWaitlistEntryRepository) Save(ctx context.Context, entry models.OpportunityWaitlistEntry) error {
+	return r.db.Save(&entry).Error
+}
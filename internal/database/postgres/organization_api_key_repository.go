@@ -0,0 +1,63 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/joinimpact/api/internal/models"
+	"github.com/rs/zerolog"
+)
+
+// organizationAPIKeyRepository stores and controls OrganizationAPIKeys in the database.
+type organizationAPIKeyRepository struct {
+	db     *gorm.DB
+	logger *zerolog.Logger
+}
+
+// NewOrganizationAPIKeyRepository creates and returns a new OrganizationAPIKeyRepository.
+func NewOrganizationAPIKeyRepository(db *gorm.DB, logger *zerolog.Logger) models.OrganizationAPIKeyRepository {
+	return &organizationAPIKeyRepository{db, logger}
+}
+
+// FindByID finds a single entity by ID.
+func (r *organizationAPIKeyRepository) FindByID(ctx context.Context, id int64) (*models.OrganizationAPIKey, error) {
+	var apiKey models.OrganizationAPIKey
+	if err := r.db.First(&apiKey, id).Error; err != nil {
+		return &apiKey, err
+	}
+	return &apiKey, nil
+}
+
+// FindByOrganizationID finds multiple entities by the organization ID.
+func (r *organizationAPIKeyRepository) FindByOrganizationID(ctx context.Context, organizationID int64) ([]models.OrganizationAPIKey, error) {
+	var apiKeys []models.OrganizationAPIKey
+	if err := r.db.Where("organization_id = ?", organizationID).Order("created_at DESC").Find(&apiKeys).Error; err != nil {
+		return apiKeys, err
+	}
+	return apiKeys, nil
+}
+
+// FindByKeyHash finds a single entity by the hash of its key.
+func (r *organizationAPIKeyRepository) FindByKeyHash(ctx context.Context, keyHash string) (*models.OrganizationAPIKey, error) {
+	var apiKey models.OrganizationAPIKey
+	if err := r.db.Where("key_hash = ?", keyHash).First(&apiKey).Error; err != nil {
+		return &apiKey, err
+	}
+	return &apiKey, nil
+}
+
+// Create creates a new entity.
+func (r *organizationAPIKeyRepository) Create(ctx context.Context, apiKey models.OrganizationAPIKey) error {
+	return r.db.Create(&apiKey).Error
+}
+
+// UpdateLastUsedAt sets when an entity was last used.
+func (r *organizationAPIKeyRepository) UpdateLastUsedAt(ctx context.Context, id int64, lastUsedAt time.Time) error {
+	return r.db.Model(&models.OrganizationAPIKey{}).Where("id = ?", id).Update("last_used_at", lastUsedAt).Error
+}
+
+// DeleteByID deletes an entity by ID.
+func (r *organizationAPIKeyRepository) DeleteByID(ctx context.Context, id int64) error {
+	return r.db.Delete(&models.OrganizationAPIKey{}, "id = ?", id).Error
+}
@@ -63,6 +63,21 @@ func (r *organizationMembershipRepository) Update(organizationMembership models.
 	return r.db.Model(&models.OrganizationMembership{}).Updates(organizationMembership).Error
 }
 
+// UpdatePermissionsFlag sets the permissions flag of a single entity by ID.
+func (r *organizationMembershipRepository) UpdatePermissionsFlag(id int64, permissionsFlag int) error {
+	return r.db.Model(&models.OrganizationMembership{}).Where("id = ?", id).Update("permissions_flag", permissionsFlag).Error
+}
+
+// UpdateRoleID sets the role of a single entity by ID, or removes it if roleID is 0.
+func (r *organizationMembershipRepository) UpdateRoleID(id, roleID int64) error {
+	return r.db.Model(&models.OrganizationMembership{}).Where("id = ?", id).Update("role_id", roleID).Error
+}
+
+// ClearRoleID removes a role from all entities which have it.
+func (r *organizationMembershipRepository) ClearRoleID(roleID int64) error {
+	return r.db.Model(&models.OrganizationMembership{}).Where("role_id = ?", roleID).Update("role_id", 0).Error
+}
+
 // DeleteByID deletes a User by ID.
 func (r *organizationMembershipRepository) DeleteByID(id int64) error {
 	return r.db.Delete(&models.OrganizationMembership{
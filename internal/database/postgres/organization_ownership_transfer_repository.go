@@ -0,0 +1,39 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jinzhu/gorm"
+	"github.com/joinimpact/api/internal/models"
+	"github.com/rs/zerolog"
+)
+
+// organizationOwnershipTransferRepository stores and controls OrganizationOwnershipTransfers in the database.
+type organizationOwnershipTransferRepository struct {
+	db     *gorm.DB
+	logger *zerolog.Logger
+}
+
+// NewOrganizationOwnershipTransferRepository creates and returns a new OrganizationOwnershipTransferRepository.
+func NewOrganizationOwnershipTransferRepository(db *gorm.DB, logger *zerolog.Logger) models.OrganizationOwnershipTransferRepository {
+	return &organizationOwnershipTransferRepository{db, logger}
+}
+
+// FindByOrganizationID finds the most recent entity of an organization.
+func (r *organizationOwnershipTransferRepository) FindByOrganizationID(ctx context.Context, organizationID int64) (*models.OrganizationOwnershipTransfer, error) {
+	var transfer models.OrganizationOwnershipTransfer
+	if err := r.db.Where("organization_id = ?", organizationID).Order("created_at DESC").First(&transfer).Error; err != nil {
+		return &transfer, err
+	}
+	return &transfer, nil
+}
+
+// Create creates a new entity.
+func (r *organizationOwnershipTransferRepository) Create(ctx context.Context, transfer models.OrganizationOwnershipTransfer) error {
+	return r.db.Create(&transfer).Error
+}
+
+// DeleteByOrganizationID deletes all entities of an organization.
+func (r *organizationOwnershipTransferRepository) DeleteByOrganizationID(ctx context.Context, organizationID int64) error {
+	return r.db.Unscoped().Delete(&models.OrganizationOwnershipTransfer{}, "organization_id = ?", organizationID).Error
+}
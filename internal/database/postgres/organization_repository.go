@@ -35,6 +35,15 @@ func (r *organizationRepository) FindByCreatorID(creatorID int64) ([]models.Orga
 	return organizations, nil
 }
 
+// FindByParentID finds the chapters of an organization.
+func (r *organizationRepository) FindByParentID(parentID int64) ([]models.Organization, error) {
+	var organizations []models.Organization
+	if err := r.db.Where("parent_id = ? AND active = True", parentID).Find(&organizations).Error; err != nil {
+		return organizations, err
+	}
+	return organizations, nil
+}
+
 // Create creates a new User.
 func (r *organizationRepository) Create(organization models.Organization) error {
 	return r.db.Create(&organization).Error
@@ -45,6 +54,31 @@ func (r *organizationRepository) Update(organization models.Organization) error
 	return r.db.Model(&models.Organization{}).Updates(organization).Error
 }
 
+// UpdateParentID sets the parent of an organization, or removes it if parentID is 0.
+func (r *organizationRepository) UpdateParentID(id, parentID int64) error {
+	return r.db.Model(&models.Organization{}).Where("id = ?", id).Update("parent_id", parentID).Error
+}
+
+// TransferOwnership makes a member the creator of an organization and turns the previous
+// creator into an owner, in a single transaction.
+func (r *organizationRepository) TransferOwnership(organizationID, fromUserID, toUserID int64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.OrganizationMembership{}).
+			Where("organization_id = ? AND user_id = ?", organizationID, fromUserID).
+			Update("permissions_flag", models.OrganizationPermissionsOwner).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.OrganizationMembership{}).
+			Where("organization_id = ? AND user_id = ?", organizationID, toUserID).
+			Update("permissions_flag", models.OrganizationPermissionsCreator).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.Organization{}).Where("id = ?", organizationID).Update("creator_id", toUserID).Error
+	})
+}
+
 // DeleteByID deletes a User by ID.
 func (r *organizationRepository) DeleteByID(id int64) error {
 	return r.db.Delete(&models.Organization{
@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jinzhu/gorm"
+	"github.com/joinimpact/api/internal/models"
+	"github.com/rs/zerolog"
+)
+
+// organizationRoleRepository stores and controls OrganizationRoles in the database.
+type organizationRoleRepository struct {
+	db     *gorm.DB
+	logger *zerolog.Logger
+}
+
+// NewOrganizationRoleRepository creates and returns a new OrganizationRoleRepository.
+func NewOrganizationRoleRepository(db *gorm.DB, logger *zerolog.Logger) models.OrganizationRoleRepository {
+	return &organizationRoleRepository{db, logger}
+}
+
+// FindByID finds a single entity by ID.
+func (r *organizationRoleRepository) FindByID(ctx context.Context, id int64) (*models.OrganizationRole, error) {
+	var role models.OrganizationRole
+	if err := r.db.First(&role, id).Error; err != nil {
+		return &role, err
+	}
+	return &role, nil
+}
+
+// FindByOrganizationID finds multiple entities by the organization ID.
+func (r *organizationRoleRepository) FindByOrganizationID(ctx context.Context, organizationID int64) ([]models.OrganizationRole, error) {
+	var roles []models.OrganizationRole
+	if err := r.db.Where("organization_id = ?", organizationID).Order("created_at ASC").Find(&roles).Error; err != nil {
+		return roles, err
+	}
+	return roles, nil
+}
+
+// Create creates a new entity.
+func (r *organizationRoleRepository) Create(ctx context.Context, role models.OrganizationRole) error {
+	return r.db.Create(&role).Error
+}
+
+// Save saves all fields in the provided entity.
+func (r *organizationRoleRepository) Save(ctx context.Context, role models.OrganizationRole) error {
+	return r.db.Save(&role).Error
+}
+
+// DeleteByID deletes an entity by ID.
+func (r *organizationRoleRepository) DeleteByID(ctx context.Context, id int64) error {
+	return r.db.Delete(&models.OrganizationRole{}, "id = ?", id).Error
+}
@@ -0,0 +1,64 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jinzhu/gorm"
+	"github.com/joinimpact/api/internal/models"
+	"github.com/joinimpact/api/pkg/dbctx"
+	"github.com/rs/zerolog"
+)
+
+// organizationVerificationRepository stores and controls OrganizationVerifications in the database.
+type organizationVerificationRepository struct {
+	db     *gorm.DB
+	logger *zerolog.Logger
+}
+
+// NewOrganizationVerificationRepository creates and returns a new OrganizationVerificationRepository.
+func NewOrganizationVerificationRepository(db *gorm.DB, logger *zerolog.Logger) models.OrganizationVerificationRepository {
+	return &organizationVerificationRepository{db, logger}
+}
+
+// FindByID finds a single entity by ID.
+func (r *organizationVerificationRepository) FindByID(ctx context.Context, id int64) (*models.OrganizationVerification, error) {
+	var organizationVerification models.OrganizationVerification
+	if err := r.db.First(&organizationVerification, id).Error; err != nil {
+		return &organizationVerification, err
+	}
+	return &organizationVerification, nil
+}
+
+// FindLatestByOrganizationID finds the most recently submitted entity of an organization.
+func (r *organizationVerificationRepository) FindLatestByOrganizationID(ctx context.Context, organizationID int64) (*models.OrganizationVerification, error) {
+	var organizationVerification models.OrganizationVerification
+	if err := r.db.Where("organization_id = ?", organizationID).Order("submitted_at DESC").First(&organizationVerification).Error; err != nil {
+		return &organizationVerification, err
+	}
+	return &organizationVerification, nil
+}
+
+// FindByStatus finds multiple entities by status, oldest first.
+func (r *organizationVerificationRepository) FindByStatus(ctx context.Context, status string) ([]models.OrganizationVerification, error) {
+	var organizationVerifications []models.OrganizationVerification
+	if err := r.db.
+		Limit(dbctx.Get(ctx).Limit).
+		Offset(dbctx.Get(ctx).Page*dbctx.Get(ctx).Limit).
+		Where("status = ?", status).
+		Order("submitted_at ASC").
+		Find(&organizationVerifications).
+		Error; err != nil {
+		return organizationVerifications, err
+	}
+	return organizationVerifications, nil
+}
+
+// Create creates a new entity.
+func (r *organizationVerificationRepository) Create(ctx context.Context, organizationVerification models.OrganizationVerification) error {
+	return r.db.Create(&organizationVerification).Error
+}
+
+// Save saves all fields in the provided entity.
+func (r *organizationVerificationRepository) Save(ctx context.Context, organizationVerification models.OrganizationVerification) error {
+	return r.db.Save(&organizationVerification).Error
+}
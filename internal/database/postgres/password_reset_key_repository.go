@@ -62,3 +62,14 @@ func (r *passwordResetKeyRepository) DeleteByID(id int64) error {
 		},
 	}).Error
 }
+
+// Consume deletes a PasswordResetKey by ID. It returns false when the key was already deleted, so
+// that concurrent requests can not both use the key.
+func (r *passwordResetKeyRepository) Consume(id int64) (bool, error) {
+	db := r.db.Delete(&models.PasswordResetKey{}, "id = ?", id)
+	if db.Error != nil {
+		return false, db.Error
+	}
+
+	return db.RowsAffected == 1, nil
+}
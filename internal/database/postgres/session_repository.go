@@ -0,0 +1,112 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/joinimpact/api/internal/models"
+	"github.com/rs/zerolog"
+)
+
+// sessionRepository stores and controls Sessions in the database.
+type sessionRepository struct {
+	db     *gorm.DB
+	logger *zerolog.Logger
+}
+
+// NewSessionRepository creates and returns a new SessionRepository.
+func NewSessionRepository(db *gorm.DB, logger *zerolog.Logger) models.SessionRepository {
+	return &sessionRepository{db, logger}
+}
+
+// FindByID finds a single entity by ID.
+func (r *sessionRepository) FindByID(ctx context.Context, id int64) (*models.Session, error) {
+	var session models.Session
+	if err := r.db.First(&session, id).Error; err != nil {
+		return &session, err
+	}
+	return &session, nil
+}
+
+// FindByUserID finds all active entities by the user ID.
+func (r *sessionRepository) FindByUserID(ctx context.Context, userID int64) ([]models.Session, error) {
+	var sessions []models.Session
+	if err := r.db.
+		Where("user_id = ? AND active = True AND expires_at > ?", userID, time.Now().UTC()).
+		Order("last_used_at DESC").
+		Find(&sessions).Error; err != nil {
+		return sessions, err
+	}
+	return sessions, nil
+}
+
+// Create creates a new entity.
+func (r *sessionRepository) Create(ctx context.Context, session models.Session) error {
+	return r.db.Create(&session).Error
+}
+
+// Update updates an entity with the ID in the provided entity.
+func (r *sessionRepository) Update(ctx context.Context, session models.Session) error {
+	return r.db.Model(&models.Session{}).Updates(session).Error
+}
+
+// RotateRefreshToken replaces the refresh token ID of a session and extends
+// its expiry, only if the current refresh token ID still matches.
+func (r *sessionRepository) RotateRefreshToken(ctx context.Context, id int64, currentTokenID, newTokenID string, expiresAt time.Time) error {
+	now := time.Now().UTC()
+
+	db := r.db.
+		Model(&models.Session{}).
+		Where("id = ? AND active = True AND refresh_token_id = ?", id, currentTokenID).
+		Updates(map[string]interface{}{
+			"refresh_token_id":          newTokenID,
+			"previous_refresh_token_id": currentTokenID,
+			"rotated_at":                now,
+			"last_used_at":              now,
+			"expires_at":                expiresAt,
+		})
+	if db.Error != nil {
+		return db.Error
+	}
+
+	if db.RowsAffected < 1 {
+		// Another request rotated the token first.
+		return errors.New("refresh token already rotated")
+	}
+
+	return nil
+}
+
+// RevokeByID revokes a single entity by ID.
+func (r *sessionRepository) RevokeByID(ctx context.Context, id int64) error {
+	return r.db.
+		Model(&models.Session{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"active":     false,
+			"revoked_at": time.Now().UTC(),
+		}).Error
+}
+
+// RevokeByUserID revokes all active entities of a user, except the one with
+// the ID exceptID.
+func (r *sessionRepository) RevokeByUserID(ctx context.Context, userID, exceptID int64) error {
+	return r.db.
+		Model(&models.Session{}).
+		Where("user_id = ? AND active = True AND id <> ?", userID, exceptID).
+		Updates(map[string]interface{}{
+			"active":     false,
+			"revoked_at": time.Now().UTC(),
+		}).Error
+}
+
+// DeleteByID deletes an entity by ID.
+func (r *sessionRepository) DeleteByID(ctx context.Context, id int64) error {
+	return r.db.Delete(&models.Session{
+		Model: models.Model{
+			ID: id,
+		},
+	}).Error
+}
@@ -46,6 +46,16 @@ func (r *thirdPartyIdentityRepository) FindUserIdentityByServiceName(userID int6
 	return &tpi, nil
 }
 
+// FindByThirdPartyID finds a single entity by service name and the user's ID at the service.
+func (r *thirdPartyIdentityRepository) FindByThirdPartyID(serviceName, thirdPartyID string) (*models.ThirdPartyIdentity, error) {
+	var tpi models.ThirdPartyIdentity
+	if err := r.db.Where("third_party_service_name = ? AND third_party_id = ?", serviceName, thirdPartyID).First(&tpi).Error; err != nil {
+		return &tpi, err
+	}
+
+	return &tpi, nil
+}
+
 // Create creates a new ThirdPartyIdentity.
 func (r *thirdPartyIdentityRepository) Create(tpi models.ThirdPartyIdentity) error {
 	return r.db.Create(&tpi).Error
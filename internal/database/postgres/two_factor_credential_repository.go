@@ -0,0 +1,44 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jinzhu/gorm"
+	"github.com/joinimpact/api/internal/models"
+	"github.com/rs/zerolog"
+)
+
+// twoFactorCredentialRepository stores and controls TwoFactorCredentials in the database.
+type twoFactorCredentialRepository struct {
+	db     *gorm.DB
+	logger *zerolog.Logger
+}
+
+// NewTwoFactorCredentialRepository creates and returns a new TwoFactorCredentialRepository.
+func NewTwoFactorCredentialRepository(db *gorm.DB, logger *zerolog.Logger) models.TwoFactorCredentialRepository {
+	return &twoFactorCredentialRepository{db, logger}
+}
+
+// FindByUserID finds a single entity by the user ID.
+func (r *twoFactorCredentialRepository) FindByUserID(ctx context.Context, userID int64) (*models.TwoFactorCredential, error) {
+	var credential models.TwoFactorCredential
+	if err := r.db.Where("user_id = ?", userID).First(&credential).Error; err != nil {
+		return &credential, err
+	}
+	return &credential, nil
+}
+
+// Create creates a new entity.
+func (r *twoFactorCredentialRepository) Create(ctx context.Context, credential models.TwoFactorCredential) error {
+	return r.db.Create(&credential).Error
+}
+
+// Save saves all fields in the provided entity.
+func (r *twoFactorCredentialRepository) Save(ctx context.Context, credential models.TwoFactorCredential) error {
+	return r.db.Save(&credential).Error
+}
+
+// DeleteByUserID deletes all entities by the user ID.
+func (r *twoFactorCredentialRepository) DeleteByUserID(ctx context.Context, userID int64) error {
+	return r.db.Unscoped().Where("user_id = ?", userID).Delete(&models.TwoFactorCredential{}).Error
+}
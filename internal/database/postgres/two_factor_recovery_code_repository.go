@@ -0,0 +1,44 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jinzhu/gorm"
+	"github.com/joinimpact/api/internal/models"
+	"github.com/rs/zerolog"
+)
+
+// twoFactorRecoveryCodeRepository stores and controls TwoFactorRecoveryCodes in the database.
+type twoFactorRecoveryCodeRepository struct {
+	db     *gorm.DB
+	logger *zerolog.Logger
+}
+
+// NewTwoFactorRecoveryCodeRepository creates and returns a new TwoFactorRecoveryCodeRepository.
+func NewTwoFactorRecoveryCodeRepository(db *gorm.DB, logger *zerolog.Logger) models.TwoFactorRecoveryCodeRepository {
+	return &twoFactorRecoveryCodeRepository{db, logger}
+}
+
+// FindUnusedByUserID finds all codes of a user which have not been used.
+func (r *twoFactorRecoveryCodeRepository) FindUnusedByUserID(ctx context.Context, userID int64) ([]models.TwoFactorRecoveryCode, error) {
+	var codes []models.TwoFactorRecoveryCode
+	if err := r.db.Where("user_id = ? AND used_at IS NULL", userID).Find(&codes).Error; err != nil {
+		return codes, err
+	}
+	return codes, nil
+}
+
+// Create creates a new entity.
+func (r *twoFactorRecoveryCodeRepository) Create(ctx context.Context, code models.TwoFactorRecoveryCode) error {
+	return r.db.Create(&code).Error
+}
+
+// Update updates an entity with the ID in the provided entity.
+func (r *twoFactorRecoveryCodeRepository) Update(ctx context.Context, code models.TwoFactorRecoveryCode) error {
+	return r.db.Model(&models.TwoFactorRecoveryCode{}).Updates(code).Error
+}
+
+// DeleteByUserID deletes all entities by the user ID.
+func (r *twoFactorRecoveryCodeRepository) DeleteByUserID(ctx context.Context, userID int64) error {
+	return r.db.Unscoped().Where("user_id = ?", userID).Delete(&models.TwoFactorRecoveryCode{}).Error
+}
@@ -52,9 +52,14 @@ func (r *userProfileFieldRepository) Create(profileField models.UserProfileField
 	return r.db.Create(&profileField).Error
 }
 
-// Update updates an entity with the ID in the provided entity.
+// Update updates an entity with the ID in the provided entity. The privacy is always
+// written so that fields can be made public again.
 func (r *userProfileFieldRepository) Update(profileField models.UserProfileField) error {
-	return r.db.Model(&models.UserProfileField{}).Updates(&profileField).Error
+	if err := r.db.Model(&models.UserProfileField{}).Updates(&profileField).Error; err != nil {
+		return err
+	}
+
+	return r.db.Model(&profileField).Update("privacy", profileField.Privacy).Error
 }
 
 // DeleteByID deletes an entity by ID.
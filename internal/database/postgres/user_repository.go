@@ -1,6 +1,8 @@
 package postgres
 
 import (
+	"time"
+
 	"github.com/jinzhu/gorm"
 	"github.com/joinimpact/api/internal/models"
 	"github.com/rs/zerolog"
@@ -53,3 +55,134 @@ func (r *userRepository) DeleteByID(id int64) error {
 		},
 	}).Error
 }
+
+// FindScheduledForDeletion finds all Users whose deletion was scheduled for before a time.
+func (r *userRepository) FindScheduledForDeletion(before time.Time) ([]models.User, error) {
+	var users []models.User
+	if err := r.db.Where("deletion_scheduled_at IS NOT NULL AND deletion_scheduled_at <= ?", before).Find(&users).Error; err != nil {
+		return users, err
+	}
+	return users, nil
+}
+
+// SetDeletionScheduledAt schedules the deletion of a User, or cancels it if deletionScheduledAt is nil.
+func (r *userRepository) SetDeletionScheduledAt(id int64, deletionScheduledAt *time.Time) error {
+	return r.db.
+		Model(&models.User{}).
+		Where("id = ?", id).
+		Update("deletion_scheduled_at", deletionScheduledAt).Error
+}
+
+// Erase permanently deletes a User and their personal data in a single
+// transaction. Records which other users and organizations rely on, such as
+// messages and volunteering hour logs, are kept but no longer reference the
+// user.
+func (r *userRepository) Erase(id int64) error {
+	var user models.User
+	if err := r.db.Unscoped().First(&user, id).Error; err != nil {
+		return err
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		tx = tx.Unscoped()
+
+		// Places the user took in event shifts are freed before their sign-ups are deleted.
+		if err := tx.
+			Model(&models.EventShift{}).
+			Where("id IN (SELECT shift_id FROM event_shift_signups WHERE user_id = ?)", id).
+			UpdateColumn("filled", gorm.Expr("filled - 1")).Error; err != nil {
+			return err
+		}
+
+		// Records which only concern the user are deleted.
+		deletions := []struct {
+			where string
+			model interface{}
+		}{
+			{"user_id = ?", &models.UserProfileField{}},
+			{"user_id = ?", &models.UserTag{}},
+			{"user_id = ?", &models.ThirdPartyIdentity{}},
+			{"user_id = ?", &models.Session{}},
+			{"user_id = ?", &models.TwoFactorCredential{}},
+			{"user_id = ?", &models.TwoFactorRecoveryCode{}},
+			{"user_id = ?", &models.PasswordResetKey{}},
+			{"user_id = ?", &models.EmailVerificationKey{}},
+			{"user_id = ?", &models.OrganizationMembership{}},
+			{"user_id = ?", &models.OpportunityMembership{}},
+			{"user_id = ?", &models.ConversationMembership{}},
+			{"user_id = ?", &models.EventResponse{}},
+			{"user_id = ?", &models.EventShiftSignup{}},
+			{"user_id = ?", &models.EventCheckIn{}},
+			{"user_id = ?", &models.CalendarFeed{}},
+			{"user_id = ?", &models.DataExport{}},
+			{"invitee_id = ?", &models.OrganizationMembershipInvite{}},
+			{"invitee_id = ?", &models.OpportunityMembershipInvite{}},
+			{"opportunity_membership_request_id IN (SELECT id FROM opportunity_membership_requests WHERE volunteer_id = ?)", &models.OpportunityApplicationAnswer{}},
+			{"volunteer_id = ?", &models.OpportunityMembershipRequest{}},
+			{"volunteer_id = ?", &models.OpportunityWaitlistEntry{}},
+			{"volunteer_id = ?", &models.VolunteeringHourLogRequest{}},
+			{"from_user_id = ?", &models.OrganizationOwnershipTransfer{}},
+			{"to_user_id = ?", &models.OrganizationOwnershipTransfer{}},
+		}
+		for _, deletion := range deletions {
+			if err := tx.Where(deletion.where, id).Delete(deletion.model).Error; err != nil {
+				return err
+			}
+		}
+
+		// Invites sent to the user's email before they registered.
+		if err := tx.Where("invitee_email = ?", user.Email).Delete(&models.OrganizationMembershipInvite{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("invitee_email = ?", user.Email).Delete(&models.OpportunityMembershipInvite{}).Error; err != nil {
+			return err
+		}
+
+		// Records which others rely on are kept without a reference to the user.
+		anonymisations := []struct {
+			column string
+			model  interface{}
+		}{
+			{"volunteer_id", &models.VolunteeringHourLog{}},
+			{"granter_id", &models.VolunteeringHourLog{}},
+			{"checked_in_by_id", &models.EventCheckIn{}},
+			{"checked_out_by_id", &models.EventCheckIn{}},
+			{"inviter_id", &models.OrganizationMembership{}},
+			{"inviter_id", &models.OpportunityMembership{}},
+			{"inviter_id", &models.OrganizationMembershipInvite{}},
+			{"inviter_id", &models.OpportunityMembershipInvite{}},
+			{"creator_id", &models.Organization{}},
+			{"creator_id", &models.Opportunity{}},
+			{"creator_id", &models.Event{}},
+			{"creator_id", &models.Conversation{}},
+			{"creator_id", &models.OrganizationAPIKey{}},
+			{"submitter_id", &models.OrganizationVerification{}},
+			{"reviewer_id", &models.OrganizationVerification{}},
+		}
+		for _, anonymisation := range anonymisations {
+			if err := tx.
+				Model(anonymisation.model).
+				Where(anonymisation.column+" = ?", id).
+				UpdateColumn(anonymisation.column, models.ErasedUserID).Error; err != nil {
+				return err
+			}
+		}
+
+		// Messages stay in their conversations, but their content is removed.
+		if err := tx.
+			Model(&models.Message{}).
+			Where("sender_id = ?", id).
+			UpdateColumns(map[string]interface{}{
+				"sender_id": models.ErasedUserID,
+				"body":      gorm.Expr("'{}'::jsonb"),
+			}).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(&models.User{
+			Model: models.Model{
+				ID: id,
+			},
+		}).Error
+	})
+}
@@ -105,6 +105,60 @@ func (r *volunteeringHourLogRepository) FindByOpportunityID(ctx context.Context,
 	return response, nil
 }
 
+// SummarizeByOrganizationIDs sums up the hours of multiple organizations.
+func (r *volunteeringHourLogRepository) SummarizeByOrganizationIDs(ctx context.Context, organizationIDs []int64) ([]models.VolunteeringHourSummary, error) {
+	summaries := []models.VolunteeringHourSummary{}
+
+	dbctx := dbctx.Get(ctx)
+
+	db := r.db.
+		Model(&models.VolunteeringHourLog{}).
+		Select("organization_id, SUM(granted_hours) AS hours, COUNT(DISTINCT volunteer_id) AS volunteers").
+		Where("organization_id IN (?)", organizationIDs).
+		Group("organization_id")
+
+	if dbctx.From != nil {
+		db = db.Where("granted_on >= ?", *dbctx.From)
+	}
+
+	if dbctx.To != nil {
+		db = db.Where("granted_on < ?", *dbctx.To)
+	}
+
+	if err := db.Scan(&summaries).Error; err != nil {
+		return summaries, err
+	}
+
+	return summaries, nil
+}
+
+// SummarizeByOpportunity sums up the hours of an organization per opportunity.
+func (r *volunteeringHourLogRepository) SummarizeByOpportunity(ctx context.Context, organizationID int64) ([]models.VolunteeringHourOpportunitySummary, error) {
+	summaries := []models.VolunteeringHourOpportunitySummary{}
+
+	dbctx := dbctx.Get(ctx)
+
+	db := r.db.
+		Model(&models.VolunteeringHourLog{}).
+		Select("opportunity_id, SUM(granted_hours) AS hours, COUNT(DISTINCT volunteer_id) AS volunteers").
+		Where("organization_id = ?", organizationID).
+		Group("opportunity_id")
+
+	if dbctx.From != nil {
+		db = db.Where("granted_on >= ?", *dbctx.From)
+	}
+
+	if dbctx.To != nil {
+		db = db.Where("granted_on < ?", *dbctx.To)
+	}
+
+	if err := db.Scan(&summaries).Error; err != nil {
+		return summaries, err
+	}
+
+	return summaries, nil
+}
+
 // FindByVolunteerID finds multiple entities by the volunteer ID.
 func (r *volunteeringHourLogRepository) FindByVolunteerID(ctx context.Context, volunteerID int64) (*models.VolunteeringHourLogsResponse, error) {
 	response := &models.VolunteeringHourLogsResponse{}
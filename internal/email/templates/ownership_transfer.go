@@ -0,0 +1,112 @@
+package templates
+
+import (
+	"fmt"
+	"strings"
+)
+
+const ownershipTransferTemplate = `
+<!DOCTYPE html>
+<html>
+  <body>
+    <table
+      style="
+        font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto,
+          Helvetica, Arial, sans-serif, 'Apple Color Emoji', 'Segoe UI Emoji',
+          'Segoe UI Symbol';
+        font-size: 16px;
+        color: rgb(10, 31, 51);
+        width: 100%;
+        padding: 30px;
+        margin: 15px;
+        border-radius: 12px;
+        border: 1px solid #e0e7ee;
+        max-width: 500px;
+        box-shadow: 0px 2px 4px rgba(10, 31, 51, 0.04);
+      "
+    >
+      <tr style="display: block; padding-bottom: 30px;">
+        <td>
+          <img
+            width="156"
+            src="https://impact-cdn.sfo2.digitaloceanspaces.com/impact-logo.png"
+          />
+        </td>
+      </tr>
+      <tr
+        style="
+          display: block;
+          padding: 8px 0px;
+          padding-top: 30px;
+          border-top: 1px solid #e0e7ee;
+        "
+      >
+        <td>
+          <center>
+            <h3 style="font-size: 1.25rem; font-weight: bold; margin: 0;">
+              {{organizationName}} is yours to take over
+            </h3>
+          </center>
+        </td>
+      </tr>
+      <tr style="display: block; padding: 12px 0px; padding-top: 0px;">
+        <td>
+          <p
+            style="
+              margin: 0;
+              line-height: 1.5;
+              color: rgb(69, 94, 117);
+              max-width: 360px;
+            "
+          >
+            Hey {{name}}, {{fromName}} would like to transfer ownership of
+            {{organizationName}} to you. As the owner you will be responsible for
+            its settings and members. The request expires in 7 days.
+          </p>
+        </td>
+      </tr>
+      <tr>
+        <td>
+          <a
+            style="color: initial;"
+            href="https://joinimpact.org/dashboard/organizations/{{organizationID}}/transfer"
+            >Review the transfer</a
+          >
+        </td>
+      </tr>
+      <tr style="display: block; padding-top: 16px;">
+        <td>
+          <p
+            style="
+              margin: 0;
+              line-height: 1.5;
+              color: rgb(69, 94, 117);
+              max-width: 360px;
+            "
+          >
+            Love,
+            <br />
+            Impact
+          </p>
+        </td>
+      </tr>
+    </table>
+  </body>
+</html>
+`
+
+// OwnershipTransferTemplate generates and returns an email asking a member to
+// accept ownership of an organization, with the provided name, organization
+// and sender name.
+func OwnershipTransferTemplate(name, organizationName string, organizationID int64, fromName string) string {
+	template := ownershipTransferTemplate
+
+	// Replace the template variables with the provided params.
+	template = strings.Replace(template, `{{name}}`, name, -1)
+	template = strings.Replace(template, `{{organizationName}}`, organizationName, -1)
+	template = strings.Replace(template, `{{organizationID}}`, fmt.Sprintf("%d", organizationID), -1)
+	template = strings.Replace(template, `{{fromName}}`, fromName, -1)
+
+	// Return the HTML string.
+	return template
+}
@@ -0,0 +1,115 @@
+package templates
+
+import (
+	"fmt"
+	"strings"
+)
+
+const waitlistOfferTemplate = `
+<!DOCTYPE html>
+<html>
+  <body>
+    <table
+      style="
+        font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto,
+          Helvetica, Arial, sans-serif, 'Apple Color Emoji', 'Segoe UI Emoji',
+          'Segoe UI Symbol';
+        font-size: 16px;
+        color: rgb(10, 31, 51);
+        width: 100%;
+        padding: 30px;
+        margin: 15px;
+        border-radius: 12px;
+        border: 1px solid #e0e7ee;
+        max-width: 500px;
+        box-shadow: 0px 2px 4px rgba(10, 31, 51, 0.04);
+      "
+    >
+      <tr style="display: block; padding-bottom: 30px;">
+        <td>
+          <img
+            width="156"
+            src="https://impact-cdn.sfo2.digitaloceanspaces.com/impact-logo.png"
+          />
+        </td>
+      </tr>
+      <tr
+        style="
+          display: block;
+          padding: 8px 0px;
+          padding-top: 30px;
+          border-top: 1px solid #e0e7ee;
+        "
+      >
+        <td>
+          <center>
+            <h3 style="font-size: 1.25rem; font-weight: bold; margin: 0;">
+              A spot opened up in {{opportunityTitle}}
+            </h3>
+          </center>
+        </td>
+      </tr>
+      <tr style="display: block; padding: 12px 0px; padding-top: 0px;">
+        <td>
+          <p
+            style="
+              margin: 0;
+              line-height: 1.5;
+              color: rgb(69, 94, 117);
+              max-width: 360px;
+            "
+          >
+            Hey {{name}}, good news! A spot opened up in {{opportunityTitle}} by
+            {{organizationName}}, and you are next on the waitlist. The spot is
+            held for you for {{hours}} hours, after which it will be offered to
+            the next volunteer. Once you claim it, {{organizationName}} will
+            review your application.
+          </p>
+        </td>
+      </tr>
+      <tr>
+        <td>
+          <a
+            style="color: initial;"
+            href="https://joinimpact.org/dashboard/user/opportunities/{{opportunityID}}/waitlist"
+            >Claim your spot</a
+          >
+        </td>
+      </tr>
+      <tr style="display: block; padding-top: 16px;">
+        <td>
+          <p
+            style="
+              margin: 0;
+              line-height: 1.5;
+              color: rgb(69, 94, 117);
+              max-width: 360px;
+            "
+          >
+            Love,
+            <br />
+            Impact
+          </p>
+        </td>
+      </tr>
+    </table>
+  </body>
+</html>
+`
+
+// WaitlistOfferTemplate generates and returns an email offering a waitlisted
+// volunteer a spot in an opportunity, with the provided name, opportunity and
+// number of hours the offer stays open.
+func WaitlistOfferTemplate(name, opportunityTitle, organizationName string, opportunityID int64, hours int) string {
+	template := waitlistOfferTemplate
+
+	// Replace the template variables with the provided params.
+	template = strings.Replace(template, `{{name}}`, name, -1)
+	template = strings.Replace(template, `{{opportunityTitle}}`, opportunityTitle, -1)
+	template = strings.Replace(template, `{{organizationName}}`, organizationName, -1)
+	template = strings.Replace(template, `{{opportunityID}}`, fmt.Sprintf("%d", opportunityID), -1)
+	template = strings.Replace(template, `{{hours}}`, fmt.Sprintf("%d", hours), -1)
+
+	// Return the HTML string.
+	return template
+}
@@ -0,0 +1,408 @@
+package events
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/joinimpact/api/internal/models"
+)
+
+// Check-in options
+const (
+	checkInCodePeriod  = 5 * time.Minute // how often check-in codes rotate; the previous code is still accepted
+	checkInOpensBefore = time.Hour       // how long before an event starts volunteers can check in
+	checkInMACBytes    = 18              // the number of bytes of the signature in check-in codes
+	maxCheckInHours    = 24              // the most hours logged for a single check-in
+)
+
+// CheckInCode is the current check-in code of an event, which coordinators show as a QR code for volunteers
+// to scan.
+type CheckInCode struct {
+	Code      string    `json:"code"`
+	ExpiresAt time.Time `json:"expiresAt"` // when the code rotates, after which it is accepted for one more period
+}
+
+// AttendanceReport reports the attendance of an event, or of a single occurrence of a recurring event.
+type AttendanceReport struct {
+	EventID    int64                  `json:"eventId"`
+	Occurrence *time.Time             `json:"occurrence,omitempty"`
+	CheckedIn  int                    `json:"checkedIn"`           // the number of check-ins
+	CheckedOut int                    `json:"checkedOut"`          // the number of check-ins which were checked out
+	TotalHours float32                `json:"totalHours"`          // the total hours logged on check-out
+	Responses  *EventResponsesSummary `json:"responses,omitempty"` // the RSVPs to compare attendance with, unless all occurrences are reported
+	Attendees  []models.EventCheckIn  `json:"attendees"`
+}
+
+// GetCheckInCode gets the current check-in code of an event.
+func (s *service) GetCheckInCode(ctx context.Context, eventID int64) (*CheckInCode, error) {
+	if _, err := s.eventRepository.FindByID(ctx, eventID); err != nil {
+		return nil, NewErrEventNotFound()
+	}
+
+	secret, err := s.checkInSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	period := checkInPeriod(time.Now())
+	return &CheckInCode{
+		Code:      signCheckInCode(secret, eventID, period),
+		ExpiresAt: time.Unix((period+1)*int64(checkInCodePeriod/time.Second), 0),
+	}, nil
+}
+
+// CheckIn checks a volunteer in to an event with the check-in code shown by a coordinator. Volunteers are
+// checked in to the occurrence of a recurring event which is running or about to start.
+func (s *service) CheckIn(ctx context.Context, eventID, userID int64, code string) (*models.EventCheckIn, error) {
+	secret, err := s.checkInSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if !verifyCheckInCode(secret, eventID, code, now) {
+		return nil, NewErrInvalidCheckInCode()
+	}
+
+	event, err := s.eventRepository.FindByID(ctx, eventID)
+	if err != nil {
+		return nil, NewErrEventNotFound()
+	}
+
+	occurrence, err := s.currentOccurrence(ctx, *event, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.checkIn(ctx, *event, userID, occurrence, 0, now)
+}
+
+// CheckInVolunteer checks a volunteer in to an event on behalf of a manager, such as when the volunteer can
+// not scan the check-in code. Managers choose the occurrence of recurring events themselves.
+func (s *service) CheckInVolunteer(ctx context.Context, eventID, userID, managerID int64, occurrence *time.Time) (*models.EventCheckIn, error) {
+	if err := s.checkOccurrence(ctx, eventID, occurrence); err != nil {
+		return nil, err
+	}
+
+	event, err := s.eventRepository.FindByID(ctx, eventID)
+	if err != nil {
+		return nil, NewErrEventNotFound()
+	}
+
+	return s.checkIn(ctx, *event, userID, occurrence, managerID, time.Now())
+}
+
+// CheckOut checks a volunteer out of an event, and logs the hours they were checked in while the event or
+// occurrence was running, capped at the hours of the event. The manager ID is 0 when volunteers check
+// themselves out.
+func (s *service) CheckOut(ctx context.Context, eventID, userID, managerID int64) (*models.EventCheckIn, error) {
+	event, err := s.eventRepository.FindByID(ctx, eventID)
+	if err != nil {
+		return nil, NewErrEventNotFound()
+	}
+
+	checkIn, err := s.eventCheckInRepository.FindOpenByUserID(ctx, eventID, userID)
+	if err != nil {
+		return nil, NewErrNotCheckedIn()
+	}
+
+	opportunity, err := s.opportunityRepository.FindByID(ctx, event.OpportunityID)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error finding event opportunity")
+		return nil, NewErrServerError()
+	}
+
+	attended, err := s.occurrenceEvent(ctx, *event, checkIn.OccurrenceStart)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error finding checked in occurrence")
+		return nil, NewErrServerError()
+	}
+
+	now := time.Now()
+	checkIn.CheckedOutAt = &now
+	checkIn.CheckedOutByID = managerID
+	checkIn.Hours = checkInHours(checkIn.CheckedInAt, now, attended.FromDate, checkInCloses(attended), s.eventHourCap(attended))
+
+	// The hours are granted by the manager who checked the volunteer out or in, or else by the creator of
+	// the event.
+	granterID := managerID
+	if granterID == 0 {
+		granterID = checkIn.CheckedInByID
+	}
+	if granterID == 0 {
+		granterID = event.CreatorID
+	}
+
+	hourLog := models.VolunteeringHourLog{
+		OpportunityID:  event.OpportunityID,
+		OrganizationID: opportunity.OrganizationID,
+		VolunteerID:    userID,
+		EventID:        eventID,
+		GranterID:      granterID,
+		GrantedOn:      now,
+		GrantedHours:   checkIn.Hours,
+	}
+	hourLog.ID = s.snowflakeService.GenerateID()
+	checkIn.HourLogID = &hourLog.ID
+
+	ok, err := s.eventCheckInRepository.CheckOut(ctx, *checkIn, hourLog)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error checking out of event")
+		return nil, NewErrServerError()
+	}
+
+	if !ok {
+		return nil, NewErrNotCheckedIn()
+	}
+
+	return checkIn, nil
+}
+
+// GetAttendanceReport reports who checked in to an event and the hours they were logged, for all occurrences
+// of a recurring event unless a single occurrence is given.
+func (s *service) GetAttendanceReport(ctx context.Context, eventID int64, occurrence *time.Time) (*AttendanceReport, error) {
+	event, err := s.eventRepository.FindByID(ctx, eventID)
+	if err != nil {
+		return nil, NewErrEventNotFound()
+	}
+
+	if occurrence != nil {
+		if err := s.checkOccurrence(ctx, eventID, occurrence); err != nil {
+			return nil, err
+		}
+	}
+
+	checkIns, err := s.eventCheckInRepository.FindByEventID(ctx, eventID, occurrence)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error finding event check-ins")
+		return nil, NewErrServerError()
+	}
+
+	report := &AttendanceReport{
+		EventID:    eventID,
+		Occurrence: occurrence,
+		Attendees:  checkIns,
+	}
+
+	for _, checkIn := range checkIns {
+		report.CheckedIn++
+		if checkIn.CheckedOutAt != nil {
+			report.CheckedOut++
+			report.TotalHours += checkIn.Hours
+		}
+	}
+
+	if event.Recurrence == "" || occurrence != nil {
+		report.Responses, err = s.getEventResponsesSummary(ctx, eventID, occurrence)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return report, nil
+}
+
+// checkIn checks a volunteer in to an event, or to a single occurrence of a recurring event, unless they
+// already checked in to it. The manager ID is 0 when volunteers check themselves in.
+func (s *service) checkIn(ctx context.Context, event models.Event, userID int64, occurrence *time.Time, managerID int64, now time.Time) (*models.EventCheckIn, error) {
+	membership, err := s.opportunityMembershipRepository.FindUserInOpportunity(ctx, event.OpportunityID, userID)
+	if err != nil || membership.PermissionsFlag != models.OpportunityPermissionsMember {
+		return nil, NewErrNotVolunteer()
+	}
+
+	checkIn := models.EventCheckIn{
+		EventID:         event.ID,
+		UserID:          userID,
+		OccurrenceStart: occurrence,
+		CheckedInAt:     now,
+		CheckedInByID:   managerID,
+	}
+	checkIn.ID = s.snowflakeService.GenerateID()
+	if occurrence != nil {
+		checkIn.OccurrenceKey = occurrence.UnixNano()
+	}
+
+	ok, err := s.eventCheckInRepository.Create(ctx, checkIn)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error creating event check-in")
+		return nil, NewErrServerError()
+	}
+
+	if !ok {
+		return nil, NewErrAlreadyCheckedIn()
+	}
+
+	return &checkIn, nil
+}
+
+// currentOccurrence finds the occurrence of a recurring event which volunteers can check in to at a time.
+// Events which do not repeat have no occurrences, so nil is returned while they are open for check-in.
+func (s *service) currentOccurrence(ctx context.Context, event models.Event, now time.Time) (*time.Time, error) {
+	if event.Recurrence == "" {
+		if !checkInOpen(event, now) {
+			return nil, NewErrCheckInClosed()
+		}
+
+		return nil, nil
+	}
+
+	rule, dtstart, err := eventRule(event)
+	if err != nil {
+		return nil, NewErrCheckInClosed()
+	}
+
+	overrides, err := s.eventOccurrenceOverrideRepository.FindByEventID(ctx, event.ID)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error finding occurrence overrides")
+		return nil, NewErrServerError()
+	}
+
+	event.FromDate = dtstart
+	for _, occurrence := range expandEvent(event, rule, overrides, now.Add(-maxCheckInHours*time.Hour), now.Add(checkInOpensBefore)) {
+		if checkInOpen(occurrence.event, now) {
+			return occurrence.start, nil
+		}
+	}
+
+	return nil, NewErrCheckInClosed()
+}
+
+// occurrenceEvent returns an event with the dates of one of its occurrences, or the event itself if no
+// occurrence is given.
+func (s *service) occurrenceEvent(ctx context.Context, event models.Event, start *time.Time) (models.Event, error) {
+	if start == nil {
+		return event, nil
+	}
+
+	duration := event.ToDate.Sub(event.FromDate)
+	if duration < 0 {
+		duration = 0
+	}
+
+	event.FromDate = *start
+	event.ToDate = start.Add(duration)
+
+	override, err := s.eventOccurrenceOverrideRepository.FindByOccurrence(ctx, event.ID, *start)
+	if gorm.IsRecordNotFoundError(err) {
+		return event, nil
+	}
+
+	if err != nil {
+		return event, err
+	}
+
+	applyOverride(&event, *override)
+	return event, nil
+}
+
+// eventHourCap returns the most hours logged for attending an event: the hours of the event if they are
+// set, or else its scheduled length, and never more than maxCheckInHours.
+func (s *service) eventHourCap(event models.Event) float64 {
+	hours := float64(s.calculateTotalHours(event))
+	if hours <= 0 {
+		hours = event.ToDate.Sub(event.FromDate).Hours()
+	}
+
+	if hours <= 0 || hours > maxCheckInHours {
+		hours = maxCheckInHours
+	}
+
+	return hours
+}
+
+// checkInSecret returns the secret check-in codes are signed with.
+func (s *service) checkInSecret() ([]byte, error) {
+	secret := s.config.CheckInSecret
+	if secret == "" {
+		secret = s.config.JWTSecret
+	}
+
+	if secret == "" {
+		s.logger.Error().Msg("No secret configured for signing check-in codes")
+		return nil, NewErrServerError()
+	}
+
+	return []byte(secret), nil
+}
+
+// checkInOpen returns true if volunteers can check in to an event at a time, from checkInOpensBefore before
+// it starts until it closes.
+func checkInOpen(event models.Event, now time.Time) bool {
+	if now.Before(event.FromDate.Add(-checkInOpensBefore)) {
+		return false
+	}
+
+	return now.Before(checkInCloses(event))
+}
+
+// checkInCloses returns when check-in to an event closes, which is when it ends. Events with only dates, or
+// without an end, close at the end of their last day.
+func checkInCloses(event models.Event) time.Time {
+	closes := event.ToDate
+	if !closes.After(event.FromDate) {
+		closes = event.FromDate
+	}
+
+	if closes.Equal(event.FromDate) || (event.DateOnly != nil && *event.DateOnly) {
+		closes = closes.AddDate(0, 0, 1)
+	}
+
+	return closes
+}
+
+// checkInHours returns the hours a volunteer was checked in while an event was running, from the later of
+// checking in and the start of the event until the earlier of checking out and the close of the event,
+// capped and rounded to two decimals.
+func checkInHours(checkedIn, checkedOut, starts, closes time.Time, cap float64) float32 {
+	if checkedIn.Before(starts) {
+		checkedIn = starts
+	}
+
+	if checkedOut.After(closes) {
+		checkedOut = closes
+	}
+
+	hours := checkedOut.Sub(checkedIn).Hours()
+	if hours < 0 {
+		hours = 0
+	}
+
+	if hours > cap {
+		hours = cap
+	}
+
+	return float32(math.Round(hours*100) / 100)
+}
+
+// checkInPeriod returns the number of the check-in code period a time is in.
+func checkInPeriod(t time.Time) int64 {
+	return t.Unix() / int64(checkInCodePeriod/time.Second)
+}
+
+// signCheckInCode returns the check-in code of an event for a period. Codes include the event ID, so that
+// the code of one event can not be used to check in to another.
+func signCheckInCode(secret []byte, eventID, period int64) string {
+	mac := hmac.New(sha256.New, secret)
+	fmt.Fprintf(mac, "check-in:%d:%d", eventID, period)
+
+	return fmt.Sprintf("%d.%d.%s", eventID, period, base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:checkInMACBytes]))
+}
+
+// verifyCheckInCode returns true if a code is the check-in code of an event for the current or the previous
+// period, so that volunteers who scan a code just before it rotates can still check in.
+func verifyCheckInCode(secret []byte, eventID int64, code string, now time.Time) bool {
+	period := checkInPeriod(now)
+	for _, p := range []int64{period, period - 1} {
+		if hmac.Equal([]byte(code), []byte(signCheckInCode(secret, eventID, p))) {
+			return true
+		}
+	}
+
+	return false
+}
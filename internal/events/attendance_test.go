@@ -0,0 +1,103 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/joinimpact/api/internal/models"
+)
+
+// TestVerifyCheckInCode tests that check-in codes are accepted for two periods and only for their event.
+func TestVerifyCheckInCode(t *testing.T) {
+	secret := []byte("secret")
+	now := time.Date(2020, 9, 1, 18, 2, 0, 0, time.UTC)
+	code := signCheckInCode(secret, 42, checkInPeriod(now))
+
+	if !verifyCheckInCode(secret, 42, code, now) {
+		t.Error("current code rejected")
+	}
+
+	if !verifyCheckInCode(secret, 42, code, now.Add(checkInCodePeriod)) {
+		t.Error("previous code rejected")
+	}
+
+	if verifyCheckInCode(secret, 42, code, now.Add(2*checkInCodePeriod)) {
+		t.Error("expired code accepted")
+	}
+
+	if verifyCheckInCode(secret, 43, code, now) {
+		t.Error("code accepted for another event")
+	}
+
+	if verifyCheckInCode([]byte("other secret"), 42, code, now) {
+		t.Error("code accepted with another secret")
+	}
+}
+
+// TestCheckInOpen tests that events are open for check-in from shortly before they start until they end.
+func TestCheckInOpen(t *testing.T) {
+	from := time.Date(2020, 9, 1, 18, 0, 0, 0, time.UTC)
+	event := models.Event{FromDate: from, ToDate: from.Add(2 * time.Hour)}
+
+	tests := []struct {
+		now  time.Time
+		open bool
+	}{
+		{from.Add(-2 * time.Hour), false},
+		{from.Add(-30 * time.Minute), true},
+		{from.Add(time.Hour), true},
+		{from.Add(3 * time.Hour), false},
+	}
+	for _, test := range tests {
+		if open := checkInOpen(event, test.now); open != test.open {
+			t.Errorf("checkInOpen at %s = %t, expected %t", test.now, open, test.open)
+		}
+	}
+
+	dateOnly := true
+	event = models.Event{FromDate: from.Truncate(24 * time.Hour), ToDate: from.Truncate(24 * time.Hour), DateOnly: &dateOnly}
+	if !checkInOpen(event, from.Add(4*time.Hour)) {
+		t.Error("date only event closed before the end of its day")
+	}
+}
+
+// TestCheckInHours tests that logged hours only count while the event runs, and are rounded and capped.
+func TestCheckInHours(t *testing.T) {
+	starts := time.Date(2020, 9, 1, 18, 0, 0, 0, time.UTC)
+	closes := starts.Add(5 * time.Hour)
+
+	tests := []struct {
+		checkedIn  time.Time
+		checkedOut time.Time
+		hours      float32
+	}{
+		{starts, starts.Add(100 * time.Minute), 1.67},
+		{starts, starts.Add(6 * time.Hour), 4},
+		{starts.Add(-time.Hour), starts.Add(time.Hour), 1},
+		{starts.Add(4 * time.Hour), starts.Add(72 * time.Hour), 1},
+		{starts.Add(-time.Hour), starts.Add(-time.Minute), 0},
+	}
+	for _, test := range tests {
+		if hours := checkInHours(test.checkedIn, test.checkedOut, starts, closes, 4); hours != test.hours {
+			t.Errorf("checkInHours from %s to %s = %v, expected %v", test.checkedIn, test.checkedOut, hours, test.hours)
+		}
+	}
+}
+
+// TestEventHourCap tests that hours are capped at the event's hours, or else at its scheduled length.
+func TestEventHourCap(t *testing.T) {
+	s := &service{}
+	from := time.Date(2020, 9, 1, 18, 0, 0, 0, time.UTC)
+
+	if cap := s.eventHourCap(models.Event{Hours: 3, FromDate: from, ToDate: from.Add(5 * time.Hour)}); cap != 3 {
+		t.Errorf("unexpected cap %v for event with hours", cap)
+	}
+
+	if cap := s.eventHourCap(models.Event{FromDate: from, ToDate: from.Add(5 * time.Hour)}); cap != 5 {
+		t.Errorf("unexpected cap %v for event without hours", cap)
+	}
+
+	if cap := s.eventHourCap(models.Event{FromDate: from, ToDate: from}); cap != maxCheckInHours {
+		t.Errorf("unexpected cap %v for event without hours or length", cap)
+	}
+}
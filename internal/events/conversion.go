@@ -2,6 +2,7 @@ package events
 
 import (
 	"context"
+	"time"
 
 	"github.com/joinimpact/api/internal/models"
 	"github.com/joinimpact/api/pkg/location"
@@ -24,6 +25,8 @@ func (s *service) requestToEvent(request ModifyEventRequest) models.Event {
 		event.DateOnly = request.EventSchedule.DateOnly
 		event.FromDate = request.EventSchedule.FromDate
 		event.ToDate = request.EventSchedule.ToDate
+		event.Recurrence = request.EventSchedule.Recurrence
+		event.TimeZone = request.EventSchedule.TimeZone
 	}
 
 	if request.Location != nil {
@@ -77,26 +80,80 @@ func (s *service) eventToView(event models.Event) (*EventView, error) {
 	view.EventSchedule.SingleDate = event.FromDate.Equal(event.ToDate)
 	view.EventSchedule.FromDate = event.FromDate
 	view.EventSchedule.ToDate = event.ToDate
+	view.EventSchedule.Recurrence = event.Recurrence
+	view.EventSchedule.TimeZone = event.TimeZone
 	view.TotalHours = s.calculateTotalHours(event)
+	view.Sequence = event.Sequence
+	view.UpdatedAt = event.UpdatedAt
 
 	return view, nil
 }
 
-// getEventResponsesSummary gets an EventResponsesSummary for a single event by ID.
-func (s *service) getEventResponsesSummary(ctx context.Context, eventID int64) (*EventResponsesSummary, error) {
+// eventResponses holds the members of an event and all of their responses to it, so that the responses
+// to each occurrence of the event can be looked up without a query per member.
+type eventResponses struct {
+	memberIDs []int64
+	responses map[eventResponseKey]models.EventResponse
+}
+
+// eventResponseKey identifies a member's response to an event, or to a single occurrence of a recurring
+// event by the occurrence's start in Unix nanoseconds.
+type eventResponseKey struct {
+	userID     int64
+	occurrence int64
+}
+
+// newEventResponseKey creates and returns the eventResponseKey of a member's response.
+func newEventResponseKey(userID int64, occurrence *time.Time) eventResponseKey {
+	key := eventResponseKey{userID: userID}
+	if occurrence != nil {
+		key.occurrence = occurrence.UnixNano()
+	}
+
+	return key
+}
+
+// getEventResponses loads the members of an event and all of their responses to it in two queries.
+func (s *service) getEventResponses(ctx context.Context, eventID int64) (*eventResponses, error) {
 	// Get all event memberships.
 	ids, err := s.getEventMemberships(ctx, eventID)
 	if err != nil {
 		return nil, err
 	}
 
+	responses, err := s.eventResponseRepository.FindByEventID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	eventResponses := &eventResponses{ids, map[eventResponseKey]models.EventResponse{}}
+	for _, response := range responses {
+		eventResponses.responses[newEventResponseKey(response.UserID, response.OccurrenceStart)] = response
+	}
+
+	return eventResponses, nil
+}
+
+// find finds a member's response to the event, or to a single occurrence of a recurring event, and
+// returns false if they have not responded.
+func (r *eventResponses) find(userID int64, occurrence *time.Time) (*models.EventResponse, bool) {
+	response, ok := r.responses[newEventResponseKey(userID, occurrence)]
+	if !ok {
+		return nil, false
+	}
+
+	return &response, true
+}
+
+// summary summarizes the members' responses to the event, or to a single occurrence of a recurring event.
+func (r *eventResponses) summary(occurrence *time.Time) *EventResponsesSummary {
 	summary := &EventResponsesSummary{}
-	summary.TotalMembers = uint(len(ids))
+	summary.TotalMembers = uint(len(r.memberIDs))
 
-	for _, id := range ids {
+	for _, id := range r.memberIDs {
 		// Find a response if one exists.
-		eventResponse, err := s.eventResponseRepository.FindInEventByUserID(ctx, eventID, id)
-		if err != nil {
+		eventResponse, ok := r.find(id, occurrence)
+		if !ok || eventResponse.Response == nil {
 			continue
 		}
 
@@ -108,7 +165,18 @@ func (s *service) getEventResponsesSummary(ctx context.Context, eventID int64) (
 		}
 	}
 
-	return summary, nil
+	return summary
+}
+
+// getEventResponsesSummary gets an EventResponsesSummary for a single event by ID, or for a single occurrence
+// of a recurring event.
+func (s *service) getEventResponsesSummary(ctx context.Context, eventID int64, occurrence *time.Time) (*EventResponsesSummary, error) {
+	responses, err := s.getEventResponses(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	return responses.summary(occurrence), nil
 }
 
 // calculateTotalHours calculates the total hours from an event's dates and the HoursFrequency set.
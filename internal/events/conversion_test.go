@@ -0,0 +1,49 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/joinimpact/api/internal/models"
+)
+
+// TestEventResponsesSummary tests that responses are summarized per occurrence, and only for members.
+func TestEventResponsesSummary(t *testing.T) {
+	canAttend := models.EventResponseCanAttend
+	canNotAttend := models.EventResponseCanNotAttend
+	first := time.Date(2020, 9, 1, 18, 0, 0, 0, time.UTC)
+	second := first.Add(7 * 24 * time.Hour)
+
+	responses := &eventResponses{[]int64{1, 2, 3}, map[eventResponseKey]models.EventResponse{}}
+	for _, response := range []models.EventResponse{
+		{UserID: 1, Response: &canAttend, OccurrenceStart: &first},
+		{UserID: 2, Response: &canNotAttend, OccurrenceStart: &first},
+		{UserID: 1, Response: &canNotAttend, OccurrenceStart: &second},
+		{UserID: 4, Response: &canAttend, OccurrenceStart: &second},
+		{UserID: 3, Response: &canAttend},
+	} {
+		responses.responses[newEventResponseKey(response.UserID, response.OccurrenceStart)] = response
+	}
+
+	tests := []struct {
+		occurrence   *time.Time
+		canAttend    uint
+		canNotAttend uint
+	}{
+		{&first, 1, 1},
+		{&second, 0, 1},
+		{nil, 1, 0},
+	}
+	for _, test := range tests {
+		summary := responses.summary(test.occurrence)
+		if summary.TotalMembers != 3 || summary.NumCanAttend != test.canAttend || summary.NumCanNotAttend != test.canNotAttend {
+			t.Errorf("unexpected summary %+v for occurrence %v", summary, test.occurrence)
+		}
+	}
+
+	// Occurrences are matched by instant, whatever their location.
+	local := first.In(time.FixedZone("EST", -5*3600))
+	if _, ok := responses.find(1, &local); !ok {
+		t.Error("response not found in another location")
+	}
+}
@@ -56,3 +56,345 @@ func (e *ErrServerError) Error() string {
 func (e *ErrServerError) Ref() string {
 	return "generic.server_error"
 }
+
+// ErrShiftNotFound is thrown when the server is unable to find an EventShift.
+type ErrShiftNotFound struct {
+}
+
+// NewErrShiftNotFound creates and returns a ErrShiftNotFound.
+func NewErrShiftNotFound() error {
+	return &ErrShiftNotFound{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrShiftNotFound) Error() string {
+	return "shift not found"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrShiftNotFound) Ref() string {
+	return "events.shift_not_found"
+}
+
+// ErrInvalidShift is thrown when a shift has no title, an invalid time range or an invalid capacity.
+type ErrInvalidShift struct {
+}
+
+// NewErrInvalidShift creates and returns a ErrInvalidShift.
+func NewErrInvalidShift() error {
+	return &ErrInvalidShift{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrInvalidShift) Error() string {
+	return "invalid shift"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrInvalidShift) Ref() string {
+	return "events.invalid_shift"
+}
+
+// ErrShiftCapacityTooLow is thrown when a shift's capacity is lowered below the number of volunteers signed up.
+type ErrShiftCapacityTooLow struct {
+}
+
+// NewErrShiftCapacityTooLow creates and returns a ErrShiftCapacityTooLow.
+func NewErrShiftCapacityTooLow() error {
+	return &ErrShiftCapacityTooLow{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrShiftCapacityTooLow) Error() string {
+	return "capacity is below the number of volunteers signed up"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrShiftCapacityTooLow) Ref() string {
+	return "events.shift_capacity_too_low"
+}
+
+// ErrShiftFull is thrown when a volunteer signs up for a shift which has no free places.
+type ErrShiftFull struct {
+}
+
+// NewErrShiftFull creates and returns a ErrShiftFull.
+func NewErrShiftFull() error {
+	return &ErrShiftFull{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrShiftFull) Error() string {
+	return "shift is full"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrShiftFull) Ref() string {
+	return "events.shift_full"
+}
+
+// ErrAlreadySignedUp is thrown when a volunteer signs up for a shift they are already signed up for.
+type ErrAlreadySignedUp struct {
+}
+
+// NewErrAlreadySignedUp creates and returns a ErrAlreadySignedUp.
+func NewErrAlreadySignedUp() error {
+	return &ErrAlreadySignedUp{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrAlreadySignedUp) Error() string {
+	return "already signed up for shift"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrAlreadySignedUp) Ref() string {
+	return "events.already_signed_up"
+}
+
+// ErrSignupNotFound is thrown when a volunteer drops or swaps a shift they are not signed up for.
+type ErrSignupNotFound struct {
+}
+
+// NewErrSignupNotFound creates and returns a ErrSignupNotFound.
+func NewErrSignupNotFound() error {
+	return &ErrSignupNotFound{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrSignupNotFound) Error() string {
+	return "not signed up for shift"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrSignupNotFound) Ref() string {
+	return "events.signup_not_found"
+}
+
+// ErrNotVolunteer is thrown when a user who is not a volunteer in an event's opportunity signs up for a shift.
+type ErrNotVolunteer struct {
+}
+
+// NewErrNotVolunteer creates and returns a ErrNotVolunteer.
+func NewErrNotVolunteer() error {
+	return &ErrNotVolunteer{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrNotVolunteer) Error() string {
+	return "only volunteers can sign up for shifts"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrNotVolunteer) Ref() string {
+	return "events.not_volunteer"
+}
+
+// ErrMissingRequiredTag is thrown when a volunteer signs up for a shift without the tag it requires.
+type ErrMissingRequiredTag struct {
+}
+
+// NewErrMissingRequiredTag creates and returns a ErrMissingRequiredTag.
+func NewErrMissingRequiredTag() error {
+	return &ErrMissingRequiredTag{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrMissingRequiredTag) Error() string {
+	return "shift requires a tag the volunteer does not have"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrMissingRequiredTag) Ref() string {
+	return "events.missing_required_tag"
+}
+
+// ErrInvalidRecurrence is thrown when an event's recurrence rule or time zone is invalid or unsupported.
+type ErrInvalidRecurrence struct {
+}
+
+// NewErrInvalidRecurrence creates and returns a ErrInvalidRecurrence.
+func NewErrInvalidRecurrence() error {
+	return &ErrInvalidRecurrence{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrInvalidRecurrence) Error() string {
+	return "invalid recurrence rule or time zone"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrInvalidRecurrence) Ref() string {
+	return "events.invalid_recurrence"
+}
+
+// ErrOccurrenceRequired is thrown when a response to a recurring event does not name an occurrence.
+type ErrOccurrenceRequired struct {
+}
+
+// NewErrOccurrenceRequired creates and returns a ErrOccurrenceRequired.
+func NewErrOccurrenceRequired() error {
+	return &ErrOccurrenceRequired{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrOccurrenceRequired) Error() string {
+	return "an occurrence is required for a recurring event"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrOccurrenceRequired) Ref() string {
+	return "events.occurrence_required"
+}
+
+// ErrOccurrenceNotFound is thrown when an event has no occurrence at the requested time.
+type ErrOccurrenceNotFound struct {
+}
+
+// NewErrOccurrenceNotFound creates and returns a ErrOccurrenceNotFound.
+func NewErrOccurrenceNotFound() error {
+	return &ErrOccurrenceNotFound{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrOccurrenceNotFound) Error() string {
+	return "occurrence not found"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrOccurrenceNotFound) Ref() string {
+	return "events.occurrence_not_found"
+}
+
+// ErrInvalidOverride is thrown when an override of an occurrence is invalid.
+type ErrInvalidOverride struct {
+}
+
+// NewErrInvalidOverride creates and returns a ErrInvalidOverride.
+func NewErrInvalidOverride() error {
+	return &ErrInvalidOverride{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrInvalidOverride) Error() string {
+	return "invalid occurrence override"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrInvalidOverride) Ref() string {
+	return "events.invalid_override"
+}
+
+// ErrOverrideNotFound is thrown when the server is unable to find an EventOccurrenceOverride.
+type ErrOverrideNotFound struct {
+}
+
+// NewErrOverrideNotFound creates and returns a ErrOverrideNotFound.
+func NewErrOverrideNotFound() error {
+	return &ErrOverrideNotFound{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrOverrideNotFound) Error() string {
+	return "occurrence override not found"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrOverrideNotFound) Ref() string {
+	return "events.override_not_found"
+}
+
+// ErrCalendarFeedNotFound is thrown when the server is unable to find a CalendarFeed.
+type ErrCalendarFeedNotFound struct {
+}
+
+// NewErrCalendarFeedNotFound creates and returns a ErrCalendarFeedNotFound.
+func NewErrCalendarFeedNotFound() error {
+	return &ErrCalendarFeedNotFound{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrCalendarFeedNotFound) Error() string {
+	return "calendar feed not found"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrCalendarFeedNotFound) Ref() string {
+	return "events.calendar_feed_not_found"
+}
+
+// ErrInvalidCheckInCode is thrown when a check-in code is invalid, expired, or for another event.
+type ErrInvalidCheckInCode struct {
+}
+
+// NewErrInvalidCheckInCode creates and returns a ErrInvalidCheckInCode.
+func NewErrInvalidCheckInCode() error {
+	return &ErrInvalidCheckInCode{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrInvalidCheckInCode) Error() string {
+	return "invalid check-in code"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrInvalidCheckInCode) Ref() string {
+	return "events.invalid_check_in_code"
+}
+
+// ErrCheckInClosed is thrown when an event is not open for check-in, such as long before it starts.
+type ErrCheckInClosed struct {
+}
+
+// NewErrCheckInClosed creates and returns a ErrCheckInClosed.
+func NewErrCheckInClosed() error {
+	return &ErrCheckInClosed{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrCheckInClosed) Error() string {
+	return "event is not open for check-in"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrCheckInClosed) Ref() string {
+	return "events.check_in_closed"
+}
+
+// ErrAlreadyCheckedIn is thrown when a volunteer already checked in to an event or occurrence.
+type ErrAlreadyCheckedIn struct {
+}
+
+// NewErrAlreadyCheckedIn creates and returns a ErrAlreadyCheckedIn.
+func NewErrAlreadyCheckedIn() error {
+	return &ErrAlreadyCheckedIn{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrAlreadyCheckedIn) Error() string {
+	return "already checked in"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrAlreadyCheckedIn) Ref() string {
+	return "events.already_checked_in"
+}
+
+// ErrNotCheckedIn is thrown when a volunteer who is not checked in to an event checks out.
+type ErrNotCheckedIn struct {
+}
+
+// NewErrNotCheckedIn creates and returns a ErrNotCheckedIn.
+func NewErrNotCheckedIn() error {
+	return &ErrNotCheckedIn{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrNotCheckedIn) Error() string {
+	return "not checked in"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrNotCheckedIn) Ref() string {
+	return "events.not_checked_in"
+}
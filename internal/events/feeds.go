@@ -0,0 +1,240 @@
+package events
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/joinimpact/api/internal/models"
+	"github.com/joinimpact/api/pkg/apikeys"
+	"github.com/joinimpact/api/pkg/dbctx"
+	"github.com/joinimpact/api/pkg/ical"
+	"github.com/joinimpact/api/pkg/location"
+)
+
+// Calendar feed options
+const (
+	feedHistory         = 30 * 24 * time.Hour  // how far back feeds list events, and cancellations of deleted events
+	feedRange           = 180 * 24 * time.Hour // how far ahead feeds list events
+	feedRefreshInterval = time.Hour            // how often calendar apps are asked to refresh feeds
+	maxFeedEvents       = 500                  // the maximum number of events, before expanding recurring events
+	feedTokenBytes      = 32                   // the number of random bytes in a feed token
+	feedUIDDomain       = "joinimpact.org"     // the domain of the UIDs of events in feeds
+	feedProductID       = "-//Impact//Events//EN"
+)
+
+// RegenerateUserCalendarFeed creates a calendar feed of the events of a volunteer's opportunities, or replaces
+// the token of the existing feed to revoke the old URL, and returns the URL of the feed.
+func (s *service) RegenerateUserCalendarFeed(ctx context.Context, userID int64) (string, error) {
+	return s.regenerateCalendarFeed(ctx, userID, nil)
+}
+
+// RegenerateOpportunityCalendarFeed creates a manager's calendar feed of the events of an opportunity, or
+// replaces the token of the existing feed to revoke the old URL, and returns the URL of the feed.
+func (s *service) RegenerateOpportunityCalendarFeed(ctx context.Context, opportunityID, userID int64) (string, error) {
+	return s.regenerateCalendarFeed(ctx, userID, &opportunityID)
+}
+
+// GetCalendarFeed gets a calendar feed by its token.
+func (s *service) GetCalendarFeed(ctx context.Context, token string) (*models.CalendarFeed, error) {
+	feed, err := s.calendarFeedRepository.FindByTokenHash(ctx, apikeys.Hash(token))
+	if err != nil {
+		return nil, NewErrCalendarFeedNotFound()
+	}
+
+	return feed, nil
+}
+
+// RenderCalendarFeed renders the events of a calendar feed in iCalendar format. Volunteers' feeds leave out
+// events the volunteer can not attend, and events deleted recently are listed as cancelled.
+func (s *service) RenderCalendarFeed(ctx context.Context, feed models.CalendarFeed, name string) ([]byte, error) {
+	now := time.Now()
+	from := now.Add(-feedHistory)
+	to := now.Add(feedRange)
+	ctx = dbctx.Inject(ctx, dbctx.Request{
+		Limit: maxFeedEvents,
+		From:  &from,
+		To:    &to,
+	})
+
+	var views []EventView
+	var opportunityIDs []int64
+	var err error
+	if feed.OpportunityID == nil {
+		views, err = s.GetUserEvents(ctx, feed.UserID)
+		if err != nil {
+			return nil, err
+		}
+
+		memberships, err := s.opportunityMembershipRepository.FindByUserID(ctx, feed.UserID)
+		if err != nil {
+			s.logger.Error().Err(err).Msg("Error finding opportunity memberships")
+			return nil, NewErrServerError()
+		}
+
+		for _, membership := range memberships {
+			opportunityIDs = append(opportunityIDs, membership.OpportunityID)
+		}
+	} else {
+		views, err = s.GetOpportunityEvents(ctx, *feed.OpportunityID)
+		if err != nil {
+			return nil, err
+		}
+
+		opportunityIDs = []int64{*feed.OpportunityID}
+	}
+
+	calendar := &ical.Calendar{
+		ProductID:       feedProductID,
+		Name:            name,
+		RefreshInterval: feedRefreshInterval,
+		Events:          []ical.Event{},
+	}
+
+	for _, view := range views {
+		if view.UserResponse != nil && view.UserResponse.Response != nil && *view.UserResponse.Response == models.EventResponseCanNotAttend {
+			continue
+		}
+
+		calendar.Events = append(calendar.Events, feedEvent(view))
+	}
+
+	if len(opportunityIDs) > 0 {
+		deleted, err := s.eventRepository.FindDeletedByOpportunityIDs(ctx, opportunityIDs, from)
+		if err != nil {
+			s.logger.Error().Err(err).Msg("Error finding deleted events")
+			return nil, NewErrServerError()
+		}
+
+		for _, event := range deleted {
+			occurrences, err := s.eventOccurrences(ctx, event)
+			if err != nil {
+				s.logger.Error().Err(err).Msg("Error expanding event occurrences")
+				return nil, NewErrServerError()
+			}
+
+			for _, occurrence := range occurrences {
+				calendar.Events = append(calendar.Events, cancelledFeedEvent(occurrence.event, occurrence.start))
+			}
+		}
+	}
+
+	return calendar.Encode(now), nil
+}
+
+// regenerateCalendarFeed creates a calendar feed, or replaces the token of an existing feed, and returns the
+// URL of the feed.
+func (s *service) regenerateCalendarFeed(ctx context.Context, userID int64, opportunityID *int64) (string, error) {
+	token, err := generateFeedToken()
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error generating calendar feed token")
+		return "", NewErrServerError()
+	}
+
+	feed, err := s.calendarFeedRepository.FindByUserID(ctx, userID, opportunityID)
+	if err == nil {
+		err = s.calendarFeedRepository.UpdateTokenHash(ctx, feed.ID, apikeys.Hash(token))
+	} else {
+		feed = &models.CalendarFeed{
+			UserID:        userID,
+			OpportunityID: opportunityID,
+			TokenHash:     apikeys.Hash(token),
+		}
+		feed.ID = s.snowflakeService.GenerateID()
+
+		err = s.calendarFeedRepository.Create(ctx, *feed)
+	}
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error saving calendar feed")
+		return "", NewErrServerError()
+	}
+
+	return fmt.Sprintf("%s/%s.ics", strings.TrimSuffix(s.config.CalendarFeedURL, "/"), token), nil
+}
+
+// generateFeedToken generates a new random feed token.
+func generateFeedToken() (string, error) {
+	b := make([]byte, feedTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// feedEvent converts an event view to an event of a calendar feed.
+func feedEvent(view EventView) ical.Event {
+	event := ical.Event{
+		UID:          feedUID(view.ID, view.Occurrence),
+		Sequence:     view.Sequence,
+		LastModified: view.UpdatedAt,
+		Summary:      view.Title,
+		Description:  view.Description,
+		Location:     formatLocation(view.Location),
+	}
+
+	if view.EventSchedule != nil {
+		event.Start = view.EventSchedule.FromDate
+		event.End = view.EventSchedule.ToDate
+		event.AllDay = view.EventSchedule.DateOnly != nil && *view.EventSchedule.DateOnly
+	}
+
+	if event.End.Before(event.Start) {
+		event.End = event.Start
+	}
+
+	return event
+}
+
+// cancelledFeedEvent converts a deleted event, or an occurrence of a deleted event, to a cancelled event of a
+// calendar feed.
+func cancelledFeedEvent(event models.Event, occurrence *time.Time) ical.Event {
+	cancelled := ical.Event{
+		UID:       feedUID(event.ID, occurrence),
+		Sequence:  event.Sequence + 1,
+		Start:     event.FromDate,
+		End:       event.ToDate,
+		AllDay:    event.DateOnly != nil && *event.DateOnly,
+		Summary:   event.Title,
+		Cancelled: true,
+	}
+
+	if event.DeletedAt != nil {
+		cancelled.LastModified = *event.DeletedAt
+	}
+
+	if cancelled.End.Before(cancelled.Start) {
+		cancelled.End = cancelled.Start
+	}
+
+	return cancelled
+}
+
+// feedUID returns the UID of an event in calendar feeds, which is the same in every feed and every version
+// of a feed. Each occurrence of a recurring event has its own UID.
+func feedUID(eventID int64, occurrence *time.Time) string {
+	if occurrence == nil {
+		return fmt.Sprintf("event-%d@%s", eventID, feedUIDDomain)
+	}
+
+	return fmt.Sprintf("event-%d-%s@%s", eventID, occurrence.UTC().Format("20060102T150405Z"), feedUIDDomain)
+}
+
+// formatLocation formats a reverse geocoded location as a single line address.
+func formatLocation(loc *location.Location) string {
+	if loc == nil {
+		return ""
+	}
+
+	parts := []string{}
+	for _, name := range []*location.LocationName{loc.StreetAddress, loc.City, loc.State, loc.Country} {
+		if name != nil && name.LongName != "" {
+			parts = append(parts, name.LongName)
+		}
+	}
+
+	return strings.Join(parts, ", ")
+}
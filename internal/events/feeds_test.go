@@ -0,0 +1,43 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/joinimpact/api/pkg/location"
+)
+
+// TestFeedUID tests that events and occurrences of recurring events have stable, distinct UIDs.
+func TestFeedUID(t *testing.T) {
+	occurrence := time.Date(2020, 9, 1, 11, 0, 0, 0, time.FixedZone("PDT", -7*60*60))
+	sameOccurrence := occurrence.UTC()
+
+	if uid := feedUID(42, nil); uid != "event-42@joinimpact.org" {
+		t.Errorf("unexpected UID %q", uid)
+	}
+
+	if uid := feedUID(42, &occurrence); uid != "event-42-20200901T180000Z@joinimpact.org" {
+		t.Errorf("unexpected occurrence UID %q", uid)
+	}
+
+	if feedUID(42, &occurrence) != feedUID(42, &sameOccurrence) {
+		t.Error("UID depends on the time zone of the occurrence")
+	}
+}
+
+// TestFormatLocation tests that the known parts of an address are joined.
+func TestFormatLocation(t *testing.T) {
+	loc := &location.Location{
+		StreetAddress: &location.LocationName{LongName: "1 Main Street"},
+		City:          &location.LocationName{LongName: "Springfield"},
+		Country:       &location.LocationName{ShortName: "US"},
+	}
+
+	if formatted := formatLocation(loc); formatted != "1 Main Street, Springfield" {
+		t.Errorf("unexpected location %q", formatted)
+	}
+
+	if formatted := formatLocation(nil); formatted != "" {
+		t.Errorf("unexpected location %q for no location", formatted)
+	}
+}
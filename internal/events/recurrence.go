@@ -0,0 +1,341 @@
+package events
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/joinimpact/api/internal/models"
+	"github.com/joinimpact/api/pkg/dbctx"
+	"github.com/joinimpact/api/pkg/rrule"
+)
+
+// defaultOccurrenceRange is how far ahead recurring events are expanded when no end date is requested.
+const defaultOccurrenceRange = 90 * 24 * time.Hour
+
+// maxOccurrenceRange is the longest range recurring events are expanded over in a single request.
+const maxOccurrenceRange = 366 * 24 * time.Hour
+
+// occurrence is a single occurrence of an event.
+type occurrence struct {
+	event models.Event // the event, with the dates, title and description of the occurrence
+	start *time.Time   // the start of the occurrence given by the recurrence rule, nil for events which do not repeat
+}
+
+// GetOccurrenceOverrides gets the overrides of the occurrences of an event, including cancelled occurrences.
+func (s *service) GetOccurrenceOverrides(ctx context.Context, eventID int64) ([]models.EventOccurrenceOverride, error) {
+	if _, err := s.eventRepository.FindByID(ctx, eventID); err != nil {
+		return nil, NewErrEventNotFound()
+	}
+
+	overrides, err := s.eventOccurrenceOverrideRepository.FindByEventID(ctx, eventID)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error finding occurrence overrides")
+		return nil, NewErrServerError()
+	}
+
+	return overrides, nil
+}
+
+// OverrideOccurrence changes the dates, title or description of a single occurrence of a recurring event, or
+// cancels it. An existing override of the occurrence is replaced.
+func (s *service) OverrideOccurrence(ctx context.Context, eventID int64, override models.EventOccurrenceOverride) error {
+	event, err := s.eventRepository.FindByID(ctx, eventID)
+	if err != nil {
+		return NewErrEventNotFound()
+	}
+
+	rule, dtstart, err := eventRule(*event)
+	if err != nil || !rule.Includes(dtstart, override.OccurrenceStart) {
+		return NewErrOccurrenceNotFound()
+	}
+
+	override, ok := validateOverride(override)
+	if !ok {
+		return NewErrInvalidOverride()
+	}
+
+	override.EventID = eventID
+	existing, err := s.eventOccurrenceOverrideRepository.FindByOccurrence(ctx, eventID, override.OccurrenceStart)
+	if err == nil {
+		override.ID = existing.ID
+		override.CreatedAt = existing.CreatedAt
+	} else {
+		override.ID = s.snowflakeService.GenerateID()
+	}
+
+	if err := s.eventOccurrenceOverrideRepository.Save(ctx, override); err != nil {
+		s.logger.Error().Err(err).Msg("Error saving occurrence override")
+		return NewErrServerError()
+	}
+
+	return s.touchEvent(ctx, eventID)
+}
+
+// RestoreOccurrence removes the override of a single occurrence of a recurring event.
+func (s *service) RestoreOccurrence(ctx context.Context, eventID int64, occurrence time.Time) error {
+	if _, err := s.eventRepository.FindByID(ctx, eventID); err != nil {
+		return NewErrEventNotFound()
+	}
+
+	override, err := s.eventOccurrenceOverrideRepository.FindByOccurrence(ctx, eventID, occurrence)
+	if err != nil {
+		return NewErrOverrideNotFound()
+	}
+
+	if err := s.eventOccurrenceOverrideRepository.DeleteByID(ctx, override.ID); err != nil {
+		s.logger.Error().Err(err).Msg("Error deleting occurrence override")
+		return NewErrServerError()
+	}
+
+	return s.touchEvent(ctx, eventID)
+}
+
+// touchEvent increases the sequence of an event after one of its occurrences changed, so that calendar
+// apps subscribed to a feed of the event update it.
+func (s *service) touchEvent(ctx context.Context, eventID int64) error {
+	if err := s.eventRepository.Touch(ctx, eventID); err != nil {
+		s.logger.Error().Err(err).Msg("Error touching event")
+		return NewErrServerError()
+	}
+
+	return nil
+}
+
+// eventOccurrences gets the occurrences of an event in the range requested in the context. Events which do
+// not repeat have a single occurrence.
+func (s *service) eventOccurrences(ctx context.Context, event models.Event) ([]occurrence, error) {
+	if event.Recurrence == "" {
+		return []occurrence{{event: event}}, nil
+	}
+
+	rule, dtstart, err := eventRule(event)
+	if err != nil {
+		s.logger.Error().Err(err).Int64("eventId", event.ID).Msg("Error parsing event recurrence")
+		return []occurrence{{event: event}}, nil
+	}
+
+	overrides, err := s.eventOccurrenceOverrideRepository.FindByEventID(ctx, event.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	from, to := occurrenceRange(ctx, time.Now())
+	event.FromDate = dtstart
+	return expandEvent(event, rule, overrides, from, to), nil
+}
+
+// checkOccurrence checks that an occurrence is given for recurring events only, and that it is an occurrence
+// of the event which is not cancelled.
+func (s *service) checkOccurrence(ctx context.Context, eventID int64, occurrence *time.Time) error {
+	event, err := s.eventRepository.FindByID(ctx, eventID)
+	if err != nil {
+		return NewErrEventNotFound()
+	}
+
+	if event.Recurrence == "" {
+		if occurrence != nil {
+			return NewErrOccurrenceNotFound()
+		}
+
+		return nil
+	}
+
+	if occurrence == nil {
+		return NewErrOccurrenceRequired()
+	}
+
+	rule, dtstart, err := eventRule(*event)
+	if err != nil || !rule.Includes(dtstart, *occurrence) {
+		return NewErrOccurrenceNotFound()
+	}
+
+	override, err := s.eventOccurrenceOverrideRepository.FindByOccurrence(ctx, eventID, *occurrence)
+	if err == nil && override.Cancelled {
+		return NewErrOccurrenceNotFound()
+	}
+
+	return nil
+}
+
+// findResponse finds a user's response to an event, or to a single occurrence of a recurring event.
+func (s *service) findResponse(ctx context.Context, eventID, userID int64, occurrence *time.Time) (*models.EventResponse, error) {
+	if occurrence == nil {
+		return s.eventResponseRepository.FindInEventByUserID(ctx, eventID, userID)
+	}
+
+	return s.eventResponseRepository.FindInOccurrenceByUserID(ctx, eventID, userID, *occurrence)
+}
+
+// eventRule parses the recurrence rule of an event, and returns it with the start of the event in the
+// event's time zone.
+func eventRule(event models.Event) (*rrule.Rule, time.Time, error) {
+	rule, err := rrule.Parse(event.Recurrence)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	location, err := time.LoadLocation(event.TimeZone)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	return rule, event.FromDate.In(location), nil
+}
+
+// normalizeRecurrence formats the recurrence rule of an event in canonical form, and returns false if the
+// rule or the event's time zone is invalid.
+func normalizeRecurrence(event *models.Event) bool {
+	event.Recurrence = strings.TrimSpace(event.Recurrence)
+	if event.Recurrence == "" {
+		event.TimeZone = ""
+		return true
+	}
+
+	if event.FromDate.IsZero() {
+		return false
+	}
+
+	rule, err := rrule.Parse(event.Recurrence)
+	if err != nil {
+		return false
+	}
+
+	if _, err := time.LoadLocation(event.TimeZone); err != nil {
+		return false
+	}
+
+	event.Recurrence = rule.String()
+	return true
+}
+
+// occurrenceRange returns the range recurring events are expanded over, starting now unless another range
+// is requested.
+func occurrenceRange(ctx context.Context, now time.Time) (time.Time, time.Time) {
+	request := dbctx.Get(ctx)
+
+	from := now
+	if request.From != nil {
+		from = *request.From
+	}
+
+	to := from.Add(defaultOccurrenceRange)
+	if request.To != nil {
+		to = *request.To
+	}
+
+	if to.Sub(from) > maxOccurrenceRange {
+		to = from.Add(maxOccurrenceRange)
+	}
+
+	return from, to
+}
+
+// expandEvent returns the occurrences of a recurring event which overlap a range, with their overrides
+// applied, ordered by start.
+func expandEvent(event models.Event, rule *rrule.Rule, overrides []models.EventOccurrenceOverride, from, to time.Time) []occurrence {
+	duration := event.ToDate.Sub(event.FromDate)
+	if duration < 0 {
+		duration = 0
+	}
+
+	// Occurrences starting before the range may still be running at its start.
+	starts := rule.Between(event.FromDate, from.Add(-duration), to)
+
+	expanded := map[int64]bool{}
+	for _, start := range starts {
+		expanded[start.UnixNano()] = true
+	}
+
+	overridden := map[int64]models.EventOccurrenceOverride{}
+	for _, override := range overrides {
+		key := override.OccurrenceStart.UnixNano()
+		overridden[key] = override
+
+		// Occurrences can be moved into the range from outside of it.
+		if !expanded[key] && override.FromDate != nil && rule.Includes(event.FromDate, override.OccurrenceStart) {
+			starts = append(starts, override.OccurrenceStart.In(event.FromDate.Location()))
+			expanded[key] = true
+		}
+	}
+
+	occurrences := []occurrence{}
+	for _, start := range starts {
+		instance := event
+		instance.FromDate = start
+		instance.ToDate = start.Add(duration)
+
+		if override, ok := overridden[start.UnixNano()]; ok {
+			if override.Cancelled {
+				continue
+			}
+
+			applyOverride(&instance, override)
+		}
+
+		if instance.ToDate.Before(from) || !instance.FromDate.Before(to) {
+			continue
+		}
+
+		start := start
+		occurrences = append(occurrences, occurrence{instance, &start})
+	}
+
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return occurrences[i].event.FromDate.Before(occurrences[j].event.FromDate)
+	})
+
+	return occurrences
+}
+
+// applyOverride applies the changes of an override to an occurrence.
+func applyOverride(event *models.Event, override models.EventOccurrenceOverride) {
+	if override.Title != nil {
+		event.Title = *override.Title
+	}
+
+	if override.Description != nil {
+		event.Description = *override.Description
+	}
+
+	if override.FromDate != nil && override.ToDate != nil {
+		event.FromDate = *override.FromDate
+		event.ToDate = *override.ToDate
+	}
+}
+
+// validateOverride normalizes an override, and returns false if it is invalid. New dates must be given
+// together.
+func validateOverride(override models.EventOccurrenceOverride) (models.EventOccurrenceOverride, bool) {
+	if override.Cancelled {
+		// Cancelled occurrences are not shown, so changes to them are dropped.
+		override.Title = nil
+		override.Description = nil
+		override.FromDate = nil
+		override.ToDate = nil
+		return override, true
+	}
+
+	if override.Title != nil {
+		title := strings.TrimSpace(*override.Title)
+		if len(title) < 4 || len(title) > 128 {
+			return override, false
+		}
+		override.Title = &title
+	}
+
+	if (override.FromDate == nil) != (override.ToDate == nil) {
+		return override, false
+	}
+
+	if override.FromDate != nil && (override.FromDate.IsZero() || override.ToDate.Before(*override.FromDate)) {
+		return override, false
+	}
+
+	if override.Title == nil && override.Description == nil && override.FromDate == nil {
+		return override, false
+	}
+
+	return override, true
+}
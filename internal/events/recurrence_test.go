@@ -0,0 +1,84 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/joinimpact/api/internal/models"
+	"github.com/joinimpact/api/pkg/rrule"
+)
+
+// TestExpandEvent tests that overrides cancel, move and rename occurrences of a recurring event.
+func TestExpandEvent(t *testing.T) {
+	// A two hour event every Tuesday and Thursday, starting Tuesday, September 1st.
+	from := time.Date(2020, 9, 1, 18, 0, 0, 0, time.UTC)
+	event := models.Event{Title: "Food bank", FromDate: from, ToDate: from.Add(2 * time.Hour)}
+
+	rule, err := rrule.Parse("FREQ=WEEKLY;BYDAY=TU,TH")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	title := "Food bank (extended)"
+	moved := time.Date(2020, 9, 9, 18, 0, 0, 0, time.UTC)
+	movedEnd := moved.Add(time.Hour)
+	overrides := []models.EventOccurrenceOverride{
+		// Thursday, September 3rd is cancelled.
+		{OccurrenceStart: from.AddDate(0, 0, 2), Cancelled: true},
+		// Tuesday, September 8th is renamed.
+		{OccurrenceStart: from.AddDate(0, 0, 7), Title: &title},
+		// Tuesday, September 15th moves into the range, to Wednesday, September 9th.
+		{OccurrenceStart: from.AddDate(0, 0, 14), FromDate: &moved, ToDate: &movedEnd},
+	}
+
+	// The range starts while the first occurrence is running, and ends as Thursday, September 10th starts.
+	occurrences := expandEvent(event, rule, overrides, from.Add(time.Hour), from.AddDate(0, 0, 9))
+
+	want := []struct {
+		start time.Time
+		from  time.Time
+		title string
+	}{
+		{from, from, "Food bank"},
+		{from.AddDate(0, 0, 7), from.AddDate(0, 0, 7), title},
+		{from.AddDate(0, 0, 14), moved, "Food bank"},
+	}
+
+	if len(occurrences) != len(want) {
+		t.Fatalf("got %d occurrences, want %d", len(occurrences), len(want))
+	}
+
+	for i, occurrence := range occurrences {
+		if !occurrence.start.Equal(want[i].start) || !occurrence.event.FromDate.Equal(want[i].from) || occurrence.event.Title != want[i].title {
+			t.Errorf("occurrence %d: got %v at %v (%q), want %v at %v (%q)", i, *occurrence.start, occurrence.event.FromDate, occurrence.event.Title, want[i].start, want[i].from, want[i].title)
+		}
+	}
+}
+
+// TestValidateOverride tests that overrides need a change, and new dates in order.
+func TestValidateOverride(t *testing.T) {
+	from := time.Date(2020, 9, 1, 18, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+	title := "Food bank"
+	short := "Foo"
+
+	tests := []struct {
+		name     string
+		override models.EventOccurrenceOverride
+		want     bool
+	}{
+		{"cancelled", models.EventOccurrenceOverride{Cancelled: true}, true},
+		{"renamed", models.EventOccurrenceOverride{Title: &title}, true},
+		{"moved", models.EventOccurrenceOverride{FromDate: &from, ToDate: &to}, true},
+		{"no changes", models.EventOccurrenceOverride{}, false},
+		{"title too short", models.EventOccurrenceOverride{Title: &short}, false},
+		{"start only", models.EventOccurrenceOverride{FromDate: &from}, false},
+		{"ends before it starts", models.EventOccurrenceOverride{FromDate: &to, ToDate: &from}, false},
+	}
+
+	for _, tt := range tests {
+		if _, ok := validateOverride(tt.override); ok != tt.want {
+			t.Errorf("%s: validateOverride() = %v, want %v", tt.name, ok, tt.want)
+		}
+	}
+}
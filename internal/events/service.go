@@ -2,6 +2,7 @@ package events
 
 import (
 	"context"
+	"time"
 
 	"github.com/joinimpact/api/internal/cdn"
 	"github.com/joinimpact/api/internal/config"
@@ -22,44 +23,98 @@ type Service interface {
 	GetEvent(ctx context.Context, eventID int64) (*EventView, error)
 	// GetMinimalEvent gets a single event with only the event base fields.
 	GetMinimalEvent(ctx context.Context, eventID int64) (*EventView, error)
-	// GetEventResponses gets all responses to an event.
-	GetEventResponses(ctx context.Context, eventID int64) ([]models.EventResponse, error)
-	// GetUserEventResponse gets a user's response to a single event.
-	GetUserEventResponse(ctx context.Context, userID, eventID int64) (*models.EventResponse, error)
+	// GetEventResponses gets all responses to an event, or to a single occurrence of a recurring event.
+	GetEventResponses(ctx context.Context, eventID int64, occurrence *time.Time) ([]models.EventResponse, error)
+	// GetUserEventResponse gets a user's response to a single event, or to a single occurrence of a recurring event.
+	GetUserEventResponse(ctx context.Context, userID, eventID int64, occurrence *time.Time) (*models.EventResponse, error)
 	// SetEventResponseCanAttend creates or updates an EventResponse with the "can attend" status.
-	SetEventResponseCanAttend(ctx context.Context, eventID, userID int64) error
+	SetEventResponseCanAttend(ctx context.Context, eventID, userID int64, occurrence *time.Time) error
 	// SetEventResponseCanNotAttend creates or updates an EventResponse with the "can not attend" status.
-	SetEventResponseCanNotAttend(ctx context.Context, eventID, userID int64) error
-	// GetOpportunityEvents gets all events by opportunity ID.
+	SetEventResponseCanNotAttend(ctx context.Context, eventID, userID int64, occurrence *time.Time) error
+	// GetOpportunityEvents gets all events by opportunity ID, with recurring events expanded to their occurrences.
 	GetOpportunityEvents(ctx context.Context, opportunityID int64) ([]EventView, error)
-	// GetUserEvents gets events from all of a user's enrolled opportunities.
+	// GetUserEvents gets events from all of a user's enrolled opportunities, with recurring events expanded to their occurrences.
 	GetUserEvents(ctx context.Context, userID int64) ([]EventView, error)
+	// GetOccurrenceOverrides gets the overrides of the occurrences of an event, including cancelled occurrences.
+	GetOccurrenceOverrides(ctx context.Context, eventID int64) ([]models.EventOccurrenceOverride, error)
+	// OverrideOccurrence changes the dates, title or description of a single occurrence of a recurring event, or cancels it.
+	OverrideOccurrence(ctx context.Context, eventID int64, override models.EventOccurrenceOverride) error
+	// RestoreOccurrence removes the override of a single occurrence of a recurring event.
+	RestoreOccurrence(ctx context.Context, eventID int64, occurrence time.Time) error
 	// DeleteEvent deletes a single event by ID.
 	DeleteEvent(ctx context.Context, eventID int64) error
+	// GetEventShifts gets all shifts of an event, marking the ones a user is signed up for.
+	GetEventShifts(ctx context.Context, eventID, userID int64) ([]ShiftView, error)
+	// CreateEventShift adds a shift to an event and returns the ID of the new shift.
+	CreateEventShift(ctx context.Context, eventID int64, shift models.EventShift) (int64, error)
+	// UpdateEventShift updates the title, times, capacity and required tag of a shift.
+	UpdateEventShift(ctx context.Context, eventID int64, shift models.EventShift) error
+	// DeleteEventShift deletes a shift and all of its sign-ups.
+	DeleteEventShift(ctx context.Context, eventID, shiftID int64) error
+	// SignUpForShift signs a volunteer up for a shift of an event.
+	SignUpForShift(ctx context.Context, eventID, shiftID, userID int64) error
+	// SwapShift moves a volunteer from a shift they are signed up for to another shift of the same event.
+	SwapShift(ctx context.Context, eventID, fromShiftID, toShiftID, userID int64) error
+	// DropShift removes a volunteer from a shift, freeing their place.
+	DropShift(ctx context.Context, eventID, shiftID, userID int64) error
+	// GetShiftRoster gets the sign-ups of a shift, in the order volunteers signed up.
+	GetShiftRoster(ctx context.Context, eventID, shiftID int64) ([]models.EventShiftSignup, error)
+	// RegenerateUserCalendarFeed creates a calendar feed of a volunteer's events, or replaces the token of the existing feed, and returns the URL of the feed.
+	RegenerateUserCalendarFeed(ctx context.Context, userID int64) (string, error)
+	// RegenerateOpportunityCalendarFeed creates a manager's calendar feed of an opportunity's events, or replaces the token of the existing feed, and returns the URL of the feed.
+	RegenerateOpportunityCalendarFeed(ctx context.Context, opportunityID, userID int64) (string, error)
+	// GetCalendarFeed gets a calendar feed by its token.
+	GetCalendarFeed(ctx context.Context, token string) (*models.CalendarFeed, error)
+	// RenderCalendarFeed renders the events of a calendar feed in iCalendar format.
+	RenderCalendarFeed(ctx context.Context, feed models.CalendarFeed, name string) ([]byte, error)
+	// GetCheckInCode gets the current check-in code of an event, which rotates every few minutes.
+	GetCheckInCode(ctx context.Context, eventID int64) (*CheckInCode, error)
+	// CheckIn checks a volunteer in to an event, or to the occurrence of a recurring event which is running, with the event's check-in code.
+	CheckIn(ctx context.Context, eventID, userID int64, code string) (*models.EventCheckIn, error)
+	// CheckInVolunteer checks a volunteer in to an event, or to a single occurrence of a recurring event, on behalf of a manager.
+	CheckInVolunteer(ctx context.Context, eventID, userID, managerID int64, occurrence *time.Time) (*models.EventCheckIn, error)
+	// CheckOut checks a volunteer out of an event and logs their hours, capped at the hours of the event. The manager ID is 0 when volunteers check themselves out.
+	CheckOut(ctx context.Context, eventID, userID, managerID int64) (*models.EventCheckIn, error)
+	// GetAttendanceReport reports the check-ins and logged hours of an event, or of a single occurrence of a recurring event.
+	GetAttendanceReport(ctx context.Context, eventID int64, occurrence *time.Time) (*AttendanceReport, error)
 }
 
 // service represents the internal implementation of the Service.
 type service struct {
-	eventRepository                 models.EventRepository
-	eventResponseRepository         models.EventResponseRepository
-	opportunityMembershipRepository models.OpportunityMembershipRepository
-	tagRepository                   models.TagRepository
-	config                          *config.Config
-	logger                          *zerolog.Logger
-	snowflakeService                snowflakes.SnowflakeService
-	emailService                    email.Service
-	cdnClient                       *cdn.Client
-	locationService                 location.Service
+	eventRepository                   models.EventRepository
+	eventResponseRepository           models.EventResponseRepository
+	eventOccurrenceOverrideRepository models.EventOccurrenceOverrideRepository
+	eventShiftRepository              models.EventShiftRepository
+	eventShiftSignupRepository        models.EventShiftSignupRepository
+	calendarFeedRepository            models.CalendarFeedRepository
+	eventCheckInRepository            models.EventCheckInRepository
+	opportunityRepository             models.OpportunityRepository
+	opportunityMembershipRepository   models.OpportunityMembershipRepository
+	tagRepository                     models.TagRepository
+	userTagRepository                 models.UserTagRepository
+	config                            *config.Config
+	logger                            *zerolog.Logger
+	snowflakeService                  snowflakes.SnowflakeService
+	emailService                      email.Service
+	cdnClient                         *cdn.Client
+	locationService                   location.Service
 }
 
 // NewService creates and returns a new events.Service with the provided
 // dependencies.
-func NewService(eventRepository models.EventRepository, eventResponseRepository models.EventResponseRepository, opportunityMembershipRepository models.OpportunityMembershipRepository, tagRepository models.TagRepository, config *config.Config, logger *zerolog.Logger, snowflakeService snowflakes.SnowflakeService, emailService email.Service, locationService location.Service) Service {
+func NewService(eventRepository models.EventRepository, eventResponseRepository models.EventResponseRepository, eventOccurrenceOverrideRepository models.EventOccurrenceOverrideRepository, eventShiftRepository models.EventShiftRepository, eventShiftSignupRepository models.EventShiftSignupRepository, calendarFeedRepository models.CalendarFeedRepository, eventCheckInRepository models.EventCheckInRepository, opportunityRepository models.OpportunityRepository, opportunityMembershipRepository models.OpportunityMembershipRepository, tagRepository models.TagRepository, userTagRepository models.UserTagRepository, config *config.Config, logger *zerolog.Logger, snowflakeService snowflakes.SnowflakeService, emailService email.Service, locationService location.Service) Service {
 	return &service{
 		eventRepository,
 		eventResponseRepository,
+		eventOccurrenceOverrideRepository,
+		eventShiftRepository,
+		eventShiftSignupRepository,
+		calendarFeedRepository,
+		eventCheckInRepository,
+		opportunityRepository,
 		opportunityMembershipRepository,
 		tagRepository,
+		userTagRepository,
 		config,
 		logger,
 		snowflakeService,
@@ -79,6 +134,10 @@ func (s *service) CreateEvent(ctx context.Context, request ModifyEventRequest) (
 		return 0, NewErrServerError()
 	}
 
+	if !normalizeRecurrence(&event) {
+		return 0, NewErrInvalidRecurrence()
+	}
+
 	// Generate an ID for the event.
 	event.ID = s.snowflakeService.GenerateID()
 
@@ -94,11 +153,23 @@ func (s *service) CreateEvent(ctx context.Context, request ModifyEventRequest) (
 func (s *service) UpdateEvent(ctx context.Context, request ModifyEventRequest) error {
 	event := s.requestToEvent(request)
 
+	if request.EventSchedule != nil && !normalizeRecurrence(&event) {
+		return NewErrInvalidRecurrence()
+	}
+
 	err := s.eventRepository.Update(ctx, event)
 	if err != nil {
 		return NewErrEventNotFound()
 	}
 
+	// The schedule replaces the recurrence, which is removed when the schedule has none.
+	if request.EventSchedule != nil {
+		if err := s.eventRepository.UpdateRecurrence(ctx, event.ID, event.Recurrence, event.TimeZone); err != nil {
+			s.logger.Error().Err(err).Msg("Error updating event recurrence")
+			return NewErrServerError()
+		}
+	}
+
 	return nil
 }
 
@@ -117,7 +188,7 @@ func (s *service) GetEvent(ctx context.Context, eventID int64) (*EventView, erro
 		return nil, NewErrServerError()
 	}
 
-	responsesSummary, err := s.getEventResponsesSummary(ctx, eventID)
+	responsesSummary, err := s.getEventResponsesSummary(ctx, eventID, nil)
 	if err != nil {
 		s.logger.Error().Err(err).Msg("Error getting EventResponsesSummary")
 		return nil, NewErrServerError()
@@ -144,27 +215,31 @@ func (s *service) GetMinimalEvent(ctx context.Context, eventID int64) (*EventVie
 	return view, nil
 }
 
-// GetEventResponses gets all responses to an event.
-func (s *service) GetEventResponses(ctx context.Context, eventID int64) ([]models.EventResponse, error) {
-	// Get all event memberships.
-	ids, err := s.getEventMemberships(ctx, eventID)
+// GetEventResponses gets all responses to an event, or to a single occurrence of a recurring event.
+func (s *service) GetEventResponses(ctx context.Context, eventID int64, occurrence *time.Time) ([]models.EventResponse, error) {
+	if err := s.checkOccurrence(ctx, eventID, occurrence); err != nil {
+		return nil, err
+	}
+
+	// Get all event memberships and their responses.
+	eventResponses, err := s.getEventResponses(ctx, eventID)
 	if err != nil {
 		return nil, NewErrServerError()
 	}
 
 	responses := []models.EventResponse{}
-	for _, id := range ids {
+	for _, id := range eventResponses.memberIDs {
 		code := models.EventResponseNull
 		// Create a default response to append when one is not found in the database.
 		response := &models.EventResponse{
-			UserID:   id,
-			EventID:  eventID,
-			Response: &code,
+			UserID:          id,
+			EventID:         eventID,
+			Response:        &code,
+			OccurrenceStart: occurrence,
 		}
 
 		// Find a response if one exists.
-		eventResponse, err := s.eventResponseRepository.FindInEventByUserID(ctx, eventID, id)
-		if err == nil {
+		if eventResponse, ok := eventResponses.find(id, occurrence); ok {
 			// If a response exists, overwrite the default response.
 			response = eventResponse
 		}
@@ -175,9 +250,13 @@ func (s *service) GetEventResponses(ctx context.Context, eventID int64) ([]model
 	return responses, nil
 }
 
-// GetUserEventResponse gets a user's response to a single event.
-func (s *service) GetUserEventResponse(ctx context.Context, userID, eventID int64) (*models.EventResponse, error) {
-	response, err := s.eventResponseRepository.FindInEventByUserID(ctx, eventID, userID)
+// GetUserEventResponse gets a user's response to a single event, or to a single occurrence of a recurring event.
+func (s *service) GetUserEventResponse(ctx context.Context, userID, eventID int64, occurrence *time.Time) (*models.EventResponse, error) {
+	if err := s.checkOccurrence(ctx, eventID, occurrence); err != nil {
+		return nil, err
+	}
+
+	response, err := s.findResponse(ctx, eventID, userID, occurrence)
 	if err != nil {
 		return nil, NewErrResponseNotFound()
 	}
@@ -186,21 +265,25 @@ func (s *service) GetUserEventResponse(ctx context.Context, userID, eventID int6
 }
 
 // SetEventResponseCanAttend creates or updates an EventResponse with the "can attend" status.
-func (s *service) SetEventResponseCanAttend(ctx context.Context, eventID, userID int64) error {
+func (s *service) SetEventResponseCanAttend(ctx context.Context, eventID, userID int64, occurrence *time.Time) error {
 	code := models.EventResponseCanAttend
-	return s.setEventResponse(ctx, eventID, userID, code)
+	return s.setEventResponse(ctx, eventID, userID, occurrence, code)
 }
 
 // SetEventResponseCanNotAttend creates or updates an EventResponse with the "can not attend" status.
-func (s *service) SetEventResponseCanNotAttend(ctx context.Context, eventID, userID int64) error {
+func (s *service) SetEventResponseCanNotAttend(ctx context.Context, eventID, userID int64, occurrence *time.Time) error {
 	code := models.EventResponseCanNotAttend
-	return s.setEventResponse(ctx, eventID, userID, code)
+	return s.setEventResponse(ctx, eventID, userID, occurrence, code)
 }
 
-// setEventResponse creates or updates an event response.
-func (s *service) setEventResponse(ctx context.Context, eventID, userID int64, code int) error {
+// setEventResponse creates or updates an event response. Responses to recurring events are stored per occurrence.
+func (s *service) setEventResponse(ctx context.Context, eventID, userID int64, occurrence *time.Time, code int) error {
+	if err := s.checkOccurrence(ctx, eventID, occurrence); err != nil {
+		return err
+	}
+
 	// Check if an event response already exists.
-	response, err := s.eventResponseRepository.FindInEventByUserID(ctx, eventID, userID)
+	response, err := s.findResponse(ctx, eventID, userID, occurrence)
 	if err == nil {
 		// If found, update the existing response.
 		response.Response = &code
@@ -219,6 +302,7 @@ func (s *service) setEventResponse(ctx context.Context, eventID, userID int64, c
 	response.EventID = eventID
 	response.UserID = userID
 	response.Response = &code
+	response.OccurrenceStart = occurrence
 
 	err = s.eventResponseRepository.Create(ctx, *response)
 	if err != nil {
@@ -238,20 +322,31 @@ func (s *service) GetOpportunityEvents(ctx context.Context, opportunityID int64)
 
 	views := []EventView{}
 	for _, event := range events {
-		// Convert the event to view.
-		view, err := s.eventToView(event)
+		occurrences, err := s.eventOccurrences(ctx, event)
 		if err != nil {
+			s.logger.Error().Err(err).Msg("Error expanding event occurrences")
 			return nil, NewErrServerError()
 		}
 
-		responsesSummary, err := s.getEventResponsesSummary(ctx, event.ID)
+		// Responses to all occurrences are loaded at once and summarized per occurrence.
+		responses, err := s.getEventResponses(ctx, event.ID)
 		if err != nil {
-			s.logger.Error().Err(err).Msg("Error getting EventResponsesSummary")
+			s.logger.Error().Err(err).Msg("Error getting event responses")
 			return nil, NewErrServerError()
 		}
-		view.EventResponsesSummary = responsesSummary
 
-		views = append(views, *view)
+		for _, occurrence := range occurrences {
+			// Convert the occurrence to view.
+			view, err := s.eventToView(occurrence.event)
+			if err != nil {
+				return nil, NewErrServerError()
+			}
+			view.Occurrence = occurrence.start
+
+			view.EventResponsesSummary = responses.summary(occurrence.start)
+
+			views = append(views, *view)
+		}
 	}
 
 	return views, nil
@@ -299,25 +394,35 @@ func (s *service) GetUserEvents(ctx context.Context, userID int64) ([]EventView,
 
 	views := []EventView{}
 	for _, event := range events {
-		// Convert the event to view.
-		view, err := s.eventToView(event)
+		occurrences, err := s.eventOccurrences(ctx, event)
 		if err != nil {
+			s.logger.Error().Err(err).Msg("Error expanding event occurrences")
 			return nil, NewErrServerError()
 		}
 
-		responsesSummary, err := s.getEventResponsesSummary(ctx, event.ID)
+		// Responses to all occurrences are loaded at once and summarized per occurrence.
+		responses, err := s.getEventResponses(ctx, event.ID)
 		if err != nil {
-			s.logger.Error().Err(err).Msg("Error getting EventResponsesSummary")
+			s.logger.Error().Err(err).Msg("Error getting event responses")
 			return nil, NewErrServerError()
 		}
-		view.EventResponsesSummary = responsesSummary
 
-		response, err := s.GetUserEventResponse(ctx, userID, event.ID)
-		if err == nil {
-			view.UserResponse = response
-		}
+		for _, occurrence := range occurrences {
+			// Convert the occurrence to view.
+			view, err := s.eventToView(occurrence.event)
+			if err != nil {
+				return nil, NewErrServerError()
+			}
+			view.Occurrence = occurrence.start
+
+			view.EventResponsesSummary = responses.summary(occurrence.start)
 
-		views = append(views, *view)
+			if response, ok := responses.find(userID, occurrence.start); ok {
+				view.UserResponse = response
+			}
+
+			views = append(views, *view)
+		}
 	}
 
 	return views, nil
@@ -0,0 +1,307 @@
+package events
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+	"github.com/joinimpact/api/internal/models"
+)
+
+// maxShiftCapacity is the maximum number of volunteers in a single shift.
+const maxShiftCapacity = 1000
+
+// ShiftView represents a shift of an event, along with whether the current user is signed up for it.
+type ShiftView struct {
+	models.EventShift
+	Remaining int  `json:"remaining"` // the number of free places in the shift
+	SignedUp  bool `json:"signedUp"`  // whether the current user is signed up for the shift
+}
+
+// GetEventShifts gets all shifts of an event, marking the ones a user is signed up for.
+func (s *service) GetEventShifts(ctx context.Context, eventID, userID int64) ([]ShiftView, error) {
+	if _, err := s.eventRepository.FindByID(ctx, eventID); err != nil {
+		return nil, NewErrEventNotFound()
+	}
+
+	shifts, err := s.eventShiftRepository.FindByEventID(ctx, eventID)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error finding event shifts")
+		return nil, NewErrServerError()
+	}
+
+	signups, err := s.eventShiftSignupRepository.FindInEventByUserID(ctx, eventID, userID)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error finding shift sign-ups")
+		return nil, NewErrServerError()
+	}
+
+	signedUp := map[int64]bool{}
+	for _, signup := range signups {
+		signedUp[signup.ShiftID] = true
+	}
+
+	views := []ShiftView{}
+	for _, shift := range shifts {
+		views = append(views, ShiftView{
+			EventShift: shift,
+			Remaining:  shift.Capacity - shift.Filled,
+			SignedUp:   signedUp[shift.ID],
+		})
+	}
+
+	return views, nil
+}
+
+// CreateEventShift adds a shift to an event and returns the ID of the new shift.
+func (s *service) CreateEventShift(ctx context.Context, eventID int64, shift models.EventShift) (int64, error) {
+	if _, err := s.eventRepository.FindByID(ctx, eventID); err != nil {
+		return 0, NewErrEventNotFound()
+	}
+
+	shift, ok := validateShift(shift)
+	if !ok || !s.tagExists(shift.RequiredTagID) {
+		return 0, NewErrInvalidShift()
+	}
+
+	shift.ID = s.snowflakeService.GenerateID()
+	shift.EventID = eventID
+	shift.Filled = 0
+
+	if err := s.eventShiftRepository.Create(ctx, shift); err != nil {
+		s.logger.Error().Err(err).Msg("Error creating event shift")
+		return 0, NewErrServerError()
+	}
+
+	return shift.ID, nil
+}
+
+// UpdateEventShift updates the title, times, capacity and required tag of a shift.
+func (s *service) UpdateEventShift(ctx context.Context, eventID int64, shift models.EventShift) error {
+	if _, err := s.findShift(ctx, eventID, shift.ID); err != nil {
+		return err
+	}
+
+	shift, ok := validateShift(shift)
+	if !ok || !s.tagExists(shift.RequiredTagID) {
+		return NewErrInvalidShift()
+	}
+
+	updated, err := s.eventShiftRepository.Update(ctx, shift)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error updating event shift")
+		return NewErrServerError()
+	}
+
+	if !updated {
+		return NewErrShiftCapacityTooLow()
+	}
+
+	return nil
+}
+
+// DeleteEventShift deletes a shift and all of its sign-ups.
+func (s *service) DeleteEventShift(ctx context.Context, eventID, shiftID int64) error {
+	if _, err := s.findShift(ctx, eventID, shiftID); err != nil {
+		return err
+	}
+
+	if err := s.eventShiftRepository.DeleteByID(ctx, shiftID); err != nil {
+		s.logger.Error().Err(err).Msg("Error deleting event shift")
+		return NewErrServerError()
+	}
+
+	return nil
+}
+
+// SignUpForShift signs a volunteer up for a shift of an event.
+func (s *service) SignUpForShift(ctx context.Context, eventID, shiftID, userID int64) error {
+	shift, err := s.findShift(ctx, eventID, shiftID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.canSignUp(ctx, shift, userID); err != nil {
+		return err
+	}
+
+	signup := models.EventShiftSignup{
+		ShiftID: shift.ID,
+		EventID: eventID,
+		UserID:  userID,
+	}
+	signup.ID = s.snowflakeService.GenerateID()
+
+	ok, err := s.eventShiftSignupRepository.Create(ctx, signup)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error creating shift sign-up")
+		return NewErrServerError()
+	}
+
+	if !ok {
+		return NewErrShiftFull()
+	}
+
+	return nil
+}
+
+// SwapShift moves a volunteer from a shift they are signed up for to another shift of the same event. The
+// volunteer keeps their place when the other shift is full.
+func (s *service) SwapShift(ctx context.Context, eventID, fromShiftID, toShiftID, userID int64) error {
+	if _, err := s.findShift(ctx, eventID, fromShiftID); err != nil {
+		return err
+	}
+
+	to, err := s.findShift(ctx, eventID, toShiftID)
+	if err != nil {
+		return err
+	}
+
+	signup, err := s.eventShiftSignupRepository.FindInShiftByUserID(ctx, fromShiftID, userID)
+	if err != nil {
+		return NewErrSignupNotFound()
+	}
+
+	if err := s.canSignUp(ctx, to, userID); err != nil {
+		return err
+	}
+
+	replacement := models.EventShiftSignup{
+		ShiftID: to.ID,
+		EventID: eventID,
+		UserID:  userID,
+	}
+	replacement.ID = s.snowflakeService.GenerateID()
+
+	ok, err := s.eventShiftSignupRepository.Swap(ctx, *signup, replacement)
+	if gorm.IsRecordNotFoundError(err) {
+		// The volunteer dropped the shift while it was being swapped.
+		return NewErrSignupNotFound()
+	}
+
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error swapping shift sign-up")
+		return NewErrServerError()
+	}
+
+	if !ok {
+		return NewErrShiftFull()
+	}
+
+	return nil
+}
+
+// DropShift removes a volunteer from a shift, freeing their place.
+func (s *service) DropShift(ctx context.Context, eventID, shiftID, userID int64) error {
+	if _, err := s.findShift(ctx, eventID, shiftID); err != nil {
+		return err
+	}
+
+	signup, err := s.eventShiftSignupRepository.FindInShiftByUserID(ctx, shiftID, userID)
+	if err != nil {
+		return NewErrSignupNotFound()
+	}
+
+	if err := s.eventShiftSignupRepository.Delete(ctx, *signup); err != nil {
+		s.logger.Error().Err(err).Msg("Error deleting shift sign-up")
+		return NewErrServerError()
+	}
+
+	return nil
+}
+
+// GetShiftRoster gets the sign-ups of a shift, in the order volunteers signed up.
+func (s *service) GetShiftRoster(ctx context.Context, eventID, shiftID int64) ([]models.EventShiftSignup, error) {
+	if _, err := s.findShift(ctx, eventID, shiftID); err != nil {
+		return nil, err
+	}
+
+	signups, err := s.eventShiftSignupRepository.FindByShiftID(ctx, shiftID)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error finding shift sign-ups")
+		return nil, NewErrServerError()
+	}
+
+	return signups, nil
+}
+
+// findShift finds a shift by ID, and checks that it belongs to the event.
+func (s *service) findShift(ctx context.Context, eventID, shiftID int64) (*models.EventShift, error) {
+	shift, err := s.eventShiftRepository.FindByID(ctx, shiftID)
+	if err != nil || shift.EventID != eventID {
+		return nil, NewErrShiftNotFound()
+	}
+
+	return shift, nil
+}
+
+// canSignUp checks that a user is a volunteer in the shift's opportunity, has the tag the shift requires,
+// and is not already signed up for it. Whether the shift is full is checked as the sign-up is saved.
+func (s *service) canSignUp(ctx context.Context, shift *models.EventShift, userID int64) error {
+	event, err := s.eventRepository.FindByID(ctx, shift.EventID)
+	if err != nil {
+		return NewErrEventNotFound()
+	}
+
+	membership, err := s.opportunityMembershipRepository.FindUserInOpportunity(ctx, event.OpportunityID, userID)
+	if err != nil || membership.PermissionsFlag != models.OpportunityPermissionsMember {
+		return NewErrNotVolunteer()
+	}
+
+	if shift.RequiredTagID != nil {
+		userTags, err := s.userTagRepository.FindByUserID(userID)
+		if err != nil {
+			s.logger.Error().Err(err).Msg("Error finding user tags")
+			return NewErrServerError()
+		}
+
+		if !hasTag(userTags, *shift.RequiredTagID) {
+			return NewErrMissingRequiredTag()
+		}
+	}
+
+	if _, err := s.eventShiftSignupRepository.FindInShiftByUserID(ctx, shift.ID, userID); err == nil {
+		return NewErrAlreadySignedUp()
+	}
+
+	return nil
+}
+
+// tagExists returns true if a shift requires no tag, or requires a tag which exists.
+func (s *service) tagExists(tagID *int64) bool {
+	if tagID == nil {
+		return true
+	}
+
+	_, err := s.tagRepository.FindByID(*tagID)
+	return err == nil
+}
+
+// hasTag returns true if a tag is among a user's tags.
+func hasTag(userTags []models.UserTag, tagID int64) bool {
+	for _, userTag := range userTags {
+		if userTag.TagID == tagID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateShift normalizes a shift, and returns false if it is invalid.
+func validateShift(shift models.EventShift) (models.EventShift, bool) {
+	shift.Title = strings.TrimSpace(shift.Title)
+	if len(shift.Title) < 1 || len(shift.Title) > 128 {
+		return shift, false
+	}
+
+	if shift.FromDate.IsZero() || !shift.ToDate.After(shift.FromDate) {
+		return shift, false
+	}
+
+	if shift.Capacity < 1 || shift.Capacity > maxShiftCapacity {
+		return shift, false
+	}
+
+	return shift, true
+}
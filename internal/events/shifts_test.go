@@ -0,0 +1,38 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/joinimpact/api/internal/models"
+)
+
+// TestValidateShift tests that shifts need a title, a time range and a capacity.
+func TestValidateShift(t *testing.T) {
+	from := time.Date(2020, 6, 6, 9, 0, 0, 0, time.UTC)
+	to := from.Add(3 * time.Hour)
+
+	tests := []struct {
+		name  string
+		shift models.EventShift
+		want  bool
+	}{
+		{"valid shift", models.EventShift{Title: " Morning ", FromDate: from, ToDate: to, Capacity: 5}, true},
+		{"blank title", models.EventShift{Title: "  ", FromDate: from, ToDate: to, Capacity: 5}, false},
+		{"ends before it starts", models.EventShift{Title: "Morning", FromDate: to, ToDate: from, Capacity: 5}, false},
+		{"no start", models.EventShift{Title: "Morning", ToDate: to, Capacity: 5}, false},
+		{"no capacity", models.EventShift{Title: "Morning", FromDate: from, ToDate: to}, false},
+		{"capacity too high", models.EventShift{Title: "Morning", FromDate: from, ToDate: to, Capacity: maxShiftCapacity + 1}, false},
+	}
+
+	for _, tt := range tests {
+		shift, ok := validateShift(tt.shift)
+		if ok != tt.want {
+			t.Errorf("%s: validateShift() = %v, want %v", tt.name, ok, tt.want)
+		}
+
+		if ok && shift.Title != "Morning" {
+			t.Errorf("%s: validateShift() title = %q, want %q", tt.name, shift.Title, "Morning")
+		}
+	}
+}
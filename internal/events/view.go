@@ -26,6 +26,9 @@ type EventView struct {
 	EventResponsesSummary *EventResponsesSummary `json:"responses,omitempty" scope:"manager"`
 	UserResponse          *models.EventResponse  `json:"userResponse,omitempty"`
 	Location              *location.Location     `json:"location"`
+	Occurrence            *time.Time             `json:"occurrence,omitempty"` // the original start of the occurrence, for recurring events
+	Sequence              int                    `json:"-"`                    // the revision of the event, for calendar feeds
+	UpdatedAt             time.Time              `json:"-"`                    // when the event was last updated, for calendar feeds
 }
 
 // EventSchedule represents a date/time range for a single event.
@@ -34,6 +37,8 @@ type EventSchedule struct {
 	DateOnly   *bool     `json:"dateOnly"`                           // when false, show a time as well
 	FromDate   time.Time `json:"from,omitempty" validate:"required"` // the starting time, if applicable
 	ToDate     time.Time `json:"to,omitempty"`                       // the ending time, if applicable
+	Recurrence string    `json:"recurrence,omitempty"`               // an iCalendar RRULE repeating the event from its dates, if applicable
+	TimeZone   string    `json:"timeZone,omitempty"`                 // the IANA time zone the recurrence rule is expanded in, UTC if empty
 }
 
 // ModifyEventRequest represents the input to create/modify an event.
@@ -0,0 +1,207 @@
+package exports
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// profileRecord is a user's profile in an archive.
+type profileRecord struct {
+	ID                int64      `json:"id"`
+	FirstName         string     `json:"firstName"`
+	LastName          string     `json:"lastName"`
+	Email             string     `json:"email"`
+	EmailVerified     bool       `json:"emailVerified"`
+	DateOfBirth       time.Time  `json:"dateOfBirth"`
+	ProfilePicture    string     `json:"profilePicture"`
+	LocationLatitude  float64    `json:"locationLatitude"`
+	LocationLongitude float64    `json:"locationLongitude"`
+	CreatedAt         time.Time  `json:"createdAt"`
+	LastOnline        *time.Time `json:"lastOnline"`
+}
+
+// profileFieldRecord is a field of a user's profile in an archive.
+type profileFieldRecord struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	Privacy int    `json:"privacy"`
+}
+
+// tagRecord is a user's tag in an archive.
+type tagRecord struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// organizationMembershipRecord is a user's organization membership in an archive.
+type organizationMembershipRecord struct {
+	OrganizationID  int64     `json:"organizationId"`
+	PermissionsFlag int       `json:"permissionsFlag"`
+	JoinedAt        time.Time `json:"joinedAt"`
+	InviterID       int64     `json:"inviterId"`
+}
+
+// opportunityMembershipRecord is a user's opportunity membership in an archive.
+type opportunityMembershipRecord struct {
+	OpportunityID   int64     `json:"opportunityId"`
+	PermissionsFlag int       `json:"permissionsFlag"`
+	JoinedAt        time.Time `json:"joinedAt"`
+	InviterID       int64     `json:"inviterId"`
+}
+
+// eventResponseRecord is a user's response to an event in an archive.
+type eventResponseRecord struct {
+	EventID     int64     `json:"eventId"`
+	Response    int       `json:"response"`
+	RespondedAt time.Time `json:"respondedAt"`
+}
+
+// hourLogRecord is a log of a user's volunteering hours in an archive.
+type hourLogRecord struct {
+	ID             int64     `json:"id"`
+	OrganizationID int64     `json:"organizationId"`
+	OpportunityID  int64     `json:"opportunityId"`
+	EventID        int64     `json:"eventId"`
+	GranterID      int64     `json:"granterId"`
+	GrantedOn      time.Time `json:"grantedOn"`
+	GrantedHours   float32   `json:"grantedHours"`
+}
+
+// hourRequestRecord is a user's request for volunteering hours in an archive.
+type hourRequestRecord struct {
+	ID             int64     `json:"id"`
+	OrganizationID int64     `json:"organizationId"`
+	OpportunityID  int64     `json:"opportunityId"`
+	EventID        int64     `json:"eventId"`
+	RequestedHours float32   `json:"requestedHours"`
+	Description    string    `json:"description"`
+	Status         string    `json:"status"`
+	RequestedAt    time.Time `json:"requestedAt"`
+}
+
+// messageRecord is a message sent by a user in an archive.
+type messageRecord struct {
+	ID             int64           `json:"id"`
+	ConversationID int64           `json:"conversationId"`
+	Timestamp      time.Time       `json:"timestamp"`
+	Type           string          `json:"type"`
+	Body           json.RawMessage `json:"body"`
+	Edited         bool            `json:"edited"`
+}
+
+// archive contains all of a user's personal data.
+type archive struct {
+	Profile                  []profileRecord
+	ProfileFields            []profileFieldRecord
+	Tags                     []tagRecord
+	OrganizationMemberships  []organizationMembershipRecord
+	OpportunityMemberships   []opportunityMembershipRecord
+	EventResponses           []eventResponseRecord
+	VolunteeringHours        []hourLogRecord
+	VolunteeringHourRequests []hourRequestRecord
+	Messages                 []messageRecord
+}
+
+// sections returns the sections of the archive by file name.
+func (a *archive) sections() []struct {
+	name    string
+	records interface{}
+} {
+	return []struct {
+		name    string
+		records interface{}
+	}{
+		{"profile", a.Profile},
+		{"profile_fields", a.ProfileFields},
+		{"tags", a.Tags},
+		{"organization_memberships", a.OrganizationMemberships},
+		{"opportunity_memberships", a.OpportunityMemberships},
+		{"event_responses", a.EventResponses},
+		{"volunteering_hours", a.VolunteeringHours},
+		{"volunteering_hour_requests", a.VolunteeringHourRequests},
+		{"messages", a.Messages},
+	}
+}
+
+// writeZIP writes the archive as a ZIP file with a JSON and a CSV file for
+// every section.
+func (a *archive) writeZIP(w io.Writer) error {
+	zipWriter := zip.NewWriter(w)
+
+	for _, section := range a.sections() {
+		jsonFile, err := zipWriter.Create(section.name + ".json")
+		if err != nil {
+			return err
+		}
+
+		encoder := json.NewEncoder(jsonFile)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(section.records); err != nil {
+			return err
+		}
+
+		csvFile, err := zipWriter.Create(section.name + ".csv")
+		if err != nil {
+			return err
+		}
+
+		if err := writeCSV(csvFile, section.records); err != nil {
+			return err
+		}
+	}
+
+	return zipWriter.Close()
+}
+
+// writeCSV writes a slice of records as CSV, with a header row of the
+// records' JSON field names.
+func writeCSV(w io.Writer, records interface{}) error {
+	value := reflect.ValueOf(records)
+	recordType := value.Type().Elem()
+
+	csvWriter := csv.NewWriter(w)
+
+	header := []string{}
+	for i := 0; i < recordType.NumField(); i++ {
+		header = append(header, strings.Split(recordType.Field(i).Tag.Get("json"), ",")[0])
+	}
+	if err := csvWriter.Write(header); err != nil {
+		return err
+	}
+
+	for i := 0; i < value.Len(); i++ {
+		row := []string{}
+		for j := 0; j < recordType.NumField(); j++ {
+			row = append(row, formatCSVValue(value.Index(i).Field(j).Interface()))
+		}
+		if err := csvWriter.Write(row); err != nil {
+			return err
+		}
+	}
+
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// formatCSVValue formats a single value of a record for CSV.
+func formatCSVValue(value interface{}) string {
+	switch value := value.(type) {
+	case time.Time:
+		return value.UTC().Format(time.RFC3339)
+	case *time.Time:
+		if value == nil {
+			return ""
+		}
+		return value.UTC().Format(time.RFC3339)
+	case json.RawMessage:
+		return string(value)
+	default:
+		return fmt.Sprint(value)
+	}
+}
@@ -0,0 +1,68 @@
+package exports
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+	"time"
+)
+
+// TestWriteZIP tests that every section is written as JSON and CSV.
+func TestWriteZIP(t *testing.T) {
+	joinedAt := time.Date(2020, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	a := &archive{
+		Profile: []profileRecord{{ID: 1, FirstName: "Yury", LastName: "Orlovskiy", Email: "yury@joinimpact.org"}},
+		OrganizationMemberships: []organizationMembershipRecord{
+			{OrganizationID: 2, PermissionsFlag: 1, JoinedAt: joinedAt},
+		},
+		Messages: []messageRecord{
+			{ID: 3, ConversationID: 4, Type: "MESSAGE_STANDARD", Body: json.RawMessage(`{"text":"hi, \"all\""}`)},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := a.writeZIP(&buf); err != nil {
+		t.Fatal("error writing zip:", err)
+	}
+
+	reader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal("error reading zip:", err)
+	}
+
+	files := map[string]string{}
+	for _, file := range reader.File {
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatal("error opening file:", err)
+		}
+		content, _ := ioutil.ReadAll(rc)
+		rc.Close()
+		files[file.Name] = string(content)
+	}
+
+	if len(files) != 2*len(a.sections()) {
+		t.Fatalf("expected %d files, got %d", 2*len(a.sections()), len(files))
+	}
+
+	expected := "organizationId,permissionsFlag,joinedAt,inviterId\n2,1,2020-07-01T12:00:00Z,0\n"
+	if files["organization_memberships.csv"] != expected {
+		t.Fatalf("unexpected csv %q", files["organization_memberships.csv"])
+	}
+
+	if files["tags.csv"] != "id,name\n" {
+		t.Fatalf("unexpected csv for empty section %q", files["tags.csv"])
+	}
+
+	var messages []struct {
+		Body struct {
+			Text string `json:"text"`
+		} `json:"body"`
+	}
+	if err := json.Unmarshal([]byte(files["messages.json"]), &messages); err != nil || len(messages) != 1 || messages[0].Body.Text != `hi, "all"` {
+		t.Fatalf("unexpected messages json %q", files["messages.json"])
+	}
+}
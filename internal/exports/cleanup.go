@@ -0,0 +1,60 @@
+package exports
+
+import (
+	"context"
+	"time"
+
+	"github.com/joinimpact/api/internal/models"
+	"github.com/rs/zerolog"
+)
+
+// DeleteExpiredExports deletes the archives of all data exports whose download
+// link expired, and returns how many were deleted.
+func (s *service) DeleteExpiredExports(ctx context.Context) (int, error) {
+	dataExports, err := s.dataExportRepository.FindExpired(ctx, time.Now().UTC())
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error finding expired data exports")
+		return 0, NewErrServerError()
+	}
+
+	deleted := 0
+	for _, dataExport := range dataExports {
+		logger := s.logger.With().Int64("dataExportId", dataExport.ID).Logger()
+
+		if err := s.cdnClient.DeleteFile(dataExport.ObjectKey); err != nil {
+			// Keep going, the export will be retried on the next run.
+			logger.Error().Err(err).Msg("Error deleting expired data export archive")
+			continue
+		}
+
+		dataExport.Status = models.DataExportStatusExpired
+		if err := s.dataExportRepository.Save(ctx, dataExport); err != nil {
+			logger.Error().Err(err).Msg("Error saving expired data export")
+			continue
+		}
+
+		deleted++
+	}
+
+	return deleted, nil
+}
+
+// RunCleanupJob deletes the archives of expired data exports once every
+// interval, until the context is done.
+func RunCleanupJob(ctx context.Context, service Service, interval time.Duration, logger *zerolog.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		deleted, err := service.DeleteExpiredExports(ctx)
+		if err == nil && deleted > 0 {
+			logger.Info().Int("deleted", deleted).Msg("Deleted expired data export archives")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
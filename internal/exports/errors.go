@@ -0,0 +1,59 @@
+package exports
+
+// ErrServerError is thrown when the server experiences an internal error.
+type ErrServerError struct {
+}
+
+// NewErrServerError creates and returns a ErrServerError.
+func NewErrServerError() error {
+	return &ErrServerError{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrServerError) Error() string {
+	return "internal error processing request, please try again"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrServerError) Ref() string {
+	return "generic.server_error"
+}
+
+// ErrUserNotFound is thrown when the user to export is not found.
+type ErrUserNotFound struct {
+}
+
+// NewErrUserNotFound creates and returns a ErrUserNotFound.
+func NewErrUserNotFound() error {
+	return &ErrUserNotFound{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrUserNotFound) Error() string {
+	return "user not found"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrUserNotFound) Ref() string {
+	return "exports.user_not_found"
+}
+
+// ErrExportRecentlyRequested is thrown when a user requests a new data export
+// too soon after their last one.
+type ErrExportRecentlyRequested struct {
+}
+
+// NewErrExportRecentlyRequested creates and returns a ErrExportRecentlyRequested.
+func NewErrExportRecentlyRequested() error {
+	return &ErrExportRecentlyRequested{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrExportRecentlyRequested) Error() string {
+	return "a data export was requested recently"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrExportRecentlyRequested) Ref() string {
+	return "exports.recently_requested"
+}
@@ -0,0 +1,316 @@
+package exports
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/joinimpact/api/internal/cdn"
+	"github.com/joinimpact/api/internal/config"
+	"github.com/joinimpact/api/internal/email"
+	"github.com/joinimpact/api/internal/email/templates"
+	"github.com/joinimpact/api/internal/models"
+	"github.com/joinimpact/api/internal/snowflakes"
+	"github.com/joinimpact/api/pkg/dbctx"
+	"github.com/rs/zerolog"
+)
+
+// exportRequestInterval is the minimum time between two data exports of a user.
+const exportRequestInterval = 24 * time.Hour
+
+// exportLinkLifespan is how long the download link of an archive stays valid.
+const exportLinkLifespan = 7 * 24 * time.Hour
+
+// exportPageSize is the number of records loaded at a time from paginated repositories.
+const exportPageSize = 500
+
+// Service represents a service for exporting users' personal data.
+type Service interface {
+	// RequestExport starts building an archive of a user's personal data in the background. The user is
+	// emailed a link to download it once it is ready.
+	RequestExport(ctx context.Context, userID int64) (*models.DataExport, error)
+	// DeleteExpiredExports deletes the archives of all data exports whose download link expired, and
+	// returns how many were deleted.
+	DeleteExpiredExports(ctx context.Context) (int, error)
+}
+
+// service is the internal implementation of the exports.Service interface.
+type service struct {
+	dataExportRepository                 models.DataExportRepository
+	userRepository                       models.UserRepository
+	userProfileFieldRepository           models.UserProfileFieldRepository
+	userTagRepository                    models.UserTagRepository
+	tagRepository                        models.TagRepository
+	organizationMembershipRepository     models.OrganizationMembershipRepository
+	opportunityMembershipRepository      models.OpportunityMembershipRepository
+	eventResponseRepository              models.EventResponseRepository
+	volunteeringHourLogRepository        models.VolunteeringHourLogRepository
+	volunteeringHourLogRequestRepository models.VolunteeringHourLogRequestRepository
+	messageRepository                    models.MessageRepository
+	config                               *config.Config
+	logger                               *zerolog.Logger
+	snowflakeService                     snowflakes.SnowflakeService
+	emailService                         email.Service
+	cdnClient                            *cdn.Client
+}
+
+// NewService creates and returns a new exports.Service.
+func NewService(dataExportRepository models.DataExportRepository, userRepository models.UserRepository, userProfileFieldRepository models.UserProfileFieldRepository, userTagRepository models.UserTagRepository, tagRepository models.TagRepository, organizationMembershipRepository models.OrganizationMembershipRepository, opportunityMembershipRepository models.OpportunityMembershipRepository, eventResponseRepository models.EventResponseRepository, volunteeringHourLogRepository models.VolunteeringHourLogRepository, volunteeringHourLogRequestRepository models.VolunteeringHourLogRequestRepository, messageRepository models.MessageRepository,
+	config *config.Config, logger *zerolog.Logger, snowflakeService snowflakes.SnowflakeService, emailService email.Service) Service {
+	return &service{
+		dataExportRepository,
+		userRepository,
+		userProfileFieldRepository,
+		userTagRepository,
+		tagRepository,
+		organizationMembershipRepository,
+		opportunityMembershipRepository,
+		eventResponseRepository,
+		volunteeringHourLogRepository,
+		volunteeringHourLogRequestRepository,
+		messageRepository,
+		config,
+		logger,
+		snowflakeService,
+		emailService,
+		cdn.NewCDNClient(config),
+	}
+}
+
+// RequestExport starts building an archive of a user's personal data in the
+// background. The user is emailed a link to download it once it is ready.
+func (s *service) RequestExport(ctx context.Context, userID int64) (*models.DataExport, error) {
+	user, err := s.userRepository.FindByID(userID)
+	if err != nil {
+		return nil, NewErrUserNotFound()
+	}
+
+	latest, err := s.dataExportRepository.FindLatestByUserID(ctx, user.ID)
+	if err == nil && latest.Status != models.DataExportStatusFailed && time.Now().Sub(latest.CreatedAt) < exportRequestInterval {
+		return nil, NewErrExportRecentlyRequested()
+	}
+
+	dataExport := models.DataExport{
+		UserID: user.ID,
+		Status: models.DataExportStatusPending,
+	}
+	dataExport.ID = s.snowflakeService.GenerateID()
+	dataExport.ObjectKey = fmt.Sprintf("exports/%d/%d.zip", user.ID, dataExport.ID)
+
+	if err := s.dataExportRepository.Create(ctx, dataExport); err != nil {
+		s.logger.Error().Err(err).Msg("Error creating data export")
+		return nil, NewErrServerError()
+	}
+
+	// The request's context ends with the response, so the archive is built
+	// with a new one.
+	go s.buildExport(context.Background(), user, dataExport)
+
+	return &dataExport, nil
+}
+
+// buildExport builds and uploads the archive of a data export, then emails
+// the user a link to download it.
+func (s *service) buildExport(ctx context.Context, user *models.User, dataExport models.DataExport) {
+	logger := s.logger.With().Int64("userId", user.ID).Int64("dataExportId", dataExport.ID).Logger()
+
+	fail := func(err error, msg string) {
+		logger.Error().Err(err).Msg(msg)
+
+		dataExport.Status = models.DataExportStatusFailed
+		if err := s.dataExportRepository.Save(ctx, dataExport); err != nil {
+			logger.Error().Err(err).Msg("Error saving failed data export")
+		}
+	}
+
+	archive, err := s.collect(ctx, user)
+	if err != nil {
+		fail(err, "Error collecting user data for export")
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := archive.writeZIP(&buf); err != nil {
+		fail(err, "Error writing data export archive")
+		return
+	}
+
+	if err := s.cdnClient.UploadPrivateFile(dataExport.ObjectKey, "application/zip", &buf); err != nil {
+		fail(err, "Error uploading data export archive")
+		return
+	}
+
+	link, err := s.cdnClient.PresignedURL(dataExport.ObjectKey, exportLinkLifespan)
+	if err != nil {
+		fail(err, "Error creating data export download link")
+		return
+	}
+
+	now := time.Now().UTC()
+	expiresAt := now.Add(exportLinkLifespan)
+	dataExport.Status = models.DataExportStatusReady
+	dataExport.CompletedAt = &now
+	dataExport.ExpiresAt = &expiresAt
+	if err := s.dataExportRepository.Save(ctx, dataExport); err != nil {
+		logger.Error().Err(err).Msg("Error saving data export")
+	}
+
+	email := s.emailService.NewEmail(
+		email.NewRecipient(fmt.Sprintf("%s %s", user.FirstName, user.LastName), user.Email),
+		"Your Impact data is ready",
+		templates.DataExportReadyTemplate(user.FirstName, link, "7 days"),
+	)
+	if err := s.emailService.Send(email); err != nil {
+		logger.Error().Err(err).Msg("Error sending data export email")
+	}
+}
+
+// collect loads all of a user's personal data into an archive.
+func (s *service) collect(ctx context.Context, user *models.User) (*archive, error) {
+	a := &archive{
+		Profile: []profileRecord{
+			{
+				ID:                user.ID,
+				FirstName:         user.FirstName,
+				LastName:          user.LastName,
+				Email:             user.Email,
+				EmailVerified:     user.EmailVerified,
+				DateOfBirth:       user.DateOfBirth,
+				ProfilePicture:    user.ProfilePicture,
+				LocationLatitude:  user.LocationLatitude,
+				LocationLongitude: user.LocationLongitude,
+				CreatedAt:         user.CreatedAt,
+				LastOnline:        user.LastOnline,
+			},
+		},
+		ProfileFields:            []profileFieldRecord{},
+		Tags:                     []tagRecord{},
+		OrganizationMemberships:  []organizationMembershipRecord{},
+		OpportunityMemberships:   []opportunityMembershipRecord{},
+		EventResponses:           []eventResponseRecord{},
+		VolunteeringHours:        []hourLogRecord{},
+		VolunteeringHourRequests: []hourRequestRecord{},
+		Messages:                 []messageRecord{},
+	}
+
+	fields, err := s.userProfileFieldRepository.FindByUserID(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, field := range fields {
+		a.ProfileFields = append(a.ProfileFields, profileFieldRecord{field.Name, field.Value, field.Privacy})
+	}
+
+	userTags, err := s.userTagRepository.FindByUserID(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, userTag := range userTags {
+		tag, err := s.tagRepository.FindByID(userTag.TagID)
+		if err != nil {
+			continue
+		}
+		a.Tags = append(a.Tags, tagRecord{tag.ID, tag.Name})
+	}
+
+	organizationMemberships, err := s.organizationMembershipRepository.FindByUserID(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, membership := range organizationMemberships {
+		a.OrganizationMemberships = append(a.OrganizationMemberships, organizationMembershipRecord{membership.OrganizationID, membership.PermissionsFlag, membership.JoinedAt, membership.InviterID})
+	}
+
+	eventResponses, err := s.eventResponseRepository.FindByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, eventResponse := range eventResponses {
+		response := models.EventResponseNull
+		if eventResponse.Response != nil {
+			response = *eventResponse.Response
+		}
+		a.EventResponses = append(a.EventResponses, eventResponseRecord{eventResponse.EventID, response, eventResponse.UpdatedAt})
+	}
+
+	// The remaining repositories are paginated, so every page is loaded.
+	for page := 0; ; page++ {
+		memberships, err := s.opportunityMembershipRepository.FindByUserID(pageContext(ctx, page), user.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, membership := range memberships {
+			a.OpportunityMemberships = append(a.OpportunityMemberships, opportunityMembershipRecord{membership.OpportunityID, membership.PermissionsFlag, membership.JoinedAt, membership.InviterID})
+		}
+		if len(memberships) < exportPageSize {
+			break
+		}
+	}
+
+	for page := 0; ; page++ {
+		res, err := s.volunteeringHourLogRepository.FindByVolunteerID(pageContext(ctx, page), user.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, log := range res.VolunteeringHourLogs {
+			a.VolunteeringHours = append(a.VolunteeringHours, hourLogRecord{log.ID, log.OrganizationID, log.OpportunityID, log.EventID, log.GranterID, log.GrantedOn, log.GrantedHours})
+		}
+		if len(res.VolunteeringHourLogs) < exportPageSize {
+			break
+		}
+	}
+
+	for page := 0; ; page++ {
+		res, err := s.volunteeringHourLogRequestRepository.FindByVolunteerID(pageContext(ctx, page), user.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, request := range res.VolunteeringHourLogRequests {
+			a.VolunteeringHourRequests = append(a.VolunteeringHourRequests, hourRequestRecord{request.ID, request.OrganizationID, request.OpportunityID, request.EventID, request.RequestedHours, request.Description, hourRequestStatus(request), request.CreatedAt})
+		}
+		if len(res.VolunteeringHourLogRequests) < exportPageSize {
+			break
+		}
+	}
+
+	for page := 0; ; page++ {
+		res, err := s.messageRepository.FindBySenderID(pageContext(ctx, page), user.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, message := range res.Messages {
+			body := message.Body.RawMessage
+			if len(body) == 0 {
+				body = nil
+			}
+			a.Messages = append(a.Messages, messageRecord{message.ID, message.ConversationID, message.Timestamp, message.Type, body, message.Edited})
+		}
+		if len(res.Messages) < exportPageSize {
+			break
+		}
+	}
+
+	return a, nil
+}
+
+// pageContext returns a context for loading a single page of records from a
+// paginated repository.
+func pageContext(ctx context.Context, page int) context.Context {
+	return dbctx.Inject(ctx, dbctx.Request{
+		Limit: exportPageSize,
+		Page:  page,
+	})
+}
+
+// hourRequestStatus returns the status of a volunteering hour request.
+func hourRequestStatus(request models.VolunteeringHourLogRequest) string {
+	switch {
+	case request.Accepted != nil && *request.Accepted:
+		return "accepted"
+	case request.Declined != nil && *request.Declined:
+		return "declined"
+	default:
+		return "pending"
+	}
+}
@@ -29,6 +29,8 @@ type Service interface {
 	DeclineRequest(ctx context.Context, granterID, requestID int64) error
 	// GetHoursByVolunteer gets a user's hours.
 	GetHoursByVolunteer(ctx context.Context, volunteerID int64) (*VolunteeringHourLogsResponse, error)
+	// GetOrganizationsReport sums up the hours of multiple organizations, such as an organization and its chapters.
+	GetOrganizationsReport(ctx context.Context, organizationIDs []int64) (*HoursReport, error)
 }
 
 // service is the internal implementation of the hours.Service interface.
@@ -197,3 +199,27 @@ func (s *service) GetHoursByVolunteer(ctx context.Context, volunteerID int64) (*
 		Pages:                uint(res.TotalResults/dbctx.Get(ctx).Limit) + 1,
 	}, nil
 }
+
+// HoursReport sums up the hours of one or more organizations.
+type HoursReport struct {
+	TotalHours    float32                          `json:"totalHours"`
+	Organizations []models.VolunteeringHourSummary `json:"organizations"`
+}
+
+// GetOrganizationsReport sums up the hours of multiple organizations, such as an organization and its chapters.
+func (s *service) GetOrganizationsReport(ctx context.Context, organizationIDs []int64) (*HoursReport, error) {
+	summaries, err := s.volunteeringHourLogRepository.SummarizeByOrganizationIDs(ctx, organizationIDs)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error summarizing organization hours")
+		return nil, NewErrServerError()
+	}
+
+	report := &HoursReport{
+		Organizations: summaries,
+	}
+	for _, summary := range summaries {
+		report.TotalHours += summary.Hours
+	}
+
+	return report, nil
+}
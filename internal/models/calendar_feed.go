@@ -0,0 +1,25 @@
+package models
+
+import "context"
+
+// CalendarFeed represents a secret URL at which calendar apps subscribe to a volunteer's events, or to the
+// events of an opportunity a manager manages. Only a hash of the feed's token is stored, and the token is
+// replaced to revoke the URL.
+type CalendarFeed struct {
+	Model
+	UserID        int64  `json:"userId" gorm:"index"`   // the id of the user the feed belongs to
+	OpportunityID *int64 `json:"opportunityId"`         // the id of the opportunity of a manager's feed, nil for a volunteer's feed
+	TokenHash     string `json:"-" gorm:"unique_index"` // the SHA-256 hash of the token
+}
+
+// CalendarFeedRepository represents a repository of calendar feeds.
+type CalendarFeedRepository interface {
+	// FindByTokenHash finds a single entity by the hash of its token.
+	FindByTokenHash(ctx context.Context, tokenHash string) (*CalendarFeed, error)
+	// FindByUserID finds a user's entity for an opportunity, or the user's own entity when opportunityID is nil.
+	FindByUserID(ctx context.Context, userID int64, opportunityID *int64) (*CalendarFeed, error)
+	// Create creates a new entity.
+	Create(ctx context.Context, feed CalendarFeed) error
+	// UpdateTokenHash replaces the hash of an entity's token.
+	UpdateTokenHash(ctx context.Context, id int64, tokenHash string) error
+}
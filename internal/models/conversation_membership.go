@@ -2,6 +2,9 @@ package models
 
 import "context"
 
+// ConversationMembershipRoleVolunteer is the role of the volunteer in an organization-volunteer conversation.
+const ConversationMembershipRoleVolunteer = 0
+
 // ConversationMembership represents a user's relation to a conversation.
 type ConversationMembership struct {
 	Model
@@ -22,10 +25,14 @@ type ConversationMembershipRepository interface {
 	FindByUserID(userID int64) ([]ConversationMembership, error)
 	// FindByUserIDAndConversationID finds a single entity by user ID and conversation ID.
 	FindByUserIDAndConversationID(ctx context.Context, userID, conversationID int64) (*ConversationMembership, error)
+	// FindByUserIDAndOrganizationID finds a user's active memberships in an organization's conversations.
+	FindByUserIDAndOrganizationID(ctx context.Context, userID, organizationID int64) ([]ConversationMembership, error)
 	// Create creates a new entity.
 	Create(conversationMembership ConversationMembership) error
 	// Update updates an entity with the ID in the provided entity.
 	Update(conversationMembership ConversationMembership) error
+	// Deactivate marks an entity as inactive.
+	Deactivate(id int64) error
 	// DeleteByID deletes an entity by ID.
 	DeleteByID(id int64) error
 }
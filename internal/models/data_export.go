@@ -0,0 +1,40 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+// Data export statuses.
+const (
+	DataExportStatusPending = "pending"
+	DataExportStatusReady   = "ready"
+	DataExportStatusFailed  = "failed"
+	DataExportStatusExpired = "expired"
+)
+
+// DataExport represents an archive of a user's personal data, requested by
+// the user.
+type DataExport struct {
+	Model
+	UserID      int64      `json:"userId"`      // the id of the user whose data is exported
+	User        User       `json:"-"`           //
+	Status      string     `json:"status"`      // the status of the export, one of the DataExportStatus constants
+	ObjectKey   string     `json:"-"`           // the key of the archive in the CDN bucket
+	CompletedAt *time.Time `json:"completedAt"` // when the archive was built
+	ExpiresAt   *time.Time `json:"expiresAt"`   // when the download link of the archive expires
+}
+
+// DataExportRepository represents a repository of data exports.
+type DataExportRepository interface {
+	// FindByID finds a single entity by ID.
+	FindByID(ctx context.Context, id int64) (*DataExport, error)
+	// FindLatestByUserID finds the most recently created entity of a user.
+	FindLatestByUserID(ctx context.Context, userID int64) (*DataExport, error)
+	// FindExpired finds all ready entities whose download link expired before a time.
+	FindExpired(ctx context.Context, before time.Time) ([]DataExport, error)
+	// Create creates a new entity.
+	Create(ctx context.Context, dataExport DataExport) error
+	// Save saves all fields in the provided entity.
+	Save(ctx context.Context, dataExport DataExport) error
+}
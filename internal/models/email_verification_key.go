@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// EmailVerificationKey represents a key that allows a user to verify that
+// they own an email address.
+type EmailVerificationKey struct {
+	Model
+	UserID    int64 `json:"userId"`
+	User      User
+	Email     string    `json:"email"`
+	Key       string    `json:"key"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// EmailVerificationKeyRepository represents the repository for the
+// EmailVerificationKey.
+type EmailVerificationKeyRepository interface {
+	// FindByID finds a single EmailVerificationKey by ID.
+	FindByID(id int64) (*EmailVerificationKey, error)
+	// FindByKey finds a single EmailVerificationKey by Key.
+	FindByKey(key string) (*EmailVerificationKey, error)
+	// FindLatestByUserID finds the most recently created EmailVerificationKey of a user.
+	FindLatestByUserID(userID int64) (*EmailVerificationKey, error)
+	// Create creates a new EmailVerificationKey.
+	Create(emailVerificationKey EmailVerificationKey) error
+	// Update updates an EmailVerificationKey with the ID in the provided EmailVerificationKey.
+	Update(emailVerificationKey EmailVerificationKey) error
+	// DeleteByID deletes an EmailVerificationKey by ID.
+	DeleteByID(id int64) error
+	// DeleteByUserID deletes all EmailVerificationKeys of a user.
+	DeleteByUserID(userID int64) error
+}
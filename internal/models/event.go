@@ -24,8 +24,11 @@ type Event struct {
 	DateOnly          *bool     `json:"dateOnly"`
 	FromDate          time.Time `json:"from"`
 	ToDate            time.Time `json:"to"`
-	LocationLatitude  float64   `json:"-"` // the latitude of the events's location
-	LocationLongitude float64   `json:"-"` // the longitude of the events's location
+	Recurrence        string    `json:"recurrence"`                  // an iCalendar RRULE repeating the event from its dates, if any
+	TimeZone          string    `json:"timeZone"`                    // the IANA time zone the recurrence rule is expanded in, UTC if empty
+	Sequence          int       `json:"-" gorm:"not null;default:0"` // the revision of the event, increased on every update for calendar feeds
+	LocationLatitude  float64   `json:"-"`                           // the latitude of the events's location
+	LocationLongitude float64   `json:"-"`                           // the longitude of the events's location
 }
 
 // EventRepository represents a repository of events.
@@ -36,12 +39,19 @@ type EventRepository interface {
 	FindByOpportunityID(ctx context.Context, opportunityID int64) ([]Event, error)
 	// FindByOpportunityIDs finds multiple entities by multiple opportunity IDs.
 	FindByOpportunityIDs(ctx context.Context, opportunityIDs []int64) ([]Event, error)
+	// FindDeletedByOpportunityIDs finds entities of multiple opportunities which were deleted after a time.
+	FindDeletedByOpportunityIDs(ctx context.Context, opportunityIDs []int64, since time.Time) ([]Event, error)
 	// FindByCreatorID finds multiple entities by the creator ID.
 	FindByCreatorID(ctx context.Context, creatorID int64) ([]Event, error)
 	// Create creates a new entity.
 	Create(ctx context.Context, event Event) error
-	// Update updates an entity with the ID in the provided entity.
+	// Update updates an entity with the ID in the provided entity, and increases its sequence.
 	Update(ctx context.Context, event Event) error
+	// UpdateRecurrence updates the recurrence rule and time zone of an entity, which may be empty, and
+	// increases its sequence.
+	UpdateRecurrence(ctx context.Context, id int64, recurrence, timeZone string) error
+	// Touch increases the sequence of an entity and sets its update time, for changes to its occurrences.
+	Touch(ctx context.Context, id int64) error
 	// DeleteByID deletes an entity by ID.
 	DeleteByID(ctx context.Context, id int64) error
 }
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+// EventCheckIn represents a volunteer's attendance at an event, or at a single occurrence of a recurring
+// event, from checking in until checking out.
+type EventCheckIn struct {
+	Model
+	EventID         int64      `json:"eventId" gorm:"unique_index:idx_event_check_ins_event_user_occurrence"`
+	UserID          int64      `json:"userId" gorm:"unique_index:idx_event_check_ins_event_user_occurrence;index"`
+	OccurrenceStart *time.Time `json:"occurrence,omitempty"`                                                               // the original start of the occurrence of a recurring event
+	OccurrenceKey   int64      `json:"-" gorm:"not null;default:0;unique_index:idx_event_check_ins_event_user_occurrence"` // OccurrenceStart in Unix nanoseconds, or 0, so that volunteers check in once per occurrence
+	CheckedInAt     time.Time  `json:"checkedInAt"`
+	CheckedInByID   int64      `json:"checkedInById,omitempty"` // the manager who checked the volunteer in, if they did not scan the check-in code
+	CheckedOutAt    *time.Time `json:"checkedOutAt"`
+	CheckedOutByID  int64      `json:"checkedOutById,omitempty"` // the manager who checked the volunteer out, if they did not check out themselves
+	Hours           float32    `json:"hours"`                    // the hours logged on check-out
+	HourLogID       *int64     `json:"hourLogId"`                // the VolunteeringHourLog created on check-out
+}
+
+// EventCheckInRepository represents a repository of event check-ins.
+type EventCheckInRepository interface {
+	// FindByEventID finds all entities of an event in check-in order, or only the entities of a single
+	// occurrence if one is given.
+	FindByEventID(ctx context.Context, eventID int64, occurrence *time.Time) ([]EventCheckIn, error)
+	// FindOpenByUserID finds a user's latest entity in an event which is not checked out.
+	FindOpenByUserID(ctx context.Context, eventID, userID int64) (*EventCheckIn, error)
+	// Create creates a new entity. It returns false without creating the entity when the user already
+	// checked in to the event or occurrence.
+	Create(ctx context.Context, checkIn EventCheckIn) (bool, error)
+	// CheckOut sets the check-out time, manager and hours of an entity and creates its hour log. It returns
+	// false without creating the hour log when the entity was already checked out.
+	CheckOut(ctx context.Context, checkIn EventCheckIn, hourLog VolunteeringHourLog) (bool, error)
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+// EventOccurrenceOverride changes or cancels a single occurrence of a recurring event.
+type EventOccurrenceOverride struct {
+	Model
+	EventID         int64      `json:"eventId" gorm:"unique_index:idx_event_occurrence_overrides_event_occurrence"`
+	OccurrenceStart time.Time  `json:"occurrence" gorm:"unique_index:idx_event_occurrence_overrides_event_occurrence"` // the start of the occurrence given by the event's recurrence rule
+	Cancelled       bool       `json:"cancelled"`                                                                      // whether the occurrence is skipped
+	Title           *string    `json:"title"`                                                                          // replaces the event's title, if set
+	Description     *string    `json:"description"`                                                                    // replaces the event's description, if set
+	FromDate        *time.Time `json:"from"`                                                                           // moves the occurrence, if set
+	ToDate          *time.Time `json:"to"`                                                                             // moves the end of the occurrence, if set
+}
+
+// EventOccurrenceOverrideRepository represents a repository of event occurrence overrides.
+type EventOccurrenceOverrideRepository interface {
+	// FindByEventID finds all entities of an event, ordered by occurrence.
+	FindByEventID(ctx context.Context, eventID int64) ([]EventOccurrenceOverride, error)
+	// FindByOccurrence finds the entity of a single occurrence of an event.
+	FindByOccurrence(ctx context.Context, eventID int64, occurrence time.Time) (*EventOccurrenceOverride, error)
+	// Save creates an entity, or updates all fields of an existing entity.
+	Save(ctx context.Context, override EventOccurrenceOverride) error
+	// DeleteByID deletes an entity by ID.
+	DeleteByID(ctx context.Context, id int64) error
+}
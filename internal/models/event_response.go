@@ -1,6 +1,9 @@
 package models
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // Event responses
 const (
@@ -12,9 +15,10 @@ const (
 // EventResponse represents a volunteer's response to an event.
 type EventResponse struct {
 	Model
-	UserID   int64 `json:"userId"`
-	EventID  int64 `json:"eventId"`
-	Response *int  `json:"response"`
+	UserID          int64      `json:"userId"`
+	EventID         int64      `json:"eventId"`
+	Response        *int       `json:"response"`
+	OccurrenceStart *time.Time `json:"occurrence,omitempty" gorm:"index"` // the original start of the occurrence of a recurring event responded to
 }
 
 // EventResponseRepository represents a repository of event responses.
@@ -23,10 +27,14 @@ type EventResponseRepository interface {
 	FindByID(ctx context.Context, id int64) (*EventResponse, error)
 	// FindByEventID finds multiple entities by the event ID.
 	FindByEventID(ctx context.Context, eventID int64) ([]EventResponse, error)
+	// FindByEventIDs finds multiple entities by multiple event IDs.
+	FindByEventIDs(ctx context.Context, eventIDs []int64) ([]EventResponse, error)
 	// FindByUserID finds multiple entities by the user's ID.
 	FindByUserID(ctx context.Context, userID int64) ([]EventResponse, error)
 	// FindInEventByUserID finds an entity by the entity and user's ID.
 	FindInEventByUserID(ctx context.Context, eventID, userID int64) (*EventResponse, error)
+	// FindInOccurrenceByUserID finds an entity by the user's ID in a single occurrence of a recurring event.
+	FindInOccurrenceByUserID(ctx context.Context, eventID, userID int64, occurrence time.Time) (*EventResponse, error)
 	// Create creates a new entity.
 	Create(ctx context.Context, eventResponse EventResponse) error
 	// Update updates an entity with the ID in the provided entity.
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+// EventShift represents a time slot of an event which volunteers sign up for, such as a morning shift.
+type EventShift struct {
+	Model
+	EventID       int64     `json:"eventId" gorm:"index"`
+	Title         string    `json:"title"`
+	FromDate      time.Time `json:"from"`
+	ToDate        time.Time `json:"to"`
+	Capacity      int       `json:"capacity"`      // the maximum number of volunteers in the shift
+	Filled        int       `json:"filled"`        // the number of volunteers signed up, kept in step with the sign-ups
+	RequiredTagID *int64    `json:"requiredTagId"` // the ID of a tag, such as a skill, which volunteers need to sign up
+}
+
+// EventShiftRepository represents a repository of event shifts.
+type EventShiftRepository interface {
+	// FindByID finds a single entity by ID.
+	FindByID(ctx context.Context, id int64) (*EventShift, error)
+	// FindByEventID finds all entities of an event, ordered by start time.
+	FindByEventID(ctx context.Context, eventID int64) ([]EventShift, error)
+	// Create creates a new entity.
+	Create(ctx context.Context, shift EventShift) error
+	// Update updates the title, times, capacity and required tag of an entity. It returns false without
+	// updating the entity when the capacity is below the number of volunteers signed up.
+	Update(ctx context.Context, shift EventShift) (bool, error)
+	// DeleteByID deletes an entity and its sign-ups by ID.
+	DeleteByID(ctx context.Context, id int64) error
+}
+
+// EventShiftSignup represents a volunteer's place in an event shift.
+type EventShiftSignup struct {
+	Model
+	ShiftID int64 `json:"shiftId" gorm:"unique_index:idx_event_shift_signups_shift_user"`
+	EventID int64 `json:"eventId" gorm:"index"`
+	UserID  int64 `json:"userId" gorm:"unique_index:idx_event_shift_signups_shift_user"`
+}
+
+// EventShiftSignupRepository represents a repository of event shift sign-ups. Sign-ups are created and
+// deleted along with the filled count of their shift, so that shifts are never overbooked.
+type EventShiftSignupRepository interface {
+	// FindByShiftID finds all entities of a shift, in sign-up order.
+	FindByShiftID(ctx context.Context, shiftID int64) ([]EventShiftSignup, error)
+	// FindInEventByUserID finds all of a user's entities in an event.
+	FindInEventByUserID(ctx context.Context, eventID, userID int64) ([]EventShiftSignup, error)
+	// FindInShiftByUserID finds a user's entity in a shift.
+	FindInShiftByUserID(ctx context.Context, shiftID, userID int64) (*EventShiftSignup, error)
+	// Create creates a new entity. It returns false without creating the entity when the shift is full.
+	Create(ctx context.Context, signup EventShiftSignup) (bool, error)
+	// Swap replaces an entity with a new entity in another shift. It returns false without changing either
+	// shift when the other shift is full, and gorm.ErrRecordNotFound when the entity was already deleted.
+	Swap(ctx context.Context, signup EventShiftSignup, replacement EventShiftSignup) (bool, error)
+	// Delete deletes an entity.
+	Delete(ctx context.Context, signup EventShiftSignup) error
+}
@@ -16,6 +16,7 @@ const (
 	MessageTypeHoursRequested             = "MESSAGE_HOURS_REQUESTED"
 	MessageTypeHoursAccepted              = "MESSAGE_HOURS_ACCEPTED"
 	MessageTypeHoursDeclined              = "MESSAGE_HOURS_DECLINED"
+	MessageTypeWaitlistSpotOffered        = "MESSAGE_WAITLIST_SPOT_OFFERED"
 )
 
 // Sender perspectives.
@@ -50,6 +51,9 @@ type MessageRepository interface {
 	FindByID(ctx context.Context, id int64) (*Message, error)
 	// FindByConversationID finds multiple entities by the conversation ID.
 	FindByConversationID(ctx context.Context, conversationID int64) (*MessagesResponse, error)
+	// FindByOrganizationID finds all entities in an organization's conversations in the time range of the
+	// context, ordered by conversation and timestamp.
+	FindByOrganizationID(ctx context.Context, organizationID int64) ([]Message, error)
 	// FindBySenderID finds multiple entities by the sender ID.
 	FindBySenderID(ctx context.Context, senderID int64) (*MessagesResponse, error)
 	// FindInConversationBySenderID finds multiple entities by the sender and conversation ID.
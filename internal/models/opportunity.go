@@ -1,6 +1,19 @@
 package models
 
-import "context"
+import (
+	"context"
+	"time"
+)
+
+// Opportunity lifecycle statuses
+const (
+	OpportunityStatusDraft     = "draft"     // the opportunity is being prepared and is only visible to the organization
+	OpportunityStatusScheduled = "scheduled" // the opportunity will open at its publish date
+	OpportunityStatusOpen      = "open"      // the opportunity is in browse and accepts new applicants
+	OpportunityStatusClosed    = "closed"    // the opportunity no longer accepts new applicants
+	OpportunityStatusCompleted = "completed" // the opportunity has ended, and only appears in volunteer histories
+	OpportunityStatusArchived  = "archived"  // the opportunity is kept for the organization's records
+)
 
 // Opportunity represents an organization's opportunity.
 type Opportunity struct {
@@ -11,6 +24,9 @@ type Opportunity struct {
 	CreatorID               int64                    `json:"creatorId"`                     // the id of the user who created the opportunity initially
 	Creator                 User                     `json:"-" gorm:"foreignkey:CreatorID"` //
 	Public                  bool                     `json:"public"`                        // whether or not the opportunity should be shown to volunteers
+	Status                  string                   `json:"status"`                        // the lifecycle status of the opportunity, empty for opportunities created before statuses
+	PublishAt               *time.Time               `json:"publishAt"`                     // the time a scheduled opportunity opens
+	CloseAt                 *time.Time               `json:"closeAt"`                       // the time an open opportunity closes to new applicants
 	Title                   string                   `json:"title"`                         // the title of the opportunity
 	ProfilePicture          string                   `json:"profilePicture"`                // a url to the opportunity's banner image
 	Description             string                   `json:"description"`                   // a long description of the opportunity and its purpose
@@ -29,6 +45,10 @@ type OpportunityRepository interface {
 	FindByOrganizationID(ctx context.Context, organizationID int64) ([]Opportunity, error)
 	// FindByCreatorID finds multiple entities by the creator ID.
 	FindByCreatorID(ctx context.Context, creatorID int64) ([]Opportunity, error)
+	// FindScheduledToPublish finds all scheduled entities whose publish time is before a time.
+	FindScheduledToPublish(ctx context.Context, before time.Time) ([]Opportunity, error)
+	// FindOpenToClose finds all open entities whose close time is before a time.
+	FindOpenToClose(ctx context.Context, before time.Time) ([]Opportunity, error)
 	// Create creates a new entity.
 	Create(ctx context.Context, opportunity Opportunity) error
 	// Update updates an entity with the ID in the provided entity.
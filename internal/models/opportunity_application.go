@@ -0,0 +1,59 @@
+package models
+
+import (
+	"context"
+
+	"github.com/lib/pq"
+)
+
+// Application field types
+const (
+	ApplicationFieldTypeShortText      = "short_text"
+	ApplicationFieldTypeLongText       = "long_text"
+	ApplicationFieldTypeSingleChoice   = "single_choice"
+	ApplicationFieldTypeMultipleChoice = "multiple_choice"
+	ApplicationFieldTypeDate           = "date"
+	ApplicationFieldTypeYesNo          = "yes_no"
+	ApplicationFieldTypeFile           = "file"
+)
+
+// OpportunityApplicationField represents a single question on an opportunity's application form.
+type OpportunityApplicationField struct {
+	Model
+	OpportunityID int64          `json:"-" gorm:"index"`
+	Position      int            `json:"position"` // the position of the field in the form
+	Label         string         `json:"label"`
+	Description   string         `json:"description,omitempty"`
+	Type          string         `json:"type"`
+	Required      bool           `json:"required"`
+	Options       pq.StringArray `json:"options,omitempty" gorm:"type:varchar(128)[]"` // the choices of a choice field
+}
+
+// OpportunityApplicationFieldRepository represents a repository of OpportunityApplicationField entities.
+type OpportunityApplicationFieldRepository interface {
+	// FindByOpportunityID finds the fields of an opportunity's form, ordered by position.
+	FindByOpportunityID(ctx context.Context, opportunityID int64) ([]OpportunityApplicationField, error)
+	// ReplaceByOpportunityID replaces all fields of an opportunity's form.
+	ReplaceByOpportunityID(ctx context.Context, opportunityID int64, fields []OpportunityApplicationField) error
+}
+
+// OpportunityApplicationAnswer represents a volunteer's answer to a question on an application form.
+// The label and type of the field are copied, so that answers stay readable when the form changes.
+type OpportunityApplicationAnswer struct {
+	Model
+	OpportunityMembershipRequestID int64          `json:"-" gorm:"index"`
+	FieldID                        int64          `json:"fieldId"`
+	Label                          string         `json:"label"`
+	Type                           string         `json:"type"`
+	Value                          string         `json:"value,omitempty"`                             // the answer to a text, date, yes/no or single choice field, or the CDN key of a file
+	Values                         pq.StringArray `json:"values,omitempty" gorm:"type:varchar(128)[]"` // the answers to a multiple choice field
+	URL                            string         `json:"url,omitempty" gorm:"-"`                      // a temporary link to download an uploaded file
+}
+
+// OpportunityApplicationAnswerRepository represents a repository of OpportunityApplicationAnswer entities.
+type OpportunityApplicationAnswerRepository interface {
+	// FindByRequestID finds the answers of a membership request.
+	FindByRequestID(ctx context.Context, requestID int64) ([]OpportunityApplicationAnswer, error)
+	// Create creates multiple new entities.
+	Create(ctx context.Context, answers []OpportunityApplicationAnswer) error
+}
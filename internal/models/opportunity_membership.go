@@ -34,10 +34,16 @@ type OpportunityMembershipRepository interface {
 	FindByOpportunityID(ctx context.Context, opportunityID int64) ([]OpportunityMembership, error)
 	// FindByOpportunityIDs finds multiple entities by multiple opportunity IDs.
 	FindByOpportunityIDs(ctx context.Context, ids []int64) ([]OpportunityMembership, error)
+	// CountVolunteers counts the active volunteer memberships of an opportunity.
+	CountVolunteers(ctx context.Context, opportunityID int64) (int, error)
 	// FindUserInOpportunity finds a user's membership in a specific opportunity.
 	FindUserInOpportunity(ctx context.Context, opportunityID, userID int64) (*OpportunityMembership, error)
 	// Create creates a new entity.
 	Create(ctx context.Context, opportunityMembership OpportunityMembership) error
+	// CreateVolunteer creates a new volunteer entity. It returns false without creating the entity when the
+	// opportunity's volunteers cap is reached by its volunteers and the spots held for other waitlisted
+	// volunteers at a time.
+	CreateVolunteer(ctx context.Context, opportunityMembership OpportunityMembership, at time.Time) (bool, error)
 	// Update updates an entity with the ID in the provided entity.
 	Update(ctx context.Context, opportunityMembership OpportunityMembership) error
 	// DeleteByID deletes an entity by ID.
@@ -1,5 +1,7 @@
 package models
 
+import "context"
+
 // OpportunityMembershipRequest represents an active request to be a member in an opportunity.
 type OpportunityMembershipRequest struct {
 	Model
@@ -20,6 +22,9 @@ type OpportunityMembershipRequestRepository interface {
 	FindByOpportunityID(opportunityID int64) ([]OpportunityMembershipRequest, error)
 	// FindByOpportunityIDs finds multiple entities by multiple opportunity IDs.
 	FindByOpportunityIDs(ids []int64) ([]OpportunityMembershipRequest, error)
+	// FindHistoryByOpportunityIDs finds all entities of multiple opportunity IDs, including accepted and declined
+	// requests, which were open at any point in the time range of the context.
+	FindHistoryByOpportunityIDs(ctx context.Context, ids []int64) ([]OpportunityMembershipRequest, error)
 	// FindInOpportunityByVolunteerID finds a single entity by opportunity and volunteer ID.
 	FindInOpportunityByVolunteerID(opportunityID, volunteerID int64) (*OpportunityMembershipRequest, error)
 	// Create creates a new entity.
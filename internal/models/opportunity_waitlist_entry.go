@@ -0,0 +1,51 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+// Waitlist entry statuses
+const (
+	WaitlistStatusWaiting  = "waiting"  // the volunteer is waiting for a spot
+	WaitlistStatusOffered  = "offered"  // a spot is held for the volunteer until the offer expires
+	WaitlistStatusClaimed  = "claimed"  // the volunteer took the spot, which is held until a manager reviews their request
+	WaitlistStatusAccepted = "accepted" // the volunteer took the spot
+	WaitlistStatusDeclined = "declined" // the volunteer turned down the spot or left the waitlist
+	WaitlistStatusExpired  = "expired"  // the volunteer did not take the spot in time
+)
+
+// OpportunityWaitlistEntry represents a volunteer's place on the waitlist of a full opportunity.
+// Entries are served in the order they were created.
+type OpportunityWaitlistEntry struct {
+	Model
+	OpportunityID                  int64      `json:"opportunityId" gorm:"index"`
+	VolunteerID                    int64      `json:"volunteerId" gorm:"index"`
+	OpportunityMembershipRequestID int64      `json:"requestId"` // the membership request the volunteer was waitlisted with
+	Status                         string     `json:"status"`
+	OfferedAt                      *time.Time `json:"offeredAt,omitempty"`
+	OfferExpiresAt                 *time.Time `json:"offerExpiresAt,omitempty"`
+}
+
+// OpportunityWaitlistEntryRepository represents a repository of OpportunityWaitlistEntry entities.
+type OpportunityWaitlistEntryRepository interface {
+	// FindByID finds a single entity by ID.
+	FindByID(ctx context.Context, id int64) (*OpportunityWaitlistEntry, error)
+	// FindOpenByOpportunityID finds the waiting, offered and claimed entities of an opportunity, in waitlist order.
+	FindOpenByOpportunityID(ctx context.Context, opportunityID int64) ([]OpportunityWaitlistEntry, error)
+	// FindOpenInOpportunityByVolunteerID finds a volunteer's waiting, offered or claimed entity in an opportunity.
+	FindOpenInOpportunityByVolunteerID(ctx context.Context, opportunityID, volunteerID int64) (*OpportunityWaitlistEntry, error)
+	// FindNextWaiting finds the first waiting entity of an opportunity.
+	FindNextWaiting(ctx context.Context, opportunityID int64) (*OpportunityWaitlistEntry, error)
+	// FindWaitingOpportunityIDs finds the IDs of all opportunities with waiting entities.
+	FindWaitingOpportunityIDs(ctx context.Context) ([]int64, error)
+	// FindExpiredOffers finds all offered entities whose offer expired before a time.
+	FindExpiredOffers(ctx context.Context, before time.Time) ([]OpportunityWaitlistEntry, error)
+	// CountOpenOffers counts the entities of an opportunity which hold a spot at a time: claimed entities, and
+	// offered entities whose offer has not expired.
+	CountOpenOffers(ctx context.Context, opportunityID int64, at time.Time) (int, error)
+	// Create creates a new entity.
+	Create(ctx context.Context, entry OpportunityWaitlistEntry) error
+	// Save saves all fields in the provided entity.
+	Save(ctx context.Context, entry OpportunityWaitlistEntry) error
+}
@@ -3,15 +3,18 @@ package models
 // Organization represents a single volunteering organization.
 type Organization struct {
 	Model
-	Active            bool                       `json:"-"`              // controls whether or not the organization is active
-	CreatorID         int64                      `json:"creatorId"`      // the organization's creator's ID (User)
-	Name              string                     `json:"name"`           // the organization's name
-	Description       string                     `json:"description"`    // a description of the organization
-	ProfilePicture    string                     `json:"profilePicture"` // the organization's profile picture/logo
-	WebsiteURL        string                     `json:"websiteUrl"`     // the organization's website's URL
-	LocationLatitude  float64                    `json:"-"`              // the latitude of the organization's city
-	LocationLongitude float64                    `json:"-"`              // the longitude of the organization's city
-	ProfileFields     []OrganizationProfileField `json:"profile"`        // fields of the organization's profile
+	Active             bool                       `json:"-"`                                              // controls whether or not the organization is active
+	CreatorID          int64                      `json:"creatorId"`                                      // the organization's creator's ID (User)
+	Name               string                     `json:"name"`                                           // the organization's name
+	Description        string                     `json:"description"`                                    // a description of the organization
+	ProfilePicture     string                     `json:"profilePicture"`                                 // the organization's profile picture/logo
+	WebsiteURL         string                     `json:"websiteUrl"`                                     // the organization's website's URL
+	LocationLatitude   float64                    `json:"-"`                                              // the latitude of the organization's city
+	LocationLongitude  float64                    `json:"-"`                                              // the longitude of the organization's city
+	ProfileFields      []OrganizationProfileField `json:"profile"`                                        // fields of the organization's profile
+	RequireTwoFactor   *bool                      `json:"-"`                                              // when true, owners must have two-factor authentication enabled
+	VerificationStatus string                     `json:"verificationStatus" gorm:"default:'unverified'"` // the organization's verification status, one of the VerificationStatus constants
+	ParentID           int64                      `json:"parentId,omitempty" gorm:"index"`                // the ID of the parent organization, if the organization is a chapter
 }
 
 // OrganizationRepository represents a repository of organizations.
@@ -20,10 +23,17 @@ type OrganizationRepository interface {
 	FindByID(id int64) (*Organization, error)
 	// FindByCreatorID finds multiple entities by the creator's ID.
 	FindByCreatorID(creatorID int64) ([]Organization, error)
+	// FindByParentID finds the chapters of an organization.
+	FindByParentID(parentID int64) ([]Organization, error)
 	// Create creates a new entity.
 	Create(organization Organization) error
 	// Update updates an entity with the ID in the provided entity.
 	Update(organization Organization) error
+	// UpdateParentID sets the parent of an organization, or removes it if parentID is 0.
+	UpdateParentID(id, parentID int64) error
+	// TransferOwnership makes a member the creator of an organization and turns the previous
+	// creator into an owner, in a single transaction.
+	TransferOwnership(organizationID, fromUserID, toUserID int64) error
 	// DeleteByID deletes an entity by ID.
 	DeleteByID(id int64) error
 }
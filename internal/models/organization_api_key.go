@@ -0,0 +1,39 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/joinimpact/api/pkg/scopes"
+)
+
+// OrganizationAPIKey represents a key which lets a server call an organization's
+// endpoints without a user logging in. Only a hash of the key is stored, and
+// revoked keys are deleted.
+type OrganizationAPIKey struct {
+	Model
+	OrganizationID int64        `json:"-" gorm:"index"`        // the id of the organization the key grants access to
+	Organization   Organization `json:"-"`                     //
+	CreatorID      int64        `json:"creatorId"`             // the id of the user who created the key
+	Name           string       `json:"name"`                  // a name describing what the key is used for
+	Prefix         string       `json:"prefix"`                // the first characters of the key, to help identify it
+	KeyHash        string       `json:"-" gorm:"unique_index"` // the SHA-256 hash of the key
+	Scope          scopes.Scope `json:"-"`                     // the scope requests made with the key receive
+	LastUsedAt     *time.Time   `json:"lastUsedAt"`            // when the key was last used
+}
+
+// OrganizationAPIKeyRepository represents a repository of organization API keys.
+type OrganizationAPIKeyRepository interface {
+	// FindByID finds a single entity by ID.
+	FindByID(ctx context.Context, id int64) (*OrganizationAPIKey, error)
+	// FindByOrganizationID finds multiple entities by the organization ID.
+	FindByOrganizationID(ctx context.Context, organizationID int64) ([]OrganizationAPIKey, error)
+	// FindByKeyHash finds a single entity by the hash of its key.
+	FindByKeyHash(ctx context.Context, keyHash string) (*OrganizationAPIKey, error)
+	// Create creates a new entity.
+	Create(ctx context.Context, apiKey OrganizationAPIKey) error
+	// UpdateLastUsedAt sets when an entity was last used.
+	UpdateLastUsedAt(ctx context.Context, id int64, lastUsedAt time.Time) error
+	// DeleteByID deletes an entity by ID.
+	DeleteByID(ctx context.Context, id int64) error
+}
@@ -12,12 +12,13 @@ const (
 // OrganizationMembership creates a relationship between Organizations and their employees.
 type OrganizationMembership struct {
 	Model
-	Active          bool      `json:"-"`               // controls whether or not the entity is active
-	UserID          int64     `json:"-"`               // the ID of the user being granted membership
-	JoinedAt        time.Time `json:"joinedAt"`        // when the user joined the organization
-	OrganizationID  int64     `json:"-"`               // the ID of the organization the user is being granted access to
-	PermissionsFlag int       `json:"permissionsFlag"` // a flag which designates permissions the user has
-	InviterID       int64     `json:"inviterId"`       // the ID of the user who invited the member to the organization
+	Active          bool      `json:"-"`                // controls whether or not the entity is active
+	UserID          int64     `json:"-"`                // the ID of the user being granted membership
+	JoinedAt        time.Time `json:"joinedAt"`         // when the user joined the organization
+	OrganizationID  int64     `json:"-"`                // the ID of the organization the user is being granted access to
+	PermissionsFlag int       `json:"permissionsFlag"`  // a flag which designates permissions the user has
+	InviterID       int64     `json:"inviterId"`        // the ID of the user who invited the member to the organization
+	RoleID          int64     `json:"roleId,omitempty"` // the ID of the member's OrganizationRole, if they were given one
 }
 
 // OrganizationMembershipRepository represents a repository of organization memberships.
@@ -34,6 +35,12 @@ type OrganizationMembershipRepository interface {
 	Create(organizationMembership OrganizationMembership) error
 	// Update updates an entity with the ID in the provided entity.
 	Update(organizationMembership OrganizationMembership) error
+	// UpdatePermissionsFlag sets the permissions flag of a single entity by ID.
+	UpdatePermissionsFlag(id int64, permissionsFlag int) error
+	// UpdateRoleID sets the role of a single entity by ID, or removes it if roleID is 0.
+	UpdateRoleID(id, roleID int64) error
+	// ClearRoleID removes a role from all entities which have it.
+	ClearRoleID(roleID int64) error
 	// DeleteByID deletes an entity by ID.
 	DeleteByID(id int64) error
 }
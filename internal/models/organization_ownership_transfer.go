@@ -0,0 +1,27 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+// OrganizationOwnershipTransfer represents a pending transfer of an organization from
+// its creator to another member, which the recipient has to accept.
+type OrganizationOwnershipTransfer struct {
+	Model
+	OrganizationID int64        `json:"organizationId" gorm:"index"` // the id of the organization being transferred
+	Organization   Organization `json:"-"`                           //
+	FromUserID     int64        `json:"fromUserId"`                  // the id of the creator transferring the organization
+	ToUserID       int64        `json:"toUserId"`                    // the id of the member receiving the organization
+	ExpiresAt      time.Time    `json:"expiresAt"`                   // when the transfer can no longer be accepted
+}
+
+// OrganizationOwnershipTransferRepository represents a repository of organization ownership transfers.
+type OrganizationOwnershipTransferRepository interface {
+	// FindByOrganizationID finds the most recent entity of an organization.
+	FindByOrganizationID(ctx context.Context, organizationID int64) (*OrganizationOwnershipTransfer, error)
+	// Create creates a new entity.
+	Create(ctx context.Context, transfer OrganizationOwnershipTransfer) error
+	// DeleteByOrganizationID deletes all entities of an organization.
+	DeleteByOrganizationID(ctx context.Context, organizationID int64) error
+}
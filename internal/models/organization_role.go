@@ -0,0 +1,99 @@
+package models
+
+import (
+	"context"
+
+	"github.com/joinimpact/api/pkg/scopes"
+	"github.com/lib/pq"
+)
+
+// Organization permissions which can be granted by an OrganizationRole.
+const (
+	PermissionOrganizationEdit   = "organization.edit"   // edit the organization's profile, tags and picture
+	PermissionMembersView        = "members.view"        // view the organization's members
+	PermissionMembersInvite      = "members.invite"      // invite new members to the organization
+	PermissionOpportunitiesEdit  = "opportunities.edit"  // create, edit, publish and delete opportunities
+	PermissionVolunteersView     = "volunteers.view"     // view volunteers and their requests
+	PermissionVolunteersManage   = "volunteers.manage"   // accept and decline volunteer requests
+	PermissionEventsEdit         = "events.edit"         // create, edit and delete events
+	PermissionConversationsRead  = "conversations.read"  // read the organization's conversations
+	PermissionConversationsReply = "conversations.reply" // send messages in the organization's conversations
+	PermissionHoursApprove       = "hours.approve"       // accept and decline volunteering hour requests
+	PermissionAnalyticsView      = "analytics.view"      // view the organization's analytics
+)
+
+// OrganizationPermissions lists every organization permission. Owners and creators of
+// an organization always hold all of them.
+var OrganizationPermissions = []string{
+	PermissionOrganizationEdit,
+	PermissionMembersView,
+	PermissionMembersInvite,
+	PermissionOpportunitiesEdit,
+	PermissionVolunteersView,
+	PermissionVolunteersManage,
+	PermissionEventsEdit,
+	PermissionConversationsRead,
+	PermissionConversationsReply,
+	PermissionHoursApprove,
+	PermissionAnalyticsView,
+}
+
+// DefaultMemberPermissions lists the permissions of members who have not been given a role.
+var DefaultMemberPermissions = []string{
+	PermissionMembersView,
+	PermissionOpportunitiesEdit,
+	PermissionVolunteersView,
+	PermissionVolunteersManage,
+	PermissionEventsEdit,
+	PermissionConversationsRead,
+	PermissionConversationsReply,
+	PermissionHoursApprove,
+}
+
+// IsOrganizationPermission returns true if a permission name is a known organization permission.
+func IsOrganizationPermission(permission string) bool {
+	for _, p := range OrganizationPermissions {
+		if p == permission {
+			return true
+		}
+	}
+
+	return false
+}
+
+// PermissionsForScope returns the permissions equivalent to an organization scope, for
+// callers such as API keys which only hold a scope.
+func PermissionsForScope(scope scopes.Scope) []string {
+	switch {
+	case scope >= scopes.ScopeAdmin:
+		return OrganizationPermissions
+	case scope >= scopes.ScopeManager:
+		return DefaultMemberPermissions
+	}
+
+	return []string{}
+}
+
+// OrganizationRole represents a custom role in an organization, which grants its
+// members a set of named permissions.
+type OrganizationRole struct {
+	Model
+	OrganizationID int64          `json:"-" gorm:"index"`                 // the id of the organization the role belongs to
+	Organization   Organization   `json:"-"`                              //
+	Name           string         `json:"name"`                           // the name of the role, such as "coordinator"
+	Permissions    pq.StringArray `json:"permissions" gorm:"type:text[]"` // the names of the permissions the role grants
+}
+
+// OrganizationRoleRepository represents a repository of organization roles.
+type OrganizationRoleRepository interface {
+	// FindByID finds a single entity by ID.
+	FindByID(ctx context.Context, id int64) (*OrganizationRole, error)
+	// FindByOrganizationID finds multiple entities by the organization ID.
+	FindByOrganizationID(ctx context.Context, organizationID int64) ([]OrganizationRole, error)
+	// Create creates a new entity.
+	Create(ctx context.Context, role OrganizationRole) error
+	// Save saves all fields in the provided entity.
+	Save(ctx context.Context, role OrganizationRole) error
+	// DeleteByID deletes an entity by ID.
+	DeleteByID(ctx context.Context, id int64) error
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Organization verification statuses.
+const (
+	VerificationStatusUnverified = "unverified"
+	VerificationStatusPending    = "pending"
+	VerificationStatusVerified   = "verified"
+	VerificationStatusRejected   = "rejected"
+)
+
+// OrganizationVerification represents a request of an organization to be verified as a
+// legitimate nonprofit, which is reviewed by platform staff.
+type OrganizationVerification struct {
+	Model
+	OrganizationID     int64          `json:"organizationId"`              // the id of the organization requesting verification
+	SubmitterID        int64          `json:"submitterId"`                 // the id of the user who submitted the request
+	RegistrationNumber string         `json:"registrationNumber"`          // the organization's charity or nonprofit registration number
+	Documents          pq.StringArray `json:"-" gorm:"type:text[]"`        // the keys of the supporting documents in the CDN bucket
+	Status             string         `json:"status"`                      // the status of the request, one of the VerificationStatus constants
+	ReviewerID         int64          `json:"reviewerId,omitempty"`        // the id of the staff member who reviewed the request
+	ReviewNote         string         `json:"reviewNote,omitempty"`        // a note from the reviewer, such as the reason for a rejection
+	ReviewedAt         *time.Time     `json:"reviewedAt,omitempty"`        // when the request was reviewed
+	SubmittedAt        time.Time      `json:"submittedAt" gorm:"not null"` // when the request was submitted
+}
+
+// OrganizationVerificationRepository represents a repository of organization verification requests.
+type OrganizationVerificationRepository interface {
+	// FindByID finds a single entity by ID.
+	FindByID(ctx context.Context, id int64) (*OrganizationVerification, error)
+	// FindLatestByOrganizationID finds the most recently submitted entity of an organization.
+	FindLatestByOrganizationID(ctx context.Context, organizationID int64) (*OrganizationVerification, error)
+	// FindByStatus finds multiple entities by status, oldest first.
+	FindByStatus(ctx context.Context, status string) ([]OrganizationVerification, error)
+	// Create creates a new entity.
+	Create(ctx context.Context, organizationVerification OrganizationVerification) error
+	// Save saves all fields in the provided entity.
+	Save(ctx context.Context, organizationVerification OrganizationVerification) error
+}
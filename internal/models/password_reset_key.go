@@ -2,16 +2,32 @@ package models
 
 import "time"
 
+const (
+	// PasswordResetKeyPurposeReset is the purpose of a key which allows a user
+	// to reset their password. Keys created before purposes were added have
+	// an empty purpose and are treated as reset keys.
+	PasswordResetKeyPurposeReset = "reset"
+	// PasswordResetKeyPurposeMagicLink is the purpose of a key which allows a
+	// user to log in once without a password.
+	PasswordResetKeyPurposeMagicLink = "magic_link"
+)
+
 // PasswordResetKey represents a key that allows a user to reset their
-// password.
+// password, or to log in through a magic link.
 type PasswordResetKey struct {
 	Model
 	UserID    int64 `json:"userId"`
 	User      User
 	Key       string    `json:"key"`
+	Purpose   string    `json:"purpose"`
 	ExpiresAt time.Time `json:"expiresAt"`
 }
 
+// IsPasswordReset returns true if the key can be used to reset a password.
+func (k *PasswordResetKey) IsPasswordReset() bool {
+	return k.Purpose == "" || k.Purpose == PasswordResetKeyPurposeReset
+}
+
 // PasswordResetKeyRepository represents the repository for the
 // PasswordResetKey.
 type PasswordResetKeyRepository interface {
@@ -25,4 +41,7 @@ type PasswordResetKeyRepository interface {
 	Update(passwordResetKey PasswordResetKey) error
 	// DeleteByID deletes a PasswordResetKey by ID.
 	DeleteByID(id int64) error
+	// Consume deletes a PasswordResetKey by ID. It returns false when the key was already deleted, so
+	// that each key can only be used once.
+	Consume(id int64) (bool, error)
 }
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+// Session represents a single logged in device of a user. A session is
+// created on every login and is kept alive by rotating refresh tokens.
+type Session struct {
+	Model
+	Active                 bool       `json:"-"`           // false once the session has been revoked
+	UserID                 int64      `json:"userId"`      // the id of the user who owns the session
+	User                   User       `json:"-"`           //
+	RefreshTokenID         string     `json:"-"`           // the jti of the only refresh token currently valid for the session
+	PreviousRefreshTokenID string     `json:"-"`           // the jti of the refresh token which was last rotated out
+	RotatedAt              *time.Time `json:"-"`           // the last time the refresh token was rotated
+	LoginMethod            string     `json:"loginMethod"` // how the user authenticated when the session was created, such as "password" or "magic_link"
+	DeviceName             string     `json:"deviceName"`  // a name for the device, provided by the client
+	UserAgent              string     `json:"userAgent"`   // the user agent of the client that created the session
+	IPAddress              string     `json:"ipAddress"`   // the IP address the session was last used from
+	LastUsedAt             time.Time  `json:"lastUsedAt"`  // the last time the session was refreshed
+	ExpiresAt              time.Time  `json:"expiresAt"`   // the time after which the session can no longer be refreshed
+	RevokedAt              *time.Time `json:"revokedAt"`   // the time the session was revoked, if it was
+}
+
+// SessionRepository represents a repository of sessions.
+type SessionRepository interface {
+	// FindByID finds a single entity by ID.
+	FindByID(ctx context.Context, id int64) (*Session, error)
+	// FindByUserID finds all active entities by the user ID.
+	FindByUserID(ctx context.Context, userID int64) ([]Session, error)
+	// Create creates a new entity.
+	Create(ctx context.Context, session Session) error
+	// Update updates an entity with the ID in the provided entity.
+	Update(ctx context.Context, session Session) error
+	// RotateRefreshToken replaces the refresh token ID of a session and extends
+	// its expiry, only if the current refresh token ID still matches.
+	RotateRefreshToken(ctx context.Context, id int64, currentTokenID, newTokenID string, expiresAt time.Time) error
+	// RevokeByID revokes a single entity by ID.
+	RevokeByID(ctx context.Context, id int64) error
+	// RevokeByUserID revokes all active entities of a user, except the one with
+	// the ID exceptID.
+	RevokeByUserID(ctx context.Context, userID, exceptID int64) error
+	// DeleteByID deletes an entity by ID.
+	DeleteByID(ctx context.Context, id int64) error
+}
@@ -19,6 +19,8 @@ type ThirdPartyIdentityRepository interface {
 	FindByUserID(userID int64) (*ThirdPartyIdentity, error)
 	// FindUserIdentityByServiceName finds a single entity by user ID and service name.
 	FindUserIdentityByServiceName(userID int64, serviceName string) (*ThirdPartyIdentity, error)
+	// FindByThirdPartyID finds a single entity by service name and the user's ID at the service.
+	FindByThirdPartyID(serviceName, thirdPartyID string) (*ThirdPartyIdentity, error)
 	// Create creates a new entity.
 	Create(thirdPartyIdentity ThirdPartyIdentity) error
 	// Update updates an entity with the ID in the provided parameter.
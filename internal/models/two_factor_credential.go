@@ -0,0 +1,33 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+// TwoFactorCredential holds a user's TOTP secret for two-factor authentication.
+// A credential is only used for logins once it has been confirmed with a
+// first code.
+type TwoFactorCredential struct {
+	Model
+	UserID          int64      `json:"userId"`      // the id of the user the credential belongs to
+	User            User       `json:"-"`           //
+	Secret          string     `json:"-"`           // the base32 encoded TOTP secret
+	Confirmed       bool       `json:"confirmed"`   // whether or not enrollment has been completed
+	ConfirmedAt     *time.Time `json:"confirmedAt"` // when enrollment was completed
+	LastUsedCounter int64      `json:"-"`           // the time step of the last accepted code, to prevent replays
+	FailedAttempts  int        `json:"-"`           // consecutive failed code attempts
+	LockedUntil     *time.Time `json:"-"`           // codes are not accepted until this time after too many failures
+}
+
+// TwoFactorCredentialRepository represents a repository of two-factor credentials.
+type TwoFactorCredentialRepository interface {
+	// FindByUserID finds a single entity by the user ID.
+	FindByUserID(ctx context.Context, userID int64) (*TwoFactorCredential, error)
+	// Create creates a new entity.
+	Create(ctx context.Context, credential TwoFactorCredential) error
+	// Save saves all fields in the provided entity.
+	Save(ctx context.Context, credential TwoFactorCredential) error
+	// DeleteByUserID deletes all entities by the user ID.
+	DeleteByUserID(ctx context.Context, userID int64) error
+}
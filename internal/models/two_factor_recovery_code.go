@@ -0,0 +1,28 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+// TwoFactorRecoveryCode is a single-use code that can be used in place of a
+// TOTP code when a user loses access to their authenticator.
+type TwoFactorRecoveryCode struct {
+	Model
+	UserID   int64      `json:"userId"` // the id of the user the code belongs to
+	User     User       `json:"-"`      //
+	CodeHash string     `json:"-"`      // a SHA-256 hash of the code
+	UsedAt   *time.Time `json:"usedAt"` // when the code was used, if it was
+}
+
+// TwoFactorRecoveryCodeRepository represents a repository of two-factor recovery codes.
+type TwoFactorRecoveryCodeRepository interface {
+	// FindUnusedByUserID finds all codes of a user which have not been used.
+	FindUnusedByUserID(ctx context.Context, userID int64) ([]TwoFactorRecoveryCode, error)
+	// Create creates a new entity.
+	Create(ctx context.Context, code TwoFactorRecoveryCode) error
+	// Update updates an entity with the ID in the provided entity.
+	Update(ctx context.Context, code TwoFactorRecoveryCode) error
+	// DeleteByUserID deletes all entities by the user ID.
+	DeleteByUserID(ctx context.Context, userID int64) error
+}
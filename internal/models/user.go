@@ -4,19 +4,26 @@ import (
 	"time"
 )
 
+// ErasedUserID replaces the ID of an erased user in records which are kept
+// after the user is erased, such as volunteering hour logs.
+const ErasedUserID int64 = 0
+
 // User represents a single user in the Impact application.
 type User struct {
 	Model
-	Active         bool               `json:"-" gorm:"default:'true'"`     // controls whether or not the account is active (false if the account is suspended)
-	Email          string             `json:"email" scope:"owner"`         // the user's email address
-	EmailVerified  bool               `json:"emailVerified" scope:"owner"` // whether or not the user has verified their email
-	Password       string             `json:"-"`                           // a bcrypt hash of the user's passowrd
-	ProfilePicture string             `json:"profilePicture"`              // a URL for the user's profile picture
-	FirstName      string             `json:"firstName"`                   // the user's first name
-	LastName       string             `json:"lastName"`                    // the user's last name
-	DateOfBirth    time.Time          `json:"dateOfBirth" level:"1"`       // the user's date of birth, used for calculating age
-	LastOnline     *time.Time         `json:"lastOnline"`                  // the last time the user was online
-	ProfileFields  []UserProfileField `json:"profile"`                     // fields of the user's profile
+	Active         bool       `json:"-" gorm:"default:'true'"`     // controls whether or not the account is active (false if the account is suspended)
+	Email          string     `json:"email" scope:"owner"`         // the user's email address
+	EmailVerified  bool       `json:"emailVerified" scope:"owner"` // whether or not the user has verified their email
+	Password       string     `json:"-"`                           // a bcrypt hash of the user's passowrd
+	ProfilePicture string     `json:"profilePicture"`              // a URL for the user's profile picture
+	FirstName      string     `json:"firstName"`                   // the user's first name
+	LastName       string     `json:"lastName"`                    // the user's last name
+	DateOfBirth    time.Time  `json:"dateOfBirth" level:"1"`       // the user's date of birth, used for calculating age
+	LastOnline     *time.Time `json:"lastOnline"`                  // the last time the user was online
+	// DeletionScheduledAt is the time after which the user will be erased,
+	// if they have requested to delete their account.
+	DeletionScheduledAt *time.Time         `json:"deletionScheduledAt,omitempty" scope:"owner"`
+	ProfileFields       []UserProfileField `json:"profile"` // fields of the user's profile
 	// ZIPCode        string             `json:"zipCode" level:"1"`       // the user's zip code, used to find nearby opportunities
 	LocationLatitude  float64 `json:"-"` // the latitude of the user's city
 	LocationLongitude float64 `json:"-"` // the longitude of the user's city
@@ -32,6 +39,13 @@ type UserRepository interface {
 	Create(user User) error
 	// Update updates a User with the ID in the provided User.
 	Update(user User) error
+	// FindScheduledForDeletion finds all Users whose deletion was scheduled for before a time.
+	FindScheduledForDeletion(before time.Time) ([]User, error)
+	// SetDeletionScheduledAt schedules the deletion of a User, or cancels it if deletionScheduledAt is nil.
+	SetDeletionScheduledAt(id int64, deletionScheduledAt *time.Time) error
 	// DeleteByID deletes a User by ID.
 	DeleteByID(id int64) error
+	// Erase permanently deletes a User and their personal data, anonymising the records
+	// which other users and organizations rely on.
+	Erase(id int64) error
 }
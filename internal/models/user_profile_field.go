@@ -1,9 +1,13 @@
 package models
 
+import "github.com/joinimpact/api/pkg/scopes"
+
 // Privacy constants
 const (
-	PrivacyPublic            = iota
-	PrivacyOrganizationsOnly = iota
+	PrivacyPublic            = iota // visible to everyone
+	PrivacyOrganizationsOnly = iota // visible to managers of organizations the user volunteers with or has applied to
+	PrivacyPrivate           = iota // visible only to the user
+	PrivacyConnectionsOnly   = iota // visible to users who share an organization or opportunity with the user
 )
 
 // UserProfileField represents a single field in a user's profile.
@@ -18,6 +22,26 @@ type UserProfileField struct {
 	Privacy int   `json:"privacy"`
 }
 
+// RequiredScope returns the minimum scope a viewer needs to see the field.
+// Unknown privacy levels are treated as private.
+func (f *UserProfileField) RequiredScope() scopes.Scope {
+	switch f.Privacy {
+	case PrivacyPublic:
+		return scopes.ScopeUnauthenticated
+	case PrivacyConnectionsOnly:
+		return scopes.ScopeCollaborator
+	case PrivacyOrganizationsOnly:
+		return scopes.ScopeManager
+	default:
+		return scopes.ScopeOwner
+	}
+}
+
+// VisibleTo returns true if a viewer with the provided scope can see the field.
+func (f *UserProfileField) VisibleTo(scope scopes.Scope) bool {
+	return scope >= f.RequiredScope()
+}
+
 // UserProfileFieldRepository represents a repository of UserProfileField.
 type UserProfileFieldRepository interface {
 	// FindByID finds a single entity by ID.
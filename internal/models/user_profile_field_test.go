@@ -0,0 +1,32 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/joinimpact/api/pkg/scopes"
+)
+
+// TestUserProfileFieldVisibleTo tests which viewers can see a field at each privacy level.
+func TestUserProfileFieldVisibleTo(t *testing.T) {
+	tests := []struct {
+		privacy int
+		scope   scopes.Scope
+		visible bool
+	}{
+		{PrivacyPublic, scopes.ScopeUnauthenticated, true},
+		{PrivacyConnectionsOnly, scopes.ScopeAuthenticated, false},
+		{PrivacyConnectionsOnly, scopes.ScopeCollaborator, true},
+		{PrivacyOrganizationsOnly, scopes.ScopeCollaborator, false},
+		{PrivacyOrganizationsOnly, scopes.ScopeManager, true},
+		{PrivacyPrivate, scopes.ScopeAdmin, false},
+		{PrivacyPrivate, scopes.ScopeOwner, true},
+		{42, scopes.ScopeManager, false},
+	}
+
+	for _, test := range tests {
+		field := UserProfileField{Privacy: test.privacy}
+		if visible := field.VisibleTo(test.scope); visible != test.visible {
+			t.Errorf("privacy %d, scope %d: expected visible to be %v, got %v", test.privacy, test.scope, test.visible, visible)
+		}
+	}
+}
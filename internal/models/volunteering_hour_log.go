@@ -28,6 +28,20 @@ type VolunteeringHourLogsResponse struct {
 	TotalResults         int
 }
 
+// VolunteeringHourSummary sums up the verified hours of an organization.
+type VolunteeringHourSummary struct {
+	OrganizationID int64   `json:"organizationId"`
+	Hours          float32 `json:"hours"`      // the total hours granted by the organization
+	Volunteers     int     `json:"volunteers"` // the number of volunteers who were granted hours
+}
+
+// VolunteeringHourOpportunitySummary sums up the verified hours of an opportunity.
+type VolunteeringHourOpportunitySummary struct {
+	OpportunityID int64   `json:"opportunityId"` // 0 for hours which were not logged for an opportunity
+	Hours         float32 `json:"hours"`         // the total hours granted for the opportunity
+	Volunteers    int     `json:"volunteers"`    // the number of volunteers who were granted hours
+}
+
 // VolunteeringHourLogRepository represents a repository of VolunteeringHourLog entities.
 type VolunteeringHourLogRepository interface {
 	// FindByID finds a single entity by ID.
@@ -36,6 +50,10 @@ type VolunteeringHourLogRepository interface {
 	FindByOpportunityID(ctx context.Context, opportunityID int64) (*VolunteeringHourLogsResponse, error)
 	// FindByOrganizationID finds multiple entities by the organization ID.
 	FindByOrganizationID(ctx context.Context, organizationID int64) (*VolunteeringHourLogsResponse, error)
+	// SummarizeByOrganizationIDs sums up the hours of multiple organizations.
+	SummarizeByOrganizationIDs(ctx context.Context, organizationIDs []int64) ([]VolunteeringHourSummary, error)
+	// SummarizeByOpportunity sums up the hours of an organization per opportunity.
+	SummarizeByOpportunity(ctx context.Context, organizationID int64) ([]VolunteeringHourOpportunitySummary, error)
 	// FindByVolunteerID finds multiple entities by volunteer ID.
 	FindByVolunteerID(ctx context.Context, volunteerID int64) (*VolunteeringHourLogsResponse, error)
 	// Create creates a new entity.
@@ -0,0 +1,266 @@
+package opportunities
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/joinimpact/api/internal/models"
+)
+
+// maxApplicationFields is the maximum number of fields on an application form.
+const maxApplicationFields = 30
+
+// maxApplicationFieldOptions is the maximum number of options of a choice field.
+const maxApplicationFieldOptions = 20
+
+// applicationFileLinkLifespan is how long links to uploaded application files stay valid.
+const applicationFileLinkLifespan = 15 * time.Minute
+
+// applicationFileExtensions maps the supported content types of application files to file extensions.
+var applicationFileExtensions = map[string]string{
+	"application/pdf": "pdf",
+	"image/png":       "png",
+	"image/jpeg":      "jpg",
+}
+
+// applicationAnswerLengths maps text field types to the maximum length of their answers.
+var applicationAnswerLengths = map[string]int{
+	models.ApplicationFieldTypeShortText: 256,
+	models.ApplicationFieldTypeLongText:  4096,
+}
+
+// ApplicationAnswer represents a volunteer's answer to a field of an application form.
+type ApplicationAnswer struct {
+	FieldID int64    `json:"fieldId"`
+	Value   string   `json:"value"`  // the answer to a text, date (YYYY-MM-DD), yes/no ("true" or "false") or single choice field, or the key of an uploaded file
+	Values  []string `json:"values"` // the answers to a multiple choice field
+}
+
+// GetApplicationForm gets the fields of an opportunity's application form.
+func (s *service) GetApplicationForm(ctx context.Context, opportunityID int64) ([]models.OpportunityApplicationField, error) {
+	if _, err := s.opportunityRepository.FindByID(ctx, opportunityID); err != nil {
+		return nil, NewErrOpportunityNotFound()
+	}
+
+	fields, err := s.opportunityApplicationFieldRepository.FindByOpportunityID(ctx, opportunityID)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error finding application form fields")
+		return nil, NewErrServerError()
+	}
+
+	return fields, nil
+}
+
+// UpdateApplicationForm replaces the fields of an opportunity's application form. An empty form removes
+// the form from the opportunity.
+func (s *service) UpdateApplicationForm(ctx context.Context, opportunityID int64, fields []models.OpportunityApplicationField) ([]models.OpportunityApplicationField, error) {
+	if _, err := s.opportunityRepository.FindByID(ctx, opportunityID); err != nil {
+		return nil, NewErrOpportunityNotFound()
+	}
+
+	if len(fields) > maxApplicationFields {
+		return nil, NewErrInvalidApplicationForm()
+	}
+
+	form := []models.OpportunityApplicationField{}
+	for i, field := range fields {
+		field, ok := validateApplicationField(field)
+		if !ok {
+			return nil, NewErrInvalidApplicationForm()
+		}
+
+		field.ID = s.snowflakeService.GenerateID()
+		field.OpportunityID = opportunityID
+		field.Position = i
+		form = append(form, field)
+	}
+
+	if err := s.opportunityApplicationFieldRepository.ReplaceByOpportunityID(ctx, opportunityID, form); err != nil {
+		s.logger.Error().Err(err).Msg("Error saving application form fields")
+		return nil, NewErrServerError()
+	}
+
+	return form, nil
+}
+
+// UploadApplicationFile uploads a file for a volunteer to attach to an application, and returns the key
+// to submit as the answer to a file field.
+func (s *service) UploadApplicationFile(ctx context.Context, opportunityID, volunteerID int64, contentType string, reader io.Reader) (string, error) {
+	if _, err := s.opportunityRepository.FindByID(ctx, opportunityID); err != nil {
+		return "", NewErrOpportunityNotFound()
+	}
+
+	extension, ok := applicationFileExtensions[contentType]
+	if !ok {
+		return "", NewErrInvalidApplicationFile()
+	}
+
+	// Files are private, and can only be downloaded by managers through expiring links.
+	key := fmt.Sprintf("%s%d.%s", applicationFilePrefix(opportunityID, volunteerID), s.snowflakeService.GenerateID(), extension)
+	if err := s.cdnClient.UploadPrivateFile(key, contentType, reader); err != nil {
+		s.logger.Error().Err(err).Msg("Error uploading application file")
+		return "", NewErrServerError()
+	}
+
+	return key, nil
+}
+
+// GetApplicationAnswers gets the answers submitted with a membership request, along with links to their files.
+func (s *service) GetApplicationAnswers(ctx context.Context, requestID int64) ([]models.OpportunityApplicationAnswer, error) {
+	answers, err := s.opportunityApplicationAnswerRepository.FindByRequestID(ctx, requestID)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error finding application answers")
+		return nil, NewErrServerError()
+	}
+
+	for i, answer := range answers {
+		if answer.Type != models.ApplicationFieldTypeFile || answer.Value == "" {
+			continue
+		}
+
+		link, err := s.cdnClient.PresignedURL(answer.Value, applicationFileLinkLifespan)
+		if err != nil {
+			s.logger.Error().Err(err).Msg("Error creating application file link")
+			return nil, NewErrServerError()
+		}
+
+		answers[i].URL = link
+	}
+
+	return answers, nil
+}
+
+// applicationFilePrefix returns the prefix of the keys of the files a volunteer uploads for an opportunity.
+func applicationFilePrefix(opportunityID, volunteerID int64) string {
+	return fmt.Sprintf("application-files/%d/%d/", opportunityID, volunteerID)
+}
+
+// validateApplicationField normalizes a field of an application form, and returns false if it is invalid.
+func validateApplicationField(field models.OpportunityApplicationField) (models.OpportunityApplicationField, bool) {
+	field.Label = strings.TrimSpace(field.Label)
+	field.Description = strings.TrimSpace(field.Description)
+	if len(field.Label) < 1 || len(field.Label) > 256 || len(field.Description) > 1024 {
+		return field, false
+	}
+
+	switch field.Type {
+	case models.ApplicationFieldTypeSingleChoice, models.ApplicationFieldTypeMultipleChoice:
+		if len(field.Options) < 2 || len(field.Options) > maxApplicationFieldOptions {
+			return field, false
+		}
+
+		seen := map[string]bool{}
+		for i, option := range field.Options {
+			option = strings.TrimSpace(option)
+			if len(option) < 1 || len(option) > 128 || seen[option] {
+				return field, false
+			}
+
+			seen[option] = true
+			field.Options[i] = option
+		}
+	case models.ApplicationFieldTypeShortText, models.ApplicationFieldTypeLongText, models.ApplicationFieldTypeDate, models.ApplicationFieldTypeYesNo, models.ApplicationFieldTypeFile:
+		field.Options = nil
+	default:
+		return field, false
+	}
+
+	return field, true
+}
+
+// validateApplicationAnswers checks a volunteer's answers against the fields of an application form. It
+// returns the answers to save, and the IDs of the fields which are missing or were answered invalidly.
+func validateApplicationAnswers(fields []models.OpportunityApplicationField, answers []ApplicationAnswer, opportunityID, volunteerID int64) ([]models.OpportunityApplicationAnswer, []int64) {
+	byField := map[int64]ApplicationAnswer{}
+	for _, answer := range answers {
+		byField[answer.FieldID] = answer
+	}
+
+	valid := []models.OpportunityApplicationAnswer{}
+	invalid := []int64{}
+	for _, field := range fields {
+		answer, ok := byField[field.ID]
+		delete(byField, field.ID)
+
+		answer.Value = strings.TrimSpace(answer.Value)
+		if !ok || (answer.Value == "" && len(answer.Values) == 0) {
+			if field.Required {
+				invalid = append(invalid, field.ID)
+			}
+			continue
+		}
+
+		if !validApplicationAnswer(field, answer, opportunityID, volunteerID) {
+			invalid = append(invalid, field.ID)
+			continue
+		}
+
+		saved := models.OpportunityApplicationAnswer{
+			FieldID: field.ID,
+			Label:   field.Label,
+			Type:    field.Type,
+		}
+		if field.Type == models.ApplicationFieldTypeMultipleChoice {
+			saved.Values = answer.Values
+		} else {
+			saved.Value = answer.Value
+		}
+
+		valid = append(valid, saved)
+	}
+
+	// Answers to fields which are not on the form are rejected too.
+	for fieldID := range byField {
+		invalid = append(invalid, fieldID)
+	}
+
+	return valid, invalid
+}
+
+// validApplicationAnswer returns true if a non-empty answer is valid for a field.
+func validApplicationAnswer(field models.OpportunityApplicationField, answer ApplicationAnswer, opportunityID, volunteerID int64) bool {
+	switch field.Type {
+	case models.ApplicationFieldTypeShortText, models.ApplicationFieldTypeLongText:
+		return len(answer.Values) == 0 && len(answer.Value) <= applicationAnswerLengths[field.Type]
+	case models.ApplicationFieldTypeSingleChoice:
+		return len(answer.Values) == 0 && hasOption(field, answer.Value)
+	case models.ApplicationFieldTypeMultipleChoice:
+		if answer.Value != "" || len(answer.Values) == 0 {
+			return false
+		}
+
+		seen := map[string]bool{}
+		for _, value := range answer.Values {
+			if seen[value] || !hasOption(field, value) {
+				return false
+			}
+			seen[value] = true
+		}
+
+		return true
+	case models.ApplicationFieldTypeDate:
+		_, err := time.Parse("2006-01-02", answer.Value)
+		return len(answer.Values) == 0 && err == nil
+	case models.ApplicationFieldTypeYesNo:
+		return len(answer.Values) == 0 && (answer.Value == "true" || answer.Value == "false")
+	case models.ApplicationFieldTypeFile:
+		// Volunteers can only attach files they uploaded for the same opportunity.
+		return len(answer.Values) == 0 && strings.HasPrefix(answer.Value, applicationFilePrefix(opportunityID, volunteerID))
+	}
+
+	return false
+}
+
+// hasOption returns true if a value is one of the options of a choice field.
+func hasOption(field models.OpportunityApplicationField, value string) bool {
+	for _, option := range field.Options {
+		if option == value {
+			return true
+		}
+	}
+
+	return false
+}
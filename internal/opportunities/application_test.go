@@ -0,0 +1,99 @@
+package opportunities
+
+import (
+	"testing"
+
+	"github.com/joinimpact/api/internal/models"
+)
+
+// TestValidateApplicationAnswers tests that answers are checked against the type and required flag of
+// each field.
+func TestValidateApplicationAnswers(t *testing.T) {
+	fields := []models.OpportunityApplicationField{
+		{Model: models.Model{ID: 1}, Label: "Why?", Type: models.ApplicationFieldTypeShortText, Required: true},
+		{Model: models.Model{ID: 2}, Label: "Shirt size", Type: models.ApplicationFieldTypeSingleChoice, Options: []string{"S", "M", "L"}},
+		{Model: models.Model{ID: 3}, Label: "Days", Type: models.ApplicationFieldTypeMultipleChoice, Options: []string{"Sat", "Sun"}},
+		{Model: models.Model{ID: 4}, Label: "Start date", Type: models.ApplicationFieldTypeDate},
+		{Model: models.Model{ID: 5}, Label: "Driver?", Type: models.ApplicationFieldTypeYesNo},
+		{Model: models.Model{ID: 6}, Label: "Resume", Type: models.ApplicationFieldTypeFile},
+	}
+
+	tests := []struct {
+		name    string
+		answers []ApplicationAnswer
+		valid   int
+		invalid []int64
+	}{
+		{
+			name: "valid answers",
+			answers: []ApplicationAnswer{
+				{FieldID: 1, Value: "To help"},
+				{FieldID: 2, Value: "M"},
+				{FieldID: 3, Values: []string{"Sat", "Sun"}},
+				{FieldID: 4, Value: "2020-08-01"},
+				{FieldID: 5, Value: "true"},
+				{FieldID: 6, Value: "application-files/10/20/30.pdf"},
+			},
+			valid: 6,
+		},
+		{
+			name:    "missing required answer",
+			answers: []ApplicationAnswer{{FieldID: 2, Value: "M"}},
+			valid:   1,
+			invalid: []int64{1},
+		},
+		{
+			name: "invalid answers",
+			answers: []ApplicationAnswer{
+				{FieldID: 1, Value: "To help"},
+				{FieldID: 2, Value: "XL"},
+				{FieldID: 3, Values: []string{"Sat", "Sat"}},
+				{FieldID: 4, Value: "August"},
+				{FieldID: 5, Value: "maybe"},
+				{FieldID: 6, Value: "application-files/10/21/30.pdf"},
+				{FieldID: 7, Value: "unknown"},
+			},
+			valid:   1,
+			invalid: []int64{2, 3, 4, 5, 6, 7},
+		},
+	}
+
+	for _, test := range tests {
+		valid, invalid := validateApplicationAnswers(fields, test.answers, 10, 20)
+		if len(valid) != test.valid {
+			t.Errorf("%s: expected %d valid answers, got %d", test.name, test.valid, len(valid))
+		}
+
+		if len(invalid) != len(test.invalid) {
+			t.Errorf("%s: expected invalid fields %v, got %v", test.name, test.invalid, invalid)
+			continue
+		}
+
+		for i := range invalid {
+			if invalid[i] != test.invalid[i] {
+				t.Errorf("%s: expected invalid fields %v, got %v", test.name, test.invalid, invalid)
+				break
+			}
+		}
+	}
+}
+
+// TestValidateApplicationField tests that choice fields need unique options and other fields lose theirs.
+func TestValidateApplicationField(t *testing.T) {
+	if _, ok := validateApplicationField(models.OpportunityApplicationField{Label: "Size", Type: models.ApplicationFieldTypeSingleChoice, Options: []string{"S", " S "}}); ok {
+		t.Error("expected duplicate options to be invalid")
+	}
+
+	if _, ok := validateApplicationField(models.OpportunityApplicationField{Label: "Size", Type: "color"}); ok {
+		t.Error("expected an unknown type to be invalid")
+	}
+
+	field, ok := validateApplicationField(models.OpportunityApplicationField{Label: " Bio ", Type: models.ApplicationFieldTypeLongText, Options: []string{"a"}})
+	if !ok {
+		t.Fatal("expected a long text field to be valid")
+	}
+
+	if field.Label != "Bio" || field.Options != nil {
+		t.Errorf("expected a trimmed label and no options, got %q and %v", field.Label, field.Options)
+	}
+}
@@ -175,3 +175,219 @@ func (e *ErrRequestNotFound) Error() string {
 func (e *ErrRequestNotFound) Ref() string {
 	return "opportunities.request_not_found"
 }
+
+// ErrEmailNotVerified is thrown when a user who has not verified their email
+// attempts an action which requires a verified email.
+type ErrEmailNotVerified struct {
+}
+
+// NewErrEmailNotVerified creates and returns a ErrEmailNotVerified.
+func NewErrEmailNotVerified() error {
+	return &ErrEmailNotVerified{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrEmailNotVerified) Error() string {
+	return "email not verified"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrEmailNotVerified) Ref() string {
+	return "opportunities.email_not_verified"
+}
+
+// ErrOrganizationNotVerified is thrown when an organization which is not verified attempts an
+// action which is restricted to verified organizations, such as inviting a minor.
+type ErrOrganizationNotVerified struct {
+}
+
+// NewErrOrganizationNotVerified creates and returns a ErrOrganizationNotVerified.
+func NewErrOrganizationNotVerified() error {
+	return &ErrOrganizationNotVerified{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrOrganizationNotVerified) Error() string {
+	return "only verified organizations can invite users under 18"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrOrganizationNotVerified) Ref() string {
+	return "opportunities.organization_not_verified"
+}
+
+// ErrInvalidApplicationForm is thrown when an application form contains too many or invalid fields.
+type ErrInvalidApplicationForm struct {
+}
+
+// NewErrInvalidApplicationForm creates and returns a ErrInvalidApplicationForm.
+func NewErrInvalidApplicationForm() error {
+	return &ErrInvalidApplicationForm{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrInvalidApplicationForm) Error() string {
+	return "invalid application form, a form can have up to 30 fields which each need a label and a valid type, and choice fields need between 2 and 20 unique options"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrInvalidApplicationForm) Ref() string {
+	return "opportunities.invalid_application_form"
+}
+
+// ErrInvalidApplicationAnswers is thrown when the answers submitted with a membership request are missing
+// or do not match the application form.
+type ErrInvalidApplicationAnswers struct {
+	FieldIDs []int64 // the IDs of the fields which are missing or were answered invalidly
+}
+
+// NewErrInvalidApplicationAnswers creates and returns a ErrInvalidApplicationAnswers.
+func NewErrInvalidApplicationAnswers(fieldIDs []int64) error {
+	return &ErrInvalidApplicationAnswers{fieldIDs}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrInvalidApplicationAnswers) Error() string {
+	return "invalid or missing answers to the application form"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrInvalidApplicationAnswers) Ref() string {
+	return "opportunities.invalid_application_answers"
+}
+
+// ErrInvalidApplicationFile is thrown when an application file of an unsupported type is uploaded.
+type ErrInvalidApplicationFile struct {
+}
+
+// NewErrInvalidApplicationFile creates and returns a ErrInvalidApplicationFile.
+func NewErrInvalidApplicationFile() error {
+	return &ErrInvalidApplicationFile{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrInvalidApplicationFile) Error() string {
+	return "invalid file, must be a PDF, PNG or JPEG"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrInvalidApplicationFile) Ref() string {
+	return "opportunities.invalid_application_file"
+}
+
+// ErrOpportunityFull is thrown when a volunteer is added to an opportunity which has reached its volunteers cap.
+type ErrOpportunityFull struct {
+}
+
+// NewErrOpportunityFull creates and returns a ErrOpportunityFull.
+func NewErrOpportunityFull() error {
+	return &ErrOpportunityFull{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrOpportunityFull) Error() string {
+	return "opportunity has reached its volunteers cap"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrOpportunityFull) Ref() string {
+	return "opportunities.opportunity_full"
+}
+
+// ErrMembershipNotFound is thrown when a user is not a volunteer in an opportunity.
+type ErrMembershipNotFound struct {
+}
+
+// NewErrMembershipNotFound creates and returns a ErrMembershipNotFound.
+func NewErrMembershipNotFound() error {
+	return &ErrMembershipNotFound{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrMembershipNotFound) Error() string {
+	return "volunteer membership not found"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrMembershipNotFound) Ref() string {
+	return "opportunities.membership_not_found"
+}
+
+// ErrWaitlistEntryNotFound is thrown when a user is not on an opportunity's waitlist, or has no open
+// offer for a spot.
+type ErrWaitlistEntryNotFound struct {
+}
+
+// NewErrWaitlistEntryNotFound creates and returns a ErrWaitlistEntryNotFound.
+func NewErrWaitlistEntryNotFound() error {
+	return &ErrWaitlistEntryNotFound{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrWaitlistEntryNotFound) Error() string {
+	return "waitlist entry not found"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrWaitlistEntryNotFound) Ref() string {
+	return "opportunities.waitlist_entry_not_found"
+}
+
+// ErrInvalidStatusTransition is thrown when an opportunity can not move from its current status to the requested status.
+type ErrInvalidStatusTransition struct {
+	From string
+	To   string
+}
+
+// NewErrInvalidStatusTransition creates and returns a ErrInvalidStatusTransition.
+func NewErrInvalidStatusTransition(from, to string) error {
+	return &ErrInvalidStatusTransition{from, to}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrInvalidStatusTransition) Error() string {
+	return "opportunity can not move from " + e.From + " to " + e.To
+}
+
+// Ref provides a representation of the error.
+func (e *ErrInvalidStatusTransition) Ref() string {
+	return "opportunities.invalid_status_transition"
+}
+
+// ErrInvalidSchedule is thrown when the publish or close date of an opportunity is missing, in the past or out of order.
+type ErrInvalidSchedule struct {
+}
+
+// NewErrInvalidSchedule creates and returns a ErrInvalidSchedule.
+func NewErrInvalidSchedule() error {
+	return &ErrInvalidSchedule{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrInvalidSchedule) Error() string {
+	return "invalid publish or close date"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrInvalidSchedule) Ref() string {
+	return "opportunities.invalid_schedule"
+}
+
+// ErrOpportunityNotOpen is thrown when a volunteer requests to join an opportunity which does not accept new applicants.
+type ErrOpportunityNotOpen struct {
+}
+
+// NewErrOpportunityNotOpen creates and returns a ErrOpportunityNotOpen.
+func NewErrOpportunityNotOpen() error {
+	return &ErrOpportunityNotOpen{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrOpportunityNotOpen) Error() string {
+	return "opportunity is not accepting new applicants"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrOpportunityNotOpen) Ref() string {
+	return "opportunities.opportunity_not_open"
+}
@@ -0,0 +1,193 @@
+package opportunities
+
+import (
+	"context"
+	"time"
+
+	"github.com/joinimpact/api/internal/models"
+	"github.com/rs/zerolog"
+)
+
+// statusTransitions maps each status to the statuses an opportunity can move to from it. Scheduled and
+// open opportunities can move to their own status to change their publish or close dates.
+var statusTransitions = map[string][]string{
+	models.OpportunityStatusDraft:     {models.OpportunityStatusScheduled, models.OpportunityStatusOpen, models.OpportunityStatusArchived},
+	models.OpportunityStatusScheduled: {models.OpportunityStatusDraft, models.OpportunityStatusScheduled, models.OpportunityStatusOpen, models.OpportunityStatusArchived},
+	models.OpportunityStatusOpen:      {models.OpportunityStatusDraft, models.OpportunityStatusOpen, models.OpportunityStatusClosed, models.OpportunityStatusCompleted},
+	models.OpportunityStatusClosed:    {models.OpportunityStatusOpen, models.OpportunityStatusCompleted},
+	models.OpportunityStatusCompleted: {models.OpportunityStatusArchived},
+	models.OpportunityStatusArchived:  {},
+}
+
+// statusOf returns the lifecycle status of an opportunity. Opportunities created before statuses are open
+// when they were published, and drafts otherwise.
+func statusOf(opportunity models.Opportunity) string {
+	if opportunity.Status != "" {
+		return opportunity.Status
+	}
+
+	if opportunity.Public {
+		return models.OpportunityStatusOpen
+	}
+
+	return models.OpportunityStatusDraft
+}
+
+// canTransition returns true if an opportunity can move from one status to another.
+func canTransition(from, to string) bool {
+	for _, status := range statusTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validSchedule returns true if the publish and close dates are valid for a status. Only scheduled
+// opportunities have a publish date, which is required, and only scheduled and open opportunities have a
+// close date, which is optional.
+func validSchedule(status string, publishAt, closeAt *time.Time, now time.Time) bool {
+	switch status {
+	case models.OpportunityStatusScheduled:
+		if publishAt == nil || !publishAt.After(now) {
+			return false
+		}
+	case models.OpportunityStatusOpen:
+		if publishAt != nil {
+			return false
+		}
+	default:
+		return publishAt == nil && closeAt == nil
+	}
+
+	if closeAt == nil {
+		return true
+	}
+
+	if publishAt != nil && !closeAt.After(*publishAt) {
+		return false
+	}
+
+	return closeAt.After(now)
+}
+
+// SetOpportunityStatus moves an opportunity to a new lifecycle status, with the publish date of a
+// scheduled opportunity and the optional close date of a scheduled or open opportunity.
+func (s *service) SetOpportunityStatus(ctx context.Context, opportunityID int64, status string, publishAt, closeAt *time.Time) error {
+	opportunity, err := s.opportunityRepository.FindByID(ctx, opportunityID)
+	if err != nil {
+		return NewErrOpportunityNotFound()
+	}
+
+	if from := statusOf(*opportunity); !canTransition(from, status) {
+		return NewErrInvalidStatusTransition(from, status)
+	}
+
+	now := time.Now().UTC()
+	if !validSchedule(status, publishAt, closeAt, now) {
+		return NewErrInvalidSchedule()
+	}
+
+	if status == models.OpportunityStatusScheduled || status == models.OpportunityStatusOpen {
+		// Validate that the opportunity is publishable.
+		if invalidFields, ok := isPublishable(*opportunity); !ok {
+			return NewErrOpportunityNotPublishable(invalidFields)
+		}
+	}
+
+	// Closed opportunities keep the time they closed at.
+	if status == models.OpportunityStatusClosed {
+		closeAt = &now
+	}
+
+	if err := s.saveStatus(ctx, opportunity, status, publishAt, closeAt); err != nil {
+		s.logger.Error().Err(err).Msg("Error saving opportunity status")
+		return NewErrServerError()
+	}
+
+	return nil
+}
+
+// ProcessOpportunitySchedules opens the scheduled opportunities whose publish date has passed, and closes
+// the open opportunities whose close date has passed. It returns how many opportunities were updated.
+func (s *service) ProcessOpportunitySchedules(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+	updated := 0
+
+	scheduled, err := s.opportunityRepository.FindScheduledToPublish(ctx, now)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error finding scheduled opportunities")
+		return 0, NewErrServerError()
+	}
+
+	for _, opportunity := range scheduled {
+		status := models.OpportunityStatusOpen
+		if _, ok := isPublishable(opportunity); !ok {
+			// The opportunity was edited after it was scheduled, so it goes back to the organization.
+			status = models.OpportunityStatusDraft
+		}
+
+		if err := s.saveStatus(ctx, &opportunity, status, nil, opportunity.CloseAt); err != nil {
+			s.logger.Error().Err(err).Int64("opportunityId", opportunity.ID).Msg("Error opening scheduled opportunity")
+			continue
+		}
+
+		updated++
+	}
+
+	// Opportunities opened above with a close date in the past are closed right away.
+	closing, err := s.opportunityRepository.FindOpenToClose(ctx, now)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error finding opportunities to close")
+		return updated, NewErrServerError()
+	}
+
+	for _, opportunity := range closing {
+		if err := s.saveStatus(ctx, &opportunity, models.OpportunityStatusClosed, nil, opportunity.CloseAt); err != nil {
+			s.logger.Error().Err(err).Int64("opportunityId", opportunity.ID).Msg("Error closing opportunity")
+			continue
+		}
+
+		updated++
+	}
+
+	return updated, nil
+}
+
+// saveStatus saves the status and dates of an opportunity, and updates the opportunity in the search
+// store. Only open opportunities are public, and appear in browse and search.
+func (s *service) saveStatus(ctx context.Context, opportunity *models.Opportunity, status string, publishAt, closeAt *time.Time) error {
+	opportunity.Status = status
+	opportunity.Public = status == models.OpportunityStatusOpen
+	opportunity.PublishAt = publishAt
+	opportunity.CloseAt = closeAt
+
+	if err := s.opportunityRepository.Save(ctx, *opportunity); err != nil {
+		return err
+	}
+
+	s.searchStore.Save(opportunity.ID)
+
+	return nil
+}
+
+// RunScheduleJob opens and closes opportunities at their publish and close dates once every interval,
+// until the context is done.
+func RunScheduleJob(ctx context.Context, service Service, interval time.Duration, logger *zerolog.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		updated, err := service.ProcessOpportunitySchedules(ctx)
+		if err == nil && updated > 0 {
+			logger.Info().Int("updated", updated).Msg("Updated statuses of scheduled opportunities")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package opportunities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/joinimpact/api/internal/models"
+)
+
+// TestStatusOf tests that opportunities created before statuses get a status from their public flag.
+func TestStatusOf(t *testing.T) {
+	tests := []struct {
+		name        string
+		opportunity models.Opportunity
+		want        string
+	}{
+		{"published legacy opportunity", models.Opportunity{Public: true}, models.OpportunityStatusOpen},
+		{"unpublished legacy opportunity", models.Opportunity{}, models.OpportunityStatusDraft},
+		{"explicit status", models.Opportunity{Public: false, Status: models.OpportunityStatusCompleted}, models.OpportunityStatusCompleted},
+	}
+
+	for _, tt := range tests {
+		if got := statusOf(tt.opportunity); got != tt.want {
+			t.Errorf("%s: statusOf() = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestCanTransition tests the allowed moves between statuses.
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from string
+		to   string
+		want bool
+	}{
+		{models.OpportunityStatusDraft, models.OpportunityStatusOpen, true},
+		{models.OpportunityStatusDraft, models.OpportunityStatusClosed, false},
+		{models.OpportunityStatusScheduled, models.OpportunityStatusScheduled, true},
+		{models.OpportunityStatusOpen, models.OpportunityStatusClosed, true},
+		{models.OpportunityStatusClosed, models.OpportunityStatusDraft, false},
+		{models.OpportunityStatusCompleted, models.OpportunityStatusOpen, false},
+		{models.OpportunityStatusCompleted, models.OpportunityStatusArchived, true},
+		{models.OpportunityStatusArchived, models.OpportunityStatusDraft, false},
+	}
+
+	for _, tt := range tests {
+		if got := canTransition(tt.from, tt.to); got != tt.want {
+			t.Errorf("canTransition(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+// TestValidSchedule tests that publish and close dates are only accepted for the statuses which use them.
+func TestValidSchedule(t *testing.T) {
+	now := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	soon := now.Add(time.Hour)
+	later := now.Add(2 * time.Hour)
+
+	tests := []struct {
+		name      string
+		status    string
+		publishAt *time.Time
+		closeAt   *time.Time
+		want      bool
+	}{
+		{"scheduled", models.OpportunityStatusScheduled, &soon, nil, true},
+		{"scheduled with close date", models.OpportunityStatusScheduled, &soon, &later, true},
+		{"scheduled without publish date", models.OpportunityStatusScheduled, nil, nil, false},
+		{"scheduled in the past", models.OpportunityStatusScheduled, &past, nil, false},
+		{"scheduled closing before publish", models.OpportunityStatusScheduled, &later, &soon, false},
+		{"open", models.OpportunityStatusOpen, nil, nil, true},
+		{"open with close date", models.OpportunityStatusOpen, nil, &soon, true},
+		{"open closing in the past", models.OpportunityStatusOpen, nil, &past, false},
+		{"open with publish date", models.OpportunityStatusOpen, &soon, nil, false},
+		{"completed", models.OpportunityStatusCompleted, nil, nil, true},
+		{"completed with close date", models.OpportunityStatusCompleted, nil, &soon, false},
+	}
+
+	for _, tt := range tests {
+		if got := validSchedule(tt.status, tt.publishAt, tt.closeAt, now); got != tt.want {
+			t.Errorf("%s: validSchedule() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
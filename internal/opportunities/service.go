@@ -56,8 +56,35 @@ type Service interface {
 	AcceptOpportunityMembershipRequest(ctx context.Context, opportunityID, volunteerID, userID int64) error
 	// DeclineOpportunityMembershipRequest accepts a membership request from a volunteer by user ID.
 	DeclineOpportunityMembershipRequest(ctx context.Context, opportunityID, volunteerID, userID int64) error
-	// RequestOpportunityMembership creates a membership request (as a volunteer) to join an opportunity.
-	RequestOpportunityMembership(ctx context.Context, opportunityID int64, volunteerID int64) (int64, error)
+	// RequestOpportunityMembership creates a membership request (as a volunteer) to join an opportunity, with
+	// answers to the opportunity's application form.
+	RequestOpportunityMembership(ctx context.Context, opportunityID int64, volunteerID int64, answers []ApplicationAnswer) (int64, error)
+	// GetApplicationForm gets the fields of an opportunity's application form.
+	GetApplicationForm(ctx context.Context, opportunityID int64) ([]models.OpportunityApplicationField, error)
+	// UpdateApplicationForm replaces the fields of an opportunity's application form. An empty form removes
+	// the form from the opportunity.
+	UpdateApplicationForm(ctx context.Context, opportunityID int64, fields []models.OpportunityApplicationField) ([]models.OpportunityApplicationField, error)
+	// UploadApplicationFile uploads a file for a volunteer to attach to an application, and returns the key
+	// to submit as the answer to a file field.
+	UploadApplicationFile(ctx context.Context, opportunityID, volunteerID int64, contentType string, reader io.Reader) (string, error)
+	// GetApplicationAnswers gets the answers submitted with a membership request, along with links to their files.
+	GetApplicationAnswers(ctx context.Context, requestID int64) ([]models.OpportunityApplicationAnswer, error)
+	// RemoveVolunteer removes a volunteer from an opportunity, whether they left or were removed by a
+	// manager. It returns the offers made to waitlisted volunteers for the free spot, who should be notified.
+	RemoveVolunteer(ctx context.Context, opportunityID, volunteerID int64) ([]models.OpportunityWaitlistEntry, error)
+	// GetWaitlist gets the waiting and offered entries of an opportunity's waitlist, in order.
+	GetWaitlist(ctx context.Context, opportunityID int64) ([]models.OpportunityWaitlistEntry, error)
+	// GetWaitlistPosition gets a volunteer's place on an opportunity's waitlist.
+	GetWaitlistPosition(ctx context.Context, opportunityID, volunteerID int64) (*WaitlistPosition, error)
+	// AcceptWaitlistOffer claims the spot offered to a waitlisted volunteer, holding it until a manager accepts
+	// or declines their membership request.
+	AcceptWaitlistOffer(ctx context.Context, opportunityID, volunteerID int64) error
+	// DeclineWaitlistOffer turns down the spot offered to a waitlisted volunteer, or removes a waiting
+	// volunteer from the waitlist. It returns the offers made to the next volunteers, who should be notified.
+	DeclineWaitlistOffer(ctx context.Context, opportunityID, volunteerID int64) ([]models.OpportunityWaitlistEntry, error)
+	// ProcessWaitlists expires the offers which were not accepted in time, and offers the free spots of all
+	// opportunities to their waitlisted volunteers. It returns the offers made, whose volunteers should be notified.
+	ProcessWaitlists(ctx context.Context) ([]models.OpportunityWaitlistEntry, error)
 	// GetOpportunityVolunteers returns an array of OpportunityMembership volunteer objects for a specified opportunity by ID.
 	GetOpportunityVolunteers(ctx context.Context, opportunityID int64) ([]models.OpportunityMembership, error)
 	// GetOpportunityPendingVolunteers returns an array of OpportunityMembershipRequest objects for a specified opportunity by ID.
@@ -68,6 +95,12 @@ type Service interface {
 	PublishOpportunity(ctx context.Context, opportunityID int64) error
 	// UnpublishOpportunity unpublishes an opportunity.
 	UnpublishOpportunity(ctx context.Context, opportunityID int64) error
+	// SetOpportunityStatus moves an opportunity to a new lifecycle status, with the publish date of a
+	// scheduled opportunity and the optional close date of a scheduled or open opportunity.
+	SetOpportunityStatus(ctx context.Context, opportunityID int64, status string, publishAt, closeAt *time.Time) error
+	// ProcessOpportunitySchedules opens the scheduled opportunities whose publish date has passed, and closes
+	// the open opportunities whose close date has passed. It returns how many opportunities were updated.
+	ProcessOpportunitySchedules(ctx context.Context) (int, error)
 	// InviteVolunteer invites a volunteer by user email to an opportunity.
 	InviteVolunteer(ctx context.Context, inviterID, opportunityID int64, userEmail string) error
 	// GetOpportunityFromInvite gets an opportunity view from an invite for UI use.
@@ -80,14 +113,16 @@ type Service interface {
 	GetOpportunityMembership(ctx context.Context, opportunityID, userID int64) (int, error)
 	// Search searches opportunities by a query struct.
 	Search(ctx context.Context, query opportunitiesSearch.Query) (*SearchResponse, error)
+	// ReindexOrganizationOpportunities saves all opportunities of an organization in the search store again.
+	ReindexOrganizationOpportunities(ctx context.Context, organizationID int64) error
 	// GetRecommendations gets a list of browse rows for a specific user based on recommendations made using a random number seeded by the current date.
 	GetRecommendations(ctx context.Context, userID int64) ([]Section, error)
-	// GetOrganizationOpportunityVolunteers gets all volunteers in all opportunities of a specified organization.
-	GetOrganizationOpportunityVolunteers(ctx context.Context, organizationID int64) ([]models.OpportunityMembership, error)
-	// GetOrganizationOpportunityInvitedVolunteers gets all invited volunteers in all opportunities of a specified organization.
-	GetOrganizationOpportunityInvitedVolunteers(ctx context.Context, organizationID int64) ([]models.OpportunityMembershipInvite, error)
-	// GetOrganizationOpportunityRequestedVolunteers gets all invited volunteers in all opportunities of a specified organization.
-	GetOrganizationOpportunityRequestedVolunteers(ctx context.Context, organizationID int64) ([]models.OpportunityMembershipRequest, error)
+	// GetOrganizationOpportunityVolunteers gets all volunteers in all opportunities of the specified organizations.
+	GetOrganizationOpportunityVolunteers(ctx context.Context, organizationIDs []int64) ([]models.OpportunityMembership, error)
+	// GetOrganizationOpportunityInvitedVolunteers gets all invited volunteers in all opportunities of the specified organizations.
+	GetOrganizationOpportunityInvitedVolunteers(ctx context.Context, organizationIDs []int64) ([]models.OpportunityMembershipInvite, error)
+	// GetOrganizationOpportunityRequestedVolunteers gets all requested volunteers in all opportunities of the specified organizations.
+	GetOrganizationOpportunityRequestedVolunteers(ctx context.Context, organizationIDs []int64) ([]models.OpportunityMembershipRequest, error)
 }
 
 // service represents the intenral implementation of the opportunities Service.
@@ -99,6 +134,9 @@ type service struct {
 	opportunityMembershipRepository        models.OpportunityMembershipRepository
 	opportunityMembershipRequestRepository models.OpportunityMembershipRequestRepository
 	opportunityMembershipInviteRepository  models.OpportunityMembershipInviteRepository
+	opportunityApplicationFieldRepository  models.OpportunityApplicationFieldRepository
+	opportunityApplicationAnswerRepository models.OpportunityApplicationAnswerRepository
+	opportunityWaitlistEntryRepository     models.OpportunityWaitlistEntryRepository
 	tagRepository                          models.TagRepository
 	userRepository                         models.UserRepository
 	userTagRepository                      models.UserTagRepository
@@ -113,7 +151,7 @@ type service struct {
 }
 
 // NewService creates and returns a new Opportunities service with the provifded dependencies.
-func NewService(opportunityRepository models.OpportunityRepository, opportunityRequirementsRepository models.OpportunityRequirementsRepository, opportunityLimitsRepository models.OpportunityLimitsRepository, opportunityTagRepository models.OpportunityTagRepository, opportunityMembershipRepository models.OpportunityMembershipRepository, opportunityMembershipRequestRepository models.OpportunityMembershipRequestRepository, opportunityMembershipInviteRepository models.OpportunityMembershipInviteRepository, tagRepository models.TagRepository, userRepository models.UserRepository, userTagRepository models.UserTagRepository, organizationRepository models.OrganizationRepository, config *config.Config, logger *zerolog.Logger, snowflakeService snowflakes.SnowflakeService, emailService email.Service, searchStore opportunitiesSearch.Store, locationService location.Service) Service {
+func NewService(opportunityRepository models.OpportunityRepository, opportunityRequirementsRepository models.OpportunityRequirementsRepository, opportunityLimitsRepository models.OpportunityLimitsRepository, opportunityTagRepository models.OpportunityTagRepository, opportunityMembershipRepository models.OpportunityMembershipRepository, opportunityMembershipRequestRepository models.OpportunityMembershipRequestRepository, opportunityMembershipInviteRepository models.OpportunityMembershipInviteRepository, opportunityApplicationFieldRepository models.OpportunityApplicationFieldRepository, opportunityApplicationAnswerRepository models.OpportunityApplicationAnswerRepository, opportunityWaitlistEntryRepository models.OpportunityWaitlistEntryRepository, tagRepository models.TagRepository, userRepository models.UserRepository, userTagRepository models.UserTagRepository, organizationRepository models.OrganizationRepository, config *config.Config, logger *zerolog.Logger, snowflakeService snowflakes.SnowflakeService, emailService email.Service, searchStore opportunitiesSearch.Store, locationService location.Service) Service {
 	return &service{
 		opportunityRepository,
 		opportunityRequirementsRepository,
@@ -122,6 +160,9 @@ func NewService(opportunityRepository models.OpportunityRepository, opportunityR
 		opportunityMembershipRepository,
 		opportunityMembershipRequestRepository,
 		opportunityMembershipInviteRepository,
+		opportunityApplicationFieldRepository,
+		opportunityApplicationAnswerRepository,
+		opportunityWaitlistEntryRepository,
 		tagRepository,
 		userRepository,
 		userTagRepository,
@@ -224,6 +265,9 @@ func (s *service) getOpportunityView(ctx context.Context, opportunity *models.Op
 	view.Title = opportunity.Title
 	view.Description = opportunity.Description
 	view.Public = opportunity.Public
+	view.Status = statusOf(*opportunity)
+	view.PublishAt = opportunity.PublishAt
+	view.CloseAt = opportunity.CloseAt
 
 	_, view.Publishable = isPublishable(*opportunity)
 
@@ -327,6 +371,9 @@ func (s *service) GetMinimalOpportunity(ctx context.Context, id int64) (*Opportu
 	view.Title = opportunity.Title
 	view.Description = opportunity.Description
 	view.Public = opportunity.Public
+	view.Status = statusOf(*opportunity)
+	view.PublishAt = opportunity.PublishAt
+	view.CloseAt = opportunity.CloseAt
 
 	return view, nil
 }
@@ -341,6 +388,7 @@ func (s *service) CreateOpportunity(ctx context.Context, view OpportunityView) (
 	opportunity.Title = view.Title
 	opportunity.Description = view.Description
 	opportunity.Public = false
+	opportunity.Status = models.OpportunityStatusDraft
 
 	// Generate a new ID for the opportunity.
 	opportunity.ID = s.snowflakeService.GenerateID()
@@ -397,7 +445,7 @@ func (s *service) UpdateOpportunity(ctx context.Context, view OpportunityView) e
 	opportunity.ProfilePicture = view.ProfilePicture
 	opportunity.Title = view.Title
 	opportunity.Description = view.Description
-	opportunity.Public = view.Public
+	// Public is only set by status changes, so that opportunities are only listed while they are open.
 
 	err = s.opportunityRepository.Update(ctx, opportunity)
 	if err != nil {
@@ -563,10 +611,33 @@ func (s *service) UploadProfilePicture(ctx context.Context, opportunityID int64,
 	})
 }
 
-// RequestOpportunityMembership creates a membership request (as a volunteer) to join an opportunity.
-func (s *service) RequestOpportunityMembership(ctx context.Context, opportunityID int64, volunteerID int64) (int64, error) {
+// RequestOpportunityMembership creates a membership request (as a volunteer) to join an opportunity, with
+// answers to the opportunity's application form.
+func (s *service) RequestOpportunityMembership(ctx context.Context, opportunityID int64, volunteerID int64, answers []ApplicationAnswer) (int64, error) {
+	if s.config.RequireEmailVerification {
+		volunteer, err := s.userRepository.FindByID(volunteerID)
+		if err != nil {
+			return 0, NewErrUserNotFound()
+		}
+
+		if !volunteer.EmailVerified {
+			return 0, NewErrEmailNotVerified()
+		}
+	}
+
 	if err := s.CanRequestOpportunityMembership(ctx, opportunityID, volunteerID); err != nil {
-		return 0, NewErrMembershipAlreadyRequested()
+		return 0, err
+	}
+
+	fields, err := s.opportunityApplicationFieldRepository.FindByOpportunityID(ctx, opportunityID)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error finding application form fields")
+		return 0, NewErrServerError()
+	}
+
+	validAnswers, invalidFieldIDs := validateApplicationAnswers(fields, answers, opportunityID, volunteerID)
+	if len(invalidFieldIDs) > 0 {
+		return 0, NewErrInvalidApplicationAnswers(invalidFieldIDs)
 	}
 
 	// Create an ID for the request.
@@ -583,18 +654,58 @@ func (s *service) RequestOpportunityMembership(ctx context.Context, opportunityI
 	}
 
 	// Attempt to create the entity.
-	err := s.opportunityMembershipRequestRepository.Create(opportunityMembershipRequest)
+	err = s.opportunityMembershipRequestRepository.Create(opportunityMembershipRequest)
 	if err != nil {
 		s.logger.Error().Err(err).Msg("Error creating opportunity membership request")
 		return 0, NewErrServerError()
 	}
 
+	if len(validAnswers) > 0 {
+		for i := range validAnswers {
+			validAnswers[i].ID = s.snowflakeService.GenerateID()
+			validAnswers[i].OpportunityMembershipRequestID = id
+		}
+
+		if err := s.opportunityApplicationAnswerRepository.Create(ctx, validAnswers); err != nil {
+			s.logger.Error().Err(err).Msg("Error creating application answers")
+			// Remove the request, so that the volunteer can apply again.
+			if err := s.opportunityMembershipRequestRepository.DeleteByID(id); err != nil {
+				s.logger.Error().Err(err).Msg("Error deleting opportunity membership request")
+			}
+			return 0, NewErrServerError()
+		}
+	}
+
+	// Volunteers who request to join a full opportunity are waitlisted, and offered the next free spot.
+	full, err := s.isFull(ctx, opportunityID)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error checking volunteers cap")
+		return 0, NewErrServerError()
+	}
+
+	if full {
+		if err := s.waitlistVolunteer(ctx, opportunityID, volunteerID, id); err != nil {
+			s.logger.Error().Err(err).Msg("Error creating waitlist entry")
+			return 0, NewErrServerError()
+		}
+	}
+
 	return id, nil
 }
 
 // CanRequestOpportunityMembership checks if a user can request membership or not.
 func (s *service) CanRequestOpportunityMembership(ctx context.Context, opportunityID, volunteerID int64) error {
-	_, err := s.opportunityMembershipRepository.FindUserInOpportunity(ctx, opportunityID, volunteerID)
+	opportunity, err := s.opportunityRepository.FindByID(ctx, opportunityID)
+	if err != nil {
+		return NewErrOpportunityNotFound()
+	}
+
+	// Only open opportunities accept new applicants.
+	if statusOf(*opportunity) != models.OpportunityStatusOpen {
+		return NewErrOpportunityNotOpen()
+	}
+
+	_, err = s.opportunityMembershipRepository.FindUserInOpportunity(ctx, opportunityID, volunteerID)
 	if err == nil {
 		return NewErrMembershipAlreadyRequested()
 	}
@@ -615,9 +726,15 @@ func (s *service) AcceptOpportunityMembershipRequest(ctx context.Context, opport
 		return NewErrRequestNotFound()
 	}
 
-	// Create the volunteer membership.
+	// Create the volunteer membership, unless the volunteers cap is reached.
 	if err := s.createVolunteerMembership(ctx, userID, membershipRequest.OpportunityID, membershipRequest.VolunteerID); err != nil {
-		s.logger.Error().Err(err).Msg("Error creating volunteer membership")
+		return err
+	}
+
+	// Mark the request as accepted, so that it is kept apart from declined requests once deleted.
+	membershipRequest.Accepted = true
+	if err := s.opportunityMembershipRequestRepository.Update(*membershipRequest); err != nil {
+		s.logger.Error().Err(err).Msg("Error updating membership request")
 		return NewErrServerError()
 	}
 
@@ -627,6 +744,12 @@ func (s *service) AcceptOpportunityMembershipRequest(ctx context.Context, opport
 		return NewErrServerError()
 	}
 
+	// Take the volunteer off the waitlist, if they were waitlisted.
+	if err := s.closeWaitlistEntry(ctx, opportunityID, volunteerID, models.WaitlistStatusAccepted); err != nil {
+		s.logger.Error().Err(err).Msg("Error closing waitlist entry")
+		return NewErrServerError()
+	}
+
 	return nil
 }
 
@@ -644,6 +767,12 @@ func (s *service) DeclineOpportunityMembershipRequest(ctx context.Context, oppor
 		return NewErrServerError()
 	}
 
+	// Take the volunteer off the waitlist, if they were waitlisted.
+	if err := s.closeWaitlistEntry(ctx, opportunityID, volunteerID, models.WaitlistStatusDeclined); err != nil {
+		s.logger.Error().Err(err).Msg("Error closing waitlist entry")
+		return NewErrServerError()
+	}
+
 	return nil
 }
 
@@ -682,47 +811,12 @@ func (s *service) GetOpportunityInvitedVolunteers(ctx context.Context, opportuni
 
 // PublishOpportunity attempts to publish an opportunity and returns an error if the opportunity is unpublishable.
 func (s *service) PublishOpportunity(ctx context.Context, opportunityID int64) error {
-	opportunity, err := s.opportunityRepository.FindByID(ctx, opportunityID)
-	if err != nil {
-		return NewErrOpportunityNotFound()
-	}
-
-	// Validate that the opportunity is publishable.
-	if invalidFields, ok := isPublishable(*opportunity); !ok {
-		return NewErrOpportunityNotPublishable(invalidFields)
-	}
-
-	opportunity.Public = true
-
-	err = s.opportunityRepository.Save(ctx, *opportunity)
-	if err != nil {
-		s.logger.Error().Err(err).Msg("Error publishing opportunity")
-		return NewErrServerError()
-	}
-
-	s.searchStore.Save(opportunityID)
-
-	return nil
+	return s.SetOpportunityStatus(ctx, opportunityID, models.OpportunityStatusOpen, nil, nil)
 }
 
 // UnpublishOpportunity unpublishes an opportunity.
 func (s *service) UnpublishOpportunity(ctx context.Context, opportunityID int64) error {
-	opportunity, err := s.opportunityRepository.FindByID(ctx, opportunityID)
-	if err != nil {
-		return NewErrOpportunityNotFound()
-	}
-
-	opportunity.Public = false
-
-	err = s.opportunityRepository.Save(ctx, *opportunity)
-	if err != nil {
-		s.logger.Error().Err(err).Msg("Error unpublishing opportunity")
-		return NewErrServerError()
-	}
-
-	s.searchStore.Save(opportunityID)
-
-	return nil
+	return s.SetOpportunityStatus(ctx, opportunityID, models.OpportunityStatusDraft, nil, nil)
 }
 
 // InviteVolunteer invites a volunteer by user email to an opportunity.
@@ -753,6 +847,10 @@ func (s *service) InviteVolunteer(ctx context.Context, inviterID, opportunityID
 
 	user, err := s.userRepository.FindByEmail(userEmail)
 	if err == nil {
+		if !s.canInvite(organization, user) {
+			return NewErrOrganizationNotVerified()
+		}
+
 		// If a user was found, add their ID to the invite.
 		invite.InviteeID = user.ID
 		userFirstName = user.FirstName
@@ -791,6 +889,24 @@ func (s *service) InviteVolunteer(ctx context.Context, inviterID, opportunityID
 	return nil
 }
 
+// minorAge is the age under which users are considered minors.
+const minorAge = 18
+
+// canInvite checks whether an organization may invite a user to its opportunities. When
+// RequireVerifiedOrganizationForMinors is enabled, only verified organizations can invite minors.
+func (s *service) canInvite(organization *models.Organization, user *models.User) bool {
+	if !s.config.RequireVerifiedOrganizationForMinors || organization.VerificationStatus == models.VerificationStatusVerified {
+		return true
+	}
+
+	// Users without a date of birth can't be told apart from adults.
+	if user.DateOfBirth.IsZero() {
+		return true
+	}
+
+	return !user.DateOfBirth.AddDate(minorAge, 0, 0).After(time.Now())
+}
+
 // GetOpportunityFromInvite gets an opportunity view from an invite for UI use.
 func (s *service) GetOpportunityFromInvite(ctx context.Context, opportunityID int64, userID, inviteID int64, inviteKey string) (*OpportunityView, error) {
 	// Get the invite by ID.
@@ -832,11 +948,25 @@ func (s *service) AcceptInvite(ctx context.Context, opportunityID int64, userID,
 		return NewErrInviteInvalid()
 	}
 
-	if err := s.createVolunteerMembership(ctx, invite.InviterID, invite.OpportunityID, userID); err != nil {
-		s.logger.Error().Err(err).Msg("Error creating volunteer membership")
+	// The invitee may have signed up after being invited, so the organization is checked again.
+	opportunity, err := s.opportunityRepository.FindByID(ctx, opportunityID)
+	if err != nil {
+		return NewErrOpportunityNotFound()
+	}
+
+	organization, err := s.organizationRepository.FindByID(opportunity.OrganizationID)
+	if err != nil {
 		return NewErrServerError()
 	}
 
+	if !s.canInvite(organization, user) {
+		return NewErrOrganizationNotVerified()
+	}
+
+	if err := s.createVolunteerMembership(ctx, invite.InviterID, invite.OpportunityID, userID); err != nil {
+		return err
+	}
+
 	if err := s.invalidateInvite(ctx, invite.ID); err != nil {
 		s.logger.Error().Err(err).Msg("Error invalidating invite")
 		return NewErrServerError()
@@ -871,7 +1001,8 @@ func (s *service) DeclineInvite(ctx context.Context, opportunityID int64, userID
 	return nil
 }
 
-// createVolunteerMembership creates a volunteer membership in an opportunity.
+// createVolunteerMembership creates a volunteer membership in an opportunity, or returns ErrOpportunityFull
+// when the volunteers cap is reached, unless a spot is held for the volunteer on the waitlist.
 func (s *service) createVolunteerMembership(ctx context.Context, inviterID int64, opportunityID, userID int64) error {
 	membership := models.OpportunityMembership{}
 
@@ -883,7 +1014,17 @@ func (s *service) createVolunteerMembership(ctx context.Context, inviterID int64
 	membership.PermissionsFlag = models.OpportunityPermissionsMember
 	membership.InviterID = inviterID
 
-	return s.opportunityMembershipRepository.Create(ctx, membership)
+	ok, err := s.opportunityMembershipRepository.CreateVolunteer(ctx, membership, time.Now().UTC())
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error creating volunteer membership")
+		return NewErrServerError()
+	}
+
+	if !ok {
+		return NewErrOpportunityFull()
+	}
+
+	return nil
 }
 
 // invalidateInvite invalidates an opportunity invite by ID.
@@ -938,6 +1079,32 @@ func (s *service) Search(ctx context.Context, query opportunitiesSearch.Query) (
 	}, nil
 }
 
+// reindexPageSize is the page size used when reading all of an organization's opportunities for reindexing.
+const reindexPageSize = 100
+
+// ReindexOrganizationOpportunities saves all opportunities of an organization in the search store
+// again, such as after a change to the organization which is part of the opportunity documents.
+func (s *service) ReindexOrganizationOpportunities(ctx context.Context, organizationID int64) error {
+	for page := 0; ; page++ {
+		opportunities, err := s.opportunityRepository.FindByOrganizationID(dbctx.Inject(ctx, dbctx.Request{
+			Limit: reindexPageSize,
+			Page:  page,
+		}), organizationID)
+		if err != nil {
+			s.logger.Error().Err(err).Msg("Error getting organization opportunities for reindexing")
+			return NewErrServerError()
+		}
+
+		for _, opportunity := range opportunities {
+			s.searchStore.Save(opportunity.ID)
+		}
+
+		if len(opportunities) < reindexPageSize {
+			return nil
+		}
+	}
+}
+
 // GetRecommendations gets a list of browse rows for a specific user based on recommendations made using a random number seeded by the current date.
 func (s *service) GetRecommendations(ctx context.Context, userID int64) ([]Section, error) {
 	user, err := s.userRepository.FindByID(userID)
@@ -1025,9 +1192,25 @@ func (s *service) GetRecommendations(ctx context.Context, userID int64) ([]Secti
 	return sections, nil
 }
 
-// GetOrganizationOpportunityVolunteers gets all volunteers in all opportunities of a specified organization.
-func (s *service) GetOrganizationOpportunityVolunteers(ctx context.Context, organizationID int64) ([]models.OpportunityMembership, error) {
-	opportunities, err := s.opportunityRepository.FindByOrganizationID(ctx, organizationID)
+// findOpportunitiesByOrganizationIDs finds the opportunities of multiple organizations.
+func (s *service) findOpportunitiesByOrganizationIDs(ctx context.Context, organizationIDs []int64) ([]models.Opportunity, error) {
+	opportunities := []models.Opportunity{}
+	for _, organizationID := range organizationIDs {
+		res, err := s.opportunityRepository.FindByOrganizationID(ctx, organizationID)
+		if err != nil {
+			return nil, err
+		}
+
+		opportunities = append(opportunities, res...)
+	}
+
+	return opportunities, nil
+}
+
+// GetOrganizationOpportunityVolunteers gets all volunteers in all opportunities of the specified organizations,
+// such as an organization and its chapters.
+func (s *service) GetOrganizationOpportunityVolunteers(ctx context.Context, organizationIDs []int64) ([]models.OpportunityMembership, error) {
+	opportunities, err := s.findOpportunitiesByOrganizationIDs(ctx, organizationIDs)
 	if err != nil {
 		return nil, NewErrServerError()
 	}
@@ -1056,9 +1239,9 @@ func (s *service) GetOrganizationOpportunityVolunteers(ctx context.Context, orga
 	return opportunityMemberships, nil
 }
 
-// GetOrganizationOpportunityInvitedVolunteers gets all invited volunteers in all opportunities of a specified organization.
-func (s *service) GetOrganizationOpportunityInvitedVolunteers(ctx context.Context, organizationID int64) ([]models.OpportunityMembershipInvite, error) {
-	opportunities, err := s.opportunityRepository.FindByOrganizationID(ctx, organizationID)
+// GetOrganizationOpportunityInvitedVolunteers gets all invited volunteers in all opportunities of the specified organizations.
+func (s *service) GetOrganizationOpportunityInvitedVolunteers(ctx context.Context, organizationIDs []int64) ([]models.OpportunityMembershipInvite, error) {
+	opportunities, err := s.findOpportunitiesByOrganizationIDs(ctx, organizationIDs)
 	if err != nil {
 		return nil, NewErrServerError()
 	}
@@ -1076,9 +1259,9 @@ func (s *service) GetOrganizationOpportunityInvitedVolunteers(ctx context.Contex
 	return opportunityMemberships, nil
 }
 
-// GetOrganizationOpportunityRequestedVolunteers gets all invited volunteers in all opportunities of a specified organization.
-func (s *service) GetOrganizationOpportunityRequestedVolunteers(ctx context.Context, organizationID int64) ([]models.OpportunityMembershipRequest, error) {
-	opportunities, err := s.opportunityRepository.FindByOrganizationID(ctx, organizationID)
+// GetOrganizationOpportunityRequestedVolunteers gets all requested volunteers in all opportunities of the specified organizations.
+func (s *service) GetOrganizationOpportunityRequestedVolunteers(ctx context.Context, organizationIDs []int64) ([]models.OpportunityMembershipRequest, error) {
+	opportunities, err := s.findOpportunitiesByOrganizationIDs(ctx, organizationIDs)
 	if err != nil {
 		return nil, NewErrServerError()
 	}
@@ -1,6 +1,8 @@
 package opportunities
 
 import (
+	"time"
+
 	"github.com/joinimpact/api/internal/models"
 	"github.com/joinimpact/api/pkg/location"
 )
@@ -17,7 +19,10 @@ type OpportunityView struct {
 	Description                    string                          `json:"description"`
 	Location                       *location.Location              `json:"location" validate:"-"`
 	Public                         bool                            `json:"public" scope:"manager"`
-	Tags                           []models.Tag                    `json:"tags"` // the model's tags
+	Status                         string                          `json:"status"`                    // the lifecycle status of the opportunity
+	PublishAt                      *time.Time                      `json:"publishAt" scope:"manager"` // the time a scheduled opportunity opens
+	CloseAt                        *time.Time                      `json:"closeAt"`                   // the time the opportunity closes, or closed, to new applicants
+	Tags                           []models.Tag                    `json:"tags"`                      // the model's tags
 	Stats                          *Stats                          `json:"stats" scope:"manager"`
 	Requirements                   *Requirements                   `json:"requirements"`
 	Limits                         *Limits                         `json:"limits"`
@@ -0,0 +1,307 @@
+package opportunities
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/joinimpact/api/internal/email"
+	"github.com/joinimpact/api/internal/email/templates"
+	"github.com/joinimpact/api/internal/models"
+	"github.com/rs/zerolog"
+)
+
+// waitlistOfferLifespan is how long a spot is held for a waitlisted volunteer before it is offered to the
+// next volunteer.
+const waitlistOfferLifespan = 48 * time.Hour
+
+// WaitlistPosition represents a volunteer's place on an opportunity's waitlist.
+type WaitlistPosition struct {
+	models.OpportunityWaitlistEntry
+	Position int `json:"position"` // the 1-based position among waiting volunteers, or 0 when a spot is offered
+}
+
+// isFull checks whether an opportunity has reached its volunteers cap. Spots held for waitlisted volunteers
+// count towards the cap.
+func (s *service) isFull(ctx context.Context, opportunityID int64) (bool, error) {
+	limits, err := s.opportunityLimitsRepository.FindByOpportunityID(opportunityID)
+	if err != nil || !limits.VolunteersCapActive {
+		return false, nil
+	}
+
+	volunteers, err := s.opportunityMembershipRepository.CountVolunteers(ctx, opportunityID)
+	if err != nil {
+		return false, err
+	}
+
+	offers, err := s.opportunityWaitlistEntryRepository.CountOpenOffers(ctx, opportunityID, time.Now().UTC())
+	if err != nil {
+		return false, err
+	}
+
+	return volunteers+offers >= limits.VolunteersCap, nil
+}
+
+// GetWaitlist gets the waiting and offered entries of an opportunity's waitlist, in order.
+func (s *service) GetWaitlist(ctx context.Context, opportunityID int64) ([]models.OpportunityWaitlistEntry, error) {
+	entries, err := s.opportunityWaitlistEntryRepository.FindOpenByOpportunityID(ctx, opportunityID)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error finding waitlist entries")
+		return nil, NewErrServerError()
+	}
+
+	return entries, nil
+}
+
+// GetWaitlistPosition gets a volunteer's place on an opportunity's waitlist.
+func (s *service) GetWaitlistPosition(ctx context.Context, opportunityID, volunteerID int64) (*WaitlistPosition, error) {
+	entries, err := s.opportunityWaitlistEntryRepository.FindOpenByOpportunityID(ctx, opportunityID)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error finding waitlist entries")
+		return nil, NewErrServerError()
+	}
+
+	position := 0
+	for _, entry := range entries {
+		if entry.Status == models.WaitlistStatusWaiting {
+			position++
+		}
+
+		if entry.VolunteerID != volunteerID {
+			continue
+		}
+
+		if entry.Status != models.WaitlistStatusWaiting {
+			position = 0
+		}
+
+		return &WaitlistPosition{entry, position}, nil
+	}
+
+	return nil, NewErrWaitlistEntryNotFound()
+}
+
+// AcceptWaitlistOffer claims the spot offered to a waitlisted volunteer. The spot stays held for them until
+// a manager accepts or declines their membership request, so that waitlisted volunteers are reviewed like
+// any other applicant.
+func (s *service) AcceptWaitlistOffer(ctx context.Context, opportunityID, volunteerID int64) error {
+	entry, err := s.opportunityWaitlistEntryRepository.FindOpenInOpportunityByVolunteerID(ctx, opportunityID, volunteerID)
+	if err != nil || !isOpenOffer(entry) {
+		return NewErrWaitlistEntryNotFound()
+	}
+
+	entry.Status = models.WaitlistStatusClaimed
+	if err := s.opportunityWaitlistEntryRepository.Save(ctx, *entry); err != nil {
+		s.logger.Error().Err(err).Msg("Error saving waitlist entry")
+		return NewErrServerError()
+	}
+
+	return nil
+}
+
+// DeclineWaitlistOffer turns down the spot offered to a waitlisted volunteer, or removes a waiting
+// volunteer from the waitlist. It returns the offers made to the next volunteers, who should be notified.
+func (s *service) DeclineWaitlistOffer(ctx context.Context, opportunityID, volunteerID int64) ([]models.OpportunityWaitlistEntry, error) {
+	entry, err := s.opportunityWaitlistEntryRepository.FindOpenInOpportunityByVolunteerID(ctx, opportunityID, volunteerID)
+	if err != nil {
+		return nil, NewErrWaitlistEntryNotFound()
+	}
+
+	entry.Status = models.WaitlistStatusDeclined
+	if err := s.opportunityWaitlistEntryRepository.Save(ctx, *entry); err != nil {
+		s.logger.Error().Err(err).Msg("Error saving waitlist entry")
+		return nil, NewErrServerError()
+	}
+
+	s.closeWaitlistedRequest(ctx, entry)
+
+	return s.offerNextSpots(ctx, opportunityID)
+}
+
+// RemoveVolunteer removes a volunteer from an opportunity, whether they left or were removed by a
+// manager. It returns the offers made to waitlisted volunteers for the free spot, who should be notified.
+func (s *service) RemoveVolunteer(ctx context.Context, opportunityID, volunteerID int64) ([]models.OpportunityWaitlistEntry, error) {
+	membership, err := s.opportunityMembershipRepository.FindUserInOpportunity(ctx, opportunityID, volunteerID)
+	if err != nil || membership.PermissionsFlag != models.OpportunityPermissionsMember {
+		return nil, NewErrMembershipNotFound()
+	}
+
+	if err := s.opportunityMembershipRepository.DeleteByID(ctx, membership.ID); err != nil {
+		s.logger.Error().Err(err).Msg("Error deleting volunteer membership")
+		return nil, NewErrServerError()
+	}
+
+	return s.offerNextSpots(ctx, opportunityID)
+}
+
+// ProcessWaitlists expires the offers which were not accepted in time, and offers the free spots of all
+// opportunities to their waitlisted volunteers, such as spots freed by expired offers or a raised cap. It
+// returns the offers made, whose volunteers should be notified.
+func (s *service) ProcessWaitlists(ctx context.Context) ([]models.OpportunityWaitlistEntry, error) {
+	expired, err := s.opportunityWaitlistEntryRepository.FindExpiredOffers(ctx, time.Now().UTC())
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error finding expired waitlist offers")
+		return nil, NewErrServerError()
+	}
+
+	for _, entry := range expired {
+		entry.Status = models.WaitlistStatusExpired
+		if err := s.opportunityWaitlistEntryRepository.Save(ctx, entry); err != nil {
+			s.logger.Error().Err(err).Int64("waitlistEntryId", entry.ID).Msg("Error expiring waitlist offer")
+			continue
+		}
+
+		s.closeWaitlistedRequest(ctx, &entry)
+	}
+
+	opportunityIDs, err := s.opportunityWaitlistEntryRepository.FindWaitingOpportunityIDs(ctx)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error finding waitlisted opportunities")
+		return nil, NewErrServerError()
+	}
+
+	offers := []models.OpportunityWaitlistEntry{}
+	for _, opportunityID := range opportunityIDs {
+		res, err := s.offerNextSpots(ctx, opportunityID)
+		if err != nil {
+			// Keep going, the opportunity will be retried on the next run.
+			continue
+		}
+
+		offers = append(offers, res...)
+	}
+
+	return offers, nil
+}
+
+// waitlistVolunteer adds a volunteer who requested to join a full opportunity to its waitlist.
+func (s *service) waitlistVolunteer(ctx context.Context, opportunityID, volunteerID, requestID int64) error {
+	entry := models.OpportunityWaitlistEntry{
+		OpportunityID:                  opportunityID,
+		VolunteerID:                    volunteerID,
+		OpportunityMembershipRequestID: requestID,
+		Status:                         models.WaitlistStatusWaiting,
+	}
+	entry.ID = s.snowflakeService.GenerateID()
+
+	return s.opportunityWaitlistEntryRepository.Create(ctx, entry)
+}
+
+// closeWaitlistEntry closes a volunteer's open waitlist entry in an opportunity, if they have one, such as
+// when their membership request is accepted or declined by a manager.
+func (s *service) closeWaitlistEntry(ctx context.Context, opportunityID, volunteerID int64, status string) error {
+	entry, err := s.opportunityWaitlistEntryRepository.FindOpenInOpportunityByVolunteerID(ctx, opportunityID, volunteerID)
+	if err != nil {
+		return nil
+	}
+
+	entry.Status = status
+	return s.opportunityWaitlistEntryRepository.Save(ctx, *entry)
+}
+
+// closeWaitlistedRequest removes the membership request a volunteer was waitlisted with, once they turned
+// down or missed their offer.
+func (s *service) closeWaitlistedRequest(ctx context.Context, entry *models.OpportunityWaitlistEntry) {
+	request, err := s.opportunityMembershipRequestRepository.FindByID(entry.OpportunityMembershipRequestID)
+	if err != nil {
+		return
+	}
+
+	if err := s.opportunityMembershipRequestRepository.DeleteByID(request.ID); err != nil {
+		s.logger.Error().Err(err).Msg("Error deleting membership request")
+	}
+}
+
+// isOpenOffer returns true if a spot is offered to a waitlisted volunteer and the offer has not expired.
+func isOpenOffer(entry *models.OpportunityWaitlistEntry) bool {
+	return entry.Status == models.WaitlistStatusOffered && entry.OfferExpiresAt != nil && entry.OfferExpiresAt.After(time.Now().UTC())
+}
+
+// offerNextSpots offers the free spots of an opportunity to its waitlisted volunteers in order, and emails
+// each of them. It returns the offers made.
+func (s *service) offerNextSpots(ctx context.Context, opportunityID int64) ([]models.OpportunityWaitlistEntry, error) {
+	offers := []models.OpportunityWaitlistEntry{}
+	for {
+		full, err := s.isFull(ctx, opportunityID)
+		if err != nil {
+			s.logger.Error().Err(err).Msg("Error checking volunteers cap")
+			return offers, NewErrServerError()
+		}
+
+		if full {
+			return offers, nil
+		}
+
+		entry, err := s.opportunityWaitlistEntryRepository.FindNextWaiting(ctx, opportunityID)
+		if err != nil {
+			// Nobody is waiting.
+			return offers, nil
+		}
+
+		now := time.Now().UTC()
+		expiresAt := now.Add(waitlistOfferLifespan)
+		entry.Status = models.WaitlistStatusOffered
+		entry.OfferedAt = &now
+		entry.OfferExpiresAt = &expiresAt
+
+		if err := s.opportunityWaitlistEntryRepository.Save(ctx, *entry); err != nil {
+			s.logger.Error().Err(err).Msg("Error offering waitlist spot")
+			return offers, NewErrServerError()
+		}
+
+		s.sendWaitlistOfferEmail(ctx, entry)
+
+		offers = append(offers, *entry)
+	}
+}
+
+// sendWaitlistOfferEmail emails a waitlisted volunteer that a spot is held for them.
+func (s *service) sendWaitlistOfferEmail(ctx context.Context, entry *models.OpportunityWaitlistEntry) {
+	volunteer, err := s.userRepository.FindByID(entry.VolunteerID)
+	if err != nil {
+		return
+	}
+
+	opportunity, err := s.opportunityRepository.FindByID(ctx, entry.OpportunityID)
+	if err != nil {
+		return
+	}
+
+	organization, err := s.organizationRepository.FindByID(opportunity.OrganizationID)
+	if err != nil {
+		return
+	}
+
+	email := s.emailService.NewEmail(
+		email.NewRecipient(fmt.Sprintf("%s %s", volunteer.FirstName, volunteer.LastName), volunteer.Email),
+		fmt.Sprintf("A spot opened up in %s", opportunity.Title),
+		templates.WaitlistOfferTemplate(volunteer.FirstName, opportunity.Title, organization.Name, opportunity.ID, int(waitlistOfferLifespan.Hours())),
+	)
+	if err := s.emailService.Send(email); err != nil {
+		s.logger.Error().Err(err).Msg("Error sending waitlist offer email")
+	}
+}
+
+// RunWaitlistJob processes the waitlists of all opportunities once every interval, until the context is
+// done. Each offer made is passed to notify.
+func RunWaitlistJob(ctx context.Context, service Service, interval time.Duration, logger *zerolog.Logger, notify func(ctx context.Context, offer models.OpportunityWaitlistEntry)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		offers, err := service.ProcessWaitlists(ctx)
+		if err == nil && len(offers) > 0 {
+			logger.Info().Int("offers", len(offers)).Msg("Offered opportunity spots to waitlisted volunteers")
+		}
+
+		for _, offer := range offers {
+			notify(ctx, offer)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package organizations
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/joinimpact/api/internal/models"
+	"github.com/joinimpact/api/pkg/apikeys"
+	"github.com/joinimpact/api/pkg/scopes"
+)
+
+// maxAPIKeyNameLength is the maximum length of the name of an API key.
+const maxAPIKeyNameLength = 64
+
+// APIKey represents an organization API key without its secret.
+type APIKey struct {
+	ID         int64      `json:"id"`
+	Name       string     `json:"name"`       // a name describing what the key is used for
+	Prefix     string     `json:"prefix"`     // the first characters of the key, to help identify it
+	Scope      string     `json:"scope"`      // the scope requests made with the key receive
+	CreatorID  int64      `json:"creatorId"`  // the id of the user who created the key
+	CreatedAt  time.Time  `json:"createdAt"`  // when the key was created
+	LastUsedAt *time.Time `json:"lastUsedAt"` // when the key was last used
+}
+
+// newAPIKey creates an APIKey from a models.OrganizationAPIKey.
+func newAPIKey(apiKey models.OrganizationAPIKey) APIKey {
+	return APIKey{
+		ID:         apiKey.ID,
+		Name:       apiKey.Name,
+		Prefix:     apiKey.Prefix,
+		Scope:      apiKey.Scope.String(),
+		CreatorID:  apiKey.CreatorID,
+		CreatedAt:  apiKey.CreatedAt,
+		LastUsedAt: apiKey.LastUsedAt,
+	}
+}
+
+// CreateAPIKey creates a new API key for an organization, and returns it along with the key
+// itself, which can not be retrieved again.
+func (s *service) CreateAPIKey(ctx context.Context, organizationID, creatorID int64, name string, scope scopes.Scope) (*APIKey, string, error) {
+	name = strings.TrimSpace(name)
+	if len(name) < 1 || len(name) > maxAPIKeyNameLength {
+		return nil, "", NewErrInvalidAPIKeyName()
+	}
+
+	// Keys can only act as organization members, never as the organization's creator.
+	if scope != scopes.ScopeManager && scope != scopes.ScopeAdmin {
+		return nil, "", NewErrInvalidAPIKeyScope()
+	}
+
+	if _, err := s.organizationRepository.FindByID(organizationID); err != nil {
+		return nil, "", NewErrOrganizationNotFound()
+	}
+
+	key, err := apikeys.Generate()
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error generating API key")
+		return nil, "", NewErrServerError()
+	}
+
+	apiKey := models.OrganizationAPIKey{
+		OrganizationID: organizationID,
+		CreatorID:      creatorID,
+		Name:           name,
+		Prefix:         apikeys.DisplayPrefix(key),
+		KeyHash:        apikeys.Hash(key),
+		Scope:          scope,
+	}
+	apiKey.ID = s.snowflakeService.GenerateID()
+	apiKey.CreatedAt = time.Now()
+
+	if err := s.organizationAPIKeyRepository.Create(ctx, apiKey); err != nil {
+		s.logger.Error().Err(err).Msg("Error creating API key")
+		return nil, "", NewErrServerError()
+	}
+
+	view := newAPIKey(apiKey)
+	return &view, key, nil
+}
+
+// GetAPIKeys gets all API keys of an organization.
+func (s *service) GetAPIKeys(ctx context.Context, organizationID int64) ([]APIKey, error) {
+	apiKeys, err := s.organizationAPIKeyRepository.FindByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return nil, NewErrServerError()
+	}
+
+	views := []APIKey{}
+	for _, apiKey := range apiKeys {
+		views = append(views, newAPIKey(apiKey))
+	}
+
+	return views, nil
+}
+
+// RevokeAPIKey revokes an organization's API key by ID.
+func (s *service) RevokeAPIKey(ctx context.Context, organizationID, apiKeyID int64) error {
+	apiKey, err := s.organizationAPIKeyRepository.FindByID(ctx, apiKeyID)
+	if err != nil || apiKey.OrganizationID != organizationID {
+		return NewErrAPIKeyNotFound()
+	}
+
+	if err := s.organizationAPIKeyRepository.DeleteByID(ctx, apiKey.ID); err != nil {
+		return NewErrServerError()
+	}
+
+	return nil
+}
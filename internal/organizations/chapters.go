@@ -0,0 +1,214 @@
+package organizations
+
+import (
+	"context"
+	"sort"
+
+	"github.com/joinimpact/api/internal/models"
+	"github.com/joinimpact/api/pkg/location"
+)
+
+// maxHierarchyDepth is the maximum number of levels of chapters below an organization.
+const maxHierarchyDepth = 5
+
+// Chapter represents a chapter of an organization, along with its distance in kilometers from the
+// location it was browsed from.
+type Chapter struct {
+	OrganizationProfile
+	Distance *float64 `json:"distance,omitempty"`
+}
+
+// SetParentOrganization makes an organization a chapter of a parent organization, or removes it
+// from its parent if parentID is 0. The user has to be an owner of the parent organization.
+func (s *service) SetParentOrganization(ctx context.Context, organizationID, parentID, userID int64) error {
+	organization, err := s.organizationRepository.FindByID(organizationID)
+	if err != nil {
+		return NewErrOrganizationNotFound()
+	}
+
+	if parentID != 0 {
+		if parentID == organizationID {
+			return NewErrInvalidParentOrganization()
+		}
+
+		if _, err := s.organizationRepository.FindByID(parentID); err != nil {
+			return NewErrInvalidParentOrganization()
+		}
+
+		flag, err := s.directMembership(parentID, userID)
+		if err != nil || flag < models.OrganizationPermissionsOwner {
+			return NewErrInvalidParentOrganization()
+		}
+
+		// The parent can't be a chapter of the organization, and the hierarchy can't grow too deep.
+		ancestors, err := s.ancestorIDs(parentID)
+		if err != nil {
+			return NewErrServerError()
+		}
+		for _, id := range ancestors {
+			if id == organizationID {
+				return NewErrInvalidParentOrganization()
+			}
+		}
+
+		if len(ancestors)+1+s.treeDepth(ctx, organizationID) > maxHierarchyDepth {
+			return NewErrInvalidParentOrganization()
+		}
+	}
+
+	if err := s.organizationRepository.UpdateParentID(organization.ID, parentID); err != nil {
+		s.logger.Error().Err(err).Msg("Error updating organization parent")
+		return NewErrServerError()
+	}
+
+	return nil
+}
+
+// ancestorIDs returns the IDs of the organizations above an organization in its hierarchy, starting
+// with its parent.
+func (s *service) ancestorIDs(organizationID int64) ([]int64, error) {
+	ids := []int64{}
+	for len(ids) <= maxHierarchyDepth {
+		organization, err := s.organizationRepository.FindByID(organizationID)
+		if err != nil {
+			return nil, err
+		}
+
+		if organization.ParentID == 0 {
+			break
+		}
+
+		organizationID = organization.ParentID
+		ids = append(ids, organizationID)
+	}
+
+	return ids, nil
+}
+
+// treeDepth returns the number of levels of chapters below an organization.
+func (s *service) treeDepth(ctx context.Context, organizationID int64) int {
+	level := []int64{organizationID}
+	for depth := 0; depth <= maxHierarchyDepth; depth++ {
+		next := []int64{}
+		for _, id := range level {
+			chapters, err := s.organizationRepository.FindByParentID(id)
+			if err != nil {
+				continue
+			}
+
+			for _, chapter := range chapters {
+				next = append(next, chapter.ID)
+			}
+		}
+
+		if len(next) == 0 {
+			return depth
+		}
+		level = next
+	}
+
+	return maxHierarchyDepth + 1
+}
+
+// isParentAdmin checks whether a user is an owner of any organization above an organization in its
+// hierarchy.
+func (s *service) isParentAdmin(organizationID, userID int64) bool {
+	ancestors, err := s.ancestorIDs(organizationID)
+	if err != nil {
+		return false
+	}
+
+	for _, id := range ancestors {
+		if flag, err := s.directMembership(id, userID); err == nil && flag >= models.OrganizationPermissionsOwner {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetOrganizationTreeIDs returns the ID of an organization followed by the IDs of all of its
+// chapters, at any level below it.
+func (s *service) GetOrganizationTreeIDs(ctx context.Context, organizationID int64) ([]int64, error) {
+	if _, err := s.organizationRepository.FindByID(organizationID); err != nil {
+		return nil, NewErrOrganizationNotFound()
+	}
+
+	ids := []int64{organizationID}
+	seen := map[int64]bool{organizationID: true}
+	level := []int64{organizationID}
+	for depth := 0; depth < maxHierarchyDepth && len(level) > 0; depth++ {
+		next := []int64{}
+		for _, id := range level {
+			chapters, err := s.organizationRepository.FindByParentID(id)
+			if err != nil {
+				s.logger.Error().Err(err).Msg("Error getting organization chapters")
+				return nil, NewErrServerError()
+			}
+
+			for _, chapter := range chapters {
+				if seen[chapter.ID] {
+					continue
+				}
+
+				seen[chapter.ID] = true
+				ids = append(ids, chapter.ID)
+				next = append(next, chapter.ID)
+			}
+		}
+		level = next
+	}
+
+	return ids, nil
+}
+
+// GetChapters gets the chapters directly below an organization, sorted by distance from a location.
+// If no location is provided, the location of the organization itself is used.
+func (s *service) GetChapters(ctx context.Context, organizationID int64, from *location.Coordinates) ([]Chapter, error) {
+	organization, err := s.organizationRepository.FindByID(organizationID)
+	if err != nil {
+		return nil, NewErrOrganizationNotFound()
+	}
+
+	if from == nil && (organization.LocationLatitude != 0 || organization.LocationLongitude != 0) {
+		from = &location.Coordinates{
+			Latitude:  organization.LocationLatitude,
+			Longitude: organization.LocationLongitude,
+		}
+	}
+
+	organizations, err := s.organizationRepository.FindByParentID(organizationID)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error getting organization chapters")
+		return nil, NewErrServerError()
+	}
+
+	chapters := []Chapter{}
+	for _, organization := range organizations {
+		profile, err := s.GetOrganizationProfile(organization.ID)
+		if err != nil {
+			continue
+		}
+
+		chapter := Chapter{OrganizationProfile: *profile}
+		if from != nil && (organization.LocationLatitude != 0 || organization.LocationLongitude != 0) {
+			distance := location.Distance(*from, location.Coordinates{
+				Latitude:  organization.LocationLatitude,
+				Longitude: organization.LocationLongitude,
+			})
+			chapter.Distance = &distance
+		}
+
+		chapters = append(chapters, chapter)
+	}
+
+	// Chapters without a location are listed last.
+	sort.SliceStable(chapters, func(i, j int) bool {
+		if chapters[i].Distance == nil || chapters[j].Distance == nil {
+			return chapters[j].Distance == nil && chapters[i].Distance != nil
+		}
+		return *chapters[i].Distance < *chapters[j].Distance
+	})
+
+	return chapters, nil
+}
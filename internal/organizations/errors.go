@@ -1,5 +1,7 @@
 package organizations
 
+import "fmt"
+
 // ErrUserNotFound is thrown when the server is unable to find a User.
 type ErrUserNotFound struct {
 }
@@ -112,3 +114,366 @@ func (e *ErrInviteInvalid) Error() string {
 func (e *ErrInviteInvalid) Ref() string {
 	return "organizations.invite_invalid"
 }
+
+// ErrTwoFactorNotEnabled is thrown when a user without two-factor authentication
+// tries to require it for an organization.
+type ErrTwoFactorNotEnabled struct {
+}
+
+// NewErrTwoFactorNotEnabled creates and returns a ErrTwoFactorNotEnabled.
+func NewErrTwoFactorNotEnabled() error {
+	return &ErrTwoFactorNotEnabled{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrTwoFactorNotEnabled) Error() string {
+	return "two-factor authentication must be enabled on your account first"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrTwoFactorNotEnabled) Ref() string {
+	return "organizations.two_factor_not_enabled"
+}
+
+// ErrAPIKeyNotFound is thrown when an API key is not found.
+type ErrAPIKeyNotFound struct {
+}
+
+// NewErrAPIKeyNotFound creates and returns a ErrAPIKeyNotFound.
+func NewErrAPIKeyNotFound() error {
+	return &ErrAPIKeyNotFound{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrAPIKeyNotFound) Error() string {
+	return "api key not found"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrAPIKeyNotFound) Ref() string {
+	return "organizations.api_key_not_found"
+}
+
+// ErrInvalidAPIKeyName is thrown when an API key is created without a valid name.
+type ErrInvalidAPIKeyName struct {
+}
+
+// NewErrInvalidAPIKeyName creates and returns a ErrInvalidAPIKeyName.
+func NewErrInvalidAPIKeyName() error {
+	return &ErrInvalidAPIKeyName{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrInvalidAPIKeyName) Error() string {
+	return fmt.Sprintf("api key name must be between 1 and %d characters", maxAPIKeyNameLength)
+}
+
+// Ref provides a representation of the error.
+func (e *ErrInvalidAPIKeyName) Ref() string {
+	return "organizations.invalid_api_key_name"
+}
+
+// ErrInvalidAPIKeyScope is thrown when an API key is created with a scope it can not be granted.
+type ErrInvalidAPIKeyScope struct {
+}
+
+// NewErrInvalidAPIKeyScope creates and returns a ErrInvalidAPIKeyScope.
+func NewErrInvalidAPIKeyScope() error {
+	return &ErrInvalidAPIKeyScope{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrInvalidAPIKeyScope) Error() string {
+	return "api key scope must be manager or admin"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrInvalidAPIKeyScope) Ref() string {
+	return "organizations.invalid_api_key_scope"
+}
+
+// ErrRoleNotFound is thrown when an organization role is not found.
+type ErrRoleNotFound struct {
+}
+
+// NewErrRoleNotFound creates and returns a ErrRoleNotFound.
+func NewErrRoleNotFound() error {
+	return &ErrRoleNotFound{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrRoleNotFound) Error() string {
+	return "role not found"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrRoleNotFound) Ref() string {
+	return "organizations.role_not_found"
+}
+
+// ErrInvalidRoleName is thrown when a role is given an invalid name.
+type ErrInvalidRoleName struct {
+}
+
+// NewErrInvalidRoleName creates and returns a ErrInvalidRoleName.
+func NewErrInvalidRoleName() error {
+	return &ErrInvalidRoleName{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrInvalidRoleName) Error() string {
+	return fmt.Sprintf("role name must be between 1 and %d characters", maxRoleNameLength)
+}
+
+// Ref provides a representation of the error.
+func (e *ErrInvalidRoleName) Ref() string {
+	return "organizations.invalid_role_name"
+}
+
+// ErrInvalidPermission is thrown when a role is given an unknown permission.
+type ErrInvalidPermission struct {
+}
+
+// NewErrInvalidPermission creates and returns a ErrInvalidPermission.
+func NewErrInvalidPermission() error {
+	return &ErrInvalidPermission{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrInvalidPermission) Error() string {
+	return "unknown permission"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrInvalidPermission) Ref() string {
+	return "organizations.invalid_permission"
+}
+
+// ErrMemberNotFound is thrown when a user is not a member of an organization.
+type ErrMemberNotFound struct {
+}
+
+// NewErrMemberNotFound creates and returns a ErrMemberNotFound.
+func NewErrMemberNotFound() error {
+	return &ErrMemberNotFound{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrMemberNotFound) Error() string {
+	return "member not found"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrMemberNotFound) Ref() string {
+	return "organizations.member_not_found"
+}
+
+// ErrInvalidPermissionsFlag is thrown when a member is given a permissions flag which can not be assigned.
+type ErrInvalidPermissionsFlag struct {
+}
+
+// NewErrInvalidPermissionsFlag creates and returns a ErrInvalidPermissionsFlag.
+func NewErrInvalidPermissionsFlag() error {
+	return &ErrInvalidPermissionsFlag{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrInvalidPermissionsFlag) Error() string {
+	return "invalid permissions flag"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrInvalidPermissionsFlag) Ref() string {
+	return "organizations.invalid_permissions_flag"
+}
+
+// ErrCannotManageMember is thrown when a user tries to change or remove a member whose permissions are not lower than their own.
+type ErrCannotManageMember struct {
+}
+
+// NewErrCannotManageMember creates and returns a ErrCannotManageMember.
+func NewErrCannotManageMember() error {
+	return &ErrCannotManageMember{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrCannotManageMember) Error() string {
+	return "you can not manage a member with equal or higher permissions"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrCannotManageMember) Ref() string {
+	return "organizations.cannot_manage_member"
+}
+
+// ErrCreatorCannotLeave is thrown when the creator of an organization tries to leave it without transferring ownership first.
+type ErrCreatorCannotLeave struct {
+}
+
+// NewErrCreatorCannotLeave creates and returns a ErrCreatorCannotLeave.
+func NewErrCreatorCannotLeave() error {
+	return &ErrCreatorCannotLeave{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrCreatorCannotLeave) Error() string {
+	return "the creator must transfer ownership before leaving the organization"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrCreatorCannotLeave) Ref() string {
+	return "organizations.creator_cannot_leave"
+}
+
+// ErrInvalidTransferRecipient is thrown when ownership is transferred to a user who can not receive it.
+type ErrInvalidTransferRecipient struct {
+}
+
+// NewErrInvalidTransferRecipient creates and returns a ErrInvalidTransferRecipient.
+func NewErrInvalidTransferRecipient() error {
+	return &ErrInvalidTransferRecipient{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrInvalidTransferRecipient) Error() string {
+	return "ownership can only be transferred to another member of the organization"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrInvalidTransferRecipient) Ref() string {
+	return "organizations.invalid_transfer_recipient"
+}
+
+// ErrTransferNotFound is thrown when no pending ownership transfer is found.
+type ErrTransferNotFound struct {
+}
+
+// NewErrTransferNotFound creates and returns a ErrTransferNotFound.
+func NewErrTransferNotFound() error {
+	return &ErrTransferNotFound{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrTransferNotFound) Error() string {
+	return "ownership transfer not found"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrTransferNotFound) Ref() string {
+	return "organizations.transfer_not_found"
+}
+
+// ErrVerificationNotFound is thrown when no verification request is found.
+type ErrVerificationNotFound struct {
+}
+
+// NewErrVerificationNotFound creates and returns a ErrVerificationNotFound.
+func NewErrVerificationNotFound() error {
+	return &ErrVerificationNotFound{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrVerificationNotFound) Error() string {
+	return "verification request not found"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrVerificationNotFound) Ref() string {
+	return "organizations.verification_not_found"
+}
+
+// ErrVerificationAlreadySubmitted is thrown when an organization which is verified or awaiting review requests verification.
+type ErrVerificationAlreadySubmitted struct {
+}
+
+// NewErrVerificationAlreadySubmitted creates and returns a ErrVerificationAlreadySubmitted.
+func NewErrVerificationAlreadySubmitted() error {
+	return &ErrVerificationAlreadySubmitted{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrVerificationAlreadySubmitted) Error() string {
+	return "organization is already verified or awaiting review"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrVerificationAlreadySubmitted) Ref() string {
+	return "organizations.verification_already_submitted"
+}
+
+// ErrInvalidRegistrationNumber is thrown when a verification request has an invalid registration number.
+type ErrInvalidRegistrationNumber struct {
+}
+
+// NewErrInvalidRegistrationNumber creates and returns a ErrInvalidRegistrationNumber.
+func NewErrInvalidRegistrationNumber() error {
+	return &ErrInvalidRegistrationNumber{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrInvalidRegistrationNumber) Error() string {
+	return "invalid registration number"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrInvalidRegistrationNumber) Ref() string {
+	return "organizations.invalid_registration_number"
+}
+
+// ErrInvalidVerificationDocuments is thrown when a verification request has too few, too many, or unsupported documents.
+type ErrInvalidVerificationDocuments struct {
+}
+
+// NewErrInvalidVerificationDocuments creates and returns a ErrInvalidVerificationDocuments.
+func NewErrInvalidVerificationDocuments() error {
+	return &ErrInvalidVerificationDocuments{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrInvalidVerificationDocuments) Error() string {
+	return "between 1 and 5 PDF, PNG or JPEG documents are required"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrInvalidVerificationDocuments) Ref() string {
+	return "organizations.invalid_verification_documents"
+}
+
+// ErrVerificationNotPending is thrown when a verification request which was already reviewed is reviewed.
+type ErrVerificationNotPending struct {
+}
+
+// NewErrVerificationNotPending creates and returns a ErrVerificationNotPending.
+func NewErrVerificationNotPending() error {
+	return &ErrVerificationNotPending{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrVerificationNotPending) Error() string {
+	return "verification request was already reviewed"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrVerificationNotPending) Ref() string {
+	return "organizations.verification_not_pending"
+}
+
+// ErrInvalidParentOrganization is thrown when an organization can't be made a chapter of a parent
+// organization, such as when the user does not own the parent or the hierarchy would form a cycle.
+type ErrInvalidParentOrganization struct {
+}
+
+// NewErrInvalidParentOrganization creates and returns a ErrInvalidParentOrganization.
+func NewErrInvalidParentOrganization() error {
+	return &ErrInvalidParentOrganization{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrInvalidParentOrganization) Error() string {
+	return "invalid parent organization"
+}
+
+// Ref provides a representation of the error.
+func (e *ErrInvalidParentOrganization) Ref() string {
+	return "organizations.invalid_parent_organization"
+}
@@ -0,0 +1,204 @@
+package organizations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/joinimpact/api/internal/email"
+	"github.com/joinimpact/api/internal/email/templates"
+	"github.com/joinimpact/api/internal/models"
+)
+
+// ownershipTransferLifespan is how long a member has to accept an ownership transfer.
+const ownershipTransferLifespan = 7 * 24 * time.Hour
+
+// canManage checks whether an actor may change or remove a member of an organization, and
+// returns the member's membership. The creator can never be managed, and only members with a
+// lower permissions flag than the actor's effective one can be.
+func (s *service) canManage(organizationID, actorID, userID int64) (*models.OrganizationMembership, int, error) {
+	actorFlag, err := s.GetOrganizationMembership(organizationID, actorID)
+	if err != nil {
+		return nil, 0, NewErrMemberNotFound()
+	}
+
+	membership, err := s.organizationMembershipRepository.FindUserInOrganization(organizationID, userID)
+	if err != nil {
+		return nil, 0, NewErrMemberNotFound()
+	}
+
+	if membership.PermissionsFlag >= models.OrganizationPermissionsCreator || membership.PermissionsFlag >= actorFlag {
+		return nil, 0, NewErrCannotManageMember()
+	}
+
+	return membership, actorFlag, nil
+}
+
+// UpdateMemberPermissions changes the permissions flag of a member of an organization. Members can
+// be promoted up to the actor's own level, but never to creator, which requires a transfer.
+func (s *service) UpdateMemberPermissions(ctx context.Context, organizationID, actorID, userID int64, permissionsFlag int) error {
+	if permissionsFlag != models.OrganizationPermissionsMember && permissionsFlag != models.OrganizationPermissionsOwner {
+		return NewErrInvalidPermissionsFlag()
+	}
+
+	membership, actorFlag, err := s.canManage(organizationID, actorID, userID)
+	if err != nil {
+		return err
+	}
+
+	if permissionsFlag > actorFlag {
+		return NewErrCannotManageMember()
+	}
+
+	if err := s.organizationMembershipRepository.UpdatePermissionsFlag(membership.ID, permissionsFlag); err != nil {
+		s.logger.Error().Err(err).Msg("Error updating membership permissions")
+		return NewErrServerError()
+	}
+
+	return nil
+}
+
+// RemoveMember removes a member from an organization.
+func (s *service) RemoveMember(ctx context.Context, organizationID, actorID, userID int64) error {
+	membership, _, err := s.canManage(organizationID, actorID, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.organizationMembershipRepository.DeleteByID(membership.ID); err != nil {
+		s.logger.Error().Err(err).Msg("Error removing member")
+		return NewErrServerError()
+	}
+
+	return nil
+}
+
+// LeaveOrganization removes a user from an organization they are a member of. The creator has to
+// transfer ownership first, so that the organization is never left without an owner.
+func (s *service) LeaveOrganization(ctx context.Context, organizationID, userID int64) error {
+	membership, err := s.organizationMembershipRepository.FindUserInOrganization(organizationID, userID)
+	if err != nil {
+		return NewErrMemberNotFound()
+	}
+
+	if membership.PermissionsFlag >= models.OrganizationPermissionsCreator {
+		return NewErrCreatorCannotLeave()
+	}
+
+	if err := s.organizationMembershipRepository.DeleteByID(membership.ID); err != nil {
+		s.logger.Error().Err(err).Msg("Error leaving organization")
+		return NewErrServerError()
+	}
+
+	return nil
+}
+
+// RequestOwnershipTransfer asks a member of an organization to take it over from its creator.
+// Any previous pending transfer is replaced.
+func (s *service) RequestOwnershipTransfer(ctx context.Context, organizationID, creatorID, toUserID int64) error {
+	organization, err := s.organizationRepository.FindByID(organizationID)
+	if err != nil {
+		return NewErrOrganizationNotFound()
+	}
+
+	if toUserID == creatorID {
+		return NewErrInvalidTransferRecipient()
+	}
+
+	if _, err := s.organizationMembershipRepository.FindUserInOrganization(organizationID, toUserID); err != nil {
+		return NewErrInvalidTransferRecipient()
+	}
+
+	creator, err := s.userRepository.FindByID(creatorID)
+	if err != nil {
+		return NewErrUserNotFound()
+	}
+
+	recipient, err := s.userRepository.FindByID(toUserID)
+	if err != nil {
+		return NewErrInvalidTransferRecipient()
+	}
+
+	if err := s.organizationOwnershipTransferRepository.DeleteByOrganizationID(ctx, organizationID); err != nil {
+		return NewErrServerError()
+	}
+
+	transfer := models.OrganizationOwnershipTransfer{
+		OrganizationID: organizationID,
+		FromUserID:     creatorID,
+		ToUserID:       toUserID,
+		ExpiresAt:      time.Now().Add(ownershipTransferLifespan),
+	}
+	transfer.ID = s.snowflakeService.GenerateID()
+
+	if err := s.organizationOwnershipTransferRepository.Create(ctx, transfer); err != nil {
+		s.logger.Error().Err(err).Msg("Error creating ownership transfer")
+		return NewErrServerError()
+	}
+
+	email := s.emailService.NewEmail(
+		email.NewRecipient(fmt.Sprintf("%s %s", recipient.FirstName, recipient.LastName), recipient.Email),
+		fmt.Sprintf("Take over %s on Impact", organization.Name),
+		templates.OwnershipTransferTemplate(recipient.FirstName, organization.Name, organization.ID, fmt.Sprintf("%s %s", creator.FirstName, creator.LastName)),
+	)
+	if err := s.emailService.Send(email); err != nil {
+		s.logger.Error().Err(err).Msg("Error sending ownership transfer email")
+	}
+
+	return nil
+}
+
+// pendingTransfer finds the pending ownership transfer of an organization.
+func (s *service) pendingTransfer(ctx context.Context, organizationID int64) (*models.OrganizationOwnershipTransfer, error) {
+	transfer, err := s.organizationOwnershipTransferRepository.FindByOrganizationID(ctx, organizationID)
+	if err != nil || time.Now().After(transfer.ExpiresAt) {
+		return nil, NewErrTransferNotFound()
+	}
+
+	return transfer, nil
+}
+
+// AcceptOwnershipTransfer accepts a pending ownership transfer as its recipient, who becomes the
+// organization's creator while the previous creator becomes an owner.
+func (s *service) AcceptOwnershipTransfer(ctx context.Context, organizationID, userID int64) error {
+	transfer, err := s.pendingTransfer(ctx, organizationID)
+	if err != nil || transfer.ToUserID != userID {
+		return NewErrTransferNotFound()
+	}
+
+	// Both users have to still hold their memberships.
+	from, err := s.organizationMembershipRepository.FindUserInOrganization(organizationID, transfer.FromUserID)
+	if err != nil || from.PermissionsFlag != models.OrganizationPermissionsCreator {
+		return NewErrTransferNotFound()
+	}
+
+	if _, err := s.organizationMembershipRepository.FindUserInOrganization(organizationID, userID); err != nil {
+		return NewErrTransferNotFound()
+	}
+
+	if err := s.organizationRepository.TransferOwnership(organizationID, transfer.FromUserID, userID); err != nil {
+		s.logger.Error().Err(err).Msg("Error transferring organization ownership")
+		return NewErrServerError()
+	}
+
+	if err := s.organizationOwnershipTransferRepository.DeleteByOrganizationID(ctx, organizationID); err != nil {
+		s.logger.Error().Err(err).Msg("Error deleting accepted ownership transfer")
+	}
+
+	return nil
+}
+
+// DeclineOwnershipTransfer declines a pending ownership transfer as its recipient, or cancels it
+// as its sender.
+func (s *service) DeclineOwnershipTransfer(ctx context.Context, organizationID, userID int64) error {
+	transfer, err := s.pendingTransfer(ctx, organizationID)
+	if err != nil || (transfer.ToUserID != userID && transfer.FromUserID != userID) {
+		return NewErrTransferNotFound()
+	}
+
+	if err := s.organizationOwnershipTransferRepository.DeleteByOrganizationID(ctx, organizationID); err != nil {
+		return NewErrServerError()
+	}
+
+	return nil
+}
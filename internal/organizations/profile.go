@@ -7,13 +7,23 @@ import (
 
 // OrganizationProfile represents an organization's profile.
 type OrganizationProfile struct {
-	ID             int64                             `json:"id"`
-	CreatorID      int64                             `json:"creatorId" scope:"collaborator"` // the organization's creator's ID (User)
-	Name           string                            `json:"name"`                           // the organization's name
-	Description    string                            `json:"description"`                    // a description of the organization
-	ProfilePicture string                            `json:"profilePicture,omitempty"`       // the url for the organization's profile picture
-	WebsiteURL     string                            `json:"websiteURL"`                     // the organization's website's URL
-	Tags           []models.Tag                      `json:"tags"`                           // the model's tags
-	Location       *location.Location                `json:"location,omitempty"`             // the location of the organization
-	ProfileFields  []models.OrganizationProfileField `json:"profile"`                        // fields of the organization's profile
+	ID                 int64                             `json:"id"`
+	CreatorID          int64                             `json:"creatorId" scope:"collaborator"`        // the organization's creator's ID (User)
+	Name               string                            `json:"name"`                                  // the organization's name
+	Description        string                            `json:"description"`                           // a description of the organization
+	ProfilePicture     string                            `json:"profilePicture,omitempty"`              // the url for the organization's profile picture
+	WebsiteURL         string                            `json:"websiteURL"`                            // the organization's website's URL
+	Tags               []models.Tag                      `json:"tags"`                                  // the model's tags
+	Location           *location.Location                `json:"location,omitempty"`                    // the location of the organization
+	ProfileFields      []models.OrganizationProfileField `json:"profile"`                               // fields of the organization's profile
+	RequireTwoFactor   bool                              `json:"requireTwoFactor" scope:"collaborator"` // whether owners must have two-factor authentication enabled
+	Parent             *OrganizationSummary              `json:"parent,omitempty"`                      // the parent organization, if the organization is a chapter
+	VerificationStatus string                            `json:"verificationStatus"`                    // whether the organization is verified as a legitimate nonprofit
+}
+
+// OrganizationSummary represents a short summary of an organization, such as the parent of a chapter.
+type OrganizationSummary struct {
+	ID             int64  `json:"id"`
+	Name           string `json:"name"`
+	ProfilePicture string `json:"profilePicture,omitempty"`
 }
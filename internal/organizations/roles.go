@@ -0,0 +1,166 @@
+package organizations
+
+import (
+	"context"
+	"strings"
+
+	"github.com/joinimpact/api/internal/models"
+)
+
+// maxRoleNameLength is the maximum length of the name of an organization role.
+const maxRoleNameLength = 48
+
+// normalizeRole validates a role's name and permissions, and returns them trimmed and
+// without duplicates.
+func normalizeRole(name string, permissions []string) (string, []string, error) {
+	name = strings.TrimSpace(name)
+	if len(name) < 1 || len(name) > maxRoleNameLength {
+		return "", nil, NewErrInvalidRoleName()
+	}
+
+	seen := map[string]bool{}
+	normalized := []string{}
+	for _, permission := range permissions {
+		if !models.IsOrganizationPermission(permission) {
+			return "", nil, NewErrInvalidPermission()
+		}
+
+		if seen[permission] {
+			continue
+		}
+		seen[permission] = true
+		normalized = append(normalized, permission)
+	}
+
+	return name, normalized, nil
+}
+
+// GetRoles gets all custom roles of an organization.
+func (s *service) GetRoles(ctx context.Context, organizationID int64) ([]models.OrganizationRole, error) {
+	roles, err := s.organizationRoleRepository.FindByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return nil, NewErrServerError()
+	}
+
+	return roles, nil
+}
+
+// CreateRole creates a new role in an organization with a set of permissions.
+func (s *service) CreateRole(ctx context.Context, organizationID int64, name string, permissions []string) (*models.OrganizationRole, error) {
+	name, permissions, err := normalizeRole(name, permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.organizationRepository.FindByID(organizationID); err != nil {
+		return nil, NewErrOrganizationNotFound()
+	}
+
+	role := models.OrganizationRole{
+		OrganizationID: organizationID,
+		Name:           name,
+		Permissions:    permissions,
+	}
+	role.ID = s.snowflakeService.GenerateID()
+
+	if err := s.organizationRoleRepository.Create(ctx, role); err != nil {
+		s.logger.Error().Err(err).Msg("Error creating organization role")
+		return nil, NewErrServerError()
+	}
+
+	return &role, nil
+}
+
+// UpdateRole replaces the name and permissions of an organization's role.
+func (s *service) UpdateRole(ctx context.Context, organizationID, roleID int64, name string, permissions []string) (*models.OrganizationRole, error) {
+	name, permissions, err := normalizeRole(name, permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := s.organizationRoleRepository.FindByID(ctx, roleID)
+	if err != nil || role.OrganizationID != organizationID {
+		return nil, NewErrRoleNotFound()
+	}
+
+	role.Name = name
+	role.Permissions = permissions
+
+	if err := s.organizationRoleRepository.Save(ctx, *role); err != nil {
+		s.logger.Error().Err(err).Msg("Error saving organization role")
+		return nil, NewErrServerError()
+	}
+
+	return role, nil
+}
+
+// DeleteRole deletes an organization's role. Members who had the role fall back to the
+// default member permissions.
+func (s *service) DeleteRole(ctx context.Context, organizationID, roleID int64) error {
+	role, err := s.organizationRoleRepository.FindByID(ctx, roleID)
+	if err != nil || role.OrganizationID != organizationID {
+		return NewErrRoleNotFound()
+	}
+
+	if err := s.organizationMembershipRepository.ClearRoleID(role.ID); err != nil {
+		return NewErrServerError()
+	}
+
+	if err := s.organizationRoleRepository.DeleteByID(ctx, role.ID); err != nil {
+		return NewErrServerError()
+	}
+
+	return nil
+}
+
+// SetMemberRole gives a member of an organization a role, or removes their role if roleID is 0.
+func (s *service) SetMemberRole(ctx context.Context, organizationID, userID, roleID int64) error {
+	membership, err := s.organizationMembershipRepository.FindUserInOrganization(organizationID, userID)
+	if err != nil {
+		return NewErrMemberNotFound()
+	}
+
+	if roleID != 0 {
+		role, err := s.organizationRoleRepository.FindByID(ctx, roleID)
+		if err != nil || role.OrganizationID != organizationID {
+			return NewErrRoleNotFound()
+		}
+	}
+
+	if err := s.organizationMembershipRepository.UpdateRoleID(membership.ID, roleID); err != nil {
+		return NewErrServerError()
+	}
+
+	return nil
+}
+
+// GetOrganizationPermissions returns the names of the permissions a user holds in an organization.
+// Owners and creators hold every permission, members hold the permissions of their role, or the
+// default member permissions if they have none. Returns an error if no membership is found.
+func (s *service) GetOrganizationPermissions(ctx context.Context, organizationID, userID int64) ([]string, error) {
+	flag, err := s.GetOrganizationMembership(organizationID, userID)
+	if err != nil {
+		return nil, NewErrMemberNotFound()
+	}
+
+	if flag >= models.OrganizationPermissionsOwner {
+		return models.OrganizationPermissions, nil
+	}
+
+	membership, err := s.organizationMembershipRepository.FindUserInOrganization(organizationID, userID)
+	if err != nil {
+		// Owners of a parent organization manage its chapters without being members of them.
+		return models.DefaultMemberPermissions, nil
+	}
+
+	if membership.RoleID == 0 {
+		return models.DefaultMemberPermissions, nil
+	}
+
+	role, err := s.organizationRoleRepository.FindByID(ctx, membership.RoleID)
+	if err != nil {
+		return models.DefaultMemberPermissions, nil
+	}
+
+	return role.Permissions, nil
+}
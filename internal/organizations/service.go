@@ -11,8 +11,11 @@ import (
 	"github.com/joinimpact/api/internal/email"
 	"github.com/joinimpact/api/internal/email/templates"
 	"github.com/joinimpact/api/internal/models"
+	organizationsSearch "github.com/joinimpact/api/internal/search/stores/organizations"
 	"github.com/joinimpact/api/internal/snowflakes"
+	"github.com/joinimpact/api/pkg/dbctx"
 	"github.com/joinimpact/api/pkg/location"
+	"github.com/joinimpact/api/pkg/scopes"
 	"github.com/rs/zerolog"
 )
 
@@ -27,6 +30,15 @@ type Service interface {
 	UpdateOrganizationProfile(organizationID int64, profile OrganizationProfile) error
 	// UpdateOrganizationLocation updates an organization's location.
 	UpdateOrganizationLocation(organizationID int64, location *location.Coordinates) error
+	// SetParentOrganization makes an organization a chapter of a parent organization, or removes it
+	// from its parent if parentID is 0.
+	SetParentOrganization(ctx context.Context, organizationID, parentID, userID int64) error
+	// GetOrganizationTreeIDs returns the ID of an organization followed by the IDs of all of its chapters.
+	GetOrganizationTreeIDs(ctx context.Context, organizationID int64) ([]int64, error)
+	// GetChapters gets the chapters directly below an organization, sorted by distance from a location.
+	GetChapters(ctx context.Context, organizationID int64, from *location.Coordinates) ([]Chapter, error)
+	// Search searches organizations by a query struct.
+	Search(ctx context.Context, query organizationsSearch.Query) (*SearchResponse, error)
 	// SetOrganizationProfileField sets an organization's profile field by name.
 	SetOrganizationProfileField(organizationID int64, profileField models.OrganizationProfileField) error
 	// CreateOrganization creates a new organization and returns the ID on success.
@@ -47,37 +59,90 @@ type Service interface {
 	GetOrganizationInvitedVolunteers(ctx context.Context, organizationID int64) ([]models.OrganizationMembershipInvite, error)
 	// GetOrganizationMemberships gets all users in an organization.
 	GetOrganizationMemberships(organizationID int64) ([]models.OrganizationMembership, error)
-	// GetOrganizationMembership returns the membership level of a user in an organization.
+	// GetOrganizationMembership returns the effective membership level of a user in an organization.
 	// Returns an error if no membership is found.
 	GetOrganizationMembership(organizationID, userID int64) (int, error)
+	// SetTwoFactorRequirement sets whether owners of an organization must have two-factor authentication enabled.
+	SetTwoFactorRequirement(ctx context.Context, organizationID, userID int64, required bool) error
 	// GetOrganizationFromInvite gets an organization profile from an invite for UI use.
 	GetOrganizationFromInvite(ctx context.Context, organizationID int64, userID, inviteID int64, inviteKey string) (*OrganizationProfile, error)
 	// AcceptInvite accepts an invite.
 	AcceptInvite(ctx context.Context, organizationID int64, userID, inviteID int64, inviteKey string) error
 	// DeclineInvite declines an invite.
 	DeclineInvite(ctx context.Context, organizationID int64, userID, inviteID int64, inviteKey string) error
+	// CreateAPIKey creates a new API key for an organization, and returns it along with the key
+	// itself, which can not be retrieved again.
+	CreateAPIKey(ctx context.Context, organizationID, creatorID int64, name string, scope scopes.Scope) (*APIKey, string, error)
+	// GetAPIKeys gets all API keys of an organization.
+	GetAPIKeys(ctx context.Context, organizationID int64) ([]APIKey, error)
+	// RevokeAPIKey revokes an organization's API key by ID.
+	RevokeAPIKey(ctx context.Context, organizationID, apiKeyID int64) error
+	// GetRoles gets all custom roles of an organization.
+	GetRoles(ctx context.Context, organizationID int64) ([]models.OrganizationRole, error)
+	// CreateRole creates a new role in an organization with a set of permissions.
+	CreateRole(ctx context.Context, organizationID int64, name string, permissions []string) (*models.OrganizationRole, error)
+	// UpdateRole replaces the name and permissions of an organization's role.
+	UpdateRole(ctx context.Context, organizationID, roleID int64, name string, permissions []string) (*models.OrganizationRole, error)
+	// DeleteRole deletes an organization's role.
+	DeleteRole(ctx context.Context, organizationID, roleID int64) error
+	// SetMemberRole gives a member of an organization a role, or removes their role if roleID is 0.
+	SetMemberRole(ctx context.Context, organizationID, userID, roleID int64) error
+	// GetOrganizationPermissions returns the names of the permissions a user holds in an organization.
+	GetOrganizationPermissions(ctx context.Context, organizationID, userID int64) ([]string, error)
+	// UpdateMemberPermissions changes the permissions flag of a member of an organization.
+	UpdateMemberPermissions(ctx context.Context, organizationID, actorID, userID int64, permissionsFlag int) error
+	// RemoveMember removes a member from an organization.
+	RemoveMember(ctx context.Context, organizationID, actorID, userID int64) error
+	// LeaveOrganization removes a user from an organization they are a member of.
+	LeaveOrganization(ctx context.Context, organizationID, userID int64) error
+	// RequestOwnershipTransfer asks a member of an organization to take it over from its creator.
+	RequestOwnershipTransfer(ctx context.Context, organizationID, creatorID, toUserID int64) error
+	// AcceptOwnershipTransfer accepts a pending ownership transfer as its recipient.
+	AcceptOwnershipTransfer(ctx context.Context, organizationID, userID int64) error
+	// DeclineOwnershipTransfer declines a pending ownership transfer as its recipient, or cancels it
+	// as its sender.
+	DeclineOwnershipTransfer(ctx context.Context, organizationID, userID int64) error
+	// SubmitVerification submits a request for an organization to be verified.
+	SubmitVerification(ctx context.Context, organizationID, submitterID int64, registrationNumber string, documents []VerificationDocument) (*models.OrganizationVerification, error)
+	// GetVerification gets the latest verification request of an organization.
+	GetVerification(ctx context.Context, organizationID int64) (*models.OrganizationVerification, error)
+	// GetPendingVerifications gets the verification requests awaiting review, oldest first.
+	GetPendingVerifications(ctx context.Context) ([]models.OrganizationVerification, error)
+	// GetVerificationReview gets a verification request by ID along with links to its documents.
+	GetVerificationReview(ctx context.Context, verificationID int64) (*VerificationReview, error)
+	// ReviewVerification approves or rejects a pending verification request.
+	ReviewVerification(ctx context.Context, verificationID, reviewerID int64, approve bool, note string) (*models.OrganizationVerification, error)
 }
 
 // service represents the internal implementation of the organizations Service.
 type service struct {
-	organizationRepository                 models.OrganizationRepository
-	organizationMembershipRepository       models.OrganizationMembershipRepository
-	organizationMembershipInviteRepository models.OrganizationMembershipInviteRepository
-	organizationProfileFieldRepository     models.OrganizationProfileFieldRepository
-	organizationTagRepository              models.OrganizationTagRepository
-	userRepository                         models.UserRepository
-	tagRepository                          models.TagRepository
-	config                                 *config.Config
-	logger                                 *zerolog.Logger
-	snowflakeService                       snowflakes.SnowflakeService
-	emailService                           email.Service
-	cdnClient                              *cdn.Client
-	locationService                        location.Service
+	organizationRepository                  models.OrganizationRepository
+	organizationMembershipRepository        models.OrganizationMembershipRepository
+	organizationMembershipInviteRepository  models.OrganizationMembershipInviteRepository
+	organizationProfileFieldRepository      models.OrganizationProfileFieldRepository
+	organizationTagRepository               models.OrganizationTagRepository
+	userRepository                          models.UserRepository
+	tagRepository                           models.TagRepository
+	twoFactorCredentialRepository           models.TwoFactorCredentialRepository
+	organizationAPIKeyRepository            models.OrganizationAPIKeyRepository
+	organizationRoleRepository              models.OrganizationRoleRepository
+	organizationOwnershipTransferRepository models.OrganizationOwnershipTransferRepository
+	organizationVerificationRepository      models.OrganizationVerificationRepository
+	config                                  *config.Config
+	logger                                  *zerolog.Logger
+	snowflakeService                        snowflakes.SnowflakeService
+	emailService                            email.Service
+	cdnClient                               *cdn.Client
+	searchStore                             organizationsSearch.Store
+	locationService                         location.Service
 }
 
 // NewService creates and returns a new Users service with the provifded dependencies.
 func NewService(organizationRepository models.OrganizationRepository, organizationMembershipRepository models.OrganizationMembershipRepository, organizationMembershipInviteRepository models.OrganizationMembershipInviteRepository, organizationProfileFieldRepository models.OrganizationProfileFieldRepository, organizationTagRepository models.OrganizationTagRepository,
-	userRepository models.UserRepository, tagRepository models.TagRepository, config *config.Config, logger *zerolog.Logger, snowflakeService snowflakes.SnowflakeService, emailService email.Service, locationService location.Service) Service {
+	userRepository models.UserRepository, tagRepository models.TagRepository, twoFactorCredentialRepository models.TwoFactorCredentialRepository,
+	organizationAPIKeyRepository models.OrganizationAPIKeyRepository, organizationRoleRepository models.OrganizationRoleRepository,
+	organizationOwnershipTransferRepository models.OrganizationOwnershipTransferRepository,
+	organizationVerificationRepository models.OrganizationVerificationRepository, config *config.Config, logger *zerolog.Logger, snowflakeService snowflakes.SnowflakeService, emailService email.Service, searchStore organizationsSearch.Store, locationService location.Service) Service {
 	return &service{
 		organizationRepository,
 		organizationMembershipRepository,
@@ -86,11 +151,17 @@ func NewService(organizationRepository models.OrganizationRepository, organizati
 		organizationTagRepository,
 		userRepository,
 		tagRepository,
+		twoFactorCredentialRepository,
+		organizationAPIKeyRepository,
+		organizationRoleRepository,
+		organizationOwnershipTransferRepository,
+		organizationVerificationRepository,
 		config,
 		logger,
 		snowflakeService,
 		emailService,
 		cdn.NewCDNClient(config),
+		searchStore,
 		locationService,
 	}
 }
@@ -141,6 +212,11 @@ func (s *service) organizationToProfile(organization *models.Organization) *Orga
 	profile.Description = organization.Description
 	profile.ProfilePicture = organization.ProfilePicture
 	profile.WebsiteURL = organization.WebsiteURL
+	profile.RequireTwoFactor = organization.RequireTwoFactor != nil && *organization.RequireTwoFactor
+	profile.VerificationStatus = organization.VerificationStatus
+	if profile.VerificationStatus == "" {
+		profile.VerificationStatus = models.VerificationStatusUnverified
+	}
 
 	return profile
 }
@@ -162,6 +238,18 @@ func (s *service) GetOrganizationProfile(organizationID int64) (*OrganizationPro
 
 	profile.Tags = tags
 
+	// Link chapters to their parent organization.
+	if organization.ParentID != 0 {
+		parent, err := s.organizationRepository.FindByID(organization.ParentID)
+		if err == nil {
+			profile.Parent = &OrganizationSummary{
+				ID:             parent.ID,
+				Name:           parent.Name,
+				ProfilePicture: parent.ProfilePicture,
+			}
+		}
+	}
+
 	// Location
 	if organization.LocationLatitude != 0.0 || organization.LocationLongitude != 0.0 {
 		coordinates := &location.Coordinates{
@@ -189,25 +277,37 @@ func (s *service) GetOrganizationProfile(organizationID int64) (*OrganizationPro
 
 // UpdateOrganizationProfile updates a user's profile.
 func (s *service) UpdateOrganizationProfile(organizationID int64, profile OrganizationProfile) error {
-	return s.organizationRepository.Update(models.Organization{
+	if err := s.organizationRepository.Update(models.Organization{
 		Model: models.Model{
 			ID: organizationID,
 		},
 		Name:        profile.Name,
 		Description: profile.Description,
 		WebsiteURL:  formatURL(profile.WebsiteURL),
-	})
+	}); err != nil {
+		return err
+	}
+
+	s.searchStore.Save(organizationID)
+
+	return nil
 }
 
 // UpdateOrganizationLocation updates an organization's location.
 func (s *service) UpdateOrganizationLocation(organizationID int64, location *location.Coordinates) error {
-	return s.organizationRepository.Update(models.Organization{
+	if err := s.organizationRepository.Update(models.Organization{
 		Model: models.Model{
 			ID: organizationID,
 		},
 		LocationLatitude:  location.Latitude,
 		LocationLongitude: location.Longitude,
-	})
+	}); err != nil {
+		return err
+	}
+
+	s.searchStore.Save(organizationID)
+
+	return nil
 }
 
 // SetOrganizationProfileField sets an organization's profile field by name.
@@ -263,6 +363,8 @@ func (s *service) CreateOrganization(organization models.Organization) (int64, e
 		return 0, NewErrServerError()
 	}
 
+	s.searchStore.Save(organization.ID)
+
 	return organization.ID, nil
 }
 
@@ -273,6 +375,8 @@ func (s *service) DeleteOrganization(id int64) error {
 		return NewErrOrganizationNotFound()
 	}
 
+	s.searchStore.Save(id)
+
 	return nil
 }
 
@@ -349,6 +453,8 @@ func (s *service) AddOrganizationTags(organizationID int64, tags []string) (int,
 		}
 	}
 
+	s.searchStore.Save(organizationID)
+
 	return successfulTags, nil
 }
 
@@ -359,7 +465,13 @@ func (s *service) RemoveOrganizationTag(organizationID, tagID int64) error {
 		return NewErrTagNotFound()
 	}
 
-	return s.organizationTagRepository.DeleteByID(organizationTag.ID)
+	if err := s.organizationTagRepository.DeleteByID(organizationTag.ID); err != nil {
+		return err
+	}
+
+	s.searchStore.Save(organizationID)
+
+	return nil
 }
 
 // UploadProfilePicture uploads a profile picture to the CDN and adds it to the user.
@@ -483,17 +595,82 @@ func (s *service) GetOrganizationMemberships(organizationID int64) ([]models.Org
 	return memberships, nil
 }
 
-// GetOrganizationMembership returns the membership level of a user in an organization.
+// GetOrganizationMembership returns the effective membership level of a user in an organization.
+// If the organization requires two-factor authentication, owners without it enabled are treated
+// as regular members, and owners of a parent organization are treated as members of its chapters.
 // Returns an error if no membership is found.
 func (s *service) GetOrganizationMembership(organizationID, userID int64) (int, error) {
+	flag, err := s.directMembership(organizationID, userID)
+	if err != nil {
+		if s.isParentAdmin(organizationID, userID) {
+			return models.OrganizationPermissionsMember, nil
+		}
+
+		return 0, err
+	}
+
+	return flag, nil
+}
+
+// directMembership returns the effective membership level of a user in an organization, without
+// taking parent organizations into account.
+func (s *service) directMembership(organizationID, userID int64) (int, error) {
 	m, err := s.organizationMembershipRepository.FindUserInOrganization(organizationID, userID)
 	if err != nil {
 		return 0, err
 	}
 
+	if m.PermissionsFlag < models.OrganizationPermissionsOwner {
+		return m.PermissionsFlag, nil
+	}
+
+	organization, err := s.organizationRepository.FindByID(organizationID)
+	if err != nil {
+		return 0, err
+	}
+
+	if organization.RequireTwoFactor != nil && *organization.RequireTwoFactor && !s.hasTwoFactor(context.Background(), userID) {
+		return models.OrganizationPermissionsMember, nil
+	}
+
 	return m.PermissionsFlag, nil
 }
 
+// hasTwoFactor checks if a user has confirmed two-factor authentication.
+func (s *service) hasTwoFactor(ctx context.Context, userID int64) bool {
+	credential, err := s.twoFactorCredentialRepository.FindByUserID(ctx, userID)
+	if err != nil {
+		return false
+	}
+
+	return credential.Confirmed
+}
+
+// SetTwoFactorRequirement sets whether owners of an organization must have two-factor
+// authentication enabled. The user making the change must have it enabled themselves,
+// so that they don't lose access to the organization's settings.
+func (s *service) SetTwoFactorRequirement(ctx context.Context, organizationID, userID int64, required bool) error {
+	if _, err := s.organizationRepository.FindByID(organizationID); err != nil {
+		return NewErrOrganizationNotFound()
+	}
+
+	if required && !s.hasTwoFactor(ctx, userID) {
+		return NewErrTwoFactorNotEnabled()
+	}
+
+	if err := s.organizationRepository.Update(models.Organization{
+		Model: models.Model{
+			ID: organizationID,
+		},
+		RequireTwoFactor: &required,
+	}); err != nil {
+		s.logger.Error().Err(err).Msg("Error updating organization")
+		return NewErrServerError()
+	}
+
+	return nil
+}
+
 // GetOrganizationFromInvite gets an organization profile from an invite for UI use.
 func (s *service) GetOrganizationFromInvite(ctx context.Context, organizationID int64, userID, inviteID int64, inviteKey string) (*OrganizationProfile, error) {
 	// Get the invite by ID.
@@ -604,3 +781,40 @@ func (s *service) invalidateInvite(ctx context.Context, inviteID int64) error {
 func (s *service) GetOrganizationVolunteers(ctx context.Context, organizationID int64) error {
 	return nil
 }
+
+// SearchResponse represents a response to the Search method.
+type SearchResponse struct {
+	TotalResults  uint
+	Pages         uint
+	Organizations []OrganizationProfile
+}
+
+// Search searches organizations by a query struct.
+func (s *service) Search(ctx context.Context, query organizationsSearch.Query) (*SearchResponse, error) {
+	dbc := dbctx.Get(ctx)
+	query.Page = uint(dbc.Page)
+	query.Limit = uint(dbc.Limit)
+
+	profiles := []OrganizationProfile{}
+
+	search, err := s.searchStore.Search(query)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error searching organizations")
+		return nil, NewErrServerError()
+	}
+
+	for _, item := range search.Documents {
+		profile, err := s.GetOrganizationProfile(item.ID)
+		if err != nil {
+			continue
+		}
+
+		profiles = append(profiles, *profile)
+	}
+
+	return &SearchResponse{
+		TotalResults:  search.TotalResults,
+		Pages:         search.Pages,
+		Organizations: profiles,
+	}, nil
+}
@@ -0,0 +1,197 @@
+package organizations
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/joinimpact/api/internal/models"
+)
+
+// maxVerificationDocuments is the maximum number of documents which can be submitted with a verification request.
+const maxVerificationDocuments = 5
+
+// verificationDocumentLinkLifespan is how long links to verification documents stay valid for reviewers.
+const verificationDocumentLinkLifespan = 15 * time.Minute
+
+// verificationDocumentExtensions maps the supported content types of verification documents to file extensions.
+var verificationDocumentExtensions = map[string]string{
+	"application/pdf": "pdf",
+	"image/png":       "png",
+	"image/jpeg":      "jpg",
+}
+
+// VerificationDocument represents a supporting document uploaded with a verification request.
+type VerificationDocument struct {
+	ContentType string
+	Reader      io.Reader
+}
+
+// VerificationReview represents a verification request along with links to its documents.
+type VerificationReview struct {
+	models.OrganizationVerification
+	Organization *OrganizationProfile `json:"organization"`
+	Documents    []string             `json:"documents"`
+}
+
+// SubmitVerification submits a request for an organization to be verified, which is reviewed by
+// platform staff.
+func (s *service) SubmitVerification(ctx context.Context, organizationID, submitterID int64, registrationNumber string, documents []VerificationDocument) (*models.OrganizationVerification, error) {
+	organization, err := s.organizationRepository.FindByID(organizationID)
+	if err != nil {
+		return nil, NewErrOrganizationNotFound()
+	}
+
+	if organization.VerificationStatus == models.VerificationStatusPending || organization.VerificationStatus == models.VerificationStatusVerified {
+		return nil, NewErrVerificationAlreadySubmitted()
+	}
+
+	registrationNumber = strings.TrimSpace(registrationNumber)
+	if len(registrationNumber) < 1 || len(registrationNumber) > 64 {
+		return nil, NewErrInvalidRegistrationNumber()
+	}
+
+	if len(documents) < 1 || len(documents) > maxVerificationDocuments {
+		return nil, NewErrInvalidVerificationDocuments()
+	}
+	for _, document := range documents {
+		if _, ok := verificationDocumentExtensions[document.ContentType]; !ok {
+			return nil, NewErrInvalidVerificationDocuments()
+		}
+	}
+
+	verification := models.OrganizationVerification{
+		OrganizationID:     organizationID,
+		SubmitterID:        submitterID,
+		RegistrationNumber: registrationNumber,
+		Documents:          []string{},
+		Status:             models.VerificationStatusPending,
+		SubmittedAt:        time.Now().UTC(),
+	}
+	verification.ID = s.snowflakeService.GenerateID()
+
+	// Documents are private, and can only be downloaded by reviewers through expiring links.
+	for _, document := range documents {
+		key := fmt.Sprintf("verification-documents/%d/%d.%s", organizationID, s.snowflakeService.GenerateID(), verificationDocumentExtensions[document.ContentType])
+		if err := s.cdnClient.UploadPrivateFile(key, document.ContentType, document.Reader); err != nil {
+			s.logger.Error().Err(err).Msg("Error uploading verification document")
+			return nil, NewErrServerError()
+		}
+
+		verification.Documents = append(verification.Documents, key)
+	}
+
+	if err := s.organizationVerificationRepository.Create(ctx, verification); err != nil {
+		s.logger.Error().Err(err).Msg("Error creating verification request")
+		return nil, NewErrServerError()
+	}
+
+	if err := s.organizationRepository.Update(models.Organization{
+		Model: models.Model{
+			ID: organizationID,
+		},
+		VerificationStatus: models.VerificationStatusPending,
+	}); err != nil {
+		s.logger.Error().Err(err).Msg("Error updating organization verification status")
+		return nil, NewErrServerError()
+	}
+
+	s.searchStore.Save(organizationID)
+
+	return &verification, nil
+}
+
+// GetVerification gets the latest verification request of an organization.
+func (s *service) GetVerification(ctx context.Context, organizationID int64) (*models.OrganizationVerification, error) {
+	verification, err := s.organizationVerificationRepository.FindLatestByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return nil, NewErrVerificationNotFound()
+	}
+
+	return verification, nil
+}
+
+// GetPendingVerifications gets the verification requests awaiting review, oldest first.
+func (s *service) GetPendingVerifications(ctx context.Context) ([]models.OrganizationVerification, error) {
+	verifications, err := s.organizationVerificationRepository.FindByStatus(ctx, models.VerificationStatusPending)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error getting pending verification requests")
+		return nil, NewErrServerError()
+	}
+
+	return verifications, nil
+}
+
+// GetVerificationReview gets a verification request by ID along with links to its documents.
+func (s *service) GetVerificationReview(ctx context.Context, verificationID int64) (*VerificationReview, error) {
+	verification, err := s.organizationVerificationRepository.FindByID(ctx, verificationID)
+	if err != nil {
+		return nil, NewErrVerificationNotFound()
+	}
+
+	organization, err := s.organizationRepository.FindByID(verification.OrganizationID)
+	if err != nil {
+		return nil, NewErrOrganizationNotFound()
+	}
+
+	review := &VerificationReview{
+		OrganizationVerification: *verification,
+		Organization:             s.organizationToProfile(organization),
+		Documents:                []string{},
+	}
+
+	for _, key := range verification.Documents {
+		link, err := s.cdnClient.PresignedURL(key, verificationDocumentLinkLifespan)
+		if err != nil {
+			s.logger.Error().Err(err).Msg("Error creating verification document link")
+			return nil, NewErrServerError()
+		}
+
+		review.Documents = append(review.Documents, link)
+	}
+
+	return review, nil
+}
+
+// ReviewVerification approves or rejects a pending verification request, and updates the
+// verification status of its organization.
+func (s *service) ReviewVerification(ctx context.Context, verificationID, reviewerID int64, approve bool, note string) (*models.OrganizationVerification, error) {
+	verification, err := s.organizationVerificationRepository.FindByID(ctx, verificationID)
+	if err != nil {
+		return nil, NewErrVerificationNotFound()
+	}
+
+	if verification.Status != models.VerificationStatusPending {
+		return nil, NewErrVerificationNotPending()
+	}
+
+	now := time.Now().UTC()
+	verification.Status = models.VerificationStatusRejected
+	if approve {
+		verification.Status = models.VerificationStatusVerified
+	}
+	verification.ReviewerID = reviewerID
+	verification.ReviewNote = strings.TrimSpace(note)
+	verification.ReviewedAt = &now
+
+	if err := s.organizationVerificationRepository.Save(ctx, *verification); err != nil {
+		s.logger.Error().Err(err).Msg("Error saving verification review")
+		return nil, NewErrServerError()
+	}
+
+	if err := s.organizationRepository.Update(models.Organization{
+		Model: models.Model{
+			ID: verification.OrganizationID,
+		},
+		VerificationStatus: verification.Status,
+	}); err != nil {
+		s.logger.Error().Err(err).Msg("Error updating organization verification status")
+		return nil, NewErrServerError()
+	}
+
+	s.searchStore.Save(verification.OrganizationID)
+
+	return verification, nil
+}
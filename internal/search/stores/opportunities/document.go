@@ -22,8 +22,9 @@ type LocationDocument struct {
 // OpportunityOrganizationDocument has a summary of the organization for an
 // opportunity.
 type OpportunityOrganizationDocument struct {
-	ID   int64  `json:"id"`
-	Name string `json:"name"`
+	ID                 int64  `json:"id"`
+	Name               string `json:"name"`
+	VerificationStatus string `json:"verificationStatus"`
 }
 
 // OpportunityTagDocument represents an opportunity's tag in the Elasticsearch database.
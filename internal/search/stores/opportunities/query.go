@@ -14,6 +14,7 @@ type Query struct {
 	Location        *location.Coordinates `json:"location"`
 	AgeRange        *AgeRange             `json:"ageRange"`
 	CommitmentRange *CommitmentRange      `json:"commitmentRange"`
+	VerifiedOnly    bool                  `json:"verifiedOnly"`
 	Limit           uint                  `json:"-"`
 	Page            uint                  `json:"-"`
 }
@@ -38,6 +39,9 @@ func buildQuery(query Query) io.Reader {
 	if query.CommitmentRange != nil {
 		filters = append(filters, fmt.Sprintf(hoursFilter, query.CommitmentRange.Minimum, query.CommitmentRange.Maximum))
 	}
+	if query.VerifiedOnly {
+		filters = append(filters, verifiedFilter)
+	}
 	if len(filters) > 0 {
 		// Append a trailing comma when filters apply.
 		filters = append(filters, " ")
@@ -166,3 +170,11 @@ const hoursFilter = `
 	}
 }
 `
+
+const verifiedFilter = `
+{
+	"match": {
+		"organization.verificationStatus": "verified"
+	}
+}
+`
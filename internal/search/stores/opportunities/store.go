@@ -157,6 +157,7 @@ func (s *store) save(opportunityID int64) error {
 	organization, err := s.organizationRepository.FindByID(opportunity.OrganizationID)
 	if err == nil {
 		document.Organization.Name = organization.Name
+		document.Organization.VerificationStatus = organization.VerificationStatus
 		if organization.LocationLatitude != 0 || organization.LocationLongitude != 0 {
 			document.Location = &LocationDocument{}
 			document.Location.Latitude = organization.LocationLatitude
@@ -0,0 +1,24 @@
+package organizations
+
+// OrganizationDocument represents an organization as an Elasticsearch NoSQL document.
+type OrganizationDocument struct {
+	ID                 int64                     `json:"organizationId"`
+	Name               string                    `json:"name"`
+	Description        string                    `json:"description"`
+	Tags               []OrganizationTagDocument `json:"tags"`
+	Location           *LocationDocument         `json:"location,omitempty"`
+	VerificationStatus string                    `json:"verificationStatus"`
+}
+
+// LocationDocument contains a location.
+type LocationDocument struct {
+	Longitude float64 `json:"lon"`
+	Latitude  float64 `json:"lat"`
+}
+
+// OrganizationTagDocument represents an organization's tag in the Elasticsearch database.
+type OrganizationTagDocument struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	Category int    `json:"category"`
+}
@@ -0,0 +1,155 @@
+package organizations
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/joinimpact/api/pkg/location"
+)
+
+// defaultDistance is the default radius in kilometers of a location filter.
+const defaultDistance = 50
+
+// Query represents a query of organizations.
+type Query struct {
+	TextQuery    string                `json:"textQuery"`
+	Tags         []string              `json:"tags"`
+	Location     *location.Coordinates `json:"location"`
+	Distance     int                   `json:"distance"` // the radius around the location in kilometers
+	VerifiedOnly bool                  `json:"verifiedOnly"`
+	Limit        uint                  `json:"-"`
+	Page         uint                  `json:"-"`
+}
+
+// buildQuery builds an io.Reader with a json query from a Query struct.
+func buildQuery(query Query) io.Reader {
+	filters := []string{}
+	for _, tag := range query.Tags {
+		filters = append(filters, fmt.Sprintf(tagFilter, quote(tag)))
+	}
+	if query.VerifiedOnly {
+		filters = append(filters, verifiedFilter)
+	}
+
+	sort := ""
+	if query.Location != nil {
+		distance := query.Distance
+		if distance <= 0 {
+			distance = defaultDistance
+		}
+
+		filters = append(filters, fmt.Sprintf(locationFilter, distance, query.Location.Longitude, query.Location.Latitude))
+		sort = fmt.Sprintf(sortTemplate, query.Location.Longitude, query.Location.Latitude)
+	}
+
+	limit := uint(20)
+	if query.Limit > 0 && query.Limit <= 100 {
+		limit = query.Limit
+	}
+
+	limits := fmt.Sprintf(limitsTemplate, limit, query.Page*limit)
+
+	queryStr := fmt.Sprintf(queryTemplate, limits, quote(query.TextQuery), quote(query.TextQuery), strings.Join(filters, ","), sort)
+
+	return strings.NewReader(queryStr)
+}
+
+// quote encodes a string as a JSON string literal, so that user input can't change the query.
+func quote(str string) string {
+	b, _ := json.Marshal(str)
+	return string(b)
+}
+
+const limitsTemplate = `
+	"size": %d,
+	"from": %d,
+`
+
+const queryTemplate = `
+{
+	%s
+	"query": {
+	  "bool": {
+		"should": [
+		  {
+			"multi_match": {
+			  "query": %s,
+			  "fields": ["name^4", "description^2"],
+			  "zero_terms_query": "all",
+			  "fuzziness": "AUTO"
+			}
+		  },
+		  {
+			"nested": {
+			  "path": "tags",
+			  "score_mode": "avg",
+			  "query": {
+				"match": {
+				  "tags.name": %s
+				}
+			  }
+			}
+		  }
+		],
+		"filter": [
+			%s
+		],
+		"minimum_should_match": 1
+	  }
+	}
+	%s
+  }
+`
+
+const tagFilter = `
+{
+	"nested": {
+	  "path": "tags",
+	  "query": {
+		"match": {
+		  "tags.name": {
+			"query": %s,
+			"operator": "and"
+		  }
+		}
+	  }
+	}
+}
+`
+
+const verifiedFilter = `
+{
+	"match": {
+		"verificationStatus": "verified"
+	}
+}
+`
+
+const locationFilter = `
+{
+  "geo_distance": {
+	"distance": "%dkm",
+	"location": {
+	  "lon": %f,
+	  "lat": %f
+	}
+  }
+}
+`
+
+const sortTemplate = `
+,"sort": [
+	{
+		"_geo_distance": {
+			"location": [%f, %f],
+			"order": "asc",
+			"unit": "km",
+			"mode": "min",
+			"distance_type": "arc",
+			"ignore_unmapped": true
+		}
+	}
+]
+`
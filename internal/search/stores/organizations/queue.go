@@ -0,0 +1,6 @@
+package organizations
+
+// queueItem contains information about a single queue item.
+type queueItem struct {
+	organizationID int64
+}
@@ -0,0 +1,249 @@
+package organizations
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/elastic/go-elasticsearch"
+	"github.com/elastic/go-elasticsearch/esapi"
+	"github.com/joinimpact/api/internal/config"
+	"github.com/joinimpact/api/internal/models"
+	"github.com/rs/zerolog"
+)
+
+const indexName = "organizations"
+
+// Store represents a storage of organizations in the Elasticsearch database.
+type Store interface {
+	// Start starts the queue processor asynchronously.
+	Start() error
+	// Save saves an organization by ID in the Elasticsearch store, or removes it from the store if
+	// the organization no longer exists.
+	Save(organizationID int64)
+	// Search searches organizations, and returns relevant a list of documents.
+	Search(query Query) (*SearchResponse, error)
+}
+
+// store represents the internal implementation of the Store.
+type store struct {
+	client                    *elasticsearch.Client
+	organizationRepository    models.OrganizationRepository
+	organizationTagRepository models.OrganizationTagRepository
+	tagRepository             models.TagRepository
+	logger                    *zerolog.Logger
+	config                    *config.Config
+	saveQueue                 chan queueItem
+}
+
+// NewStore creates and returns a new Store with the provided dependencies.
+func NewStore(
+	client *elasticsearch.Client,
+	organizationRepository models.OrganizationRepository,
+	organizationTagRepository models.OrganizationTagRepository,
+	tagRepository models.TagRepository,
+	logger *zerolog.Logger,
+	config *config.Config,
+) Store {
+	saveQueue := make(chan queueItem, 4)
+
+	return &store{
+		client,
+		organizationRepository,
+		organizationTagRepository,
+		tagRepository,
+		logger,
+		config,
+		saveQueue,
+	}
+}
+
+// Start starts the queue processor asynchronously.
+func (s *store) Start() error {
+	// Run the queueWatcher asynchronously with a goroutine.
+	go s.queueWatcher()
+
+	return nil
+}
+
+// queueWatcher watches the queue for events and processes them.
+func (s *store) queueWatcher() {
+	for {
+		select {
+		case item := <-s.saveQueue:
+			err := s.save(item.organizationID)
+			if err != nil {
+				s.logger.Error().Err(err).Msgf("Error saving organization %d", item.organizationID)
+			}
+		}
+	}
+}
+
+// save saves an organization by ID into the Elasticsearch store.
+func (s *store) save(organizationID int64) error {
+	ctx := context.Background()
+
+	organization, err := s.organizationRepository.FindByID(organizationID)
+	if err != nil || !organization.Active {
+		// The organization was deleted.
+		return s.delete(ctx, organizationID)
+	}
+
+	document := &OrganizationDocument{}
+	document.Tags = []OrganizationTagDocument{}
+
+	document.ID = organization.ID
+	document.Name = organization.Name
+	document.Description = organization.Description
+	document.VerificationStatus = organization.VerificationStatus
+	if organization.LocationLatitude != 0 || organization.LocationLongitude != 0 {
+		document.Location = &LocationDocument{}
+		document.Location.Latitude = organization.LocationLatitude
+		document.Location.Longitude = organization.LocationLongitude
+	}
+
+	tags, err := s.organizationTagRepository.FindByOrganizationID(organizationID)
+	if err == nil {
+		for _, tag := range tags {
+			tag, err := s.tagRepository.FindByID(tag.TagID)
+			if err != nil {
+				continue
+			}
+
+			document.Tags = append(document.Tags, OrganizationTagDocument{
+				ID:       tag.ID,
+				Name:     tag.Name,
+				Category: tag.Category,
+			})
+		}
+	}
+
+	payloadMap := map[string]interface{}{
+		"doc":           document,
+		"doc_as_upsert": true,
+	}
+
+	payload, err := json.Marshal(payloadMap)
+	if err != nil {
+		return err
+	}
+
+	res, err := esapi.UpdateRequest{
+		Index:      indexName,
+		DocumentID: fmt.Sprintf("%d", document.ID),
+		Body:       bytes.NewReader(payload),
+	}.Do(ctx, s.client)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return responseError(res)
+	}
+
+	return nil
+}
+
+// delete removes an organization by ID from the Elasticsearch store.
+func (s *store) delete(ctx context.Context, organizationID int64) error {
+	res, err := esapi.DeleteRequest{
+		Index:      indexName,
+		DocumentID: fmt.Sprintf("%d", organizationID),
+	}.Do(ctx, s.client)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	// The organization may never have been indexed.
+	if res.IsError() && res.StatusCode != 404 {
+		return responseError(res)
+	}
+
+	return nil
+}
+
+// Save adds an organization save event to the queue.
+func (s *store) Save(organizationID int64) {
+	// Add the item to the queue.
+	s.saveQueue <- queueItem{
+		organizationID: organizationID,
+	}
+}
+
+// SearchResponse represents a response to a Search query.
+type SearchResponse struct {
+	TotalResults uint
+	Pages        uint
+	Documents    []OrganizationDocument
+}
+
+// Search searches organizations, and returns relevant a list of documents.
+func (s *store) Search(query Query) (*SearchResponse, error) {
+	documents := []OrganizationDocument{}
+
+	queryReader := buildQuery(query)
+
+	res, err := s.client.Search(
+		s.client.Search.WithIndex(indexName),
+		s.client.Search.WithBody(queryReader),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, responseError(res)
+	}
+
+	type envelopeResponse struct {
+		Took int
+		Hits struct {
+			Total struct {
+				Value int
+			}
+			Hits []struct {
+				ID     string          `json:"_id"`
+				Source json.RawMessage `json:"_source"`
+			}
+		}
+	}
+
+	var r envelopeResponse
+	if err := json.NewDecoder(res.Body).Decode(&r); err != nil {
+		return nil, err
+	}
+
+	for _, hit := range r.Hits.Hits {
+		doc := OrganizationDocument{}
+
+		if err := json.Unmarshal(hit.Source, &doc); err != nil {
+			return nil, err
+		}
+
+		documents = append(documents, doc)
+	}
+
+	limit := uint(20)
+	if query.Limit > 0 && query.Limit <= 100 {
+		limit = query.Limit
+	}
+
+	return &SearchResponse{
+		TotalResults: uint(r.Hits.Total.Value),
+		Pages:        uint(uint(r.Hits.Total.Value)/limit) + 1,
+		Documents:    documents,
+	}, nil
+}
+
+// responseError creates an error from an Elasticsearch error response.
+func responseError(res *esapi.Response) error {
+	var e map[string]interface{}
+	if err := json.NewDecoder(res.Body).Decode(&e); err != nil {
+		return err
+	}
+	return fmt.Errorf("[%s] %s: %s", res.Status(), e["error"].(map[string]interface{})["type"], e["error"].(map[string]interface{})["reason"])
+}
@@ -0,0 +1,111 @@
+package users
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/joinimpact/api/internal/models"
+	"github.com/rs/zerolog"
+)
+
+// DeletionGracePeriod is how long a user can cancel the deletion of their
+// account by logging in, before their data is erased.
+const DeletionGracePeriod = 30 * 24 * time.Hour
+
+// EraseScheduledUsers erases all users whose deletion grace period has ended,
+// and returns how many users were erased.
+func (s *service) EraseScheduledUsers(ctx context.Context) (int, error) {
+	users, err := s.userRepository.FindScheduledForDeletion(time.Now().UTC())
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error finding users scheduled for deletion")
+		return 0, NewErrServerError()
+	}
+
+	erased := 0
+	for _, user := range users {
+		if owns, err := s.ownsOrganization(user.ID); err != nil || owns {
+			s.logger.Warn().Int64("userId", user.ID).Msg("Skipping erasure of user who owns an organization")
+			continue
+		}
+
+		if err := s.userRepository.Erase(user.ID); err != nil {
+			// Keep going, the user will be retried on the next run.
+			s.logger.Error().Err(err).Int64("userId", user.ID).Msg("Error erasing user")
+			continue
+		}
+
+		// The user's files are only deleted once their records are, so that a failed erasure leaves
+		// nothing pointing at missing files.
+		s.eraseFiles(user.ID)
+
+		erased++
+	}
+
+	return erased, nil
+}
+
+// eraseFiles deletes a user's profile pictures, data export archives and the files they uploaded with
+// applications to opportunities from the CDN. Failures are logged, as the user's records are already erased.
+func (s *service) eraseFiles(userID int64) {
+	logger := s.logger.With().Int64("userId", userID).Logger()
+
+	// Every upload of a profile picture is kept under a new name, see UploadProfilePicture.
+	if err := s.cdnClient.DeleteFiles(fmt.Sprintf("profile-picture-%d-", userID)); err != nil {
+		logger.Error().Err(err).Msg("Error deleting profile pictures of erased user")
+	}
+
+	if err := s.cdnClient.DeleteFiles(fmt.Sprintf("exports/%d/", userID)); err != nil {
+		logger.Error().Err(err).Msg("Error deleting data export archives of erased user")
+	}
+
+	// Application files are stored under application-files/{opportunityID}/{volunteerID}/, which
+	// includes files uploaded for applications that were never submitted.
+	opportunities, err := s.cdnClient.ListFolders("application-files/")
+	if err != nil {
+		logger.Error().Err(err).Msg("Error listing application files of erased user")
+		return
+	}
+
+	for _, opportunity := range opportunities {
+		if err := s.cdnClient.DeleteFiles(fmt.Sprintf("%s%d/", opportunity, userID)); err != nil {
+			logger.Error().Err(err).Str("prefix", opportunity).Msg("Error deleting application files of erased user")
+		}
+	}
+}
+
+// ownsOrganization checks whether a user is the creator of any organization.
+func (s *service) ownsOrganization(userID int64) (bool, error) {
+	memberships, err := s.organizationMembershipRepository.FindByUserID(userID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, membership := range memberships {
+		if membership.PermissionsFlag == models.OrganizationPermissionsCreator {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// RunErasureJob erases users whose deletion grace period has ended once
+// every interval, until the context is done.
+func RunErasureJob(ctx context.Context, service Service, interval time.Duration, logger *zerolog.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		erased, err := service.EraseScheduledUsers(ctx)
+		if err == nil && erased > 0 {
+			logger.Info().Int("erased", erased).Msg("Erased users after deletion grace period")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -41,3 +41,31 @@ func NewErrServerError() error {
 func (e *ErrServerError) Error() string {
 	return "internal error processing request, please try again"
 }
+
+// ErrInvalidPrivacy is thrown when a profile field has an unknown privacy level.
+type ErrInvalidPrivacy struct {
+}
+
+// NewErrInvalidPrivacy creates and returns a ErrInvalidPrivacy.
+func NewErrInvalidPrivacy() error {
+	return &ErrInvalidPrivacy{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrInvalidPrivacy) Error() string {
+	return "invalid profile field privacy"
+}
+
+// ErrUserOwnsOrganization is thrown when a user who created an organization tries to delete their account.
+type ErrUserOwnsOrganization struct {
+}
+
+// NewErrUserOwnsOrganization creates and returns a ErrUserOwnsOrganization.
+func NewErrUserOwnsOrganization() error {
+	return &ErrUserOwnsOrganization{}
+}
+
+// Error provides a string representation of the error.
+func (e *ErrUserOwnsOrganization) Error() string {
+	return "user owns an organization, transfer ownership before deleting the account"
+}
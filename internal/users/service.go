@@ -11,13 +11,16 @@ import (
 	"github.com/joinimpact/api/internal/models"
 	"github.com/joinimpact/api/internal/snowflakes"
 	"github.com/joinimpact/api/pkg/location"
+	"github.com/joinimpact/api/pkg/scopes"
 	"github.com/rs/zerolog"
 )
 
 // Service represents a provider of User services (excluding authentication).
 type Service interface {
-	// GetUserProfile retrieves a single user's profile.
-	GetUserProfile(userID int64, self bool) (*UserProfile, error)
+	// GetUserProfile retrieves a single user's profile as seen by a viewer with the provided scope.
+	GetUserProfile(userID int64, viewerScope scopes.Scope) (*UserProfile, error)
+	// GetViewerScope calculates the scope a viewer has on a user's profile.
+	GetViewerScope(ctx context.Context, userID, viewerID int64) (scopes.Scope, error)
 	// GetMinimalUserProfile retrieves a single user's profile but skips extra fields such as tags and profile.
 	GetMinimalUserProfile(userID int64) (*UserProfile, error)
 	// UpdateUserProfile updates a user's profile.
@@ -34,31 +37,47 @@ type Service interface {
 	SetUserProfileField(userID int64, profileField models.UserProfileField) error
 	// UploadProfilePicture uploads a profile picture to the CDN and adds it to the user.
 	UploadProfilePicture(userID int64, fileReader io.Reader) (string, error)
-	// DeleteUser deletes a user's account by ID.
+	// DeleteUser schedules a user's account for deletion after the deletion grace period, and logs out
+	// all of their sessions. Logging in again cancels the deletion.
 	DeleteUser(ctx context.Context, userID int64) error
+	// EraseScheduledUsers erases all users whose deletion grace period has ended, and returns how many
+	// users were erased.
+	EraseScheduledUsers(ctx context.Context) (int, error)
 }
 
 // service represents the internal implementation of the Service interface.
 type service struct {
-	userRepository             models.UserRepository
-	userProfileFieldRepository models.UserProfileFieldRepository
-	userTagRepository          models.UserTagRepository
-	tagRepository              models.TagRepository
-	config                     *config.Config
-	logger                     *zerolog.Logger
-	snowflakeService           snowflakes.SnowflakeService
-	cdnClient                  *cdn.Client
-	locationService            location.Service
+	userRepository                         models.UserRepository
+	userProfileFieldRepository             models.UserProfileFieldRepository
+	userTagRepository                      models.UserTagRepository
+	tagRepository                          models.TagRepository
+	sessionRepository                      models.SessionRepository
+	organizationMembershipRepository       models.OrganizationMembershipRepository
+	opportunityRepository                  models.OpportunityRepository
+	opportunityMembershipRepository        models.OpportunityMembershipRepository
+	opportunityMembershipRequestRepository models.OpportunityMembershipRequestRepository
+	config                                 *config.Config
+	logger                                 *zerolog.Logger
+	snowflakeService                       snowflakes.SnowflakeService
+	cdnClient                              *cdn.Client
+	locationService                        location.Service
 }
 
 // NewService creates and returns a new Users service with the provifded dependencies.
 func NewService(userRepository models.UserRepository, userProfileFieldRepository models.UserProfileFieldRepository, userTagRepository models.UserTagRepository,
-	tagRepository models.TagRepository, config *config.Config, logger *zerolog.Logger, snowflakeService snowflakes.SnowflakeService, locationService location.Service) Service {
+	tagRepository models.TagRepository, sessionRepository models.SessionRepository, organizationMembershipRepository models.OrganizationMembershipRepository,
+	opportunityRepository models.OpportunityRepository, opportunityMembershipRepository models.OpportunityMembershipRepository,
+	opportunityMembershipRequestRepository models.OpportunityMembershipRequestRepository, config *config.Config, logger *zerolog.Logger, snowflakeService snowflakes.SnowflakeService, locationService location.Service) Service {
 	return &service{
 		userRepository,
 		userProfileFieldRepository,
 		userTagRepository,
 		tagRepository,
+		sessionRepository,
+		organizationMembershipRepository,
+		opportunityRepository,
+		opportunityMembershipRepository,
+		opportunityMembershipRequestRepository,
 		config,
 		logger,
 		snowflakeService,
@@ -67,9 +86,9 @@ func NewService(userRepository models.UserRepository, userProfileFieldRepository
 	}
 }
 
-// GetUserProfile retrieves a single user's profile. If self is true, it will also add sensitive fields
-// such as email.
-func (s *service) GetUserProfile(userID int64, self bool) (*UserProfile, error) {
+// GetUserProfile retrieves a single user's profile as seen by a viewer with the provided scope. Sensitive
+// fields such as email are only added for scopes.ScopeOwner, and profile fields are filtered by their privacy.
+func (s *service) GetUserProfile(userID int64, viewerScope scopes.Scope) (*UserProfile, error) {
 	profile := &UserProfile{}
 	// Find the user to verify that it is active.
 	user, err := s.userRepository.FindByID(userID)
@@ -83,10 +102,11 @@ func (s *service) GetUserProfile(userID int64, self bool) (*UserProfile, error)
 	profile.ProfilePicture = user.ProfilePicture
 	profile.LastOnline = user.LastOnline
 
-	if self {
+	if viewerScope >= scopes.ScopeOwner {
 		profile.Email = user.Email
 		profile.DateOfBirth = user.DateOfBirth
 		profile.CreatedAt = user.CreatedAt
+		profile.DeletionScheduledAt = user.DeletionScheduledAt
 	}
 
 	tags, err := s.GetUserTags(userID)
@@ -117,14 +137,9 @@ func (s *service) GetUserProfile(userID int64, self bool) (*UserProfile, error)
 
 	profile.ProfileFields = []models.UserProfileField{}
 
-	// TODO: replace with scoped struct tags
-	if self {
-		profile.ProfileFields = fields
-	} else {
-		for _, field := range fields {
-			if field.Privacy == 1 {
-				profile.ProfileFields = append(profile.ProfileFields, field)
-			}
+	for _, field := range fields {
+		if field.VisibleTo(viewerScope) {
+			profile.ProfileFields = append(profile.ProfileFields, field)
 		}
 	}
 
@@ -261,6 +276,10 @@ func (s *service) RemoveUserTag(userID, tagID int64) error {
 
 // SetUserProfileField sets a user's profile field by name.
 func (s *service) SetUserProfileField(userID int64, profileField models.UserProfileField) error {
+	if profileField.Privacy < models.PrivacyPublic || profileField.Privacy > models.PrivacyConnectionsOnly {
+		return NewErrInvalidPrivacy()
+	}
+
 	field, err := s.userProfileFieldRepository.FindUserFieldByName(userID, profileField.Name)
 	if err == nil {
 		profileField.ID = field.ID
@@ -292,9 +311,31 @@ func (s *service) UploadProfilePicture(userID int64, fileReader io.Reader) (stri
 	})
 }
 
-// DeleteUser deletes a user by ID.
+// DeleteUser schedules a user's account for deletion after the deletion
+// grace period, and logs out all of their sessions. Logging in again cancels
+// the deletion.
 func (s *service) DeleteUser(ctx context.Context, userID int64) error {
-	if err := s.userRepository.DeleteByID(userID); err != nil {
+	if _, err := s.userRepository.FindByID(userID); err != nil {
+		return NewErrUserNotFound()
+	}
+
+	// The creator of an organization has to transfer it first, so that it is never left without an owner.
+	owns, err := s.ownsOrganization(userID)
+	if err != nil {
+		return NewErrServerError()
+	}
+	if owns {
+		return NewErrUserOwnsOrganization()
+	}
+
+	deletionScheduledAt := time.Now().UTC().Add(DeletionGracePeriod)
+	if err := s.userRepository.SetDeletionScheduledAt(userID, &deletionScheduledAt); err != nil {
+		s.logger.Error().Err(err).Msg("Error scheduling user deletion")
+		return NewErrServerError()
+	}
+
+	if err := s.sessionRepository.RevokeByUserID(ctx, userID, 0); err != nil {
+		s.logger.Error().Err(err).Msg("Error revoking sessions of user scheduled for deletion")
 		return NewErrServerError()
 	}
 
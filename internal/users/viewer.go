@@ -0,0 +1,110 @@
+package users
+
+import (
+	"context"
+
+	"github.com/joinimpact/api/pkg/dbctx"
+	"github.com/joinimpact/api/pkg/scopes"
+)
+
+// membershipPageSize is the page size used when reading all of a user's opportunity memberships.
+const membershipPageSize = 100
+
+// GetViewerScope calculates the scope a viewer has on a user's profile. Managers of organizations the
+// user volunteers with or has applied to receive scopes.ScopeManager, users who share an organization
+// or opportunity with the user receive scopes.ScopeCollaborator.
+func (s *service) GetViewerScope(ctx context.Context, userID, viewerID int64) (scopes.Scope, error) {
+	if userID == viewerID {
+		return scopes.ScopeOwner, nil
+	}
+
+	viewerOrganizationMemberships, err := s.organizationMembershipRepository.FindByUserID(viewerID)
+	if err != nil {
+		return scopes.ScopeAuthenticated, NewErrServerError()
+	}
+
+	viewerOrganizations := map[int64]bool{}
+	for _, membership := range viewerOrganizationMemberships {
+		viewerOrganizations[membership.OrganizationID] = true
+	}
+
+	// Find the organizations of all opportunities the user volunteers with or has applied to.
+	userOpportunities, err := s.opportunityIDsByUser(ctx, userID)
+	if err != nil {
+		return scopes.ScopeAuthenticated, NewErrServerError()
+	}
+
+	requests, err := s.opportunityMembershipRequestRepository.FindByVolunteerID(userID)
+	if err != nil {
+		return scopes.ScopeAuthenticated, NewErrServerError()
+	}
+
+	opportunityIDs := []int64{}
+	for id := range userOpportunities {
+		opportunityIDs = append(opportunityIDs, id)
+	}
+	for _, request := range requests {
+		opportunityIDs = append(opportunityIDs, request.OpportunityID)
+	}
+
+	if len(opportunityIDs) > 0 && len(viewerOrganizations) > 0 {
+		opportunities, err := s.opportunityRepository.FindByIDs(ctx, opportunityIDs)
+		if err != nil {
+			return scopes.ScopeAuthenticated, NewErrServerError()
+		}
+
+		for _, opportunity := range opportunities {
+			if viewerOrganizations[opportunity.OrganizationID] {
+				return scopes.ScopeManager, nil
+			}
+		}
+	}
+
+	// Check whether the users share an organization.
+	userOrganizationMemberships, err := s.organizationMembershipRepository.FindByUserID(userID)
+	if err != nil {
+		return scopes.ScopeAuthenticated, NewErrServerError()
+	}
+
+	for _, membership := range userOrganizationMemberships {
+		if viewerOrganizations[membership.OrganizationID] {
+			return scopes.ScopeCollaborator, nil
+		}
+	}
+
+	// Check whether the users volunteer with the same opportunity.
+	viewerOpportunities, err := s.opportunityIDsByUser(ctx, viewerID)
+	if err != nil {
+		return scopes.ScopeAuthenticated, NewErrServerError()
+	}
+
+	for id := range viewerOpportunities {
+		if userOpportunities[id] {
+			return scopes.ScopeCollaborator, nil
+		}
+	}
+
+	return scopes.ScopeAuthenticated, nil
+}
+
+// opportunityIDsByUser returns the IDs of all opportunities a user is a member of.
+func (s *service) opportunityIDsByUser(ctx context.Context, userID int64) (map[int64]bool, error) {
+	ids := map[int64]bool{}
+	for page := 0; ; page++ {
+		memberships, err := s.opportunityMembershipRepository.FindByUserID(dbctx.Inject(ctx, dbctx.Request{
+			Limit: membershipPageSize,
+			Page:  page,
+		}), userID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, membership := range memberships {
+			ids[membership.OpportunityID] = true
+		}
+
+		if len(memberships) < membershipPageSize {
+			return ids, nil
+		}
+	}
+}
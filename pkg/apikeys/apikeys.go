@@ -0,0 +1,53 @@
+// Package apikeys generates and hashes API keys. Keys contain enough
+// randomness that a single SHA-256 hash is sufficient to store them, which
+// also allows a key to be looked up by its hash.
+package apikeys
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// Prefix is prepended to every key so that keys can be told apart from other
+// tokens.
+const Prefix = "imp_"
+
+// keyBytes is the number of random bytes in a key.
+const keyBytes = 32
+
+// displayLength is the number of characters of a key which are kept to help
+// users identify it.
+const displayLength = len(Prefix) + 8
+
+// Generate generates a new random key.
+func Generate() (string, error) {
+	b := make([]byte, keyBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return Prefix + hex.EncodeToString(b), nil
+}
+
+// Hash returns the hex encoded SHA-256 hash of a key.
+func Hash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsKey returns true if a token looks like an API key.
+func IsKey(token string) bool {
+	return strings.HasPrefix(token, Prefix) && len(token) == len(Prefix)+keyBytes*2
+}
+
+// DisplayPrefix returns the beginning of a key, which is safe to store and
+// show to identify the key.
+func DisplayPrefix(key string) string {
+	if len(key) < displayLength {
+		return key
+	}
+
+	return key[:displayLength]
+}
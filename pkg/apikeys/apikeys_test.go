@@ -0,0 +1,41 @@
+package apikeys
+
+import "testing"
+
+// TestGenerate tests that generated keys are recognised and hashed consistently.
+func TestGenerate(t *testing.T) {
+	key, err := Generate()
+	if err != nil {
+		t.Fatal("error generating key:", err)
+	}
+
+	if !IsKey(key) {
+		t.Fatalf("generated key %q is not recognised as a key", key)
+	}
+
+	other, err := Generate()
+	if err != nil {
+		t.Fatal("error generating key:", err)
+	}
+
+	if key == other {
+		t.Fatal("generated the same key twice")
+	}
+
+	if Hash(key) != Hash(key) || Hash(key) == Hash(other) {
+		t.Fatal("hashes do not identify keys")
+	}
+
+	if prefix := DisplayPrefix(key); len(prefix) != displayLength || key[:displayLength] != prefix {
+		t.Fatalf("unexpected display prefix %q", prefix)
+	}
+}
+
+// TestIsKey tests that other tokens are not recognised as keys.
+func TestIsKey(t *testing.T) {
+	for _, token := range []string{"", Prefix, "eyJhbGciOiJIUzUxMiJ9.e30.sig", Prefix + "abc"} {
+		if IsKey(token) {
+			t.Errorf("token %q recognised as a key", token)
+		}
+	}
+}
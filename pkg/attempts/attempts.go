@@ -0,0 +1,165 @@
+// Package attempts tracks failed attempts at an action by key, locking a key
+// out with exponential backoff once it has failed too many times.
+package attempts
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/bradfitz/gomemcache/memcache"
+)
+
+// Options represents the options of a Tracker.
+type Options struct {
+	Prefix       string        // prefix of the tracker's keys in the store
+	FreeAttempts int           // number of failures allowed before a key is locked
+	BaseLockout  time.Duration // duration of the first lockout, doubled with every further failure
+	MaxLockout   time.Duration // maximum duration of a single lockout
+	Window       time.Duration // time after the last failure after which failures are forgotten
+}
+
+// Result describes the state of a key after a failure.
+type Result struct {
+	Locked     bool          // whether the key is now locked
+	RetryAfter time.Duration // how long the key is locked for
+	Lockouts   int           // number of consecutive lockouts, 1 for the first
+}
+
+// Tracker tracks failed attempts by key.
+type Tracker interface {
+	// Locked checks if a key is locked, and returns the remaining duration of
+	// the lockout if it is.
+	Locked(key string) (time.Duration, bool)
+	// Fail records a failed attempt for a key.
+	Fail(key string) Result
+	// Reset forgets all failed attempts for a key.
+	Reset(key string)
+}
+
+// record is the stored state of a single key.
+type record struct {
+	Failures    int       `json:"f"`
+	LockedUntil time.Time `json:"l"`
+}
+
+// tracker represents the internal implementation of the Tracker.
+type tracker struct {
+	options  *Options
+	store    store
+	fallback *memoryStore
+	now      func() time.Time
+}
+
+// NewTracker creates and returns a new Tracker storing attempts in memcached.
+// If cache is nil, or memcached is unavailable, attempts are stored in
+// process memory instead.
+func NewTracker(cache *memcache.Client, options *Options) Tracker {
+	t := &tracker{
+		options:  options,
+		fallback: newMemoryStore(),
+		now:      time.Now,
+	}
+
+	if cache != nil {
+		t.store = &memcacheStore{cache}
+	}
+
+	return t
+}
+
+// key returns the store key for a tracked key. Keys are hashed, as memcached
+// restricts the characters and length of keys.
+func (t *tracker) key(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return t.options.Prefix + ":" + hex.EncodeToString(sum[:])
+}
+
+// get gets the record of a key, falling back to the memory store if the
+// primary store is unavailable.
+func (t *tracker) get(key string) record {
+	if t.store != nil {
+		r, err := t.store.get(key)
+		if err == nil {
+			return r
+		}
+	}
+
+	r, _ := t.fallback.get(key)
+	return r
+}
+
+// update changes the record of a key atomically, falling back to the memory
+// store if the primary store is unavailable.
+func (t *tracker) update(key string, update func(r *record) time.Duration) {
+	if t.store != nil {
+		if err := t.store.update(key, update); err == nil {
+			return
+		}
+	}
+
+	t.fallback.update(key, update)
+}
+
+// Locked checks if a key is locked, and returns the remaining duration of
+// the lockout if it is.
+func (t *tracker) Locked(key string) (time.Duration, bool) {
+	r := t.get(t.key(key))
+
+	remaining := r.LockedUntil.Sub(t.now())
+	if remaining <= 0 {
+		return 0, false
+	}
+
+	return remaining, true
+}
+
+// Fail records a failed attempt for a key. The failure is counted atomically,
+// so that concurrent failures are all counted.
+func (t *tracker) Fail(key string) Result {
+	result := Result{}
+	t.update(t.key(key), func(r *record) time.Duration {
+		now := t.now()
+		r.Failures++
+
+		result = Result{}
+		if r.Failures >= t.options.FreeAttempts {
+			result.Locked = true
+			result.Lockouts = r.Failures - t.options.FreeAttempts + 1
+			result.RetryAfter = t.lockout(result.Lockouts)
+			r.LockedUntil = now.Add(result.RetryAfter)
+		}
+
+		expiration := t.options.Window
+		if result.RetryAfter > expiration {
+			expiration = result.RetryAfter
+		}
+
+		return expiration
+	})
+
+	return result
+}
+
+// lockout returns the duration of the nth consecutive lockout.
+func (t *tracker) lockout(n int) time.Duration {
+	lockout := t.options.BaseLockout
+	for i := 1; i < n; i++ {
+		lockout *= 2
+		if lockout >= t.options.MaxLockout {
+			return t.options.MaxLockout
+		}
+	}
+
+	return lockout
+}
+
+// Reset forgets all failed attempts for a key.
+func (t *tracker) Reset(key string) {
+	storeKey := t.key(key)
+
+	if t.store != nil {
+		t.store.delete(storeKey)
+	}
+	t.fallback.delete(storeKey)
+}
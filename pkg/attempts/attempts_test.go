@@ -0,0 +1,106 @@
+package attempts
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+var testOptions = &Options{
+	Prefix:       "test",
+	FreeAttempts: 3,
+	BaseLockout:  time.Minute,
+	MaxLockout:   5 * time.Minute,
+	Window:       time.Hour,
+}
+
+// TestLockout tests that a key is locked after the free attempts, with the
+// lockout doubling on every further failure up to the maximum.
+func TestLockout(t *testing.T) {
+	tracker := NewTracker(nil, testOptions).(*tracker)
+
+	for i := 0; i < testOptions.FreeAttempts-1; i++ {
+		if result := tracker.Fail("key"); result.Locked {
+			t.Fatalf("key locked after %d failures", i+1)
+		}
+	}
+
+	if _, locked := tracker.Locked("key"); locked {
+		t.Fatal("key locked before the free attempts were used")
+	}
+
+	expected := []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute, 5 * time.Minute, 5 * time.Minute}
+	for i, lockout := range expected {
+		result := tracker.Fail("key")
+		if !result.Locked || result.RetryAfter != lockout || result.Lockouts != i+1 {
+			t.Fatalf("lockout %d: expected %v, got %+v", i+1, lockout, result)
+		}
+	}
+
+	if _, locked := tracker.Locked("key"); !locked {
+		t.Fatal("key not locked")
+	}
+
+	if _, locked := tracker.Locked("other"); locked {
+		t.Fatal("unrelated key locked")
+	}
+}
+
+// TestLockoutExpires tests that a lockout ends after its duration.
+func TestLockoutExpires(t *testing.T) {
+	now := time.Now()
+	tracker := NewTracker(nil, testOptions).(*tracker)
+	tracker.now = func() time.Time { return now }
+
+	for i := 0; i < testOptions.FreeAttempts; i++ {
+		tracker.Fail("key")
+	}
+
+	if remaining, locked := tracker.Locked("key"); !locked || remaining != time.Minute {
+		t.Fatalf("expected key to be locked for a minute, got %v", remaining)
+	}
+
+	now = now.Add(time.Minute + time.Second)
+	if _, locked := tracker.Locked("key"); locked {
+		t.Fatal("key still locked after the lockout")
+	}
+}
+
+// TestReset tests that resetting a key forgets its failures.
+func TestReset(t *testing.T) {
+	tracker := NewTracker(nil, testOptions)
+
+	for i := 0; i < testOptions.FreeAttempts; i++ {
+		tracker.Fail("key")
+	}
+
+	tracker.Reset("key")
+
+	if _, locked := tracker.Locked("key"); locked {
+		t.Fatal("key locked after reset")
+	}
+
+	if result := tracker.Fail("key"); result.Locked {
+		t.Fatal("key locked on first failure after reset")
+	}
+}
+
+// TestConcurrentFailures tests that failures recorded at the same time are all counted.
+func TestConcurrentFailures(t *testing.T) {
+	tracker := NewTracker(nil, testOptions).(*tracker)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tracker.Fail("key")
+		}()
+	}
+	wg.Wait()
+
+	r, _ := tracker.fallback.get(tracker.key("key"))
+	if r.Failures != 50 {
+		t.Fatalf("expected 50 failures, got %d", r.Failures)
+	}
+}
@@ -0,0 +1,180 @@
+package attempts
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/bradfitz/gomemcache/memcache"
+)
+
+// maxUpdateRetries is the number of times an update of a record in memcached
+// is retried when another update changed the record at the same time.
+const maxUpdateRetries = 10
+
+// purgeInterval is the minimum time between two purges of expired records
+// from the memory store.
+const purgeInterval = time.Minute
+
+// store stores records by key.
+type store interface {
+	// get gets a record by key, returning an empty record if none is found.
+	get(key string) (record, error)
+	// update changes a record by key atomically, so that concurrent updates
+	// of the record are not lost. The update function changes the record and
+	// returns its expiration.
+	update(key string, update func(r *record) time.Duration) error
+	// delete deletes a record by key.
+	delete(key string) error
+}
+
+// memcacheStore stores records in memcached.
+type memcacheStore struct {
+	cache *memcache.Client
+}
+
+// get gets a record by key, returning an empty record if none is found.
+func (s *memcacheStore) get(key string) (record, error) {
+	var r record
+
+	item, err := s.cache.Get(key)
+	if err == memcache.ErrCacheMiss {
+		return r, nil
+	}
+	if err != nil {
+		return r, err
+	}
+
+	err = json.Unmarshal(item.Value, &r)
+	return r, err
+}
+
+// update changes a record by key atomically. Records are added if they don't
+// exist yet, and otherwise compared and swapped, retrying when another update
+// changed the record in between.
+func (s *memcacheStore) update(key string, update func(r *record) time.Duration) error {
+	for i := 0; i < maxUpdateRetries; i++ {
+		var r record
+
+		item, err := s.cache.Get(key)
+		if err != nil && err != memcache.ErrCacheMiss {
+			return err
+		}
+		if err == nil {
+			if err := json.Unmarshal(item.Value, &r); err != nil {
+				return err
+			}
+		}
+
+		expiration := update(&r)
+		value, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+
+		if item == nil {
+			err = s.cache.Add(&memcache.Item{
+				Key:        key,
+				Value:      value,
+				Expiration: int32(expiration / time.Second),
+			})
+		} else {
+			item.Value = value
+			item.Expiration = int32(expiration / time.Second)
+			err = s.cache.CompareAndSwap(item)
+		}
+
+		switch err {
+		case nil:
+			return nil
+		case memcache.ErrNotStored, memcache.ErrCASConflict, memcache.ErrCacheMiss:
+			// Another update added, changed or deleted the record, try again.
+			continue
+		default:
+			return err
+		}
+	}
+
+	return memcache.ErrCASConflict
+}
+
+// delete deletes a record by key.
+func (s *memcacheStore) delete(key string) error {
+	err := s.cache.Delete(key)
+	if err == memcache.ErrCacheMiss {
+		return nil
+	}
+
+	return err
+}
+
+// memoryItem is a record stored in memory with its expiry.
+type memoryItem struct {
+	record    record
+	expiresAt time.Time
+}
+
+// memoryStore stores records in process memory.
+type memoryStore struct {
+	mu        sync.Mutex
+	items     map[string]memoryItem
+	lastPurge time.Time
+}
+
+// newMemoryStore creates and returns a new memoryStore.
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		items:     map[string]memoryItem{},
+		lastPurge: time.Now(),
+	}
+}
+
+// get gets a record by key, returning an empty record if none is found.
+func (s *memoryStore) get(key string) (record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item, ok := s.items[key]
+	if !ok || time.Now().After(item.expiresAt) {
+		return record{}, nil
+	}
+
+	return item.record, nil
+}
+
+// update changes a record by key atomically.
+func (s *memoryStore) update(key string, update func(r *record) time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.lastPurge) > purgeInterval {
+		// Remove expired records so the store doesn't grow without bound.
+		for k, item := range s.items {
+			if now.After(item.expiresAt) {
+				delete(s.items, k)
+			}
+		}
+		s.lastPurge = now
+	}
+
+	var r record
+	if item, ok := s.items[key]; ok && !now.After(item.expiresAt) {
+		r = item.record
+	}
+
+	expiration := update(&r)
+	s.items[key] = memoryItem{r, now.Add(expiration)}
+
+	return nil
+}
+
+// delete deletes a record by key.
+func (s *memoryStore) delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.items, key)
+
+	return nil
+}
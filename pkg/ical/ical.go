@@ -0,0 +1,145 @@
+// Package ical writes iCalendar (RFC 5545) calendars, such as the feeds
+// calendar apps like Google Calendar and Outlook subscribe to.
+package ical
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// lineLength is the maximum length of a line in octets, after which lines
+// are folded.
+const lineLength = 75
+
+// Layouts of date-time and date values.
+const (
+	dateTimeLayout = "20060102T150405Z"
+	dateLayout     = "20060102"
+)
+
+// textEscaper escapes the characters with a special meaning in text values.
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// Calendar is a calendar of events.
+type Calendar struct {
+	ProductID       string        // identifies the product which created the calendar
+	Name            string        // the name calendar apps show for the calendar
+	RefreshInterval time.Duration // how often calendar apps should refresh the calendar, if set
+	Events          []Event
+}
+
+// Event is a single event in a calendar.
+type Event struct {
+	UID          string    // identifies the event across versions of the calendar
+	Sequence     int       // the revision of the event, increased every time it changes
+	LastModified time.Time // when the event was last changed
+	Start        time.Time
+	End          time.Time
+	AllDay       bool // whether only the dates of Start and End are shown, with End included
+	Summary      string
+	Description  string
+	Location     string
+	Cancelled    bool
+}
+
+// Encode encodes the calendar in iCalendar format.
+func (c *Calendar) Encode(now time.Time) []byte {
+	w := &writer{}
+
+	w.line("BEGIN", "VCALENDAR")
+	w.line("VERSION", "2.0")
+	w.line("PRODID", c.ProductID)
+	w.line("CALSCALE", "GREGORIAN")
+	w.line("METHOD", "PUBLISH")
+
+	if c.Name != "" {
+		w.line("X-WR-CALNAME", escape(c.Name))
+	}
+
+	if c.RefreshInterval > 0 {
+		duration := "PT" + strconv.Itoa(int(c.RefreshInterval.Minutes())) + "M"
+		w.line("REFRESH-INTERVAL;VALUE=DURATION", duration)
+		w.line("X-PUBLISHED-TTL", duration)
+	}
+
+	for _, event := range c.Events {
+		event.encode(w, now)
+	}
+
+	w.line("END", "VCALENDAR")
+
+	return w.buf.Bytes()
+}
+
+// encode writes the event as a VEVENT component.
+func (e *Event) encode(w *writer, now time.Time) {
+	w.line("BEGIN", "VEVENT")
+	w.line("UID", e.UID)
+	w.line("DTSTAMP", now.UTC().Format(dateTimeLayout))
+
+	if e.AllDay {
+		w.line("DTSTART;VALUE=DATE", e.Start.Format(dateLayout))
+		// The end date of an all day event is not included in the event.
+		w.line("DTEND;VALUE=DATE", e.End.AddDate(0, 0, 1).Format(dateLayout))
+	} else {
+		w.line("DTSTART", e.Start.UTC().Format(dateTimeLayout))
+		w.line("DTEND", e.End.UTC().Format(dateTimeLayout))
+	}
+
+	w.line("SEQUENCE", strconv.Itoa(e.Sequence))
+	if !e.LastModified.IsZero() {
+		w.line("LAST-MODIFIED", e.LastModified.UTC().Format(dateTimeLayout))
+	}
+
+	w.line("SUMMARY", escape(e.Summary))
+	if e.Description != "" {
+		w.line("DESCRIPTION", escape(e.Description))
+	}
+
+	if e.Location != "" {
+		w.line("LOCATION", escape(e.Location))
+	}
+
+	if e.Cancelled {
+		w.line("STATUS", "CANCELLED")
+	} else {
+		w.line("STATUS", "CONFIRMED")
+	}
+
+	w.line("END", "VEVENT")
+}
+
+// writer writes content lines, folding long lines.
+type writer struct {
+	buf bytes.Buffer
+}
+
+// line writes a single content line.
+func (w *writer) line(name, value string) {
+	line := name + ":" + value
+
+	// Long lines are folded by continuing them on lines starting with a space,
+	// without splitting multi-byte characters.
+	length := 0
+	for len(line) > 0 {
+		r, size := utf8.DecodeRuneInString(line)
+		if length+size > lineLength {
+			w.buf.WriteString("\r\n ")
+			length = 1
+		}
+
+		w.buf.WriteRune(r)
+		length += size
+		line = line[size:]
+	}
+
+	w.buf.WriteString("\r\n")
+}
+
+// escape escapes a text value.
+func escape(text string) string {
+	return textEscaper.Replace(text)
+}
@@ -0,0 +1,69 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+// TestEncode tests that events are encoded with escaped text and folded lines.
+func TestEncode(t *testing.T) {
+	start := time.Date(2020, 9, 1, 18, 0, 0, 0, time.FixedZone("PDT", -7*60*60))
+	calendar := &Calendar{
+		ProductID:       "-//Impact//Events//EN",
+		Name:            "My events",
+		RefreshInterval: time.Hour,
+		Events: []Event{
+			{
+				UID:         "event-1@joinimpact.org",
+				Sequence:    2,
+				Start:       start,
+				End:         start.Add(2 * time.Hour),
+				Summary:     "Food bank; sorting, packing",
+				Description: "Bring gloves.\nParking is behind the building, " + strings.Repeat("é", 40),
+			},
+			{
+				UID:       "event-2@joinimpact.org",
+				Start:     start,
+				End:       start,
+				AllDay:    true,
+				Summary:   "Cleanup",
+				Cancelled: true,
+			},
+		},
+	}
+
+	encoded := string(calendar.Encode(start))
+
+	expected := []string{
+		"BEGIN:VCALENDAR\r\n",
+		"X-PUBLISHED-TTL:PT60M\r\n",
+		"UID:event-1@joinimpact.org\r\n",
+		"DTSTART:20200902T010000Z\r\n",
+		"DTEND:20200902T030000Z\r\n",
+		"SEQUENCE:2\r\n",
+		"SUMMARY:Food bank\\; sorting\\, packing\r\n",
+		"DESCRIPTION:Bring gloves.\\nParking is behind the building\\, ",
+		"STATUS:CONFIRMED\r\n",
+		"DTSTART;VALUE=DATE:20200901\r\n",
+		"DTEND;VALUE=DATE:20200902\r\n",
+		"STATUS:CANCELLED\r\n",
+		"END:VCALENDAR\r\n",
+	}
+	for _, line := range expected {
+		if !strings.Contains(encoded, line) {
+			t.Errorf("expected calendar to contain %q, got:\n%s", line, encoded)
+		}
+	}
+
+	for _, line := range strings.Split(encoded, "\r\n") {
+		if len(line) > lineLength {
+			t.Errorf("line longer than %d octets: %q", lineLength, line)
+		}
+
+		if !utf8.ValidString(line) {
+			t.Errorf("folding split a multi-byte character: %q", line)
+		}
+	}
+}
@@ -1,7 +1,24 @@
 package location
 
+import "math"
+
 // Coordinates represents a latitude and longitude representation of a location.
 type Coordinates struct {
 	Latitude  float64 `json:"lat"`
 	Longitude float64 `json:"long"`
 }
+
+// earthRadius is the mean radius of the Earth in kilometers.
+const earthRadius = 6371.0
+
+// Distance calculates the great-circle distance between two coordinates in kilometers.
+func Distance(a, b Coordinates) float64 {
+	lat1 := a.Latitude * math.Pi / 180
+	lat2 := b.Latitude * math.Pi / 180
+	deltaLat := (b.Latitude - a.Latitude) * math.Pi / 180
+	deltaLong := (b.Longitude - a.Longitude) * math.Pi / 180
+
+	h := math.Sin(deltaLat/2)*math.Sin(deltaLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(deltaLong/2)*math.Sin(deltaLong/2)
+
+	return 2 * earthRadius * math.Asin(math.Sqrt(h))
+}
@@ -108,6 +108,15 @@ func Forbidden(w http.ResponseWriter, r *http.Request, errors ...Err) {
 	marshal(w, r, nil, resp)
 }
 
+// TooManyRequests returns an HTTP 429 response.
+func TooManyRequests(w http.ResponseWriter, r *http.Request, errors ...Err) {
+	resp := response{}
+	resp.Errors = errors
+
+	w.WriteHeader(http.StatusTooManyRequests)
+	marshal(w, r, nil, resp)
+}
+
 // Error returns a client-facing error.
 func Error(code int, message string) Err {
 	return Err{
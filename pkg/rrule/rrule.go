@@ -0,0 +1,537 @@
+// Package rrule implements the iCalendar recurrence rules (RFC 5545, section
+// 3.3.10) used to repeat events, such as "weekly on Tuesday and Thursday" or
+// "monthly on the first Saturday".
+//
+// The FREQ, INTERVAL, COUNT, UNTIL, BYDAY, BYMONTHDAY, BYMONTH and WKST parts
+// are supported, which covers the rules created by common calendar apps.
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency is the period a rule repeats over.
+type Frequency int
+
+// Frequencies
+const (
+	Daily Frequency = iota
+	Weekly
+	Monthly
+	Yearly
+)
+
+// frequencyNames maps frequencies to their names in a rule.
+var frequencyNames = map[Frequency]string{
+	Daily:   "DAILY",
+	Weekly:  "WEEKLY",
+	Monthly: "MONTHLY",
+	Yearly:  "YEARLY",
+}
+
+// dayNames maps weekdays to their names in a rule.
+var dayNames = map[time.Weekday]string{
+	time.Monday:    "MO",
+	time.Tuesday:   "TU",
+	time.Wednesday: "WE",
+	time.Thursday:  "TH",
+	time.Friday:    "FR",
+	time.Saturday:  "SA",
+	time.Sunday:    "SU",
+}
+
+// untilLayouts are the layouts accepted for the UNTIL part, in UTC, local
+// and date-only form.
+var untilLayouts = []string{"20060102T150405Z", "20060102T150405", "20060102"}
+
+// maxPeriods is the maximum number of periods a rule is expanded over, so
+// that a rule which rarely or never matches can not expand forever.
+const maxPeriods = 10000
+
+// ErrInvalidRule is returned when a rule can not be parsed.
+var ErrInvalidRule = errors.New("rrule: invalid rule")
+
+// Weekday is a day of the week in the BYDAY part of a rule. N selects a
+// single occurrence of the day within the month, counting from the end when
+// negative, and is 0 for every occurrence.
+type Weekday struct {
+	Day time.Weekday
+	N   int
+}
+
+// Rule is a parsed recurrence rule.
+type Rule struct {
+	Frequency  Frequency
+	Interval   int        // the number of periods between repeats, at least 1
+	Count      int        // the maximum number of occurrences, 0 for no limit
+	Until      *time.Time // the last time an occurrence can start at, if any
+	ByDay      []Weekday
+	ByMonthDay []int
+	ByMonth    []time.Month
+	WeekStart  time.Weekday
+}
+
+// Parse parses a rule such as "FREQ=WEEKLY;BYDAY=TU,TH". An "RRULE:" prefix
+// is allowed.
+func Parse(s string) (*Rule, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	if s == "" {
+		return nil, ErrInvalidRule
+	}
+
+	rule := &Rule{Frequency: -1, Interval: 1, WeekStart: time.Monday}
+	seen := map[string]bool{}
+	for _, part := range strings.Split(s, ";") {
+		pair := strings.SplitN(part, "=", 2)
+		if len(pair) != 2 || pair[1] == "" {
+			return nil, invalid("part", part)
+		}
+
+		key, value := strings.ToUpper(pair[0]), strings.ToUpper(pair[1])
+		if seen[key] {
+			return nil, invalid(key, value)
+		}
+		seen[key] = true
+
+		if err := rule.parsePart(key, value); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := rule.validate(); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// parsePart parses a single KEY=VALUE part of a rule.
+func (r *Rule) parsePart(key, value string) error {
+	switch key {
+	case "FREQ":
+		for frequency, name := range frequencyNames {
+			if name == value {
+				r.Frequency = frequency
+				return nil
+			}
+		}
+		return invalid(key, value)
+	case "INTERVAL":
+		interval, err := strconv.Atoi(value)
+		if err != nil || interval < 1 {
+			return invalid(key, value)
+		}
+		r.Interval = interval
+	case "COUNT":
+		count, err := strconv.Atoi(value)
+		if err != nil || count < 1 {
+			return invalid(key, value)
+		}
+		r.Count = count
+	case "UNTIL":
+		until, err := parseUntil(value)
+		if err != nil {
+			return invalid(key, value)
+		}
+		r.Until = &until
+	case "BYDAY":
+		for _, day := range strings.Split(value, ",") {
+			weekday, err := parseWeekday(day)
+			if err != nil {
+				return invalid(key, value)
+			}
+			r.ByDay = append(r.ByDay, weekday)
+		}
+	case "BYMONTHDAY":
+		for _, day := range strings.Split(value, ",") {
+			n, err := strconv.Atoi(day)
+			if err != nil || n == 0 || n < -31 || n > 31 {
+				return invalid(key, value)
+			}
+			r.ByMonthDay = append(r.ByMonthDay, n)
+		}
+	case "BYMONTH":
+		for _, month := range strings.Split(value, ",") {
+			n, err := strconv.Atoi(month)
+			if err != nil || n < 1 || n > 12 {
+				return invalid(key, value)
+			}
+			r.ByMonth = append(r.ByMonth, time.Month(n))
+		}
+	case "WKST":
+		weekday, err := parseWeekday(value)
+		if err != nil || weekday.N != 0 {
+			return invalid(key, value)
+		}
+		r.WeekStart = weekday.Day
+	default:
+		return fmt.Errorf("%w: unsupported part %s", ErrInvalidRule, key)
+	}
+
+	return nil
+}
+
+// validate checks that the parts of a rule can be combined.
+func (r *Rule) validate() error {
+	if r.Frequency < Daily || r.Frequency > Yearly {
+		return fmt.Errorf("%w: FREQ is required", ErrInvalidRule)
+	}
+
+	if r.Count > 0 && r.Until != nil {
+		return fmt.Errorf("%w: COUNT and UNTIL can not be combined", ErrInvalidRule)
+	}
+
+	for _, weekday := range r.ByDay {
+		// Numbered days only make sense within a month.
+		if weekday.N != 0 && r.Frequency != Monthly && !(r.Frequency == Yearly && len(r.ByMonth) > 0) {
+			return fmt.Errorf("%w: numbered BYDAY requires FREQ=MONTHLY, or FREQ=YEARLY with BYMONTH", ErrInvalidRule)
+		}
+	}
+
+	if len(r.ByMonthDay) > 0 && r.Frequency == Weekly {
+		return fmt.Errorf("%w: BYMONTHDAY can not be used with FREQ=WEEKLY", ErrInvalidRule)
+	}
+
+	if r.Frequency == Yearly && len(r.ByMonth) == 0 && (len(r.ByDay) > 0 || len(r.ByMonthDay) > 0) {
+		return fmt.Errorf("%w: FREQ=YEARLY with BYDAY or BYMONTHDAY requires BYMONTH", ErrInvalidRule)
+	}
+
+	return nil
+}
+
+// String formats the rule in its canonical form.
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + frequencyNames[r.Frequency]}
+
+	if r.Interval > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%d", r.Interval))
+	}
+
+	if r.Count > 0 {
+		parts = append(parts, fmt.Sprintf("COUNT=%d", r.Count))
+	}
+
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format(untilLayouts[0]))
+	}
+
+	if len(r.ByDay) > 0 {
+		days := []string{}
+		for _, weekday := range r.ByDay {
+			day := dayNames[weekday.Day]
+			if weekday.N != 0 {
+				day = strconv.Itoa(weekday.N) + day
+			}
+			days = append(days, day)
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+
+	if len(r.ByMonthDay) > 0 {
+		parts = append(parts, "BYMONTHDAY="+joinInts(r.ByMonthDay))
+	}
+
+	if len(r.ByMonth) > 0 {
+		months := []int{}
+		for _, month := range r.ByMonth {
+			months = append(months, int(month))
+		}
+		parts = append(parts, "BYMONTH="+joinInts(months))
+	}
+
+	if r.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+dayNames[r.WeekStart])
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// Between returns the start times of the occurrences of a rule starting at
+// dtstart which start at or after from and before to. Occurrences keep the
+// time of day and location of dtstart.
+func (r *Rule) Between(dtstart, from, to time.Time) []time.Time {
+	occurrences := []time.Time{}
+
+	// Rules without a count do not depend on earlier occurrences, so periods
+	// before the range can be skipped.
+	period := 0
+	if r.Count == 0 {
+		period = r.periodsBefore(dtstart, from)
+	}
+
+	n := 0
+	for ; period < maxPeriods; period++ {
+		start := r.periodStart(dtstart, period)
+		if !start.Before(to) || (r.Until != nil && start.After(*r.Until)) {
+			break
+		}
+
+		for _, candidate := range r.candidates(dtstart, start) {
+			if candidate.Before(dtstart) {
+				continue
+			}
+
+			if r.Until != nil && candidate.After(*r.Until) {
+				return occurrences
+			}
+
+			n++
+			if r.Count > 0 && n > r.Count {
+				return occurrences
+			}
+
+			if !candidate.Before(to) {
+				return occurrences
+			}
+
+			if !candidate.Before(from) {
+				occurrences = append(occurrences, candidate)
+			}
+		}
+	}
+
+	return occurrences
+}
+
+// Includes returns true if an occurrence of the rule starting at dtstart
+// starts at t.
+func (r *Rule) Includes(dtstart, t time.Time) bool {
+	occurrences := r.Between(dtstart, t, t.Add(time.Nanosecond))
+	return len(occurrences) == 1
+}
+
+// periodsBefore returns a number of periods which all end before from. It
+// errs on the side of skipping one period too few.
+func (r *Rule) periodsBefore(dtstart, from time.Time) int {
+	if !from.After(dtstart) {
+		return 0
+	}
+
+	var periods int
+	switch r.Frequency {
+	case Daily:
+		periods = int(from.Sub(dtstart).Hours() / 24)
+	case Weekly:
+		periods = int(from.Sub(dtstart).Hours() / (24 * 7))
+	case Monthly:
+		periods = (from.Year()-dtstart.Year())*12 + int(from.Month()-dtstart.Month())
+	case Yearly:
+		periods = from.Year() - dtstart.Year()
+	}
+
+	skipped := periods/r.Interval - 1
+	if skipped < 0 {
+		return 0
+	}
+
+	return skipped
+}
+
+// periodStart returns the start of the nth period of the rule, at the time of
+// day of dtstart.
+func (r *Rule) periodStart(dtstart time.Time, n int) time.Time {
+	hour, min, sec := dtstart.Clock()
+	year, month, day := dtstart.Date()
+	steps := n * r.Interval
+
+	switch r.Frequency {
+	case Weekly:
+		offset := (int(dtstart.Weekday()) - int(r.WeekStart) + 7) % 7
+		day += steps*7 - offset
+	case Monthly:
+		month += time.Month(steps)
+		day = 1
+	case Yearly:
+		year += steps
+		month = time.January
+		day = 1
+	default:
+		day += steps
+	}
+
+	return time.Date(year, month, day, hour, min, sec, dtstart.Nanosecond(), dtstart.Location())
+}
+
+// candidates returns the days of a period matching the rule, in order.
+func (r *Rule) candidates(dtstart, start time.Time) []time.Time {
+	candidates := []time.Time{}
+
+	switch r.Frequency {
+	case Daily:
+		if r.matchesMonth(start.Month()) && r.matchesWeekday(start) && r.matchesMonthDay(start) {
+			candidates = append(candidates, start)
+		}
+	case Weekly:
+		for i := 0; i < 7; i++ {
+			day := start.AddDate(0, 0, i)
+			if !r.matchesMonth(day.Month()) {
+				continue
+			}
+
+			if len(r.ByDay) > 0 && r.matchesWeekday(day) || len(r.ByDay) == 0 && day.Weekday() == dtstart.Weekday() {
+				candidates = append(candidates, day)
+			}
+		}
+	case Monthly:
+		if r.matchesMonth(start.Month()) {
+			candidates = r.monthCandidates(dtstart, start)
+		}
+	case Yearly:
+		months := r.ByMonth
+		if len(months) == 0 {
+			months = []time.Month{dtstart.Month()}
+		}
+
+		sorted := append([]time.Month{}, months...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+		for _, month := range sorted {
+			candidates = append(candidates, r.monthCandidates(dtstart, start.AddDate(0, int(month-time.January), 0))...)
+		}
+	}
+
+	return candidates
+}
+
+// monthCandidates returns the days of the month starting at start which
+// match the rule, in order.
+func (r *Rule) monthCandidates(dtstart, start time.Time) []time.Time {
+	candidates := []time.Time{}
+
+	for day := start; day.Month() == start.Month(); day = day.AddDate(0, 0, 1) {
+		var matches bool
+		if len(r.ByDay) == 0 && len(r.ByMonthDay) == 0 {
+			// Months without the day of dtstart, such as February 30th, are skipped.
+			matches = day.Day() == dtstart.Day()
+		} else {
+			matches = r.matchesWeekday(day) && r.matchesMonthDay(day)
+		}
+
+		if matches {
+			candidates = append(candidates, day)
+		}
+	}
+
+	return candidates
+}
+
+// matchesWeekday returns true if a day matches the BYDAY part of the rule,
+// or the rule has no BYDAY part.
+func (r *Rule) matchesWeekday(day time.Time) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+
+	n := (day.Day()-1)/7 + 1
+	last := -((daysIn(day)-day.Day())/7 + 1)
+	for _, weekday := range r.ByDay {
+		if weekday.Day == day.Weekday() && (weekday.N == 0 || weekday.N == n || weekday.N == last) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchesMonthDay returns true if a day matches the BYMONTHDAY part of the
+// rule, or the rule has no BYMONTHDAY part.
+func (r *Rule) matchesMonthDay(day time.Time) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+
+	for _, monthDay := range r.ByMonthDay {
+		if monthDay == day.Day() || monthDay == day.Day()-daysIn(day)-1 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchesMonth returns true if a month matches the BYMONTH part of the rule,
+// or the rule has no BYMONTH part.
+func (r *Rule) matchesMonth(month time.Month) bool {
+	if len(r.ByMonth) == 0 {
+		return true
+	}
+
+	for _, m := range r.ByMonth {
+		if m == month {
+			return true
+		}
+	}
+
+	return false
+}
+
+// daysIn returns the number of days in the month of a day.
+func daysIn(day time.Time) int {
+	return time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// parseWeekday parses a day such as "TU", "1SA" or "-1FR".
+func parseWeekday(s string) (Weekday, error) {
+	if len(s) < 2 {
+		return Weekday{}, ErrInvalidRule
+	}
+
+	name, number := s[len(s)-2:], s[:len(s)-2]
+	weekday := Weekday{Day: -1}
+	for day, dayName := range dayNames {
+		if dayName == name {
+			weekday.Day = day
+		}
+	}
+
+	if weekday.Day < 0 {
+		return Weekday{}, ErrInvalidRule
+	}
+
+	if number != "" {
+		n, err := strconv.Atoi(number)
+		if err != nil || n == 0 || n < -5 || n > 5 {
+			return Weekday{}, ErrInvalidRule
+		}
+		weekday.N = n
+	}
+
+	return weekday, nil
+}
+
+// parseUntil parses the value of an UNTIL part. Dates include the whole day.
+func parseUntil(s string) (time.Time, error) {
+	for i, layout := range untilLayouts {
+		until, err := time.Parse(layout, s)
+		if err != nil {
+			continue
+		}
+
+		if i == len(untilLayouts)-1 {
+			until = until.Add(24*time.Hour - time.Nanosecond)
+		}
+
+		return until, nil
+	}
+
+	return time.Time{}, ErrInvalidRule
+}
+
+// invalid returns an error for an invalid part of a rule.
+func invalid(key, value string) error {
+	return fmt.Errorf("%w: %s=%s", ErrInvalidRule, key, value)
+}
+
+// joinInts joins numbers with commas.
+func joinInts(numbers []int) string {
+	strs := []string{}
+	for _, n := range numbers {
+		strs = append(strs, strconv.Itoa(n))
+	}
+
+	return strings.Join(strs, ",")
+}
@@ -0,0 +1,154 @@
+package rrule
+
+import (
+	"testing"
+	"time"
+)
+
+// dtstart is a Tuesday morning.
+var dtstart = time.Date(2020, 9, 1, 9, 30, 0, 0, time.UTC)
+
+// date returns 9:30 UTC on a day in 2020 or later.
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 9, 30, 0, 0, time.UTC)
+}
+
+// TestBetween tests the expansion of common rules.
+func TestBetween(t *testing.T) {
+	from := dtstart
+	to := date(2020, 12, 1)
+
+	tests := []struct {
+		rule string
+		want []time.Time
+	}{
+		{
+			"FREQ=WEEKLY;BYDAY=TU,TH;COUNT=5",
+			[]time.Time{date(2020, 9, 1), date(2020, 9, 3), date(2020, 9, 8), date(2020, 9, 10), date(2020, 9, 15)},
+		},
+		{
+			"FREQ=MONTHLY;BYDAY=1SA",
+			[]time.Time{date(2020, 9, 5), date(2020, 10, 3), date(2020, 11, 7)},
+		},
+		{
+			"FREQ=MONTHLY;BYDAY=-1FR",
+			[]time.Time{date(2020, 9, 25), date(2020, 10, 30), date(2020, 11, 27)},
+		},
+		{
+			"FREQ=MONTHLY;BYMONTHDAY=-1",
+			[]time.Time{date(2020, 9, 30), date(2020, 10, 31), date(2020, 11, 30)},
+		},
+		{
+			"FREQ=WEEKLY;INTERVAL=4;UNTIL=20201027",
+			[]time.Time{date(2020, 9, 1), date(2020, 9, 29), date(2020, 10, 27)},
+		},
+		{
+			"FREQ=DAILY;BYDAY=SA,SU;UNTIL=20200913T000000Z",
+			[]time.Time{date(2020, 9, 5), date(2020, 9, 6), date(2020, 9, 12)},
+		},
+		{
+			"RRULE:FREQ=YEARLY;BYMONTH=10;BYDAY=2MO",
+			[]time.Time{date(2020, 10, 12)},
+		},
+	}
+
+	for _, tt := range tests {
+		rule, err := Parse(tt.rule)
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", tt.rule, err)
+			continue
+		}
+
+		got := rule.Between(dtstart, from, to)
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.rule, got, tt.want)
+			continue
+		}
+
+		for i := range got {
+			if !got[i].Equal(tt.want[i]) {
+				t.Errorf("%s: got %v, want %v", tt.rule, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+// TestBetweenSkipsEarlierPeriods tests that expanding a range long after the
+// start of a rule returns the same occurrences as counting from the start.
+func TestBetweenSkipsEarlierPeriods(t *testing.T) {
+	rule, err := Parse("FREQ=WEEKLY;INTERVAL=3;BYDAY=MO,FR")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	from := date(2023, 3, 1)
+	to := date(2023, 5, 1)
+
+	var want []time.Time
+	for _, occurrence := range rule.Between(dtstart, dtstart, to) {
+		if !occurrence.Before(from) {
+			want = append(want, occurrence)
+		}
+	}
+
+	got := rule.Between(dtstart, from, to)
+	if len(got) == 0 || len(got) != len(want) || !got[0].Equal(want[0]) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+// TestIncludes tests that only occurrences of a rule are included.
+func TestIncludes(t *testing.T) {
+	rule, err := Parse("FREQ=WEEKLY;BYDAY=TU,TH")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !rule.Includes(dtstart, date(2021, 1, 7)) {
+		t.Error("Thursday not included")
+	}
+
+	if rule.Includes(dtstart, date(2021, 1, 8)) {
+		t.Error("Friday included")
+	}
+
+	if rule.Includes(dtstart, date(2021, 1, 7).Add(time.Hour)) {
+		t.Error("occurrence at a different time included")
+	}
+}
+
+// TestParse tests that invalid or unsupported rules are rejected, and that
+// valid rules are formatted in canonical form.
+func TestParse(t *testing.T) {
+	invalid := []string{
+		"",
+		"BYDAY=MO",
+		"FREQ=HOURLY",
+		"FREQ=WEEKLY;INTERVAL=0",
+		"FREQ=WEEKLY;BYDAY=XX",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=WEEKLY;BYMONTHDAY=1",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=DAILY;COUNT=3;UNTIL=20201231",
+		"FREQ=DAILY;FREQ=WEEKLY",
+		"FREQ=MONTHLY;BYSETPOS=1",
+		"FREQ=YEARLY;BYDAY=MO",
+	}
+
+	for _, s := range invalid {
+		if _, err := Parse(s); err == nil {
+			t.Errorf("%q: expected an error", s)
+		}
+	}
+
+	rule, err := Parse("freq=monthly;byday=1sa;interval=2;until=20201231")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "FREQ=MONTHLY;INTERVAL=2;UNTIL=20201231T235959Z;BYDAY=1SA"
+	if rule.String() != expected {
+		t.Errorf("expected %q, got %q", expected, rule.String())
+	}
+}
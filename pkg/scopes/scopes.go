@@ -37,3 +37,35 @@ func stringToScope(scope string) Scope {
 	// Fallback to unauthenticated.
 	return ScopeUnauthenticated
 }
+
+// String returns the name of a Scope (ex: "admin").
+func (s Scope) String() string {
+	switch s {
+	case ScopeUnauthenticated:
+		return "unauthenticated"
+	case ScopeAuthenticated:
+		return "authenticated"
+	case ScopeCollaborator:
+		return "collaborator"
+	case ScopeManager:
+		return "manager"
+	case ScopeAdmin:
+		return "admin"
+	case ScopeOwner:
+		return "owner"
+	}
+
+	return ""
+}
+
+// ParseScope converts the name of a scope to a Scope, and returns false if the
+// name is unknown.
+func ParseScope(name string) (Scope, bool) {
+	for scope := ScopeUnauthenticated; scope <= ScopeOwner; scope++ {
+		if scope.String() == name {
+			return scope, true
+		}
+	}
+
+	return ScopeUnauthenticated, false
+}
@@ -0,0 +1,111 @@
+// Package totp implements time-based one-time passwords (RFC 6238) as used by
+// authenticator apps.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Period is the number of seconds each code is valid for.
+const Period = 30
+
+// Digits is the number of digits in each code.
+const Digits = 6
+
+// secretSize is the size of generated secrets in bytes.
+const secretSize = 20
+
+// encoding is the encoding used for secrets, which authenticator apps expect
+// to be unpadded base32.
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret generates a new random base32 encoded secret.
+func GenerateSecret() (string, error) {
+	b := make([]byte, secretSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return encoding.EncodeToString(b), nil
+}
+
+// URI builds an otpauth:// URI for the secret which can be shown as a QR code
+// to be scanned by an authenticator app.
+func URI(secret, issuer, accountName string) string {
+	label := url.PathEscape(fmt.Sprintf("%s:%s", issuer, accountName))
+
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprintf("%d", Digits))
+	params.Set("period", fmt.Sprintf("%d", Period))
+
+	return fmt.Sprintf("otpauth://totp/%s?%s", label, params.Encode())
+}
+
+// Counter returns the time step counter for a time.
+func Counter(t time.Time) int64 {
+	return t.Unix() / Period
+}
+
+// CodeAt generates the code for a secret at a specific time step counter.
+func CodeAt(secret string, counter int64) (string, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", err
+	}
+
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(counter))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	// Dynamic truncation as described in RFC 4226.
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	modulo := uint32(1)
+	for i := 0; i < Digits; i++ {
+		modulo *= 10
+	}
+
+	return fmt.Sprintf("%0*d", Digits, value%modulo), nil
+}
+
+// Validate checks a code against a secret at a time, allowing for the given
+// number of time steps of clock drift in either direction. On success, the
+// matched time step counter is returned so that callers can prevent the same
+// code from being used twice.
+func Validate(secret, code string, t time.Time, skew int) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != Digits {
+		return 0, false
+	}
+
+	current := Counter(t)
+	for i := -skew; i <= skew; i++ {
+		counter := current + int64(i)
+
+		expected, err := CodeAt(secret, counter)
+		if err != nil {
+			return 0, false
+		}
+
+		if hmac.Equal([]byte(expected), []byte(code)) {
+			return counter, true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,69 @@
+package totp
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA1 test secret from RFC 6238, "12345678901234567890".
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+// TestCodeAt tests code generation against the RFC 6238 test vectors,
+// truncated to six digits.
+func TestCodeAt(t *testing.T) {
+	vectors := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+
+	for unix, expected := range vectors {
+		code, err := CodeAt(rfcSecret, Counter(time.Unix(unix, 0)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if code != expected {
+			t.Fatalf("code at %d: expected %s, got %s", unix, expected, code)
+		}
+	}
+}
+
+// TestValidate tests that codes are accepted within the allowed skew only.
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+
+	code, err := CodeAt(rfcSecret, Counter(now)-1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	counter, ok := Validate(rfcSecret, code, now, 1)
+	if !ok || counter != Counter(now)-1 {
+		t.Fatal("expected code from the previous step to be accepted")
+	}
+
+	if _, ok := Validate(rfcSecret, code, now, 0); ok {
+		t.Fatal("expected code from the previous step to be rejected without skew")
+	}
+
+	if _, ok := Validate(rfcSecret, "12345", now, 1); ok {
+		t.Fatal("expected short code to be rejected")
+	}
+}
+
+// TestGenerateSecret tests that generated secrets can be used to generate codes.
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := CodeAt(secret, 1); err != nil {
+		t.Fatal(err)
+	}
+}